@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/joho/godotenv"
 
 	"github.com/catering-event-manager/scheduling-service/internal/api"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
 	"github.com/catering-event-manager/scheduling-service/internal/logger"
 	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+	"github.com/catering-event-manager/scheduling-service/internal/server"
 )
 
 func main() {
@@ -31,27 +37,106 @@ func main() {
 
 	l := logger.Get()
 
+	// Size the pool first; the conflict batch limit is checked against it
+	repository.LoadPoolSettings()
+
+	// Fail fast on a bad template rather than on the first conflict
+	if err := scheduler.LoadConflictMessageTemplate(); err != nil {
+		log.Fatalf("Failed to load conflict message template: %v", err)
+	}
+	if err := scheduler.LoadOvertimePolicy(); err != nil {
+		log.Fatalf("Failed to load overtime policy: %v", err)
+	}
+	if err := scheduler.LoadConflictBatchConcurrency(); err != nil {
+		log.Fatalf("Failed to load conflict batch concurrency: %v", err)
+	}
+	if err := scheduler.LoadWeeklyCapacity(); err != nil {
+		log.Fatalf("Failed to load weekly capacity: %v", err)
+	}
+	if err := scheduler.LoadBusinessTimezone(); err != nil {
+		log.Fatalf("Failed to load business timezone: %v", err)
+	}
+	conflictWebhook, err := scheduler.LoadConflictWebhook()
+	if err != nil {
+		log.Fatalf("Failed to load conflict webhook: %v", err)
+	}
+	if err := repository.LoadQueryTimeout(); err != nil {
+		log.Fatalf("Failed to load query timeout: %v", err)
+	}
+	if err := repository.LoadRetryPolicy(); err != nil {
+		log.Fatalf("Failed to load database retry policy: %v", err)
+	}
+	shutdownTimeout, err := server.LoadShutdownTimeout()
+	if err != nil {
+		log.Fatalf("Failed to load shutdown timeout: %v", err)
+	}
+	auth, err := api.LoadAuthenticator()
+	if err != nil {
+		log.Fatalf("Failed to load authentication: %v", err)
+	}
+	drainDelay, err := server.LoadDrainDelay()
+	if err != nil {
+		log.Fatalf("Failed to load shutdown drain delay: %v", err)
+	}
+
 	// Initialize database connection
 	db, err := repository.NewDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
+
+	publisher, err := events.LoadPublisher(db)
+	if err != nil {
+		log.Fatalf("Failed to load event publisher: %v", err)
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
-		AppName: "Catering Scheduler Service v1.0",
+		AppName:      "Catering Scheduler Service v1.0",
+		ErrorHandler: api.ErrorHandler,
 	})
 
 	// Register middleware
 	api.RegisterMiddleware(app)
 
 	// Register routes
-	api.RegisterRoutes(app, db)
+	api.RegisterRoutes(app, db, publisher, auth, conflictWebhook)
+
+	// Stop on SIGINT or SIGTERM, which Kubernetes sends before replacing a pod
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Delete expired idempotency keys until shutdown
+	go scheduler.SweepIdempotencyKeys(ctx, db, scheduler.IdempotencyKeySweepInterval)
 
 	// Start server
 	l.Info().Str("port", port).Msg("Starting scheduler service")
-	if err := app.Listen(":" + port); err != nil {
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listen(":" + port)
+	}()
+
+	select {
+	case err := <-listenErr:
 		log.Fatalf("Server failed to start: %v", err)
+	case <-ctx.Done():
+		// A second signal kills the process instead of waiting for the drain
+		stop()
+		l.Info().Msg("Shutdown signal received")
+	}
+
+	// Fail readiness first, and keep serving until the load balancer has
+	// noticed and stopped sending new requests
+	server.BeginDrain(api.SetDraining, drainDelay)
+
+	// Publish the events still queued while the database is open, and
+	// deliver the conflict notifications still queued
+	flushers := []server.Flusher{publisher}
+	if conflictWebhook != nil {
+		flushers = append(flushers, conflictWebhook)
+	}
+	if err := server.Shutdown(app, db, shutdownTimeout, flushers...); err != nil {
+		os.Exit(1)
 	}
+	l.Info().Msg("Scheduler service stopped")
 }
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// taskWindowBody is the wire form of domain.TaskWindow, with times decoded through parseTime
+type taskWindowBody struct {
+	TaskID    int32       `json:"task_id"`
+	StartTime requestTime `json:"start_time"`
+	EndTime   requestTime `json:"end_time"`
+}
+
+// assignTasksBody is the wire form of domain.AssignTasksRequest
+type assignTasksBody struct {
+	Tasks []taskWindowBody `json:"tasks"`
+}
+
+func (b assignTasksBody) toDomain() domain.AssignTasksRequest {
+	req := domain.AssignTasksRequest{
+		Tasks: make([]domain.TaskWindow, 0, len(b.Tasks)),
+	}
+	for _, t := range b.Tasks {
+		req.Tasks = append(req.Tasks, domain.TaskWindow{
+			TaskID:    t.TaskID,
+			StartTime: t.StartTime.Time,
+			EndTime:   t.EndTime.Time,
+		})
+	}
+	return req
+}
+
+func registerAssignmentRoutes(scheduling fiber.Router, assignmentService *scheduler.AssignmentService) {
+	// POST /api/v1/scheduling/assign-tasks
+	scheduling.Post("/assign-tasks", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		var body assignTasksBody
+		if err := c.Bind().JSON(&body); err != nil {
+			log.Warn().Err(err).Msg("Invalid request body for assign-tasks")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		result, err := assignmentService.AssignTasks(c.Context(), body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int("task_count", len(body.Tasks)).
+			Int("assigned_count", len(result.Assignments)).
+			Int("unassigned_count", len(result.Unassigned)).
+			Msg("Task assignment computed")
+
+		return c.JSON(result)
+	})
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// Values of the user_role enum that may call the scheduling service
+const (
+	RoleAdministrator = "administrator"
+	RoleManager       = "manager"
+)
+
+// AuthSecretEnv names the environment variable holding the key callers sign
+// their bearer tokens with, as HS256 JWTs
+const AuthSecretEnv = "AUTH_JWT_SECRET"
+
+// minAuthSecretLength is the shortest secret accepted, matching the web app's
+// NEXTAUTH_SECRET
+const minAuthSecretLength = 32
+
+// Authenticator verifies the bearer tokens callers send. A token is an HS256
+// JWT whose sub (or id, as the web app's session tokens name it) is the
+// caller's user ID and whose role claim is their user_role. It must carry an
+// exp.
+type Authenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewAuthenticator creates an authenticator checking tokens against secret.
+// With an empty secret no token can be verified, so every token is refused.
+func NewAuthenticator(secret string) *Authenticator {
+	return &Authenticator{secret: []byte(secret), now: time.Now}
+}
+
+// LoadAuthenticator returns an authenticator for AUTH_JWT_SECRET. Call it
+// once at startup; a secret that is set but too short to be safe is returned
+// as an error so the service can refuse to start.
+func LoadAuthenticator() (*Authenticator, error) {
+	secret := os.Getenv(AuthSecretEnv)
+	if secret != "" && len(secret) < minAuthSecretLength {
+		return nil, fmt.Errorf("invalid %s: must be at least %d characters", AuthSecretEnv, minAuthSecretLength)
+	}
+	return NewAuthenticator(secret), nil
+}
+
+// tokenClaims are the claims of a bearer token the service reads
+type tokenClaims struct {
+	Subject   string   `json:"sub"`
+	ID        string   `json:"id"`
+	Role      string   `json:"role"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// errInvalidToken is returned for any token that fails verification; the
+// reason is logged, not sent back
+var errInvalidToken = fiber.NewError(fiber.StatusUnauthorized, "invalid or expired bearer token")
+
+// verify checks token's signature and lifetime and returns the caller's user
+// ID and role
+func (a *Authenticator) verify(token string) (int32, string, error) {
+	if len(a.secret) == 0 {
+		return 0, "", errors.New("no AUTH_JWT_SECRET to verify tokens with")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, "", errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return 0, "", fmt.Errorf("malformed header: %w", err)
+	}
+	// Only HS256 is accepted, so a token can't pick a weaker algorithm
+	// such as "none"
+	if header.Alg != "HS256" {
+		return 0, "", fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return 0, "", errors.New("bad signature")
+	}
+
+	var claims tokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return 0, "", fmt.Errorf("malformed claims: %w", err)
+	}
+	now := float64(a.now().Unix())
+	if claims.ExpiresAt == nil || now >= *claims.ExpiresAt {
+		return 0, "", errors.New("token expired or without exp")
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return 0, "", errors.New("token not yet valid")
+	}
+	subject := claims.Subject
+	if subject == "" {
+		subject = claims.ID
+	}
+	userID, err := strconv.ParseInt(subject, 10, 32)
+	if err != nil || userID <= 0 {
+		return 0, "", fmt.Errorf("subject %q isn't a user ID", subject)
+	}
+	if claims.Role == "" {
+		return 0, "", errors.New("token without role")
+	}
+	return int32(userID), claims.Role, nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token into v
+func decodeSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Authenticate verifies the caller's bearer token, if any, and stores their
+// role and user ID for RequireRole and the handlers. A request without an
+// Authorization header goes through anonymously, as internal jobs' do, and is
+// refused by any route guarded with RequireRole. A token that fails
+// verification is refused with 401.
+func Authenticate(a *Authenticator) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		if header == "" {
+			return c.Next()
+		}
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			requestLogger(c).Warn().Msg("Authorization header isn't a bearer token")
+			return errInvalidToken
+		}
+		userID, role, err := a.verify(token)
+		if err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Bearer token refused")
+			return errInvalidToken
+		}
+		c.Locals(userIDLocal, userID)
+		c.Locals(roleLocal, role)
+		return c.Next()
+	}
+}
+
+// roleLocal is the fiber.Ctx local holding the authenticated caller's role.
+// Authenticate stores it once the caller's token has been verified;
+// RequireRole only reads it.
+const roleLocal = "role"
+
+// callerRole returns the role stored by Authenticate, or "" when the request
+// carries none
+func callerRole(c fiber.Ctx) string {
+	role, _ := c.Locals(roleLocal).(string)
+	return role
+}
+
+// userIDLocal is the fiber.Ctx local holding the authenticated caller's user
+// ID, stored by Authenticate alongside the role
+const userIDLocal = "user_id"
+
+// callerUserID returns the user ID stored by Authenticate, or nil when the
+// request carries none, as for internal jobs
+func callerUserID(c fiber.Ctx) *int32 {
+	id, ok := c.Locals(userIDLocal).(int32)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// RequireRole allows the request through only if the caller's role is one of
+// roles, and refuses it with 403 otherwise. A request without a role is
+// refused too, so a route guarded by RequireRole is never open by accident.
+func RequireRole(roles ...string) fiber.Handler {
+	message := fmt.Sprintf("requires the %s role", strings.Join(roles, " or "))
+	return func(c fiber.Ctx) error {
+		role := callerRole(c)
+		if !slices.Contains(roles, role) {
+			requestLogger(c).Warn().
+				Str("role", role).
+				Str("method", c.Method()).
+				Str("path", c.Path()).
+				Msg("Request refused for role")
+			return domain.NewForbiddenError(message)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRoleTestApp guards a delete route for administrators and a read route
+// for administrators and managers. The caller's role is taken from a test
+// header, standing in for authentication.
+func setupRoleTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(func(c fiber.Ctx) error {
+		if role := c.Get("X-Test-Role"); role != "" {
+			c.Locals(roleLocal, role)
+		}
+		return c.Next()
+	})
+	app.Group("/admin", RequireRole(RoleAdministrator)).Delete("/entries/1", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Group("/read", RequireRole(RoleAdministrator, RoleManager)).Get("/entries/1", func(c fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+	return app
+}
+
+func TestRequireRole(t *testing.T) {
+	app := setupRoleTestApp()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		role   string
+		status int
+	}{
+		{"administrator deletes", http.MethodDelete, "/admin/entries/1", RoleAdministrator, http.StatusNoContent},
+		{"manager can't delete", http.MethodDelete, "/admin/entries/1", RoleManager, http.StatusForbidden},
+		{"client can't delete", http.MethodDelete, "/admin/entries/1", "client", http.StatusForbidden},
+		{"no role can't delete", http.MethodDelete, "/admin/entries/1", "", http.StatusForbidden},
+		{"administrator reads", http.MethodGet, "/read/entries/1", RoleAdministrator, http.StatusOK},
+		{"manager reads", http.MethodGet, "/read/entries/1", RoleManager, http.StatusOK},
+		{"client can't read", http.MethodGet, "/read/entries/1", "client", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				req.Header.Set("X-Test-Role", tt.role)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestRequireRole_ForbiddenBody(t *testing.T) {
+	app := setupRoleTestApp()
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/entries/1", nil)
+	req.Header.Set("X-Test-Role", RoleManager)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "FORBIDDEN", result.Error)
+	assert.Equal(t, "requires the administrator role", result.Message)
+}
+
+func TestCallerUserID(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if c.Get("X-Test-User") != "" {
+			c.Locals(userIDLocal, int32(42))
+		}
+		return c.Next()
+	})
+	app.Get("/whoami", func(c fiber.Ctx) error {
+		if id := callerUserID(c); id != nil {
+			return c.JSON(*id)
+		}
+		return c.SendString("null")
+	})
+
+	tests := []struct {
+		name string
+		user bool
+		body string
+	}{
+		{"authenticated", true, "42"},
+		{"internal job", false, "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			if tt.user {
+				req.Header.Set("X-Test-User", "1")
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+// testAuthSecret signs the bearer tokens tests send
+const testAuthSecret = "test-secret-signing-bearer-tokens-0123456789"
+
+// signToken signs claims as an HS256 JWT under secret, with header as the
+// token's header
+func signToken(secret string, header, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		raw, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	unsigned := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// bearer returns an Authorization header naming user and role, valid for an
+// hour
+func bearer(userID int32, role string) string {
+	return "Bearer " + signToken(testAuthSecret, map[string]interface{}{"alg": "HS256", "typ": "JWT"},
+		map[string]interface{}{"sub": itoa(int(userID)), "role": role, "exp": time.Now().Add(time.Hour).Unix()})
+}
+
+func TestAuthenticate(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(Authenticate(NewAuthenticator(testAuthSecret)))
+	app.Delete("/entries/1", RequireRole(RoleAdministrator), func(c fiber.Ctx) error {
+		return c.JSON(*callerUserID(c))
+	})
+	app.Get("/open", func(c fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	claims := func(extra map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"sub": "7", "role": RoleAdministrator, "exp": time.Now().Add(time.Hour).Unix()}
+		for k, v := range extra {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		status int
+		body   string
+	}{
+		{"administrator", http.MethodDelete, "/entries/1", bearer(7, RoleAdministrator), http.StatusOK, "7"},
+		{"user id from the session's id claim", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"sub": nil, "id": "9"})), http.StatusOK, "9"},
+		{"manager", http.MethodDelete, "/entries/1", bearer(7, RoleManager), http.StatusForbidden, ""},
+		{"no token", http.MethodDelete, "/entries/1", "", http.StatusForbidden, ""},
+		{"no token on an open route", http.MethodGet, "/open", "", http.StatusOK, "OK"},
+		{"wrong secret", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken("another-secret-of-the-same-length-0123456", hs256, claims(nil)), http.StatusUnauthorized, ""},
+		{"expired", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})), http.StatusUnauthorized, ""},
+		{"without exp", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"exp": nil})), http.StatusUnauthorized, ""},
+		{"not yet valid", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()})), http.StatusUnauthorized, ""},
+		{"alg none", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, map[string]interface{}{"alg": "none"}, claims(nil)), http.StatusUnauthorized, ""},
+		{"subject isn't a user ID", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"sub": "alice"})), http.StatusUnauthorized, ""},
+		{"without role", http.MethodDelete, "/entries/1",
+			"Bearer " + signToken(testAuthSecret, hs256, claims(map[string]interface{}{"role": nil})), http.StatusUnauthorized, ""},
+		{"not a bearer token", http.MethodDelete, "/entries/1", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, ""},
+		{"bad token on an open route", http.MethodGet, "/open", "Bearer x.y.z", http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, string(body))
+			}
+			if tt.status == http.StatusUnauthorized {
+				var result ErrorResponse
+				require.NoError(t, json.Unmarshal(body, &result))
+				assert.Equal(t, "unauthorized", result.Error)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_WithoutSecretRefusesTokens(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(Authenticate(NewAuthenticator("")))
+	app.Get("/open", func(c fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+
+	// An unsigned token would otherwise pass for any role
+	req := httptest.NewRequest(http.MethodGet, "/open", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken("", map[string]interface{}{"alg": "HS256"},
+		map[string]interface{}{"sub": "1", "role": RoleAdministrator, "exp": time.Now().Add(time.Hour).Unix()}))
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/open", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLoadAuthenticator(t *testing.T) {
+	t.Setenv(AuthSecretEnv, "too-short")
+	_, err := LoadAuthenticator()
+	assert.ErrorContains(t, err, AuthSecretEnv)
+
+	t.Setenv(AuthSecretEnv, testAuthSecret)
+	a, err := LoadAuthenticator()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(testAuthSecret), a.secret)
+
+	t.Setenv(AuthSecretEnv, "")
+	a, err = LoadAuthenticator()
+	require.NoError(t, err)
+	assert.Empty(t, a.secret)
+}
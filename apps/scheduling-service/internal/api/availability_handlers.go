@@ -0,0 +1,580 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// jointSlotBody asks for a window in which all the resources are free together
+type jointSlotBody struct {
+	ResourceIDs     []int32     `json:"resource_ids"`
+	DurationMinutes int32       `json:"duration_minutes"`
+	From            requestTime `json:"from"`
+	Until           requestTime `json:"until"`
+}
+
+func (b jointSlotBody) toDomain() domain.JointSlotRequest {
+	return domain.JointSlotRequest{
+		ResourceIDs: b.ResourceIDs,
+		Duration:    time.Duration(b.DurationMinutes) * time.Minute,
+		From:        b.From.Time,
+		Until:       b.Until.Time,
+	}
+}
+
+func registerAvailabilityRoutes(scheduling fiber.Router, availabilityService *scheduler.AvailabilityService) {
+	// GET /api/v1/scheduling/resource-availability/summary
+	scheduling.Get("/resource-availability/summary", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		resourceIDStr := c.Query("resource_id")
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+
+		if resourceIDStr == "" || startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "resource_id, start_date, and end_date are required",
+			})
+		}
+
+		resourceID, err := strconv.ParseInt(resourceIDStr, 10, 32)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "resource_id must be a valid integer",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		req := domain.AvailabilitySummaryRequest{
+			ResourceID:    int32(resourceID),
+			StartDate:     startDate,
+			EndDate:       endDate,
+			Granularity:   domain.Granularity(c.Query("granularity", string(domain.GranularityDay))),
+			ReportOptions: opts,
+		}
+
+		result, err := availabilityService.GetAvailabilitySummary(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int32("resource_id", int32(resourceID)).
+			Str("granularity", string(req.Granularity)).
+			Int("bucket_count", len(result.Buckets)).
+			Msg("Availability summary retrieved")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/soonest-available
+	scheduling.Get("/soonest-available", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		resourceType := c.Query("type")
+		durationStr := c.Query("duration")
+		if resourceType == "" || durationStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "type and duration are required",
+			})
+		}
+
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_duration",
+				Message: "duration must be a Go duration such as 90m or 2h",
+			})
+		}
+
+		req := domain.SoonestAvailableRequest{
+			Type:     domain.ResourceType(resourceType),
+			Duration: duration,
+		}
+
+		result, err := availabilityService.FindSoonestAvailable(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Str("type", resourceType).
+			Dur("duration", duration).
+			Int32("resource_id", result.Resource.ID).
+			Msg("Soonest available resource found")
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/best-joint-slot
+	scheduling.Post("/best-joint-slot", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		var body jointSlotBody
+		if err := c.Bind().JSON(&body); err != nil {
+			log.Warn().Err(err).Msg("Invalid request body for best-joint-slot")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		result, err := availabilityService.FindBestJointSlot(c.Context(), body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int("resource_count", len(result.ResourceIDs)).
+			Int32("duration_minutes", body.DurationMinutes).
+			Str("slot_start", result.SlotStart.Format(time.RFC3339)).
+			Msg("Joint slot found")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/type-busy-windows
+	scheduling.Get("/type-busy-windows", func(c fiber.Ctx) error {
+		resourceType := c.Query("type")
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if resourceType == "" || startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "type, start_date, and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		result, err := availabilityService.GetTypeBusyWindows(c.Context(), domain.TypeBusyWindowsRequest{
+			Type:      domain.ResourceType(resourceType),
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/around
+	scheduling.Get("/resources/:id/around", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		at := time.Now().UTC()
+		if atStr := c.Query("at"); atStr != "" {
+			at, err = parseTime(atStr, time.UTC)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_at",
+					Message: "at must be " + timeFormatHint,
+				})
+			}
+		}
+
+		result, err := availabilityService.GetBookingsAround(c.Context(), resourceID, at)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/active
+	scheduling.Get("/active", func(c fiber.Ctx) error {
+		req := domain.ActiveBookingsRequest{At: time.Now().UTC()}
+		if atStr := c.Query("at"); atStr != "" {
+			at, err := parseTime(atStr, time.UTC)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_at",
+					Message: "at must be " + timeFormatHint,
+				})
+			}
+			req.At = at
+		}
+		if t := c.Query("type"); t != "" {
+			resourceType := domain.ResourceType(t)
+			req.ResourceType = &resourceType
+		}
+
+		result, err := availabilityService.GetActiveBookings(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/roster
+	scheduling.Get("/resources/:id/roster", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		weekStartStr := c.Query("week_start")
+		if weekStartStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "week_start is required",
+			})
+		}
+		weekStart, err := parseTime(weekStartStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_week_start",
+				Message: "week_start must be " + timeFormatHint,
+			})
+		}
+
+		result, err := availabilityService.GetWeeklyRoster(c.Context(), domain.WeeklyRosterRequest{
+			ResourceID: resourceID,
+			WeekStart:  weekStart,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/bookable-windows
+	scheduling.Get("/resources/:id/bookable-windows", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		req := domain.BookableWindowsRequest{
+			ResourceID: resourceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+		}
+		if v := c.Query("min_duration"); v != "" {
+			req.MinDuration, err = time.ParseDuration(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_min_duration",
+					Message: "min_duration must be a Go duration such as 30m or 2h",
+				})
+			}
+		}
+
+		result, err := availabilityService.GetBookableWindows(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/free-slots
+	scheduling.Get("/free-slots", func(c fiber.Ctx) error {
+		resourceIDStr := c.Query("resource_id")
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if resourceIDStr == "" || startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "resource_id, start_date, and end_date are required",
+			})
+		}
+
+		resourceID, err := parseID(resourceIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "resource_id must be a valid integer",
+			})
+		}
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		var minMinutes int32
+		if v := c.Query("min_minutes"); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_min_minutes",
+					Message: "min_minutes must be a valid integer",
+				})
+			}
+			minMinutes = int32(parsed)
+		}
+
+		slots, err := availabilityService.GetFreeSlots(c.Context(), resourceID, startDate, endDate,
+			time.Duration(minMinutes)*time.Minute)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(domain.FreeSlotsResponse{
+			ResourceID: resourceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+			MinMinutes: minMinutes,
+			Slots:      slots,
+		})
+	})
+
+	// GET /api/v1/scheduling/equipment/:id/changeover
+	scheduling.Get("/equipment/:id/changeover", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		dateStr := c.Query("date")
+		if dateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "date is required",
+			})
+		}
+		date, err := parseTime(dateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_date",
+				Message: "date must be " + timeFormatHint,
+			})
+		}
+
+		req := domain.ChangeoverRequest{ResourceID: resourceID, Date: date}
+		if v := c.Query("min_gap_minutes"); v != "" {
+			minGap, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_min_gap_minutes",
+					Message: "min_gap_minutes must be a valid integer",
+				})
+			}
+			gap := int32(minGap)
+			req.MinGapMinutes = &gap
+		}
+
+		result, err := availabilityService.GetChangeovers(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/chains
+	scheduling.Get("/resources/:id/chains", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		dateStr := c.Query("date")
+		if dateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "date is required",
+			})
+		}
+		date, err := parseTime(dateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_date",
+				Message: "date must be " + timeFormatHint,
+			})
+		}
+
+		req := domain.BookingChainsRequest{ResourceID: resourceID, Date: date}
+		if v := c.Query("max_gap_minutes"); v != "" {
+			maxGap, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_max_gap_minutes",
+					Message: "max_gap_minutes must be a valid integer",
+				})
+			}
+			gap := int32(maxGap)
+			req.MaxGapMinutes = &gap
+		}
+		if v := c.Query("max_continuous_minutes"); v != "" {
+			maxContinuous, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_max_continuous_minutes",
+					Message: "max_continuous_minutes must be a valid integer",
+				})
+			}
+			limit := int32(maxContinuous)
+			req.MaxContinuousMinutes = &limit
+		}
+
+		result, err := availabilityService.GetBookingChains(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+}
+
+// singleResourceAvailabilityParams are the GET /resource-availability query
+// parameters that only apply to a single resource
+var singleResourceAvailabilityParams = []string{
+	"resource_id", "external_code", "use_resource_tz", "limit", "cursor", "event_id", "task_id", "sort",
+}
+
+// multiResourceAvailability serves GET /resource-availability when
+// resource_ids lists several resources. Their entries are loaded with one query
+// rather than a request per resource.
+func multiResourceAvailability(c fiber.Ctx, availabilityService *scheduler.AvailabilityService) error {
+	for _, param := range singleResourceAvailabilityParams {
+		if c.Query(param) != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_parameters",
+				Message: "resource_ids cannot be combined with " + strings.Join(singleResourceAvailabilityParams, ", "),
+			})
+		}
+	}
+
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "missing_parameters",
+			Message: "resource_ids, start_date, and end_date are required",
+		})
+	}
+
+	resourceIDs, err := parseIDList(c.Query("resource_ids"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "invalid_resource_ids",
+			Message: "resource_ids must be a comma-separated list of integers",
+		})
+	}
+	startDate, err := parseTime(startDateStr, time.UTC)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "invalid_start_date",
+			Message: "start_date must be " + timeFormatHint,
+		})
+	}
+	endDate, err := parseTime(endDateStr, time.UTC)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "invalid_end_date",
+			Message: "end_date must be " + timeFormatHint,
+		})
+	}
+
+	entries, err := availabilityService.GetMultiResourceAvailability(c.Context(), domain.MultiResourceAvailabilityRequest{
+		ResourceIDs: resourceIDs,
+		StartDate:   startDate,
+		EndDate:     endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	requestLogger(c).Info().
+		Int("resource_count", len(entries)).
+		Msg("Multi-resource availability retrieved")
+
+	return c.JSON(domain.MultiResourceAvailabilityResponse{Resources: entries})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerBlackoutRoutes(scheduling fiber.Router, blackoutService *scheduler.BlackoutService) {
+	// GET /api/v1/scheduling/blackouts
+	scheduling.Get("/blackouts", func(c fiber.Ctx) error {
+		result, err := blackoutService.ListBlackouts(c.Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/blackouts
+	scheduling.Post("/blackouts", func(c fiber.Ctx) error {
+		var req domain.BlackoutRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for create blackout")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		blackout, err := blackoutService.CreateBlackout(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("blackout_id", blackout.ID).
+			Str("start_date", blackout.StartDate).
+			Str("end_date", blackout.EndDate).
+			Msg("Blackout created")
+
+		return c.Status(fiber.StatusCreated).JSON(blackout)
+	})
+
+	// DELETE /api/v1/scheduling/blackouts/:id
+	scheduling.Delete("/blackouts/:id", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_blackout_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		if err := blackoutService.DeleteBlackout(c.Context(), id); err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("blackout_id", id).
+			Msg("Blackout deleted")
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/ical"
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// CalendarFeedSecretEnv names the environment variable holding the key that
+// resource calendar feed tokens are signed with
+const CalendarFeedSecretEnv = "CALENDAR_FEED_SECRET"
+
+// ResourceCalendarToken returns the token that opens a resource's calendar
+// feed to clients that can't authenticate, such as Google or Apple Calendar:
+// the unpadded base64url HMAC-SHA256 of "resource:<id>" under secret. The web
+// app signs subscription links the same way.
+func ResourceCalendarToken(secret string, resourceID int32) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "resource:%d", resourceID)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// calendarFeedAllowed reports whether the request may read a resource's
+// calendar feed. Authenticated callers always may; anyone else must present
+// the feed's token. Without a secret no token is valid, since anyone could
+// sign one, so feeds are only open to authenticated callers.
+func calendarFeedAllowed(c fiber.Ctx, secret string, resourceID int32) bool {
+	if callerRole(c) != "" {
+		return true
+	}
+	if secret == "" {
+		return false
+	}
+	token := c.Query("token")
+	return hmac.Equal([]byte(token), []byte(ResourceCalendarToken(secret, resourceID)))
+}
+
+// sendCalendar writes cal as an iCalendar attachment named filename
+func sendCalendar(c fiber.Ctx, cal *ical.Calendar, filename, failure string) error {
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, *cal); err != nil {
+		requestLogger(c).Error().Err(err).Msg("Failed to encode calendar")
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+			Error:   "internal_error",
+			Message: failure,
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, ical.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Send(buf.Bytes())
+}
+
+// scheduleCSVHeader names the columns of a schedule export
+var scheduleCSVHeader = []string{"id", "event_name", "task_title", "start_time", "end_time", "notes"}
+
+// scheduleCSVRecord renders an exported entry as a CSV row. Times are RFC 3339
+// in UTC; a missing task or note is an empty cell.
+func scheduleCSVRecord(e domain.ScheduleExportEntry) []string {
+	var taskTitle, notes string
+	if e.TaskTitle != nil {
+		taskTitle = *e.TaskTitle
+	}
+	if e.Notes != nil {
+		notes = *e.Notes
+	}
+	return []string{
+		strconv.Itoa(int(e.ID)),
+		csvText(e.EventName),
+		csvText(taskTitle),
+		e.StartTime.UTC().Format(time.RFC3339),
+		e.EndTime.UTC().Format(time.RFC3339),
+		csvText(notes),
+	}
+}
+
+// csvText keeps free text from being run as a formula by spreadsheet apps,
+// which treat a cell starting with =, +, -, or @ as one
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func registerCalendarRoutes(scheduling fiber.Router, scheduleService *scheduler.ScheduleService) {
+	feedSecret := os.Getenv(CalendarFeedSecretEnv)
+	if feedSecret == "" {
+		logger.Get().Warn().Str("variable", CalendarFeedSecretEnv).Msg("Calendar feed secret not set, feeds need a bearer token")
+	}
+
+	// GET /api/v1/scheduling/events/:event_id/schedule.ics
+	scheduling.Get("/events/:event_id/schedule.ics", func(c fiber.Ctx) error {
+		eventID, err := parseID(c.Params("event_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "event_id must be a valid integer",
+			})
+		}
+
+		cal, err := scheduleService.EventCalendar(c.Context(), eventID)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("event_id", eventID).
+			Int("entry_count", len(cal.Events)).
+			Msg("Event schedule exported")
+
+		return sendCalendar(c, cal, fmt.Sprintf("event-%d-schedule.ics", eventID), "Failed to export event schedule")
+	})
+
+	// GET /api/v1/scheduling/resources/:id/calendar.ics
+	scheduling.Get("/resources/:id/calendar.ics", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		if !calendarFeedAllowed(c, feedSecret, resourceID) {
+			requestLogger(c).Warn().
+				Int32("resource_id", resourceID).
+				Msg("Calendar feed refused for missing or invalid token")
+			return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
+				Error:   "invalid_token",
+				Message: "a valid calendar token is required",
+			})
+		}
+
+		cal, err := scheduleService.ResourceCalendar(c.Context(), resourceID)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int("entry_count", len(cal.Events)).
+			Msg("Resource calendar exported")
+
+		return sendCalendar(c, cal, fmt.Sprintf("resource-%d-calendar.ics", resourceID), "Failed to export resource calendar")
+	})
+
+	// GET /api/v1/scheduling/resources/:id/schedule.csv
+	scheduling.Get("/resources/:id/schedule.csv", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		req := domain.ScheduleExportRequest{
+			ResourceID: resourceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+		}
+		// Anything that would fail the request is reported before streaming
+		// starts; after that the status has been sent
+		if err := scheduleService.CheckScheduleExport(c.Context(), req); err != nil {
+			return err
+		}
+
+		l := requestLogger(c)
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="resource-%d-schedule.csv"`, resourceID))
+		return c.SendStreamWriter(func(w *bufio.Writer) {
+			// The body is written after the handler returns, when the request's
+			// context can no longer be used; QUERY_TIMEOUT still bounds the query
+			ctx := context.Background()
+
+			cw := csv.NewWriter(w)
+			count := 0
+			err := cw.Write(scheduleCSVHeader)
+			if err == nil {
+				err = scheduleService.ExportSchedule(ctx, req, func(e domain.ScheduleExportEntry) error {
+					count++
+					return cw.Write(scheduleCSVRecord(e))
+				})
+			}
+			cw.Flush()
+			if err == nil {
+				err = cw.Error()
+			}
+			if err != nil {
+				l.Error().Err(err).Int32("resource_id", resourceID).Int("entry_count", count).
+					Msg("Resource schedule export cut short")
+				return
+			}
+
+			l.Info().
+				Int32("resource_id", resourceID).
+				Int("entry_count", count).
+				Msg("Resource schedule exported")
+		})
+	})
+
+	// GET /api/v1/scheduling/events/:event_id/gantt
+	scheduling.Get("/events/:event_id/gantt", func(c fiber.Ctx) error {
+		eventID, err := parseID(c.Params("event_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "event_id must be a valid integer",
+			})
+		}
+
+		gantt, err := scheduleService.EventGantt(c.Context(), eventID)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(gantt)
+	})
+}
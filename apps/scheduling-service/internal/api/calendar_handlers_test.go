@@ -0,0 +1,341 @@
+package api
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestEventScheduleICS_OneEventPerEntry(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, _ := testutil.SetupBaseData(t, testDB.DB)
+	eventID := testutil.CreateEvent(t, testDB.DB, clientID, userID, &testutil.EventOpts{
+		EventName: "Garden Gala",
+	})
+	otherEventID := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Oven", Type: testutil.ResourceTypeEquipment})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, baseDay.Add(10*time.Hour), baseDay.Add(14*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(15*time.Hour), baseDay.Add(16*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	// Another event's booking never appears in this event's file
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, otherEventID, baseDay.Add(18*time.Hour), baseDay.Add(20*time.Hour), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scheduling/events/"+itoa(int(eventID))+"/schedule.ics", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+
+	body, _ := io.ReadAll(resp.Body)
+	ics := string(body)
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "X-WR-CALNAME:Garden Gala\r\n")
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Equal(t, 2, strings.Count(ics, "END:VEVENT"))
+	assert.Contains(t, ics, "SUMMARY:Head Chef\r\n")
+	assert.Contains(t, ics, "SUMMARY:Oven\r\n")
+	assert.Contains(t, ics, "DTSTART:20250615T090000Z\r\n")
+}
+
+func TestEventScheduleICS_UnknownEvent(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scheduling/events/999999/schedule.ics", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// parseVEvents unfolds an iCalendar file and returns the properties of each
+// VEVENT, keyed by name
+func parseVEvents(t *testing.T, ics string) []map[string]string {
+	t.Helper()
+
+	var events []map[string]string
+	var current map[string]string
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, line := range strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n") {
+		name, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "malformed content line %q", line)
+		switch {
+		case line == "BEGIN:VEVENT":
+			current = map[string]string{}
+		case line == "END:VEVENT":
+			events = append(events, current)
+			current = nil
+		case current != nil:
+			current[name] = value
+		}
+	}
+	return events
+}
+
+// getResourceCalendar requests a resource's calendar feed at path, sending
+// authorization unless it is empty
+func getResourceCalendar(t *testing.T, app *fiber.App, path, authorization string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if authorization != "" {
+		req.Header.Set(fiber.HeaderAuthorization, authorization)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestResourceCalendarICS_UpcomingEntries(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, _ := testutil.SetupBaseData(t, testDB.DB)
+	gala := testutil.CreateEvent(t, testDB.DB, clientID, userID, &testutil.EventOpts{EventName: "Garden Gala"})
+	wedding := testutil.CreateEvent(t, testDB.DB, clientID, userID, &testutil.EventOpts{EventName: "Smith Wedding"})
+	prep := testutil.CreateTask(t, testDB.DB, wedding, &testutil.TaskOpts{Title: "Prep"})
+	chicago := "America/Chicago"
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Head Chef",
+		Type:     testutil.ResourceTypeStaff,
+		Timezone: &chicago,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Oven", Type: testutil.ResourceTypeEquipment})
+
+	day := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 2)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, wedding, day.Add(14*time.Hour), day.Add(18*time.Hour),
+		&testutil.ScheduleEntryOpts{TaskID: &prep, ApprovalStatus: "pending"})
+	// Finished, rejected, cancelled, and other resources' bookings are left out
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.AddDate(0, 0, -5), day.AddDate(0, 0, -5).Add(time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(19*time.Hour), day.Add(20*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	cancelledAt := time.Now()
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(21*time.Hour), day.Add(22*time.Hour),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+
+	resp, ics := getResourceCalendar(t, app, "/api/v1/scheduling/resources/"+itoa(int(chef))+"/calendar.ics", bearer(userID, RoleManager))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "X-WR-CALNAME:Head Chef\r\n")
+	assert.Contains(t, ics, "X-WR-TIMEZONE:America/Chicago\r\n")
+
+	events := parseVEvents(t, ics)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "Garden Gala", events[0]["SUMMARY"])
+	loc, err := time.LoadLocation(chicago)
+	require.NoError(t, err)
+	assert.Contains(t, ics, "BEGIN:VTIMEZONE\r\nTZID:America/Chicago\r\n")
+	assert.Equal(t, day.Add(9*time.Hour).In(loc).Format("20060102T150405"), events[0]["DTSTART;TZID=America/Chicago"])
+	assert.Equal(t, day.Add(12*time.Hour).In(loc).Format("20060102T150405"), events[0]["DTEND;TZID=America/Chicago"])
+	assert.Equal(t, "CONFIRMED", events[0]["STATUS"])
+	assert.NotEmpty(t, events[0]["UID"])
+	assert.NotEmpty(t, events[0]["DTSTAMP"])
+
+	assert.Equal(t, "Smith Wedding - Prep", events[1]["SUMMARY"])
+	assert.Equal(t, "TENTATIVE", events[1]["STATUS"])
+	assert.NotEqual(t, events[0]["UID"], events[1]["UID"])
+}
+
+func TestResourceCalendarICS_UnknownResource(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	resp, _ := getResourceCalendar(t, app, "/api/v1/scheduling/resources/999999/calendar.ics", bearer(1, RoleManager))
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestResourceCalendarICS_Token(t *testing.T) {
+	t.Setenv(CalendarFeedSecretEnv, "feed-secret")
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+	path := "/api/v1/scheduling/resources/" + itoa(int(chef)) + "/calendar.ics"
+
+	resp, _ := getResourceCalendar(t, app, path+"?token="+ResourceCalendarToken("feed-secret", chef), "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = getResourceCalendar(t, app, path, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// A token for another resource doesn't open this one
+	resp, _ = getResourceCalendar(t, app, path+"?token="+ResourceCalendarToken("feed-secret", chef+1), "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// A bearer token opens it without a feed token
+	resp, _ = getResourceCalendar(t, app, path, bearer(1, RoleManager))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestResourceCalendarICS_NoSecret(t *testing.T) {
+	t.Setenv(CalendarFeedSecretEnv, "")
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+	path := "/api/v1/scheduling/resources/" + itoa(int(chef)) + "/calendar.ics"
+
+	// Anyone could sign a token with an empty secret, so none is accepted
+	resp, _ := getResourceCalendar(t, app, path+"?token="+ResourceCalendarToken("", chef), "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp, _ = getResourceCalendar(t, app, path, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, _ = getResourceCalendar(t, app, path, bearer(1, RoleManager))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCalendarFeedAllowed(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		if role := c.Get("X-Test-Role"); role != "" {
+			c.Locals(roleLocal, role)
+		}
+		return c.Next()
+	})
+	secret := ""
+	app.Get("/feed", func(c fiber.Ctx) error {
+		if calendarFeedAllowed(c, secret, 7) {
+			return c.SendString("allowed")
+		}
+		return c.SendString("refused")
+	})
+	get := func(query, role string) string {
+		req := httptest.NewRequest(http.MethodGet, "/feed"+query, nil)
+		if role != "" {
+			req.Header.Set("X-Test-Role", role)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	// Without a secret no token is accepted, only authenticated callers
+	assert.Equal(t, "refused", get("", ""))
+	assert.Equal(t, "refused", get("?token="+ResourceCalendarToken("", 7), ""))
+	assert.Equal(t, "allowed", get("", RoleManager))
+
+	secret = "feed-secret"
+	assert.Equal(t, "allowed", get("?token="+ResourceCalendarToken(secret, 7), ""))
+	assert.Equal(t, "refused", get("?token="+ResourceCalendarToken("other-secret", 7), ""))
+	assert.Equal(t, "refused", get("?token=", ""))
+	assert.Equal(t, "refused", get("", ""))
+	assert.Equal(t, "allowed", get("", RoleManager), "authenticated callers need no token")
+}
+
+func TestResourceScheduleCSV(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, _ := testutil.SetupBaseData(t, testDB.DB)
+	gala := testutil.CreateEvent(t, testDB.DB, clientID, userID, &testutil.EventOpts{EventName: "Garden Gala"})
+	prep := testutil.CreateTask(t, testDB.DB, gala, &testutil.TaskOpts{Title: "Prep, then plate"})
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	notes := "Bring knives"
+	withTask := testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(9*time.Hour), day.Add(12*time.Hour),
+		&testutil.ScheduleEntryOpts{TaskID: &prep, Notes: &notes})
+	noTask := testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(14*time.Hour), day.Add(16*time.Hour), nil)
+	// Outside the range, and rejected, so not exported
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.AddDate(0, 0, 3), day.AddDate(0, 0, 3).Add(time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(18*time.Hour), day.Add(19*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scheduling/resources/"+itoa(int(chef))+
+		"/schedule.csv?start_date=2025-06-15T00:00:00Z&end_date=2025-06-16T00:00:00Z", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "schedule.csv")
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "event_name", "task_title", "start_time", "end_time", "notes"}, records[0])
+	assert.Equal(t, []string{itoa(int(withTask)), "Garden Gala", "Prep, then plate",
+		"2025-06-15T09:00:00Z", "2025-06-15T12:00:00Z", "Bring knives"}, records[1])
+	assert.Equal(t, []string{itoa(int(noTask)), "Garden Gala", "",
+		"2025-06-15T14:00:00Z", "2025-06-15T16:00:00Z", ""}, records[2])
+}
+
+func TestResourceScheduleCSV_Errors(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+	base := "/api/v1/scheduling/resources/"
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"missing range", base + itoa(int(chef)) + "/schedule.csv", http.StatusBadRequest},
+		{"bad start", base + itoa(int(chef)) + "/schedule.csv?start_date=soon&end_date=2025-06-16T00:00:00Z", http.StatusBadRequest},
+		{"end before start", base + itoa(int(chef)) + "/schedule.csv?start_date=2025-06-16T00:00:00Z&end_date=2025-06-15T00:00:00Z", http.StatusBadRequest},
+		{"unknown resource", base + "999999/schedule.csv?start_date=2025-06-15T00:00:00Z&end_date=2025-06-16T00:00:00Z", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+		})
+	}
+}
+
+func TestScheduleCSVRecord(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.FixedZone("CDT", -5*3600))
+	title := "=HYPERLINK(\"http://example.com\")"
+	record := scheduleCSVRecord(domain.ScheduleExportEntry{
+		ID:        42,
+		EventName: "Garden Gala",
+		TaskTitle: &title,
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+	})
+
+	assert.Equal(t, []string{"42", "Garden Gala", "'" + title, "2025-06-15T14:00:00Z", "2025-06-15T16:00:00Z", ""}, record)
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerConflictRoutes(scheduling fiber.Router, conflictService *scheduler.ConflictService) {
+	// GET /api/v1/scheduling/conflict-clusters
+	scheduling.Get("/conflict-clusters", func(c fiber.Ctx) error {
+		resourceIDStr := c.Query("resource_id")
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+
+		if resourceIDStr == "" || startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "resource_id, start_date, and end_date are required",
+			})
+		}
+
+		resourceID, err := parseID(resourceIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "resource_id must be a valid integer",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		result, err := conflictService.GetConflictClusters(c.Context(), domain.ConflictClustersRequest{
+			ResourceID: resourceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int("cluster_count", len(result.Clusters)).
+			Msg("Conflict clusters computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/all-conflicts
+	scheduling.Get("/all-conflicts", func(c fiber.Ctx) error {
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		limit, offset, errResp := parsePagination(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		result, err := conflictService.ListAllConflicts(c.Context(), domain.AllConflictsRequest{
+			StartDate: startDate,
+			EndDate:   endDate,
+			Limit:     limit,
+			Offset:    offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		if result.Total > 0 {
+			requestLogger(c).Warn().
+				Int("pair_count", int(result.Total)).
+				Msg("Overlapping bookings found in global conflict scan")
+		}
+
+		setPaginationHeaders(c, result.Total, result.Limit, result.Offset)
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/alternatives
+	scheduling.Get("/alternatives", func(c fiber.Ctx) error {
+		resourceIDStr := c.Query("resource_id")
+		startTimeStr := c.Query("start_time")
+		endTimeStr := c.Query("end_time")
+
+		if resourceIDStr == "" || startTimeStr == "" || endTimeStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "resource_id, start_time, and end_time are required",
+			})
+		}
+
+		resourceID, err := parseID(resourceIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "resource_id must be a valid integer",
+			})
+		}
+
+		startTime, err := parseTime(startTimeStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_time",
+				Message: "start_time must be " + timeFormatHint,
+			})
+		}
+
+		endTime, err := parseTime(endTimeStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_time",
+				Message: "end_time must be " + timeFormatHint,
+			})
+		}
+
+		alternatives, err := conflictService.SuggestAlternativeResources(c.Context(), resourceID, startTime, endTime)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int("alternative_count", len(alternatives)).
+			Msg("Alternative resources suggested")
+
+		return c.JSON(domain.AlternativeResourcesResponse{
+			ResourceID:   resourceID,
+			StartTime:    startTime,
+			EndTime:      endTime,
+			Alternatives: alternatives,
+		})
+	})
+
+	// POST /api/v1/scheduling/revalidate
+	scheduling.Post("/revalidate", func(c fiber.Ctx) error {
+		eventIDStr := c.Query("event_id")
+		resourceIDStr := c.Query("resource_id")
+		if eventIDStr == "" && resourceIDStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "event_id or resource_id is required",
+			})
+		}
+		if eventIDStr != "" && resourceIDStr != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_parameters",
+				Message: "event_id cannot be combined with resource_id",
+			})
+		}
+
+		var req domain.RevalidateRequest
+		if eventIDStr != "" {
+			eventID, err := parseID(eventIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_event_id",
+					Message: "event_id must be a valid integer",
+				})
+			}
+			req.EventID = &eventID
+		} else {
+			resourceID, err := parseID(resourceIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_resource_id",
+					Message: "resource_id must be a valid integer",
+				})
+			}
+			req.ResourceID = &resourceID
+		}
+
+		result, err := conflictService.Revalidate(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Str("scope", string(result.Scope)).
+			Int32("scope_id", result.ScopeID).
+			Int("conflict_count", result.ConflictCount).
+			Msg("Conflicts revalidated")
+
+		return c.JSON(result)
+	})
+}
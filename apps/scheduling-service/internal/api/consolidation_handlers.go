@@ -0,0 +1,76 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerConsolidationRoutes(scheduling fiber.Router, consolidationService *scheduler.ConsolidationService) {
+	// GET /api/v1/scheduling/resources/:id/consolidation-suggestions
+	scheduling.Get("/resources/:id/consolidation-suggestions", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		req := domain.ConsolidationSuggestionsRequest{ResourceID: id}
+		if v := c.Query("event_id"); v != "" {
+			eventID, err := parseID(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_event_id",
+					Message: "event_id must be a valid integer",
+				})
+			}
+			req.EventID = &eventID
+		}
+
+		result, err := consolidationService.SuggestConsolidations(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/resources/:id/consolidation-suggestions
+	scheduling.Post("/resources/:id/consolidation-suggestions", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		var req domain.ConsolidateRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			log.Warn().Err(err).Msg("Invalid request body for consolidation")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+		req.ResourceID = id
+
+		result, err := consolidationService.Consolidate(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int32("resource_id", id).
+			Int32("schedule_id", result.Entry.ID).
+			Int("removed_count", len(result.RemovedIDs)).
+			Msg("Schedule entries consolidated")
+
+		return c.JSON(result)
+	})
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerCostRoutes(scheduling fiber.Router, costService *scheduler.CostService) {
+	// GET /api/v1/scheduling/clients/:client_id/cost
+	scheduling.Get("/clients/:client_id/cost", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		clientID, err := strconv.ParseInt(c.Params("client_id"), 10, 32)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_client_id",
+				Message: "client_id must be a valid integer",
+			})
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		result, err := costService.GetClientCost(c.Context(), domain.ClientCostRequest{
+			ClientID:      int32(clientID),
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int32("client_id", int32(clientID)).
+			Int("resource_count", len(result.Resources)).
+			Msg("Client cost computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/events/:event_id/marginal-cost
+	scheduling.Get("/events/:event_id/marginal-cost", func(c fiber.Ctx) error {
+		eventID, err := parseID(c.Params("event_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "event_id must be a valid integer",
+			})
+		}
+
+		resourceIDStr := c.Query("resource_id")
+		startTimeStr := c.Query("start_time")
+		endTimeStr := c.Query("end_time")
+		if resourceIDStr == "" || startTimeStr == "" || endTimeStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "resource_id, start_time, and end_time are required",
+			})
+		}
+
+		resourceID, err := parseID(resourceIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "resource_id must be a valid integer",
+			})
+		}
+
+		startTime, err := parseTime(startTimeStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_time",
+				Message: "start_time must be " + timeFormatHint,
+			})
+		}
+
+		endTime, err := parseTime(endTimeStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_time",
+				Message: "end_time must be " + timeFormatHint,
+			})
+		}
+
+		result, err := costService.GetMarginalCost(c.Context(), domain.MarginalCostRequest{
+			EventID:    eventID,
+			ResourceID: resourceID,
+			StartTime:  startTime,
+			EndTime:    endTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/cost-with-overtime
+	scheduling.Get("/resources/:id/cost-with-overtime", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		result, err := costService.GetOvertimeCost(c.Context(), domain.OvertimeCostRequest{
+			ResourceID: resourceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(result)
+	})
+}
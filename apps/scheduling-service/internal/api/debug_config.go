@@ -0,0 +1,60 @@
+package api
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/config"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// DebugConfigResponse is the effective configuration of the running instance,
+// with secrets redacted
+type DebugConfigResponse struct {
+	Config       config.Config   `json:"config"`
+	Pool         DebugPoolStatus `json:"pool"`
+	FeatureFlags map[string]bool `json:"feature_flags"`
+}
+
+// DebugPoolStatus reports the configured connection pool limits alongside the
+// pool's current state
+type DebugPoolStatus struct {
+	MaxOpenConns    int    `json:"max_open_conns"`
+	MaxIdleConns    int    `json:"max_idle_conns"`
+	ConnMaxLifetime string `json:"conn_max_lifetime"`
+	ConnMaxIdleTime string `json:"conn_max_idle_time"`
+	OpenConnections int    `json:"open_connections"`
+	InUse           int    `json:"in_use"`
+	Idle            int    `json:"idle"`
+}
+
+// debugConfigHandler reports the configuration the process loaded from its
+// environment. The database password is never included.
+func debugConfigHandler(db *sql.DB, flags *scheduler.FlagService) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to load configuration",
+			})
+		}
+
+		stats := db.Stats()
+		return c.JSON(DebugConfigResponse{
+			Config: cfg.Redacted(),
+			Pool: DebugPoolStatus{
+				MaxOpenConns:    repository.Pool.MaxOpenConns,
+				MaxIdleConns:    repository.Pool.MaxIdleConns,
+				ConnMaxLifetime: repository.Pool.ConnMaxLifetime.String(),
+				ConnMaxIdleTime: repository.Pool.ConnMaxIdleTime.String(),
+				OpenConnections: stats.OpenConnections,
+				InUse:           stats.InUse,
+				Idle:            stats.Idle,
+			},
+			FeatureFlags: flags.States(c.Context()),
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// ErrorHandler is the app-wide fiber error handler. A handler can return a
+// service error as is: domain errors get the status for their code, fiber
+// errors (unknown routes, oversized bodies) keep theirs, and anything else is
+// logged and reported as a 500. Every error uses the ErrorResponse envelope.
+func ErrorHandler(c fiber.Ctx, err error) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return writeDomainError(c, domainErr, "Request failed")
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return c.Status(fiberErr.Code).JSON(ErrorResponse{
+			Error:   errorName(fiberErr.Code),
+			Message: fiberErr.Message,
+		})
+	}
+
+	requestLogger(c).Error().Err(err).Str("route", c.Route().Path).Msg("Unhandled error")
+	return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+		Error:   "internal_error",
+		Message: "Internal server error",
+	})
+}
+
+// errorStatus returns the HTTP status for a domain error code
+func errorStatus(code domain.ErrorCode) int {
+	switch code {
+	case domain.ErrCodeValidation:
+		return fiber.StatusBadRequest
+	case domain.ErrCodeNotFound:
+		return fiber.StatusNotFound
+	case domain.ErrCodeConflict:
+		return fiber.StatusConflict
+	case domain.ErrCodeTimeout:
+		return fiber.StatusGatewayTimeout
+	case domain.ErrCodeForbidden:
+		return fiber.StatusForbidden
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// writeDomainError writes a domain error with the status for its code. Server
+// side failures are logged under message; client errors aren't.
+func writeDomainError(c fiber.Ctx, domainErr *domain.DomainError, message string) error {
+	status := errorStatus(domainErr.Code)
+	if status >= fiber.StatusInternalServerError {
+		requestLogger(c).Error().Err(domainErr).Msg(message)
+	}
+	return c.Status(status).JSON(ErrorResponse{
+		Error:     string(domainErr.Code),
+		Message:   domainErr.Message,
+		Conflicts: domainErr.Conflicts,
+	})
+}
+
+// errorName turns an HTTP status into an error name such as "not_found"
+func errorName(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+func TestErrorHandler_MapsErrors(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	errs := map[string]error{
+		"validation": domain.NewValidationError("end_time must be after start_time"),
+		"not-found":  domain.NewNotFoundError("resource not found"),
+		"conflict":   domain.NewConflictError("schedule entry is cancelled"),
+		"internal":   domain.NewInternalError("failed to get resource", errors.New("connection reset")),
+		"timeout":    domain.NewTimeoutError("database query timed out", errors.New("canceling statement")),
+		"forbidden":  domain.NewForbiddenError("requires the administrator role"),
+		"wrapped":    errors.Join(errors.New("loading"), domain.NewNotFoundError("event not found")),
+		"plain":      errors.New("boom"),
+	}
+	for name, err := range errs {
+		app.Get("/"+name, func(c fiber.Ctx) error { return err })
+	}
+
+	tests := []struct {
+		path    string
+		status  int
+		error   string
+		message string
+	}{
+		{"/validation", http.StatusBadRequest, "VALIDATION", "end_time must be after start_time"},
+		{"/not-found", http.StatusNotFound, "NOT_FOUND", "resource not found"},
+		{"/conflict", http.StatusConflict, "CONFLICT", "schedule entry is cancelled"},
+		{"/internal", http.StatusInternalServerError, "INTERNAL", "failed to get resource"},
+		{"/timeout", http.StatusGatewayTimeout, "TIMEOUT", "database query timed out"},
+		{"/forbidden", http.StatusForbidden, "FORBIDDEN", "requires the administrator role"},
+		{"/wrapped", http.StatusNotFound, "NOT_FOUND", "event not found"},
+		{"/plain", http.StatusInternalServerError, "internal_error", "Internal server error"},
+		{"/no-such-route", http.StatusNotFound, "not_found", "Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+
+			body, _ := io.ReadAll(resp.Body)
+			var result ErrorResponse
+			require.NoError(t, json.Unmarshal(body, &result))
+			assert.Equal(t, tt.error, result.Error)
+			assert.Equal(t, tt.message, result.Message)
+			// The underlying cause is never exposed
+			assert.NotContains(t, string(body), "connection reset")
+		})
+	}
+}
+
+func TestErrorHandler_IncludesBookingConflicts(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/entries", func(c fiber.Ctx) error {
+		return domain.NewBookingConflictError("resource is already booked", []domain.Conflict{
+			{Kind: domain.ConflictKindBooking, ResourceID: 4, ConflictingEventID: 9},
+		})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/entries", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	var result ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, int32(9), result.Conflicts[0].ConflictingEventID)
+}
@@ -7,8 +7,9 @@ import (
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/catering-event-manager/scheduling-service/internal/domain"
-	"github.com/catering-event-manager/scheduling-service/internal/logger"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
 	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+	"github.com/catering-event-manager/scheduling-service/internal/webhook"
 )
 
 type HealthResponse struct {
@@ -20,15 +21,86 @@ type ErrorResponse struct {
 	Error   string `json:"error"`
 	Code    string `json:"code,omitempty"`
 	Message string `json:"message,omitempty"`
+	// Conflicts lists the bookings that caused a 409, when known
+	Conflicts []domain.Conflict `json:"conflicts,omitempty"`
 }
 
-func RegisterRoutes(app *fiber.App, db *sql.DB) {
-	// Initialize services
-	conflictService := scheduler.NewConflictService(db)
-	availabilityService := scheduler.NewAvailabilityService(db)
+// checkConflictsBody is the wire form of domain.CheckConflictsRequest, with
+// times decoded through parseTime
+type checkConflictsBody struct {
+	ResourceIDs       []int32     `json:"resource_ids"`
+	StartTime         requestTime `json:"start_time"`
+	EndTime           requestTime `json:"end_time"`
+	ExcludeScheduleID *int32      `json:"exclude_schedule_id,omitempty"`
+	ExternalBusy      []timeRange `json:"external_busy,omitempty"`
+	MinOverlapMinutes int32       `json:"min_overlap_minutes,omitempty"`
+	BufferMinutes     int32       `json:"buffer_minutes,omitempty"`
+	CountOnly         bool        `json:"count_only,omitempty"`
+	// RequiredCertification names a certification every resource must hold
+	RequiredCertification string `json:"required_certification,omitempty"`
+	Limit                 int32  `json:"limit,omitempty"`
+	Offset                int32  `json:"offset,omitempty"`
+	Sort                  string `json:"sort,omitempty"`
+	Quantity              int32  `json:"quantity,omitempty"`
+	DurationFormat        string `json:"duration_format,omitempty"`
+	BestEffort            bool   `json:"best_effort,omitempty"`
+	GroupByResource       bool   `json:"group_by_resource,omitempty"`
+	RejectPast            bool   `json:"reject_past,omitempty"`
+}
+
+func (b checkConflictsBody) toDomain() domain.CheckConflictsRequest {
+	req := domain.CheckConflictsRequest{
+		ResourceIDs:           b.ResourceIDs,
+		StartTime:             b.StartTime.Time,
+		EndTime:               b.EndTime.Time,
+		ExcludeScheduleID:     b.ExcludeScheduleID,
+		MinOverlapMinutes:     b.MinOverlapMinutes,
+		BufferMinutes:         b.BufferMinutes,
+		CountOnly:             b.CountOnly,
+		RequiredCertification: b.RequiredCertification,
+		Limit:                 b.Limit,
+		Offset:                b.Offset,
+		Sort:                  domain.ConflictSort(b.Sort),
+		Quantity:              b.Quantity,
+		DurationFormat:        domain.DurationFormat(b.DurationFormat),
+		BestEffort:            b.BestEffort,
+		GroupByResource:       b.GroupByResource,
+		RejectPast:            b.RejectPast,
+	}
+	for _, r := range b.ExternalBusy {
+		req.ExternalBusy = append(req.ExternalBusy, r.toDomain())
+	}
+	return req
+}
+
+// checkConflictsBatchBody holds independent conflict checks to run together
+type checkConflictsBatchBody struct {
+	Checks []checkConflictsBody `json:"checks"`
+}
+
+// RegisterRoutes mounts the API on app. Services changing schedule entries
+// publish the changes through publisher, callers' bearer tokens are verified
+// by auth, and conflicts found are posted to conflictWebhook unless it is nil.
+func RegisterRoutes(app *fiber.App, db *sql.DB, publisher events.Publisher, auth *Authenticator, conflictWebhook *webhook.Notifier) {
+	// Initialize services, sharing one feature flag cache
+	flags := scheduler.NewFlagService(db)
+	conflictService := scheduler.NewConflictService(db, flags, conflictWebhook)
+	availabilityService := scheduler.NewAvailabilityService(db, flags)
+	costService := scheduler.NewCostService(db)
+	resourceService := scheduler.NewResourceService(db)
+	reportService := scheduler.NewReportService(db)
+	integrityService := scheduler.NewIntegrityService(db, publisher)
+	scheduleService := scheduler.NewScheduleService(db, conflictService, publisher)
+	assignmentService := scheduler.NewAssignmentService(db, availabilityService)
+	consolidationService := scheduler.NewConsolidationService(db, publisher)
+	mergeService := scheduler.NewEventMergeService(db, publisher)
+	blackoutService := scheduler.NewBlackoutService(db)
 
 	api := app.Group("/api/v1")
 
+	// Identify the caller, for role checks and auditing
+	api.Use(Authenticate(auth))
+
 	// Health check endpoint
 	api.Get("/health", func(c fiber.Ctx) error {
 		dbStatus := "connected"
@@ -42,40 +114,37 @@ func RegisterRoutes(app *fiber.App, db *sql.DB) {
 		})
 	})
 
+	// Detailed health for operators; load balancers keep using /health
+	api.Get("/health/detailed", detailedHealthHandler(db))
+
+	// Kubernetes probes: liveness only needs the process, readiness the database
+	api.Get("/livez", livezHandler)
+	api.Get("/readyz", readyzHandler(db))
+
+	// Build information, for support tickets
+	api.Get("/version", versionHandler)
+
 	// Scheduling endpoints
 	scheduling := api.Group("/scheduling")
 
 	// POST /api/v1/scheduling/check-conflicts
 	scheduling.Post("/check-conflicts", func(c fiber.Ctx) error {
-		log := logger.Get()
+		log := requestLogger(c)
 		startTime := time.Now()
 
-		var req domain.CheckConflictsRequest
-		if err := c.Bind().JSON(&req); err != nil {
+		var body checkConflictsBody
+		if err := c.Bind().JSON(&body); err != nil {
 			log.Warn().Err(err).Msg("Invalid request body for check-conflicts")
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 				Error:   "invalid_request",
 				Message: "Invalid request body",
 			})
 		}
+		req := body.toDomain()
 
 		result, err := conflictService.CheckConflicts(c.Context(), req)
 		if err != nil {
-			if domainErr, ok := err.(*domain.DomainError); ok {
-				status := fiber.StatusInternalServerError
-				if domainErr.Code == domain.ErrCodeValidation {
-					status = fiber.StatusBadRequest
-				}
-				return c.Status(status).JSON(ErrorResponse{
-					Error:   string(domainErr.Code),
-					Message: domainErr.Message,
-				})
-			}
-			log.Error().Err(err).Msg("Failed to check conflicts")
-			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to check conflicts",
-			})
+			return err
 		}
 
 		duration := time.Since(startTime)
@@ -88,79 +157,189 @@ func RegisterRoutes(app *fiber.App, db *sql.DB) {
 		return c.JSON(result)
 	})
 
+	// POST /api/v1/scheduling/check-conflicts/batch
+	scheduling.Post("/check-conflicts/batch", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+		startTime := time.Now()
+
+		var body checkConflictsBatchBody
+		if err := c.Bind().JSON(&body); err != nil {
+			log.Warn().Err(err).Msg("Invalid request body for check-conflicts batch")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+		reqs := make([]domain.CheckConflictsRequest, 0, len(body.Checks))
+		for _, check := range body.Checks {
+			reqs = append(reqs, check.toDomain())
+		}
+
+		results, err := conflictService.CheckConflictsBatch(c.Context(), reqs)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, r := range results {
+			if r.Error != nil {
+				failed++
+			}
+		}
+		log.Info().
+			Int("check_count", len(results)).
+			Int("failed_count", failed).
+			Dur("duration_ms", time.Since(startTime)).
+			Msg("Conflict batch completed")
+
+		return c.JSON(domain.CheckConflictsBatchResponse{Results: results})
+	})
+
 	// GET /api/v1/scheduling/resource-availability
 	scheduling.Get("/resource-availability", func(c fiber.Ctx) error {
-		log := logger.Get()
+		log := requestLogger(c)
+
+		// Several resources at once are a separate lookup
+		if c.Query("resource_ids") != "" {
+			return multiResourceAvailability(c, availabilityService)
+		}
 
 		// Parse query parameters
 		resourceIDStr := c.Query("resource_id")
+		externalCode := c.Query("external_code")
 		startDateStr := c.Query("start_date")
 		endDateStr := c.Query("end_date")
 
-		if resourceIDStr == "" || startDateStr == "" || endDateStr == "" {
+		if (resourceIDStr == "" && externalCode == "") || startDateStr == "" || endDateStr == "" {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 				Error:   "missing_parameters",
-				Message: "resource_id, start_date, and end_date are required",
+				Message: "resource_id or external_code, start_date, and end_date are required",
 			})
 		}
-
-		resourceID, err := strconv.ParseInt(resourceIDStr, 10, 32)
-		if err != nil {
+		if resourceIDStr != "" && externalCode != "" {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-				Error:   "invalid_resource_id",
-				Message: "resource_id must be a valid integer",
+				Error:   "invalid_parameters",
+				Message: "resource_id and external_code are mutually exclusive",
 			})
 		}
 
-		startDate, err := time.Parse(time.RFC3339, startDateStr)
+		var resourceID int64
+		var err error
+		if externalCode != "" {
+			// Integrations know resources by their external code
+			id, err := resourceService.ResolveExternalCode(c.Context(), externalCode)
+			if err != nil {
+				return err
+			}
+			resourceID = int64(id)
+		} else {
+			resourceID, err = strconv.ParseInt(resourceIDStr, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_resource_id",
+					Message: "resource_id must be a valid integer",
+				})
+			}
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 				Error:   "invalid_start_date",
-				Message: "start_date must be in RFC3339 format",
+				Message: "start_date must be " + timeFormatHint,
 			})
 		}
 
-		endDate, err := time.Parse(time.RFC3339, endDateStr)
+		endDate, err := parseTime(endDateStr, time.UTC)
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
 				Error:   "invalid_end_date",
-				Message: "end_date must be in RFC3339 format",
+				Message: "end_date must be " + timeFormatHint,
 			})
 		}
 
+		useResourceTZ := false
+		if v := c.Query("use_resource_tz"); v != "" {
+			useResourceTZ, err = strconv.ParseBool(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_use_resource_tz",
+					Message: "use_resource_tz must be true or false",
+				})
+			}
+		}
+
+		limit := 0
+		if v := c.Query("limit"); v != "" {
+			limit, err = strconv.Atoi(v)
+			if err != nil || limit <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_limit",
+					Message: "limit must be a positive integer",
+				})
+			}
+		}
+
 		req := domain.ResourceAvailabilityRequest{
-			ResourceID: int32(resourceID),
-			StartDate:  startDate,
-			EndDate:    endDate,
+			ResourceID:    int32(resourceID),
+			StartDate:     startDate,
+			EndDate:       endDate,
+			UseResourceTZ: useResourceTZ,
+			Limit:         limit,
+			Cursor:        c.Query("cursor"),
+			Sort:          domain.AvailabilitySort(c.Query("sort")),
+		}
+		if v := c.Query("event_id"); v != "" {
+			id, err := parseID(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_event_id",
+					Message: "event_id must be a valid integer",
+				})
+			}
+			req.EventID = &id
+		}
+		if v := c.Query("task_id"); v != "" {
+			id, err := parseID(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_task_id",
+					Message: "task_id must be a valid integer",
+				})
+			}
+			req.TaskID = &id
 		}
 
 		result, err := availabilityService.GetResourceAvailability(c.Context(), req)
 		if err != nil {
-			if domainErr, ok := err.(*domain.DomainError); ok {
-				status := fiber.StatusInternalServerError
-				switch domainErr.Code {
-				case domain.ErrCodeValidation:
-					status = fiber.StatusBadRequest
-				case domain.ErrCodeNotFound:
-					status = fiber.StatusNotFound
-				}
-				return c.Status(status).JSON(ErrorResponse{
-					Error:   string(domainErr.Code),
-					Message: domainErr.Message,
-				})
-			}
-			log.Error().Err(err).Int32("resource_id", int32(resourceID)).Msg("Failed to get resource availability")
-			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to get resource availability",
-			})
+			return err
 		}
 
 		log.Info().
 			Int32("resource_id", int32(resourceID)).
 			Int("entry_count", len(result.Entries)).
+			Int("total_count", int(result.TotalCount)).
 			Msg("Resource availability retrieved")
 
 		return c.JSON(result)
 	})
+
+	registerAvailabilityRoutes(scheduling, availabilityService)
+	registerCostRoutes(scheduling, costService)
+	registerResourceRoutes(scheduling, resourceService)
+	registerReportRoutes(scheduling, reportService)
+	registerIntegrityRoutes(scheduling, integrityService)
+	registerScheduleRoutes(scheduling, scheduleService)
+	registerAssignmentRoutes(scheduling, assignmentService)
+	registerCalendarRoutes(scheduling, scheduleService)
+	registerConflictRoutes(scheduling, conflictService)
+	registerConsolidationRoutes(scheduling, consolidationService)
+	registerMergeRoutes(scheduling, mergeService)
+	registerResolutionRoutes(scheduling, availabilityService)
+	registerPlanRoutes(scheduling, scheduleService)
+	registerBlackoutRoutes(scheduling, blackoutService)
+
+	if debugEndpointsEnabled() {
+		registerDebugRoutes(scheduling, db, flags)
+	}
 }
@@ -2,11 +2,13 @@ package api
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
 	"github.com/catering-event-manager/scheduling-service/internal/testutil"
 )
 
@@ -24,9 +27,9 @@ func setupTestApp(t *testing.T) (*fiber.App, *testutil.TestDB) {
 
 	testDB := testutil.SetupTestDB(t)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
 	RegisterMiddleware(app)
-	RegisterRoutes(app, testDB.DB)
+	RegisterRoutes(app, testDB.DB, events.Noop{}, NewAuthenticator(testAuthSecret), nil)
 
 	return app, testDB
 }
@@ -180,6 +183,35 @@ func TestCheckConflicts_ValidationError(t *testing.T) {
 	assert.Equal(t, "VALIDATION", result.Error)
 }
 
+func TestBulkResourceAvailability_AdministratorOnly(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Oven", Type: testutil.ResourceTypeEquipment})
+	post := func(authorization string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduling/resources/availability",
+			strings.NewReader(`{"resource_ids": [`+itoa(int(oven))+`], "is_available": false}`))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusForbidden, post("").StatusCode)
+	assert.Equal(t, http.StatusForbidden, post(bearer(1, RoleManager)).StatusCode)
+	var available bool
+	require.NoError(t, testDB.DB.QueryRow("SELECT is_available FROM resources WHERE id = $1", oven).Scan(&available))
+	assert.True(t, available, "a refused request changes nothing")
+
+	assert.Equal(t, http.StatusOK, post(bearer(1, RoleAdministrator)).StatusCode)
+	require.NoError(t, testDB.DB.QueryRow("SELECT is_available FROM resources WHERE id = $1", oven).Scan(&available))
+	assert.False(t, available)
+}
+
 func TestResourceAvailability_Success(t *testing.T) {
 	app, testDB := setupTestApp(t)
 	defer testutil.TeardownTestDB(t, testDB)
@@ -215,6 +247,150 @@ func TestResourceAvailability_Success(t *testing.T) {
 	assert.Len(t, result.Entries, 1)
 }
 
+func TestResourceAvailability_FilterAndSort(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, wedding := testutil.SetupBaseData(t, testDB.DB)
+	gala := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	setup := testutil.CreateTask(t, testDB.DB, wedding, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int, eventID int32, taskID *int32) int32 {
+		start := baseDay.Add(time.Duration(hour) * time.Hour)
+		return testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, start, start.Add(time.Hour),
+			&testutil.ScheduleEntryOpts{TaskID: taskID})
+	}
+	weddingSetup := at(8, wedding, &setup)
+	weddingService := at(10, wedding, nil)
+	galaEntry := at(12, gala, nil)
+
+	base := "/api/v1/scheduling/resource-availability?resource_id=" + itoa(int(resourceID)) +
+		"&start_date=2025-06-15&end_date=2025-06-16"
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int32
+	}{
+		{"no filter", "", []int32{weddingSetup, weddingService, galaEntry}},
+		{"start_asc", "&sort=start_asc", []int32{weddingSetup, weddingService, galaEntry}},
+		{"start_desc", "&sort=start_desc", []int32{galaEntry, weddingService, weddingSetup}},
+		{"event", "&event_id=" + itoa(int(wedding)), []int32{weddingSetup, weddingService}},
+		{"event descending", "&event_id=" + itoa(int(wedding)) + "&sort=start_desc", []int32{weddingService, weddingSetup}},
+		{"task", "&task_id=" + itoa(int(setup)), []int32{weddingSetup}},
+		{"task descending", "&task_id=" + itoa(int(setup)) + "&sort=start_desc", []int32{weddingSetup}},
+		{"event and task", "&event_id=" + itoa(int(wedding)) + "&task_id=" + itoa(int(setup)), []int32{weddingSetup}},
+		{"task of another event", "&event_id=" + itoa(int(gala)) + "&task_id=" + itoa(int(setup)), []int32{}},
+		{"unknown event", "&event_id=999999", []int32{}},
+		{"unknown task", "&task_id=999999&sort=start_desc", []int32{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, base+tt.query, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+			var result domain.ResourceAvailabilityResponse
+			require.NoError(t, json.Unmarshal(body, &result))
+
+			got := []int32{}
+			for _, e := range result.Entries {
+				got = append(got, e.ID)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, int64(len(tt.want)), result.TotalCount)
+		})
+	}
+
+	// Paging backwards keeps the descending order across pages
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, base+"&sort=start_desc&limit=2", nil))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var page domain.ResourceAvailabilityResponse
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.NotNil(t, page.NextCursor)
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, base+"&sort=start_desc&limit=2&cursor="+*page.NextCursor, nil))
+	require.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, weddingSetup, page.Entries[0].ID)
+	assert.Nil(t, page.NextCursor)
+}
+
+func TestResourceAvailability_InvalidFilterAndSort(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	base := "/api/v1/scheduling/resource-availability?start_date=2025-06-15&end_date=2025-06-16"
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{"unknown sort", "&resource_id=1&sort=name", "VALIDATION"},
+		{"bad event", "&resource_id=1&event_id=abc", "invalid_event_id"},
+		{"bad task", "&resource_id=1&task_id=0", "invalid_task_id"},
+		{"with resource_ids", "&resource_ids=1,2&sort=start_desc", "invalid_parameters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, base+tt.query, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			var result ErrorResponse
+			require.NoError(t, json.Unmarshal(body, &result))
+			assert.Equal(t, tt.code, result.Error)
+		})
+	}
+}
+
+func TestResourceAvailability_MultipleResources(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	first := testutil.CreateResource(t, testDB.DB, nil)
+	second := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, first, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, first, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(17*time.Hour), nil)
+
+	startDate := baseDay.Format(time.RFC3339)
+	endDate := baseDay.Add(24 * time.Hour).Format(time.RFC3339)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?resource_ids="+
+			itoa(int(first))+","+itoa(int(second))+"&start_date="+startDate+"&end_date="+endDate, nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result domain.MultiResourceAvailabilityResponse
+	err = json.Unmarshal(body, &result)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Resources, 2)
+	assert.Len(t, result.Resources[first], 2)
+	assert.Empty(t, result.Resources[second])
+}
+
 func TestResourceAvailability_MissingParams(t *testing.T) {
 	app, testDB := setupTestApp(t)
 	defer testutil.TeardownTestDB(t, testDB)
@@ -262,6 +438,79 @@ func TestResourceAvailability_InvalidResourceID(t *testing.T) {
 	assert.Equal(t, "invalid_resource_id", result.Error)
 }
 
+func TestResourceAvailability_ByExternalCode(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	code := "OVEN-7"
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{ExternalCode: &code, IsAvailable: true})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour), nil)
+
+	startDate := baseDay.Format(time.RFC3339)
+	endDate := baseDay.Add(24 * time.Hour).Format(time.RFC3339)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?external_code="+code+"&start_date="+startDate+"&end_date="+endDate, nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result domain.ResourceAvailabilityResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, resourceID, result.ResourceID)
+	assert.Len(t, result.Entries, 1)
+}
+
+func TestResourceAvailability_UnknownExternalCode(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?external_code=NOPE-1&start_date=2025-06-15&end_date=2025-06-16", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, "NOT_FOUND", result.Error)
+	assert.Equal(t, `no resource has external_code "NOPE-1"`, result.Message)
+}
+
+func TestResourceAvailability_IDAndExternalCode(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?resource_id=1&external_code=OVEN-7&start_date=2025-06-15&end_date=2025-06-16", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	assert.Equal(t, "invalid_parameters", result.Error)
+}
+
 func TestResourceAvailability_InvalidDateFormat(t *testing.T) {
 	app, testDB := setupTestApp(t)
 	defer testutil.TeardownTestDB(t, testDB)
@@ -312,6 +561,354 @@ func TestResourceAvailability_ValidationError_EndBeforeStart(t *testing.T) {
 	assert.Equal(t, "VALIDATION", result.Error)
 }
 
+func TestResourceAvailability_DateOnlyParams(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour), nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?resource_id="+
+			itoa(int(resourceID))+"&start_date=2025-06-15&end_date=2025-06-16", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result domain.ResourceAvailabilityResponse
+	err = json.Unmarshal(body, &result)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Entries, 1)
+}
+
+func TestCheckConflicts_PendingBookingsBlockFlag(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+
+	body, _ := json.Marshal(domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(10 * time.Hour),
+		EndTime:     baseDay.Add(14 * time.Hour),
+	})
+
+	// A fresh app per case so flags aren't served from another case's cache
+	check := func(t *testing.T) domain.CheckConflictsResponse {
+		app := fiber.New()
+		RegisterRoutes(app, testDB.DB, events.Noop{}, NewAuthenticator(testAuthSecret), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduling/check-conflicts", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		respBody, _ := io.ReadAll(resp.Body)
+		var result domain.CheckConflictsResponse
+		require.NoError(t, json.Unmarshal(respBody, &result))
+		require.Len(t, result.Conflicts, 1)
+		return result
+	}
+
+	t.Run("flag off warns", func(t *testing.T) {
+		testutil.SetFeatureFlag(t, testDB.DB, "pending_bookings_block", false)
+
+		result := check(t)
+		assert.False(t, result.HasHardConflicts)
+		assert.Equal(t, domain.ConflictSeveritySoft, result.Conflicts[0].Severity)
+	})
+
+	t.Run("flag on blocks", func(t *testing.T) {
+		testutil.SetFeatureFlag(t, testDB.DB, "pending_bookings_block", true)
+
+		result := check(t)
+		assert.True(t, result.HasHardConflicts)
+		assert.Equal(t, domain.ConflictSeverityHard, result.Conflicts[0].Severity)
+	})
+}
+
+func TestResourceAvailability_UseResourceTZ(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chicago := "America/Chicago"
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Lakeside Venue",
+		Type:     testutil.ResourceTypeEquipment,
+		Timezone: &chicago,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(15*time.Hour), baseDay.Add(18*time.Hour), nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/scheduling/resource-availability?resource_id="+
+			itoa(int(resourceID))+"&start_date=2025-06-15&end_date=2025-06-16&use_resource_tz=true", nil)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result struct {
+		Timezone string `json:"timezone"`
+		Entries  []struct {
+			StartTime string `json:"start_time"`
+			EndTime   string `json:"end_time"`
+		} `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal(body, &result))
+
+	// 15:00 UTC is 10:00 CDT
+	assert.Equal(t, "America/Chicago", result.Timezone)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, "2025-06-15T10:00:00-05:00", result.Entries[0].StartTime)
+	assert.Equal(t, "2025-06-15T13:00:00-05:00", result.Entries[0].EndTime)
+}
+
+func TestScheduleEntries_CRUD(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef"})
+
+	send := func(method, path, body string) (*http.Response, []byte) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+	entryBody := func(start, end string) string {
+		return fmt.Sprintf(`{"resource_id": %d, "event_id": %d, "start_time": %q, "end_time": %q}`,
+			resourceID, eventID, start, end)
+	}
+
+	// Create
+	resp, body := send(http.MethodPost, "/api/v1/scheduling/schedule-entries",
+		entryBody("2025-06-15T09:00:00Z", "2025-06-15T12:00:00Z"))
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	var created domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(body, &created))
+	entryPath := "/api/v1/scheduling/schedule-entries/" + itoa(int(created.ID))
+
+	// An overlapping booking is refused with the conflicts
+	resp, body = send(http.MethodPost, "/api/v1/scheduling/schedule-entries",
+		entryBody("2025-06-15T11:00:00Z", "2025-06-15T13:00:00Z"))
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	var conflict ErrorResponse
+	require.NoError(t, json.Unmarshal(body, &conflict))
+	require.Len(t, conflict.Conflicts, 1)
+	assert.Equal(t, "Chef", conflict.Conflicts[0].ResourceName)
+
+	// Read
+	resp, body = send(http.MethodGet, entryPath, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var fetched domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(body, &fetched))
+	assert.Equal(t, created.ID, fetched.ID)
+
+	// Update over its own range
+	resp, body = send(http.MethodPut, entryPath,
+		entryBody("2025-06-15T10:00:00Z", "2025-06-15T13:00:00Z"))
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var updated domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(body, &updated))
+	assert.Equal(t, "2025-06-15T13:00:00Z", updated.EndTime.UTC().Format(time.RFC3339))
+
+	// Delete is for administrators only
+	resp, _ = send(http.MethodDelete, entryPath, "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	req := httptest.NewRequest(http.MethodDelete, entryPath, nil)
+	req.Header.Set("Authorization", bearer(1, RoleManager))
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodDelete, entryPath, nil)
+	req.Header.Set("Authorization", bearer(1, RoleAdministrator))
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, _ = send(http.MethodGet, entryPath, "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestScheduleEntries_EntriesPath(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	send := func(method, path, body string) (*http.Response, []byte) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+
+	resp, body := send(http.MethodPost, "/api/v1/scheduling/entries",
+		fmt.Sprintf(`{"resource_id": %d, "event_id": %d, "start_time": "2025-06-15T09:00:00Z", "end_time": "2025-06-15T12:00:00Z"}`,
+			resourceID, eventID))
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	var created domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(body, &created))
+
+	resp, body = send(http.MethodPost, "/api/v1/scheduling/entries/"+itoa(int(created.ID))+"/cancel", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	// /schedule-entries still serves the same entries
+	resp, body = send(http.MethodGet, "/api/v1/scheduling/schedule-entries/"+itoa(int(created.ID)), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var fetched domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(body, &fetched))
+	assert.NotNil(t, fetched.CancelledAt)
+}
+
+func TestScheduleEntries_CreatedByFromToken(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	create := func(path, auth, body string) domain.ScheduleEntry {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(respBody))
+
+		if path == "/api/v1/scheduling/schedule-entries/recurring" {
+			var result domain.RecurringEntryResponse
+			require.NoError(t, json.Unmarshal(respBody, &result))
+			require.NotEmpty(t, result.Created)
+			return result.Created[0]
+		}
+		var entry domain.ScheduleEntry
+		require.NoError(t, json.Unmarshal(respBody, &entry))
+		return entry
+	}
+	storedCreatedBy := func(id int32) *int32 {
+		var createdBy sql.NullInt32
+		require.NoError(t, testDB.DB.QueryRow("SELECT created_by FROM resource_schedule WHERE id = $1", id).Scan(&createdBy))
+		if !createdBy.Valid {
+			return nil
+		}
+		return &createdBy.Int32
+	}
+	entryBody := func(day int) string {
+		return fmt.Sprintf(`{"resource_id": %d, "event_id": %d, "start_time": "2025-06-%02dT09:00:00Z", "end_time": "2025-06-%02dT12:00:00Z"}`,
+			resourceID, eventID, day, day)
+	}
+
+	// The token's user is recorded, in the response and the row
+	entry := create("/api/v1/scheduling/schedule-entries", bearer(userID, RoleManager), entryBody(15))
+	require.NotNil(t, entry.CreatedBy)
+	assert.Equal(t, userID, *entry.CreatedBy)
+	assert.Equal(t, &userID, storedCreatedBy(entry.ID))
+
+	// Recurring bookings record it on every occurrence
+	recurring := create("/api/v1/scheduling/schedule-entries/recurring", bearer(userID, RoleAdministrator),
+		fmt.Sprintf(`{"entry": %s, "rule": {"frequency": "daily", "interval": 1, "until": "2025-06-21T23:00:00Z"}}`, entryBody(20)))
+	assert.Equal(t, &userID, storedCreatedBy(recurring.ID))
+
+	// Without a token there is no user to record
+	anonymous := create("/api/v1/scheduling/schedule-entries", "", entryBody(16))
+	assert.Nil(t, anonymous.CreatedBy)
+	assert.Nil(t, storedCreatedBy(anonymous.ID))
+}
+
+func TestScheduleEntries_IdempotencyKey(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	create := func(key, body string) (*http.Response, []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scheduling/schedule-entries", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, respBody
+	}
+	body := fmt.Sprintf(`{"resource_id": %d, "event_id": %d, "start_time": "2025-06-15T09:00:00Z", "end_time": "2025-06-15T12:00:00Z"}`,
+		resourceID, eventID)
+
+	resp, first := create("booking-1", body)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(first))
+	assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+	var created domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(first, &created))
+
+	// The retry, reformatted, returns the same entry without booking again,
+	// where a fresh booking of the slot would conflict
+	retry := fmt.Sprintf(`{"end_time":"2025-06-15T12:00:00Z","start_time":"2025-06-15T09:00:00Z","event_id":%d,"resource_id":%d}`,
+		eventID, resourceID)
+	resp, replayed := create("booking-1", retry)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(replayed))
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	var again domain.ScheduleEntry
+	require.NoError(t, json.Unmarshal(replayed, &again))
+	assert.Equal(t, created.ID, again.ID)
+
+	var count int
+	require.NoError(t, testDB.DB.QueryRow("SELECT COUNT(*) FROM resource_schedule").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// The same key for a different booking is refused
+	other := fmt.Sprintf(`{"resource_id": %d, "event_id": %d, "start_time": "2025-06-16T09:00:00Z", "end_time": "2025-06-16T12:00:00Z"}`,
+		resourceID, eventID)
+	resp, refused := create("booking-1", other)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, string(refused))
+	require.NoError(t, testDB.DB.QueryRow("SELECT COUNT(*) FROM resource_schedule").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// A different key books it
+	resp, _ = create("booking-2", other)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
 // Helper function to convert int to string
 func itoa(i int) string {
 	return fmt.Sprintf("%d", i)
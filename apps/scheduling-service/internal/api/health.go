@@ -0,0 +1,135 @@
+package api
+
+import (
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/version"
+)
+
+// startedAt is when the process started, for uptime
+var startedAt = time.Now()
+
+// draining is set once shutdown starts, so readiness fails while in-flight
+// requests finish
+var draining atomic.Bool
+
+// SetDraining marks the service as shutting down. From then on /readyz
+// responds 503, so load balancers stop routing new requests here.
+func SetDraining() {
+	draining.Store(true)
+}
+
+// ProbeResponse is the body of the liveness probe
+type ProbeResponse struct {
+	Status string `json:"status"`
+}
+
+// VersionResponse identifies the running build
+type VersionResponse struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// DetailedHealthResponse adds the connection pool's state, uptime, and build
+// version to the basic health check
+type DetailedHealthResponse struct {
+	Status        string    `json:"status"`
+	Database      string    `json:"database"`
+	Version       string    `json:"version"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	Pool          PoolStats `json:"pool"`
+}
+
+// PoolStats is the database connection pool's state, from sql.DBStats
+type PoolStats struct {
+	MaxOpenConnections int `json:"max_open_connections"`
+	OpenConnections    int `json:"open_connections"`
+	InUse              int `json:"in_use"`
+	Idle               int `json:"idle"`
+	// WaitCount and WaitDurationMs total the waits for a free connection
+	// since startup; a rising count means the pool is exhausted
+	WaitCount         int64 `json:"wait_count"`
+	WaitDurationMs    int64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+}
+
+// toPoolStats converts the pool statistics reported by database/sql
+func toPoolStats(stats sql.DBStats) PoolStats {
+	return PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+}
+
+// detailedHealthHandler reports the same status as /health along with the
+// pool's state, so connection exhaustion shows before requests start failing.
+// Like /health it responds 200 even when the database is unreachable.
+func detailedHealthHandler(db *sql.DB) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		dbStatus := "connected"
+		if err := db.PingContext(c.Context()); err != nil {
+			dbStatus = "disconnected"
+		}
+
+		return c.JSON(DetailedHealthResponse{
+			Status:        "ok",
+			Database:      dbStatus,
+			Version:       version.Version,
+			UptimeSeconds: int64(time.Since(startedAt).Seconds()),
+			Pool:          toPoolStats(db.Stats()),
+		})
+	}
+}
+
+// versionHandler reports the build information stamped in at build time
+func versionHandler(c fiber.Ctx) error {
+	return c.JSON(VersionResponse{
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildTime: version.BuildTime,
+	})
+}
+
+// livezHandler answers the liveness probe. It touches nothing but the process
+// itself, so a database outage doesn't get the pod restarted.
+func livezHandler(c fiber.Ctx) error {
+	return c.JSON(ProbeResponse{Status: "ok"})
+}
+
+// readyzHandler answers the readiness probe: 200 when the database answers a
+// ping, 503 when it doesn't or once shutdown has started
+func readyzHandler(db *sql.DB) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if draining.Load() {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(HealthResponse{
+				Status:   "shutting_down",
+				Database: "unknown",
+			})
+		}
+		if err := db.PingContext(c.Context()); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Readiness check failed to reach database")
+			return c.Status(fiber.StatusServiceUnavailable).JSON(HealthResponse{
+				Status:   "unavailable",
+				Database: "disconnected",
+			})
+		}
+		return c.JSON(HealthResponse{
+			Status:   "ok",
+			Database: "connected",
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+	"github.com/catering-event-manager/scheduling-service/internal/version"
+)
+
+// getDetailedHealth requests the detailed health check and decodes it both
+// into its type and as raw fields
+func getDetailedHealth(t *testing.T, app *fiber.App) (DetailedHealthResponse, map[string]json.RawMessage) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/health/detailed", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result DetailedHealthResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+	var raw struct {
+		Pool map[string]json.RawMessage `json:"pool"`
+	}
+	require.NoError(t, json.Unmarshal(body, &raw))
+	return result, raw.Pool
+}
+
+func TestDetailedHealth_Success(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	testDB.DB.SetMaxOpenConns(50)
+
+	result, pool := getDetailedHealth(t, app)
+
+	assert.Equal(t, "ok", result.Status)
+	assert.Equal(t, "connected", result.Database)
+	assert.Equal(t, version.Version, result.Version)
+	assert.GreaterOrEqual(t, result.UptimeSeconds, int64(0))
+	assert.Equal(t, 50, result.Pool.MaxOpenConnections)
+	// The ping just used a connection, which is back in the pool
+	assert.GreaterOrEqual(t, result.Pool.OpenConnections, 1)
+	assert.Zero(t, result.Pool.InUse)
+
+	for _, field := range []string{
+		"max_open_connections", "open_connections", "in_use", "idle",
+		"wait_count", "wait_duration_ms",
+		"max_idle_closed", "max_idle_time_closed", "max_lifetime_closed",
+	} {
+		assert.Contains(t, pool, field)
+	}
+}
+
+func TestDetailedHealth_DatabaseUnreachable(t *testing.T) {
+	// Nothing listens on port 1, so every ping fails straight away
+	db, err := sql.Open("postgres", "postgres://scheduler@127.0.0.1:1/scheduler?sslmode=disable&connect_timeout=1")
+	require.NoError(t, err)
+	defer db.Close()
+
+	app := fiber.New()
+	app.Get("/api/v1/health/detailed", detailedHealthHandler(db))
+
+	result, pool := getDetailedHealth(t, app)
+
+	assert.Equal(t, "ok", result.Status)
+	assert.Equal(t, "disconnected", result.Database)
+	assert.Zero(t, result.Pool.OpenConnections)
+	assert.Contains(t, pool, "wait_count")
+}
+
+// closedDB returns a pool that has already been closed, so every ping fails
+func closedDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("postgres", "postgres://scheduler@127.0.0.1:1/scheduler?sslmode=disable")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	return db
+}
+
+// setupProbeTestApp serves the probes backed by db
+func setupProbeTestApp(db *sql.DB) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/livez", livezHandler)
+	app.Get("/api/v1/readyz", readyzHandler(db))
+	return app
+}
+
+// getProbe requests a probe and decodes its body as a HealthResponse
+func getProbe(t *testing.T, app *fiber.App, path string) (int, HealthResponse) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var result HealthResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+	return resp.StatusCode, result
+}
+
+func TestProbes_HealthyDB(t *testing.T) {
+	app, testDB := setupTestApp(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	status, result := getProbe(t, app, "/api/v1/livez")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", result.Status)
+
+	status, result = getProbe(t, app, "/api/v1/readyz")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", result.Status)
+	assert.Equal(t, "connected", result.Database)
+}
+
+func TestProbes_ClosedDB(t *testing.T) {
+	app := setupProbeTestApp(closedDB(t))
+
+	// The process is still alive, so it mustn't be restarted
+	status, result := getProbe(t, app, "/api/v1/livez")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", result.Status)
+
+	status, result = getProbe(t, app, "/api/v1/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unavailable", result.Status)
+	assert.Equal(t, "disconnected", result.Database)
+}
+
+func TestReadyz_Draining(t *testing.T) {
+	app := setupProbeTestApp(closedDB(t))
+	SetDraining()
+	t.Cleanup(func() { draining.Store(false) })
+
+	status, result := getProbe(t, app, "/api/v1/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "shutting_down", result.Status)
+
+	status, _ = getProbe(t, app, "/api/v1/livez")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// setBuildInfo stands in for -ldflags -X, restoring the defaults afterwards
+func setBuildInfo(t *testing.T, v, commit, buildTime string) {
+	t.Helper()
+	oldVersion, oldCommit, oldBuildTime := version.Version, version.Commit, version.BuildTime
+	version.Version, version.Commit, version.BuildTime = v, commit, buildTime
+	t.Cleanup(func() {
+		version.Version, version.Commit, version.BuildTime = oldVersion, oldCommit, oldBuildTime
+	})
+}
+
+// getVersion requests the build information
+func getVersion(t *testing.T) VersionResponse {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/api/v1/version", versionHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/version", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result VersionResponse
+	require.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
+
+func TestVersion_Injected(t *testing.T) {
+	setBuildInfo(t, "1.4.0", "3f2c9e1", "2025-06-16T09:30:00Z")
+
+	assert.Equal(t, VersionResponse{
+		Version:   "1.4.0",
+		Commit:    "3f2c9e1",
+		BuildTime: "2025-06-16T09:30:00Z",
+	}, getVersion(t))
+}
+
+func TestVersion_Defaults(t *testing.T) {
+	assert.Equal(t, VersionResponse{
+		Version:   "dev",
+		Commit:    "unknown",
+		BuildTime: "unknown",
+	}, getVersion(t))
+}
+
+func TestDetailedHealth_IncludesVersion(t *testing.T) {
+	setBuildInfo(t, "1.4.0", "3f2c9e1", "2025-06-16T09:30:00Z")
+	app := fiber.New()
+	app.Get("/api/v1/health/detailed", detailedHealthHandler(closedDB(t)))
+
+	result, _ := getDetailedHealth(t, app)
+	assert.Equal(t, "1.4.0", result.Version)
+}
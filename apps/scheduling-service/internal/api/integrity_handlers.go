@@ -0,0 +1,102 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerIntegrityRoutes(scheduling fiber.Router, integrityService *scheduler.IntegrityService) {
+	// GET /api/v1/scheduling/inconsistent-task-events
+	scheduling.Get("/inconsistent-task-events", func(c fiber.Ctx) error {
+		result, err := integrityService.FindTaskEventMismatches(c.Context())
+		if err != nil {
+			return err
+		}
+
+		if result.Count > 0 {
+			requestLogger(c).Warn().
+				Int("entry_count", result.Count).
+				Msg("Schedule entries reference tasks from a different event")
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/created-while-unavailable
+	scheduling.Get("/created-while-unavailable", func(c fiber.Ctx) error {
+		result, err := integrityService.FindCreatedWhileUnavailable(c.Context())
+		if err != nil {
+			return err
+		}
+
+		if result.Count > 0 {
+			requestLogger(c).Warn().
+				Int("entry_count", result.Count).
+				Msg("Schedule entries were created while their resource was unavailable")
+		}
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/stale-by-task-status
+	scheduling.Get("/stale-by-task-status", func(c fiber.Ctx) error {
+		result, err := integrityService.FindStaleByTaskStatus(c.Context())
+		if err != nil {
+			return err
+		}
+
+		if result.Count > 0 {
+			requestLogger(c).Warn().
+				Int("entry_count", result.Count).
+				Msg("Upcoming schedule entries belong to completed tasks")
+		}
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/stale-by-task-status/cancel
+	scheduling.Post("/stale-by-task-status/cancel", func(c fiber.Ctx) error {
+		result, err := integrityService.CancelStaleByTaskStatus(c.Context())
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int("cancelled_count", len(result.CancelledIDs)).
+			Msg("Stale task bookings cancelled")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/integrity/inverted-ranges
+	scheduling.Get("/integrity/inverted-ranges", func(c fiber.Ctx) error {
+		result, err := integrityService.FindInvertedRanges(c.Context())
+		if err != nil {
+			return err
+		}
+
+		if result.Count > 0 {
+			requestLogger(c).Warn().
+				Int("entry_count", result.Count).
+				Msg("Schedule entries have inverted time ranges")
+		}
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/integrity/inverted-ranges/repair
+	scheduling.Post("/integrity/inverted-ranges/repair", func(c fiber.Ctx) error {
+		result, err := integrityService.RepairInvertedRanges(c.Context())
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int("swapped_count", len(result.SwappedIDs)).
+			Int("remaining_count", len(result.RemainingIDs)).
+			Msg("Inverted time ranges repaired")
+
+		return c.JSON(result)
+	})
+}
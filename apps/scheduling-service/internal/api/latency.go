@@ -0,0 +1,144 @@
+package api
+
+import (
+	"database/sql"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// latencySampleSize is how many recent samples are kept per route
+const latencySampleSize = 512
+
+// routeLatencies holds latency samples for every route served by this process
+var routeLatencies = newLatencyTracker(latencySampleSize)
+
+// debugEndpointsEnabled reports whether DEBUG_ENDPOINTS turns on the debug routes
+func debugEndpointsEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("DEBUG_ENDPOINTS"))
+	return enabled
+}
+
+// latencyTracker keeps a fixed-size ring of the most recent request latencies
+// per route, so memory stays bounded however long the process runs
+type latencyTracker struct {
+	mu      sync.Mutex
+	size    int
+	samples map[string]*latencyRing
+}
+
+type latencyRing struct {
+	values []time.Duration
+	next   int
+	count  int64
+}
+
+func newLatencyTracker(size int) *latencyTracker {
+	return &latencyTracker{
+		size:    size,
+		samples: make(map[string]*latencyRing),
+	}
+}
+
+// record adds a latency sample for the route, overwriting the oldest once full
+func (t *latencyTracker) record(route string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ring, ok := t.samples[route]
+	if !ok {
+		ring = &latencyRing{values: make([]time.Duration, 0, t.size)}
+		t.samples[route] = ring
+	}
+	if len(ring.values) < t.size {
+		ring.values = append(ring.values, d)
+	} else {
+		ring.values[ring.next] = d
+	}
+	ring.next = (ring.next + 1) % t.size
+	ring.count++
+}
+
+// RouteLatency summarizes the recent latencies of one route
+type RouteLatency struct {
+	Route   string  `json:"route"`
+	Count   int64   `json:"count"`
+	Samples int     `json:"samples"`
+	P50Ms   float64 `json:"p50_ms"`
+	P95Ms   float64 `json:"p95_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+}
+
+// snapshot returns percentile summaries for every route, sorted by route
+func (t *latencyTracker) snapshot() []RouteLatency {
+	t.mu.Lock()
+	copies := make(map[string][]time.Duration, len(t.samples))
+	counts := make(map[string]int64, len(t.samples))
+	for route, ring := range t.samples {
+		copies[route] = append([]time.Duration(nil), ring.values...)
+		counts[route] = ring.count
+	}
+	t.mu.Unlock()
+
+	result := make([]RouteLatency, 0, len(copies))
+	for route, values := range copies {
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+		result = append(result, RouteLatency{
+			Route:   route,
+			Count:   counts[route],
+			Samples: len(values),
+			P50Ms:   durationMs(percentile(values, 50)),
+			P95Ms:   durationMs(percentile(values, 95)),
+			P99Ms:   durationMs(percentile(values, 99)),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Route < result[j].Route })
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// latencyMiddleware records each request's latency under its matched route
+// pattern, so /resources/1 and /resources/2 share a bucket
+func latencyMiddleware(tracker *latencyTracker) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		tracker.record(c.Method()+" "+c.Route().Path, time.Since(start))
+		return err
+	}
+}
+
+func registerDebugRoutes(scheduling fiber.Router, db *sql.DB, flags *scheduler.FlagService) {
+	debug := scheduling.Group("/debug")
+
+	// GET /api/v1/scheduling/debug/config
+	debug.Get("/config", debugConfigHandler(db, flags))
+
+	// GET /api/v1/scheduling/debug/latencies
+	debug.Get("/latencies", func(c fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"routes": routeLatencies.snapshot(),
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerMergeRoutes(scheduling fiber.Router, mergeService *scheduler.EventMergeService) {
+	// POST /api/v1/scheduling/events/:source_id/merge-into/:target_id
+	scheduling.Post("/events/:source_id/merge-into/:target_id", func(c fiber.Ctx) error {
+		sourceID, err := parseID(c.Params("source_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "source_id must be a valid integer",
+			})
+		}
+		targetID, err := parseID(c.Params("target_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "target_id must be a valid integer",
+			})
+		}
+
+		result, err := mergeService.MergeInto(c.Context(), sourceID, targetID)
+		if err != nil {
+			return err
+		}
+
+		log := requestLogger(c)
+		if !result.Merged {
+			log.Warn().
+				Int32("source_event_id", sourceID).
+				Int32("target_event_id", targetID).
+				Int("conflict_count", len(result.Conflicts)).
+				Msg("Event merge blocked by conflicts")
+			return c.Status(fiber.StatusConflict).JSON(result)
+		}
+
+		log.Info().
+			Int32("source_event_id", sourceID).
+			Int32("target_event_id", targetID).
+			Int("moved_count", int(result.MovedCount)).
+			Msg("Event bookings merged")
+
+		return c.JSON(result)
+	})
+}
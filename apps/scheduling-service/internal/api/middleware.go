@@ -10,6 +10,8 @@ import (
 	"github.com/gofiber/fiber/v3/middleware/limiter"
 	"github.com/gofiber/fiber/v3/middleware/logger"
 	"github.com/gofiber/fiber/v3/middleware/recover"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/gofiber/utils/v2"
 
 	applogger "github.com/catering-event-manager/scheduling-service/internal/logger"
 )
@@ -18,11 +20,26 @@ func RegisterMiddleware(app *fiber.App) {
 	// Recover from panics
 	app.Use(recover.New())
 
+	// Request ID - taken from X-Request-ID when the caller sends one, so logs
+	// can be matched with the calling service's, and generated as a UUID
+	// otherwise. It's echoed in the response header, and carried in the
+	// request's context so services log it too.
+	app.Use(requestid.New(requestid.Config{Generator: utils.UUIDv4}))
+	app.Use(func(c fiber.Ctx) error {
+		c.SetContext(applogger.ContextWithRequestID(c.Context(), requestid.FromContext(c)))
+		return c.Next()
+	})
+
 	// Request logging
 	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} (${latency})\n",
+		Format: "[${time}] ${respHeader:X-Request-ID} ${status} - ${method} ${path} (${latency})\n",
 	}))
 
+	// Per-route latency samples for the debug latencies endpoint
+	if debugEndpointsEnabled() {
+		app.Use(latencyMiddleware(routeLatencies))
+	}
+
 	// Rate limiting - 200 requests per minute per IP
 	// Protects against DoS and resource exhaustion (SEC-003)
 	app.Use(limiter.New(limiter.Config{
@@ -36,7 +53,7 @@ func RegisterMiddleware(app *fiber.App) {
 			return c.IP()
 		},
 		LimitReached: func(c fiber.Ctx) error {
-			applogger.Get().Warn().Str("ip", c.IP()).Msg("Rate limit exceeded")
+			requestLogger(c).Warn().Str("ip", c.IP()).Msg("Rate limit exceeded")
 			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
 				"error":   "Too many requests",
 				"message": "Rate limit exceeded. Please try again later.",
@@ -52,8 +69,16 @@ func RegisterMiddleware(app *fiber.App) {
 		allowedOrigins = "http://localhost:3000"
 	}
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: strings.Split(allowedOrigins, ","),
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders: []string{"Content-Type", "Authorization"},
+		AllowOrigins:  strings.Split(allowedOrigins, ","),
+		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE"},
+		AllowHeaders:  []string{"Content-Type", "Authorization"},
+		ExposeHeaders: append([]string{fiber.HeaderXRequestID}, paginationHeaders...),
 	}))
 }
+
+// requestLogger returns the logger for a request, which adds the request's ID
+// to every entry. Handlers log through it so all lines for one request share
+// the ID.
+func requestLogger(c fiber.Ctx) *applogger.Logger {
+	return applogger.Get().WithRequestID(c.Context())
+}
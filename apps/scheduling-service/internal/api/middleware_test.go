@@ -4,11 +4,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"testing"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	applogger "github.com/catering-event-manager/scheduling-service/internal/logger"
 )
 
 // setupMiddlewareTestApp creates a minimal Fiber app with middleware for testing
@@ -126,3 +130,64 @@ func TestRateLimiting_Returns429WhenExceeded(t *testing.T) {
 	body, _ := io.ReadAll(resp.Body)
 	assert.Contains(t, string(body), "Too many requests")
 }
+
+func TestRequestID_EchoesCallerID(t *testing.T) {
+	app := fiber.New()
+	RegisterMiddleware(app)
+	app.Get("/test", func(c fiber.Ctx) error {
+		return c.SendString(requestid.FromContext(c))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "web-7f3a9c")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "web-7f3a9c", resp.Header.Get("X-Request-ID"))
+	// Handlers see the same ID the caller sent
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "web-7f3a9c", string(body))
+}
+
+func TestRequestID_CarriedInContext(t *testing.T) {
+	app := fiber.New()
+	RegisterMiddleware(app)
+	app.Get("/test", func(c fiber.Ctx) error {
+		// Services only get the request's context, and log its ID from there
+		return c.SendString(applogger.RequestID(c.Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "web-7f3a9c")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "web-7f3a9c", string(body))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, resp.Header.Get("X-Request-ID"), string(body), "a generated ID is carried too")
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func TestRequestID_GeneratedWhenAbsent(t *testing.T) {
+	app := setupMiddlewareTestApp()
+
+	ids := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		id := resp.Header.Get("X-Request-ID")
+		assert.Regexp(t, uuidPattern, id)
+		ids[id] = true
+	}
+	assert.Len(t, ids, 2, "each request gets its own ID")
+}
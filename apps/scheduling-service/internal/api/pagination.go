@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Pagination headers set alongside the body fields of paginated list endpoints
+const (
+	headerTotalCount = "X-Total-Count"
+	headerPageLimit  = "X-Page-Limit"
+	headerLink       = "Link"
+)
+
+const (
+	// defaultPageLimit is the page size when a request doesn't pass limit
+	defaultPageLimit = 50
+	// maxPageLimit caps the page size a request may ask for
+	maxPageLimit = 500
+)
+
+// paginationHeaders are exposed to browsers through CORS so clients can read them
+var paginationHeaders = []string{headerTotalCount, headerPageLimit, headerLink}
+
+// parsePagination reads the limit and offset query parameters of a paginated
+// list endpoint. A non-nil ErrorResponse means the request should be rejected
+// with 400.
+func parsePagination(c fiber.Ctx) (limit, offset int, errResp *ErrorResponse) {
+	limit = defaultPageLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxPageLimit {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_limit",
+				Message: fmt.Sprintf("limit must be an integer between 1 and %d", maxPageLimit),
+			}
+		}
+		limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_offset",
+				Message: "offset must be a non-negative integer",
+			}
+		}
+		offset = n
+	}
+	return limit, offset, nil
+}
+
+// setPaginationHeaders describes the current page of a limit/offset list. The
+// Link header points at the next and previous pages, keeping every other query
+// parameter of the request. It is omitted when there is no other page.
+func setPaginationHeaders(c fiber.Ctx, total int64, limit, offset int) {
+	c.Set(headerTotalCount, strconv.FormatInt(total, 10))
+	c.Set(headerPageLimit, strconv.Itoa(limit))
+	if limit <= 0 {
+		return
+	}
+
+	var links []string
+	if int64(offset+limit) < total {
+		links = append(links, pageLink(c, limit, offset+limit, "next"))
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, pageLink(c, limit, prev, "prev"))
+	}
+	if len(links) > 0 {
+		c.Set(headerLink, strings.Join(links, ", "))
+	}
+}
+
+// pageLink builds one Link header entry for the request URL at another offset
+func pageLink(c fiber.Ctx, limit, offset int, rel string) string {
+	u, err := url.Parse(c.OriginalURL())
+	if err != nil {
+		u = &url.URL{Path: c.Path()}
+	}
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+	u.RawQuery = q.Encode()
+	return fmt.Sprintf(`<%s%s>; rel="%s"`, c.BaseURL(), u.RequestURI(), rel)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPaginationHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Get("/items", func(c fiber.Ctx) error {
+		setPaginationHeaders(c, 45, 20, 20)
+		return c.SendString("OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/items?type=staff&limit=20&offset=20", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "45", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "20", resp.Header.Get("X-Page-Limit"))
+
+	links := map[string]string{}
+	for _, m := range regexp.MustCompile(`<([^>]+)>; rel="(\w+)"`).FindAllStringSubmatch(resp.Header.Get("Link"), -1) {
+		links[m[2]] = m[1]
+	}
+	require.Contains(t, links, "next")
+	require.Contains(t, links, "prev")
+
+	next, err := url.Parse(links["next"])
+	require.NoError(t, err)
+	assert.Equal(t, "http", next.Scheme)
+	assert.Equal(t, "example.com", next.Host)
+	assert.Equal(t, "/items", next.Path)
+	assert.Equal(t, "40", next.Query().Get("offset"))
+	assert.Equal(t, "20", next.Query().Get("limit"))
+	assert.Equal(t, "staff", next.Query().Get("type"))
+
+	prev, err := url.Parse(links["prev"])
+	require.NoError(t, err)
+	assert.Equal(t, "0", prev.Query().Get("offset"))
+}
+
+func TestSetPaginationHeaders_LastPage(t *testing.T) {
+	app := fiber.New()
+	app.Get("/items", func(c fiber.Ctx) error {
+		setPaginationHeaders(c, 5, 20, 0)
+		return c.SendString("OK")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/items", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "5", resp.Header.Get("X-Total-Count"))
+	assert.Empty(t, resp.Header.Get("Link"))
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantOffset int
+		wantErr    string
+	}{
+		{name: "defaults", query: "", wantLimit: defaultPageLimit},
+		{name: "explicit", query: "?limit=10&offset=30", wantLimit: 10, wantOffset: 30},
+		{name: "zero limit", query: "?limit=0", wantErr: "invalid_limit"},
+		{name: "limit too large", query: "?limit=501", wantErr: "invalid_limit"},
+		{name: "negative offset", query: "?offset=-1", wantErr: "invalid_offset"},
+		{name: "non-numeric offset", query: "?offset=abc", wantErr: "invalid_offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/items", func(c fiber.Ctx) error {
+				limit, offset, errResp := parsePagination(c)
+				if tt.wantErr != "" {
+					require.NotNil(t, errResp)
+					assert.Equal(t, tt.wantErr, errResp.Error)
+					return nil
+				}
+				require.Nil(t, errResp)
+				assert.Equal(t, tt.wantLimit, limit)
+				assert.Equal(t, tt.wantOffset, offset)
+				return nil
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	// dateOnlyLayout is the layout for date-only values such as 2025-06-15
+	dateOnlyLayout = "2006-01-02"
+	// timeFormatHint describes the formats parseTime accepts, for error messages
+	timeFormatHint = "RFC3339, YYYY-MM-DD, or epoch milliseconds"
+)
+
+// parseTime parses a time from a query parameter or request body. It accepts
+// RFC3339, RFC3339Nano, a date-only YYYY-MM-DD value (midnight in loc), or
+// milliseconds since the Unix epoch. loc defaults to UTC when nil.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("time value is empty")
+	}
+
+	for _, layout := range []string{time.RFC3339, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, err := time.ParseInLocation(dateOnlyLayout, s, loc); err == nil {
+		return t, nil
+	}
+
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).In(loc), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: expected %s", s, timeFormatHint)
+}
+
+// parseID parses a positive int32 identifier from a path or query parameter
+func parseID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if id <= 0 {
+		return 0, fmt.Errorf("id must be positive")
+	}
+	return int32(id), nil
+}
+
+// parseIDList parses a comma-separated list of positive int32 IDs such as
+// "1,2,3". Empty elements are rejected.
+func parseIDList(s string) ([]int32, error) {
+	parts := strings.Split(s, ",")
+	ids := make([]int32, 0, len(parts))
+	for _, part := range parts {
+		id, err := parseID(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseReportOptions reads the query parameters every reporting endpoint
+// shares. A non-nil ErrorResponse means the request should be rejected with 400.
+func parseReportOptions(c fiber.Ctx) (domain.ReportOptions, *ErrorResponse) {
+	var opts domain.ReportOptions
+	if v := c.Query("include_cancelled"); v != "" {
+		includeCancelled, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, &ErrorResponse{
+				Error:   "invalid_include_cancelled",
+				Message: "include_cancelled must be true or false",
+			}
+		}
+		opts.IncludeCancelled = includeCancelled
+	}
+	return opts, nil
+}
+
+// requestTime is a time.Time decoded from a JSON body using parseTime, so body
+// fields accept the same formats as query parameters. Epoch milliseconds may be
+// sent either as a JSON number or a string.
+type requestTime struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *requestTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+	}
+
+	parsed, err := parseTime(s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// timeRange is the wire form of domain.TimeRange, with times decoded through parseTime
+type timeRange struct {
+	Start requestTime `json:"start"`
+	End   requestTime `json:"end"`
+}
+
+func (r timeRange) toDomain() domain.TimeRange {
+	return domain.TimeRange{Start: r.Start.Time, End: r.End.Time}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		loc   *time.Location
+		want  time.Time
+	}{
+		{
+			name:  "RFC3339 UTC",
+			input: "2025-06-15T10:00:00Z",
+			want:  time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339 with offset",
+			input: "2025-06-15T10:00:00-05:00",
+			want:  time.Date(2025, 6, 15, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "RFC3339Nano",
+			input: "2025-06-15T10:00:00.123456789Z",
+			want:  time.Date(2025, 6, 15, 10, 0, 0, 123456789, time.UTC),
+		},
+		{
+			name:  "date-only defaults to UTC midnight",
+			input: "2025-06-15",
+			want:  time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "date-only in location",
+			input: "2025-06-15",
+			loc:   chicago,
+			want:  time.Date(2025, 6, 15, 0, 0, 0, 0, chicago),
+		},
+		{
+			name:  "epoch milliseconds",
+			input: "1749981600000",
+			want:  time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "surrounding whitespace",
+			input: " 2025-06-15T10:00:00Z ",
+			want:  time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.input, tt.loc)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestParseTime_Rejected(t *testing.T) {
+	tests := []string{
+		"",
+		"invalid",
+		"2025-06-15 10:00:00",
+		"15/06/2025",
+		"2025-13-01",
+		"2025-06-15T10:00:00",
+		"1749981600000.5",
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := parseTime(input, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseIDList(t *testing.T) {
+	ids, err := parseIDList("3, 1,2")
+	require.NoError(t, err)
+	assert.Equal(t, []int32{3, 1, 2}, ids)
+
+	for _, input := range []string{"", "1,,2", "1,x", "0", "-4", "1,"} {
+		_, err := parseIDList(input)
+		assert.Error(t, err, "%q", input)
+	}
+}
+
+func TestRequestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	for _, raw := range []string{`"2025-06-15T10:00:00Z"`, `"1749981600000"`, `1749981600000`} {
+		var got requestTime
+		require.NoError(t, json.Unmarshal([]byte(raw), &got), raw)
+		assert.True(t, want.Equal(got.Time), raw)
+	}
+
+	var bad requestTime
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &bad))
+}
+
+func TestParseReportOptions(t *testing.T) {
+	app := fiber.New()
+	app.Get("/report", func(c fiber.Ctx) error {
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+		return c.JSON(fiber.Map{"include_cancelled": opts.IncludeCancelled})
+	})
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		want       bool
+	}{
+		{"", fiber.StatusOK, false},
+		{"?include_cancelled=false", fiber.StatusOK, false},
+		{"?include_cancelled=true", fiber.StatusOK, true},
+		{"?include_cancelled=1", fiber.StatusOK, true},
+		{"?include_cancelled=maybe", fiber.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/report"+tt.query, nil))
+		require.NoError(t, err, tt.query)
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.query)
+		if tt.wantStatus != fiber.StatusOK {
+			continue
+		}
+
+		var body map[string]bool
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, tt.want, body["include_cancelled"], tt.query)
+	}
+}
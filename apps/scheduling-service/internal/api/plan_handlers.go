@@ -0,0 +1,81 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// planEntryBody is the wire form of domain.PlanEntry, with times decoded
+// through parseTime
+type planEntryBody struct {
+	ScheduleID *int32      `json:"schedule_id,omitempty"`
+	ResourceID int32       `json:"resource_id"`
+	TaskID     *int32      `json:"task_id,omitempty"`
+	StartTime  requestTime `json:"start_time"`
+	EndTime    requestTime `json:"end_time"`
+	Notes      *string     `json:"notes,omitempty"`
+	Quantity   int32       `json:"quantity,omitempty"`
+}
+
+func (b planEntryBody) toDomain() domain.PlanEntry {
+	return domain.PlanEntry{
+		ScheduleID: b.ScheduleID,
+		ResourceID: b.ResourceID,
+		TaskID:     b.TaskID,
+		StartTime:  b.StartTime.Time,
+		EndTime:    b.EndTime.Time,
+		Notes:      b.Notes,
+		Quantity:   b.Quantity,
+	}
+}
+
+// schedulePlanBody is the full set of bookings proposed for an event
+type schedulePlanBody struct {
+	Entries []planEntryBody `json:"entries"`
+}
+
+func registerPlanRoutes(scheduling fiber.Router, scheduleService *scheduler.ScheduleService) {
+	// POST /api/v1/scheduling/events/:event_id/schedule-diff
+	scheduling.Post("/events/:event_id/schedule-diff", func(c fiber.Ctx) error {
+		eventID, err := parseID(c.Params("event_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "event_id must be a valid integer",
+			})
+		}
+
+		var body schedulePlanBody
+		if err := c.Bind().JSON(&body); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for schedule diff")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		req := domain.SchedulePlanRequest{
+			EventID: eventID,
+			Entries: make([]domain.PlanEntry, 0, len(body.Entries)),
+		}
+		for _, e := range body.Entries {
+			req.Entries = append(req.Entries, e.toDomain())
+		}
+
+		diff, err := scheduleService.DiffEventPlan(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("event_id", eventID).
+			Int("create_count", len(diff.Create)).
+			Int("update_count", len(diff.Update)).
+			Int("delete_count", len(diff.Delete)).
+			Msg("Schedule plan diffed")
+
+		return c.JSON(diff)
+	})
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerReportRoutes(scheduling fiber.Router, reportService *scheduler.ReportService) {
+	// GET /api/v1/scheduling/duration-histogram
+	scheduling.Get("/duration-histogram", func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		req := domain.DurationHistogramRequest{
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		}
+		if t := c.Query("resource_type"); t != "" {
+			resourceType := domain.ResourceType(t)
+			req.ResourceType = &resourceType
+		}
+
+		result, err := reportService.GetDurationHistogram(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int("booking_count", int(result.Total)).
+			Msg("Duration histogram computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/peak-demand
+	scheduling.Get("/peak-demand", func(c fiber.Ctx) error {
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		req := domain.PeakDemandRequest{
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		}
+		if t := c.Query("type"); t != "" {
+			resourceType := domain.ResourceType(t)
+			req.ResourceType = &resourceType
+		}
+
+		result, err := reportService.GetPeakDemand(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int("peak_count", result.PeakCount).
+			Msg("Peak demand computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/reports/utilization
+	scheduling.Get("/reports/utilization", func(c fiber.Ctx) error {
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		req := domain.UtilizationReportRequest{
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		}
+		if t := c.Query("resource_type"); t != "" {
+			resourceType := domain.ResourceType(t)
+			req.ResourceType = &resourceType
+		}
+
+		result, err := reportService.GetUtilizationReport(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int("resource_count", result.Totals.ResourceCount).
+			Msg("Utilization report computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resource-sharing
+	scheduling.Get("/resource-sharing", func(c fiber.Ctx) error {
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		result, err := reportService.GetResourceSharing(c.Context(), domain.ResourceSharingRequest{
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		})
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int("event_count", result.EventCount).
+			Int("group_count", len(result.Groups)).
+			Int("isolated_count", len(result.IsolatedEvents)).
+			Msg("Resource sharing computed")
+
+		return c.JSON(result)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/utilization-trend
+	scheduling.Get("/resources/:id/utilization-trend", func(c fiber.Ctx) error {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		opts, errResp := parseReportOptions(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		result, err := reportService.GetUtilizationTrend(c.Context(), domain.UtilizationTrendRequest{
+			ResourceID:    resourceID,
+			StartDate:     startDate,
+			EndDate:       endDate,
+			ReportOptions: opts,
+		})
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int("weeks", len(result.Weeks)).
+			Msg("Utilization trend computed")
+
+		return c.JSON(result)
+	})
+}
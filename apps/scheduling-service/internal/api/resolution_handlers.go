@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+func registerResolutionRoutes(scheduling fiber.Router, availabilityService *scheduler.AvailabilityService) {
+	// POST /api/v1/scheduling/events/:event_id/resolve-all-preview
+	scheduling.Post("/events/:event_id/resolve-all-preview", func(c fiber.Ctx) error {
+		eventID, err := parseID(c.Params("event_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_event_id",
+				Message: "event_id must be a valid integer",
+			})
+		}
+
+		plan, err := availabilityService.PreviewEventResolutions(c.Context(), eventID)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("event_id", eventID).
+			Int("conflict_count", len(plan.Resolutions)).
+			Int("unresolved_count", plan.UnresolvedCount).
+			Msg("Conflict resolutions previewed")
+
+		return c.JSON(plan)
+	})
+}
@@ -0,0 +1,360 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// downtimeBody is the wire form of domain.DowntimeRequest, with times decoded through parseTime
+type downtimeBody struct {
+	StartTime requestTime `json:"start_time"`
+	EndTime   requestTime `json:"end_time"`
+	Reason    *string     `json:"reason"`
+}
+
+func (b downtimeBody) toDomain() domain.DowntimeRequest {
+	return domain.DowntimeRequest{
+		StartTime: b.StartTime.Time,
+		EndTime:   b.EndTime.Time,
+		Reason:    b.Reason,
+	}
+}
+
+func registerResourceRoutes(scheduling fiber.Router, resourceService *scheduler.ResourceService) {
+	// GET /api/v1/scheduling/resources
+	scheduling.Get("/resources", func(c fiber.Ctx) error {
+		limit, offset, errResp := parsePagination(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		req := domain.ResourceListRequest{
+			Sort:   domain.ResourceSort(c.Query("sort")),
+			Order:  domain.SortOrder(c.Query("order")),
+			Limit:  limit,
+			Offset: offset,
+		}
+		if t := c.Query("type"); t != "" {
+			resourceType := domain.ResourceType(t)
+			req.Type = &resourceType
+		}
+		if v := c.Query("is_available"); v != "" {
+			isAvailable, err := strconv.ParseBool(v)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_is_available",
+					Message: "is_available must be true or false",
+				})
+			}
+			req.IsAvailable = &isAvailable
+		}
+		if v := c.Query("min_rate"); v != "" {
+			req.MinRate = &v
+		}
+		if v := c.Query("max_rate"); v != "" {
+			req.MaxRate = &v
+		}
+
+		result, err := resourceService.ListResources(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		setPaginationHeaders(c, result.Total, result.Limit, result.Offset)
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/resources/availability
+	scheduling.Post("/resources/availability", RequireRole(RoleAdministrator), func(c fiber.Ctx) error {
+		log := requestLogger(c)
+
+		var req domain.BulkAvailabilityRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			log.Warn().Err(err).Msg("Invalid request body for bulk resource availability")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		result, err := resourceService.SetAvailability(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		log.Info().
+			Int("resource_count", len(req.ResourceIDs)).
+			Int("updated_count", int(result.UpdatedCount)).
+			Int("warning_count", len(result.Warnings)).
+			Msg("Resource availability updated")
+
+		return c.JSON(result)
+	})
+
+	// PUT /api/v1/scheduling/resources/:id/timezone
+	scheduling.Put("/resources/:id/timezone", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		var req domain.SetTimezoneRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for resource timezone")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		resource, err := resourceService.SetTimezone(c.Context(), id, req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(resource)
+	})
+
+	// workingHoursIDs parses the resource ID and, when present, the shift ID
+	workingHoursIDs := func(c fiber.Ctx) (int32, int32, *ErrorResponse) {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			}
+		}
+		if c.Params("hoursId") == "" {
+			return resourceID, 0, nil
+		}
+		hoursID, err := parseID(c.Params("hoursId"))
+		if err != nil {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_working_hours_id",
+				Message: "hoursId must be a valid integer",
+			}
+		}
+		return resourceID, hoursID, nil
+	}
+
+	// GET /api/v1/scheduling/resources/:id/working-hours
+	scheduling.Get("/resources/:id/working-hours", func(c fiber.Ctx) error {
+		resourceID, _, errResp := workingHoursIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		hours, err := resourceService.ListWorkingHours(c.Context(), resourceID)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(hours)
+	})
+
+	// POST /api/v1/scheduling/resources/:id/working-hours
+	scheduling.Post("/resources/:id/working-hours", func(c fiber.Ctx) error {
+		resourceID, _, errResp := workingHoursIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		var req domain.WorkingHoursRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for create working hours")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		hours, err := resourceService.CreateWorkingHours(c.Context(), resourceID, req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int32("working_hours_id", hours.ID).
+			Msg("Working hours created")
+
+		return c.Status(fiber.StatusCreated).JSON(hours)
+	})
+
+	// PUT /api/v1/scheduling/resources/:id/working-hours/:hoursId
+	scheduling.Put("/resources/:id/working-hours/:hoursId", func(c fiber.Ctx) error {
+		resourceID, hoursID, errResp := workingHoursIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		var req domain.WorkingHoursRequest
+		if err := c.Bind().JSON(&req); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for update working hours")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		hours, err := resourceService.UpdateWorkingHours(c.Context(), resourceID, hoursID, req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(hours)
+	})
+
+	// DELETE /api/v1/scheduling/resources/:id/working-hours/:hoursId
+	scheduling.Delete("/resources/:id/working-hours/:hoursId", func(c fiber.Ctx) error {
+		resourceID, hoursID, errResp := workingHoursIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		if err := resourceService.DeleteWorkingHours(c.Context(), resourceID, hoursID); err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int32("working_hours_id", hoursID).
+			Msg("Working hours deleted")
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// downtimeIDs parses the resource ID and, when present, the downtime ID
+	downtimeIDs := func(c fiber.Ctx) (int32, int32, *ErrorResponse) {
+		resourceID, err := parseID(c.Params("id"))
+		if err != nil {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			}
+		}
+		if c.Params("downtimeId") == "" {
+			return resourceID, 0, nil
+		}
+		downtimeID, err := parseID(c.Params("downtimeId"))
+		if err != nil {
+			return 0, 0, &ErrorResponse{
+				Error:   "invalid_downtime_id",
+				Message: "downtimeId must be a valid integer",
+			}
+		}
+		return resourceID, downtimeID, nil
+	}
+
+	// GET /api/v1/scheduling/resources/:id/downtime
+	scheduling.Get("/resources/:id/downtime", func(c fiber.Ctx) error {
+		resourceID, _, errResp := downtimeIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		startDateStr := c.Query("start_date")
+		endDateStr := c.Query("end_date")
+		if startDateStr == "" || endDateStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "missing_parameters",
+				Message: "start_date and end_date are required",
+			})
+		}
+		startDate, err := parseTime(startDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_start_date",
+				Message: "start_date must be " + timeFormatHint,
+			})
+		}
+		endDate, err := parseTime(endDateStr, time.UTC)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_end_date",
+				Message: "end_date must be " + timeFormatHint,
+			})
+		}
+
+		downtime, err := resourceService.ListDowntime(c.Context(), resourceID, domain.TimeRange{Start: startDate, End: endDate})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(downtime)
+	})
+
+	// POST /api/v1/scheduling/resources/:id/downtime
+	scheduling.Post("/resources/:id/downtime", func(c fiber.Ctx) error {
+		resourceID, _, errResp := downtimeIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		var body downtimeBody
+		if err := c.Bind().JSON(&body); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for create downtime")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		downtime, err := resourceService.CreateDowntime(c.Context(), resourceID, body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int32("downtime_id", downtime.ID).
+			Msg("Resource downtime created")
+
+		return c.Status(fiber.StatusCreated).JSON(downtime)
+	})
+
+	// DELETE /api/v1/scheduling/resources/:id/downtime/:downtimeId
+	scheduling.Delete("/resources/:id/downtime/:downtimeId", func(c fiber.Ctx) error {
+		resourceID, downtimeID, errResp := downtimeIDs(c)
+		if errResp != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(errResp)
+		}
+
+		if err := resourceService.DeleteDowntime(c.Context(), resourceID, downtimeID); err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("resource_id", resourceID).
+			Int32("downtime_id", downtimeID).
+			Msg("Resource downtime deleted")
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// GET /api/v1/scheduling/resources/:id/delete-impact
+	scheduling.Get("/resources/:id/delete-impact", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_resource_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		impact, err := resourceService.GetDeleteImpact(c.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(impact)
+	})
+}
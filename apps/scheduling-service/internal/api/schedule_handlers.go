@@ -0,0 +1,382 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/scheduler"
+)
+
+// Headers letting a client retry creating a schedule entry safely: the
+// request carries a key, and a response repeating an earlier one says so
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// scheduleEntryBody is the wire form of domain.ScheduleEntryRequest, with
+// times decoded through parseTime
+type scheduleEntryBody struct {
+	ResourceID int32       `json:"resource_id"`
+	EventID    int32       `json:"event_id"`
+	TaskID     *int32      `json:"task_id,omitempty"`
+	StartTime  requestTime `json:"start_time"`
+	EndTime    requestTime `json:"end_time"`
+	Notes      *string     `json:"notes,omitempty"`
+	Quantity   int32       `json:"quantity,omitempty"`
+	RejectPast bool        `json:"reject_past,omitempty"`
+}
+
+func (b scheduleEntryBody) toDomain() domain.ScheduleEntryRequest {
+	return domain.ScheduleEntryRequest{
+		ResourceID: b.ResourceID,
+		EventID:    b.EventID,
+		TaskID:     b.TaskID,
+		StartTime:  b.StartTime.Time,
+		EndTime:    b.EndTime.Time,
+		Notes:      b.Notes,
+		Quantity:   b.Quantity,
+		RejectPast: b.RejectPast,
+	}
+}
+
+// recurrenceRuleBody is the wire form of domain.RecurrenceRule
+type recurrenceRuleBody struct {
+	Frequency string         `json:"frequency"`
+	Interval  int            `json:"interval"`
+	Until     requestTime    `json:"until"`
+	ByWeekday []time.Weekday `json:"by_weekday,omitempty"`
+}
+
+// recurringEntryBody is the wire form of domain.RecurringEntryRequest
+type recurringEntryBody struct {
+	Entry scheduleEntryBody  `json:"entry"`
+	Rule  recurrenceRuleBody `json:"rule"`
+}
+
+func (b recurringEntryBody) toDomain() domain.RecurringEntryRequest {
+	return domain.RecurringEntryRequest{
+		Entry: b.Entry.toDomain(),
+		Rule: domain.RecurrenceRule{
+			Frequency: b.Rule.Frequency,
+			Interval:  b.Rule.Interval,
+			Until:     b.Rule.Until.Time,
+			ByWeekday: b.Rule.ByWeekday,
+		},
+	}
+}
+
+// entryPrefixes are the paths the schedule entry routes are mounted at:
+// /entries, and /schedule-entries, kept for clients written against it
+var entryPrefixes = []string{"/entries", "/schedule-entries"}
+
+func registerScheduleRoutes(scheduling fiber.Router, scheduleService *scheduler.ScheduleService) {
+	for _, prefix := range entryPrefixes {
+		registerEntryRoutes(scheduling.Group(prefix), scheduleService)
+	}
+
+	// DELETE /api/v1/scheduling/recurrence/:groupId
+	scheduling.Delete("/recurrence/:groupId", RequireRole(RoleAdministrator), func(c fiber.Ctx) error {
+		groupID, err := parseID(c.Params("groupId"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_recurrence_group_id",
+				Message: "groupId must be a valid integer",
+			})
+		}
+
+		var from *time.Time
+		if fromStr := c.Query("from"); fromStr != "" {
+			parsed, err := parseTime(fromStr, time.UTC)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_from",
+					Message: "from must be " + timeFormatHint,
+				})
+			}
+			from = &parsed
+		}
+
+		result, err := scheduleService.DeleteRecurrenceGroup(c.Context(), groupID, from)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("recurrence_group_id", groupID).
+			Int("deleted", int(result.DeletedCount)).
+			Msg("Recurring booking deleted")
+
+		return c.JSON(result)
+	})
+
+	// POST /api/v1/scheduling/recurrence-groups/:id/cancel
+	scheduling.Post("/recurrence-groups/:id/cancel", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_recurrence_group_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		var req domain.CancelEntryRequest
+		if len(c.Body()) > 0 {
+			if err := c.Bind().JSON(&req); err != nil {
+				requestLogger(c).Warn().Err(err).Msg("Invalid request body for cancel recurring booking")
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_request",
+					Message: "Invalid request body",
+				})
+			}
+		}
+
+		result, err := scheduleService.CancelRecurrenceGroup(c.Context(), id, req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("recurrence_group_id", id).
+			Int("cancelled", len(result.CancelledIDs)).
+			Msg("Recurring booking cancelled")
+
+		return c.JSON(result)
+	})
+}
+
+func registerEntryRoutes(entries fiber.Router, scheduleService *scheduler.ScheduleService) {
+	// POST /api/v1/scheduling/entries/recurring
+	entries.Post("/recurring", func(c fiber.Ctx) error {
+		var body recurringEntryBody
+		if err := c.Bind().JSON(&body); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for create recurring schedule entry")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		req := body.toDomain()
+		req.Entry.CreatedBy = callerUserID(c)
+		result, err := scheduleService.CreateRecurring(c.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("recurrence_group_id", result.RecurrenceGroupID).
+			Int32("resource_id", body.Entry.ResourceID).
+			Int("created", len(result.Created)).
+			Int("failed", len(result.Failed)).
+			Msg("Recurring schedule entry created")
+
+		if len(result.Created) == 0 {
+			return c.JSON(result)
+		}
+		return c.Status(fiber.StatusCreated).JSON(result)
+	})
+
+	// POST /api/v1/scheduling/entries
+	entries.Post("/", func(c fiber.Ctx) error {
+		var body scheduleEntryBody
+		if err := c.Bind().JSON(&body); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for create schedule entry")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		req := body.toDomain()
+		req.CreatedBy = callerUserID(c)
+		var (
+			entry *domain.ScheduleEntry
+			err   error
+		)
+		if key := c.Get(idempotencyKeyHeader); key != "" {
+			var replayed bool
+			entry, replayed, err = scheduleService.CreateEntryIdempotent(c.Context(), key, req)
+			if err == nil && replayed {
+				requestLogger(c).Info().
+					Int32("schedule_id", entry.ID).
+					Msg("Schedule entry creation replayed")
+				c.Set(idempotentReplayedHeader, "true")
+				return c.Status(fiber.StatusCreated).JSON(entry)
+			}
+		} else {
+			entry, err = scheduleService.CreateEntryChecked(c.Context(), req)
+		}
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("schedule_id", entry.ID).
+			Int32("resource_id", entry.ResourceID).
+			Msg("Schedule entry created")
+
+		return c.Status(fiber.StatusCreated).JSON(entry)
+	})
+
+	// GET /api/v1/scheduling/entries/:id
+	entries.Get("/:id", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_schedule_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		entry, err := scheduleService.GetEntry(c.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(entry)
+	})
+
+	// PUT /api/v1/scheduling/entries/:id
+	entries.Put("/:id", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_schedule_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		var body scheduleEntryBody
+		if err := c.Bind().JSON(&body); err != nil {
+			requestLogger(c).Warn().Err(err).Msg("Invalid request body for update schedule entry")
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+		}
+
+		entry, err := scheduleService.UpdateEntry(c.Context(), id, body.toDomain())
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("schedule_id", id).
+			Int32("resource_id", entry.ResourceID).
+			Msg("Schedule entry updated")
+
+		return c.JSON(entry)
+	})
+
+	// DELETE /api/v1/scheduling/entries/:id
+	entries.Delete("/:id", RequireRole(RoleAdministrator), func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_schedule_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		if err := scheduleService.DeleteEntry(c.Context(), id); err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("schedule_id", id).
+			Msg("Schedule entry deleted")
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// decision builds a handler that applies an approval decision to an entry
+	decision := func(action string, apply func(ctx context.Context, id int32) (*domain.ScheduleEntry, error)) fiber.Handler {
+		return func(c fiber.Ctx) error {
+			id, err := parseID(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_schedule_id",
+					Message: "id must be a valid integer",
+				})
+			}
+
+			entry, err := apply(c.Context(), id)
+			if err != nil {
+				return err
+			}
+
+			requestLogger(c).Info().
+				Int32("schedule_id", id).
+				Str("approval_status", string(entry.ApprovalStatus)).
+				Msg("Schedule entry approval decided")
+
+			return c.JSON(entry)
+		}
+	}
+
+	// POST /api/v1/scheduling/entries/:id/approve
+	entries.Post("/:id/approve", decision("approve", scheduleService.ApproveEntry))
+
+	// POST /api/v1/scheduling/entries/:id/reject
+	entries.Post("/:id/reject", decision("reject", scheduleService.RejectEntry))
+
+	// POST /api/v1/scheduling/entries/:id/auto-reschedule
+	entries.Post("/:id/auto-reschedule", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_schedule_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		entry, err := scheduleService.AutoReschedule(c.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("schedule_id", id).
+			Msg("Schedule entry rescheduled")
+
+		return c.JSON(entry)
+	})
+
+	// POST /api/v1/scheduling/entries/:id/cancel
+	entries.Post("/:id/cancel", func(c fiber.Ctx) error {
+		id, err := parseID(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "invalid_schedule_id",
+				Message: "id must be a valid integer",
+			})
+		}
+
+		// The body is optional; it only carries a reason
+		var req domain.CancelEntryRequest
+		if len(c.Body()) > 0 {
+			if err := c.Bind().JSON(&req); err != nil {
+				requestLogger(c).Warn().Err(err).Msg("Invalid request body for cancel")
+				return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+					Error:   "invalid_request",
+					Message: "Invalid request body",
+				})
+			}
+		}
+
+		entry, err := scheduleService.CancelEntry(c.Context(), id, req)
+		if err != nil {
+			return err
+		}
+
+		requestLogger(c).Info().
+			Int32("schedule_id", id).
+			Msg("Schedule entry cancelled")
+
+		return c.JSON(entry)
+	})
+}
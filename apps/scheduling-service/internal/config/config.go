@@ -6,8 +6,8 @@ import (
 )
 
 type Config struct {
-	DatabaseURL string
-	Port        string
+	DatabaseURL string `json:"database_url"`
+	Port        string `json:"port"`
 }
 
 func Load() (*Config, error) {
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// TaskWindow is a task that needs one staff member for a time window
+type TaskWindow struct {
+	TaskID    int32     `json:"task_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// AssignTasksRequest represents a batch of concurrent tasks to staff
+type AssignTasksRequest struct {
+	Tasks []TaskWindow `json:"tasks"`
+}
+
+// TaskAssignment pairs a task with the staff member chosen for it. HourlyRate
+// and Cost are nil when the staff member has no rate configured.
+type TaskAssignment struct {
+	TaskID       int32   `json:"task_id"`
+	ResourceID   int32   `json:"resource_id"`
+	ResourceName string  `json:"resource_name"`
+	HourlyRate   *string `json:"hourly_rate,omitempty"`
+	Cost         *string `json:"cost,omitempty"`
+}
+
+// UnassignedTask is a task no staff member could be matched to
+type UnassignedTask struct {
+	TaskID int32  `json:"task_id"`
+	Reason string `json:"reason"`
+}
+
+// AssignTasksResponse is the chosen staff assignment for a batch of tasks.
+// TotalCost covers priced assignments only.
+type AssignTasksResponse struct {
+	Assignments []TaskAssignment `json:"assignments"`
+	Unassigned  []UnassignedTask `json:"unassigned"`
+	TotalCost   string           `json:"total_cost"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Blackout is a run of whole days, such as a public holiday or a staff
+// training day, on which no resource can be booked. Dates are YYYY-MM-DD in
+// the business timezone, and EndDate is inclusive: a single-day blackout
+// starts and ends on the same date.
+type Blackout struct {
+	ID        int32     `json:"id"`
+	StartDate string    `json:"start_date"`
+	EndDate   string    `json:"end_date"`
+	Reason    *string   `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlackoutRequest blacks out StartDate through EndDate. An empty EndDate
+// blacks out StartDate alone.
+type BlackoutRequest struct {
+	StartDate string  `json:"start_date"`
+	EndDate   string  `json:"end_date"`
+	Reason    *string `json:"reason"`
+}
+
+// BlackoutListResponse lists every blackout, earliest first, with the
+// timezone its dates are read in
+type BlackoutListResponse struct {
+	Timezone  string     `json:"timezone"`
+	Blackouts []Blackout `json:"blackouts"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// BookableWindowsRequest asks when a resource can actually take a booking of
+// at least MinDuration within [StartDate, EndDate)
+type BookableWindowsRequest struct {
+	ResourceID  int32
+	StartDate   time.Time
+	EndDate     time.Time
+	MinDuration time.Duration
+}
+
+// BookableWindowsResponse lists the windows a UI should offer for a resource:
+// inside its working hours and free of bookings. WorkingHoursApplied is false
+// when the resource has no working hours, in which case any free time counts.
+type BookableWindowsResponse struct {
+	ResourceID          int32       `json:"resource_id"`
+	Timezone            string      `json:"timezone"`
+	StartDate           time.Time   `json:"start_date"`
+	EndDate             time.Time   `json:"end_date"`
+	WorkingHoursApplied bool        `json:"working_hours_applied"`
+	Windows             []TimeRange `json:"windows"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// BookingChainsRequest asks for one day of a resource's bookings grouped into
+// back-to-back chains. Only the calendar date of Date is used. MaxGapMinutes
+// and MaxContinuousMinutes fall back to the service defaults when nil.
+type BookingChainsRequest struct {
+	ResourceID           int32
+	Date                 time.Time
+	MaxGapMinutes        *int32
+	MaxContinuousMinutes *int32
+}
+
+// ChainBooking is one booking within a chain
+type ChainBooking struct {
+	ScheduleID int32     `json:"schedule_id"`
+	EventID    int32     `json:"event_id"`
+	EventName  string    `json:"event_name"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+// BookingChain is a run of bookings where each starts no more than the
+// maximum gap after the chain so far ends. SpanMinutes runs from the first
+// start to the latest end, gaps included.
+type BookingChain struct {
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+	SpanMinutes float64        `json:"span_minutes"`
+	ExceedsMax  bool           `json:"exceeds_max"`
+	Bookings    []ChainBooking `json:"bookings"`
+}
+
+// BookingChainsResponse lists a day's booking chains for a resource in order,
+// in the resource's timezone (UTC when it has none)
+type BookingChainsResponse struct {
+	ResourceID           int32          `json:"resource_id"`
+	Date                 string         `json:"date"`
+	Timezone             string         `json:"timezone"`
+	MaxGapMinutes        int32          `json:"max_gap_minutes"`
+	MaxContinuousMinutes int32          `json:"max_continuous_minutes"`
+	Chains               []BookingChain `json:"chains"`
+	ExceedingCount       int            `json:"exceeding_count"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// ChangeoverRequest asks for one day of an equipment resource's bookings and
+// the gaps between them. Only the calendar date of Date is used. MinGapMinutes
+// defaults to the resource's release grace when nil.
+type ChangeoverRequest struct {
+	ResourceID    int32
+	Date          time.Time
+	MinGapMinutes *int32
+}
+
+// ChangeoverWindow is the time between a booking's end and the start of the
+// next booking that day. Minutes is negative when the two overlap.
+type ChangeoverWindow struct {
+	NextScheduleID int32     `json:"next_schedule_id"`
+	NextEventID    int32     `json:"next_event_id"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	Minutes        float64   `json:"minutes"`
+	Tight          bool      `json:"tight"`
+}
+
+// ChangeoverBooking is one booking in a changeover schedule. Changeover is nil
+// for the day's last booking.
+type ChangeoverBooking struct {
+	ScheduleID int32             `json:"schedule_id"`
+	EventID    int32             `json:"event_id"`
+	EventName  string            `json:"event_name"`
+	StartTime  time.Time         `json:"start_time"`
+	EndTime    time.Time         `json:"end_time"`
+	Changeover *ChangeoverWindow `json:"changeover,omitempty"`
+}
+
+// ChangeoverResponse lists a day's bookings for a piece of equipment in order,
+// in the resource's timezone (UTC when it has none)
+type ChangeoverResponse struct {
+	ResourceID    int32               `json:"resource_id"`
+	Date          string              `json:"date"`
+	Timezone      string              `json:"timezone"`
+	MinGapMinutes int32               `json:"min_gap_minutes"`
+	Bookings      []ChangeoverBooking `json:"bookings"`
+	TightCount    int                 `json:"tight_count"`
+}
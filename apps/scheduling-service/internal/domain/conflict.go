@@ -2,19 +2,88 @@ package domain
 
 import "time"
 
-// Conflict represents a scheduling conflict for a resource
+// ConflictKind identifies what the requested time collided with
+type ConflictKind string
+
+const (
+	// ConflictKindBooking is an existing resource_schedule entry
+	ConflictKindBooking ConflictKind = "booking"
+	// ConflictKindExternal is a busy window supplied by the caller
+	ConflictKindExternal ConflictKind = "external"
+	// ConflictKindCertification is a resource lacking a required certification
+	ConflictKindCertification ConflictKind = "certification"
+	// ConflictKindWorkingHours is time booked outside the resource's working
+	// hours; it is always soft
+	ConflictKindWorkingHours ConflictKind = "working_hours"
+	// ConflictKindCapacity is a pooled resource with too few units free
+	ConflictKindCapacity ConflictKind = "capacity"
+	// ConflictKindDowntime is time the resource is out of service; it is
+	// always hard
+	ConflictKindDowntime ConflictKind = "downtime"
+)
+
+// ConflictSeverity says whether a conflict blocks the booking
+type ConflictSeverity string
+
+const (
+	// ConflictSeverityHard blocks the booking
+	ConflictSeverityHard ConflictSeverity = "hard"
+	// ConflictSeveritySoft is a warning, e.g. against an entry pending approval
+	ConflictSeveritySoft ConflictSeverity = "soft"
+)
+
+// ConflictSort orders the conflicts in a paged conflict check
+type ConflictSort string
+
+const (
+	// ConflictSortOverlapDesc puts the longest overlap with the requested range first
+	ConflictSortOverlapDesc ConflictSort = "overlap_desc"
+	// ConflictSortStartAsc orders conflicts by when the existing booking starts
+	ConflictSortStartAsc ConflictSort = "start_asc"
+)
+
+// DurationFormat selects how a conflict check renders durations
+type DurationFormat string
+
+const (
+	// DurationFormatMinutes reports durations as minute counts only; it is
+	// the default
+	DurationFormatMinutes DurationFormat = "minutes"
+	// DurationFormatISO8601 adds ISO 8601 duration strings such as PT2H15M
+	// alongside the minute counts
+	DurationFormatISO8601 DurationFormat = "iso8601"
+)
+
+// Conflict represents a scheduling conflict for a resource. External conflicts
+// aren't tied to a stored resource or event, so those fields are left empty;
+// certification, working-hours, capacity, and downtime conflicts aren't tied to an event.
 type Conflict struct {
-	ResourceID          int32     `json:"resource_id"`
-	ResourceName        string    `json:"resource_name"`
-	ConflictingEventID  int32     `json:"conflicting_event_id"`
-	ConflictingEventName string   `json:"conflicting_event_name"`
-	ConflictingTaskID   *int32    `json:"conflicting_task_id,omitempty"`
-	ConflictingTaskTitle *string  `json:"conflicting_task_title,omitempty"`
-	ExistingStartTime   time.Time `json:"existing_start_time"`
-	ExistingEndTime     time.Time `json:"existing_end_time"`
-	RequestedStartTime  time.Time `json:"requested_start_time"`
-	RequestedEndTime    time.Time `json:"requested_end_time"`
-	Message             string    `json:"message"`
+	Kind                 ConflictKind     `json:"kind"`
+	Severity             ConflictSeverity `json:"severity"`
+	ResourceID           int32            `json:"resource_id"`
+	ResourceName         string           `json:"resource_name"`
+	ConflictingEventID   int32            `json:"conflicting_event_id"`
+	ConflictingEventName string           `json:"conflicting_event_name"`
+	ConflictingTaskID    *int32           `json:"conflicting_task_id,omitempty"`
+	ConflictingTaskTitle *string          `json:"conflicting_task_title,omitempty"`
+	ExistingStartTime    time.Time        `json:"existing_start_time"`
+	ExistingEndTime      time.Time        `json:"existing_end_time"`
+	RequestedStartTime   time.Time        `json:"requested_start_time"`
+	RequestedEndTime     time.Time        `json:"requested_end_time"`
+	// OverlapStart and OverlapEnd bound where the conflict meets the
+	// requested range: the later of the two starts and the earlier of the two
+	// ends, counting release grace. Capacity and working hours conflicts span
+	// from the first to the last affected minute, and a certification conflict
+	// covers the whole requested range.
+	OverlapStart time.Time `json:"overlap_start"`
+	OverlapEnd   time.Time `json:"overlap_end"`
+	// OverlapMinutes is how much of the requested range the conflict covers,
+	// counting release grace; a certification conflict covers all of it
+	OverlapMinutes float64 `json:"overlap_minutes"`
+	// Overlap is OverlapMinutes as an ISO 8601 duration, set only when the
+	// check asked for iso8601 durations
+	Overlap string `json:"overlap,omitempty"`
+	Message string `json:"message"`
 }
 
 // CheckConflictsRequest represents a request to check for scheduling conflicts
@@ -24,12 +93,74 @@ type CheckConflictsRequest struct {
 	EndTime     time.Time `json:"end_time"`
 	// ExcludeScheduleID allows excluding a specific schedule entry (for updates)
 	ExcludeScheduleID *int32 `json:"exclude_schedule_id,omitempty"`
+	// ExternalBusy lists busy windows from calendars the service doesn't own;
+	// they are treated like existing bookings
+	ExternalBusy []TimeRange `json:"external_busy,omitempty"`
+	// MinOverlapMinutes drops conflicts whose overlap with the requested range
+	// is shorter than this many minutes; zero reports every overlap
+	MinOverlapMinutes int32 `json:"min_overlap_minutes,omitempty"`
+	// BufferMinutes widens the requested range by this many minutes on both
+	// sides for overlap purposes, so bookings must leave at least that much
+	// time around the request, e.g. for travel between venues
+	BufferMinutes int32 `json:"buffer_minutes,omitempty"`
+	// CountOnly counts conflicts in the database instead of loading them, for
+	// a cheap "is it free?" check; Conflicts is left empty
+	CountOnly bool `json:"count_only,omitempty"`
+	// RequiredCertification makes every resource that doesn't hold this
+	// certification through the end of the range a hard conflict
+	RequiredCertification string `json:"required_certification,omitempty"`
+	// Limit and Offset page through the conflicts, ordered by Sort; a zero
+	// Limit returns every conflict from Offset on. Setting any of the three
+	// switches to a sorted, paged check.
+	Limit  int32        `json:"limit,omitempty"`
+	Offset int32        `json:"offset,omitempty"`
+	Sort   ConflictSort `json:"sort,omitempty"`
+	// Quantity is the number of units needed from each pooled resource
+	// (equipment or materials owning more than one unit); zero means one.
+	// Other resources are always booked whole.
+	Quantity int32 `json:"quantity,omitempty"`
+	// DurationFormat chooses how durations in the response are rendered;
+	// empty means minutes
+	DurationFormat DurationFormat `json:"duration_format,omitempty"`
+	// BestEffort checks each resource on its own, so one that fails is
+	// reported in Errors while the others still return their conflicts.
+	// By default any failure fails the whole check.
+	BestEffort bool `json:"best_effort,omitempty"`
+	// GroupByResource adds ByResource and ResourceCounts to the response
+	GroupByResource bool `json:"group_by_resource,omitempty"`
+	// RejectPast refuses a check whose range starts in the past
+	RejectPast bool `json:"reject_past,omitempty"`
 }
 
 // CheckConflictsResponse represents the response from conflict checking
 type CheckConflictsResponse struct {
-	HasConflicts bool       `json:"has_conflicts"`
-	Conflicts    []Conflict `json:"conflicts"`
+	HasConflicts bool `json:"has_conflicts"`
+	// HasHardConflicts is true when at least one conflict blocks the booking
+	HasHardConflicts bool `json:"has_hard_conflicts"`
+	ConflictCount    int  `json:"conflict_count"`
+	// TotalConflicts is the number of conflicts before paging, so a client
+	// paging through the results knows when it has seen them all
+	TotalConflicts int `json:"total_conflicts"`
+	// Buffer is the requested buffer as an ISO 8601 duration, set only when
+	// the check asked for iso8601 durations and a buffer
+	Buffer    string     `json:"buffer,omitempty"`
+	Conflicts []Conflict `json:"conflicts"`
+	// ByResource holds the same conflicts as Conflicts, keyed by resource,
+	// with ResourceCounts giving the size of each group. Both are set only
+	// when the request asked to group by resource; every requested resource
+	// has an entry. External busy conflicts belong to no resource and only
+	// appear in Conflicts.
+	ByResource     map[int32][]Conflict `json:"by_resource,omitempty"`
+	ResourceCounts map[int32]int        `json:"resource_counts,omitempty"`
+	// Errors lists the resources a best-effort check couldn't check; their
+	// conflicts are missing from the response
+	Errors []ResourceCheckError `json:"errors,omitempty"`
+}
+
+// ResourceCheckError reports a resource a best-effort conflict check failed on
+type ResourceCheckError struct {
+	ResourceID int32  `json:"resource_id"`
+	Message    string `json:"message"`
 }
 
 // ResourceAvailabilityRequest represents a request for resource availability
@@ -37,10 +168,296 @@ type ResourceAvailabilityRequest struct {
 	ResourceID int32     `json:"resource_id"`
 	StartDate  time.Time `json:"start_date"`
 	EndDate    time.Time `json:"end_date"`
+	// UseResourceTZ renders entry times in the resource's own timezone
+	UseResourceTZ bool `json:"use_resource_tz,omitempty"`
+	// Limit caps how many entries are returned; zero means the default
+	Limit int `json:"limit,omitempty"`
+	// Cursor is the NextCursor of the previous page, or empty for the first
+	Cursor string `json:"cursor,omitempty"`
+	// EventID and TaskID, when set, keep only the entries of that event or task
+	EventID *int32 `json:"event_id,omitempty"`
+	TaskID  *int32 `json:"task_id,omitempty"`
+	// Sort orders the entries by start time; empty means AvailabilitySortStartAsc
+	Sort AvailabilitySort `json:"sort,omitempty"`
 }
 
-// ResourceAvailabilityResponse represents the response with schedule entries
+// AvailabilitySort orders the entries of a resource availability page
+type AvailabilitySort string
+
+const (
+	AvailabilitySortStartAsc  AvailabilitySort = "start_asc"
+	AvailabilitySortStartDesc AvailabilitySort = "start_desc"
+)
+
+// ResourceAvailabilityResponse represents the response with schedule entries.
+// Timezone names the zone entry times are rendered in when UseResourceTZ was set.
 type ResourceAvailabilityResponse struct {
 	ResourceID int32           `json:"resource_id"`
+	Timezone   string          `json:"timezone,omitempty"`
 	Entries    []ScheduleEntry `json:"entries"`
+	// TotalCount is how many entries the range holds across all pages
+	TotalCount int64 `json:"total_count"`
+	// NextCursor fetches the page after this one, and is nil on the last page
+	NextCursor *string `json:"next_cursor"`
+	// CapacityTimeline covers the range with how many units are in use, and
+	// is only set for pooled resources (equipment and materials owning more
+	// than one unit)
+	CapacityTimeline []CapacitySlot `json:"capacity_timeline,omitempty"`
+	// Downtime lists the resource's maintenance windows overlapping the
+	// range; they are kept apart from Entries since no event owns them
+	Downtime []Downtime `json:"downtime"`
+}
+
+// CapacitySlot is a stretch of time during which a pooled resource has the
+// same number of units in use. Remaining never drops below zero, even when
+// the resource is overbooked.
+type CapacitySlot struct {
+	Range     TimeRange `json:"range"`
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+}
+
+// MultiResourceAvailabilityRequest asks for the schedule entries of several
+// resources over the same date range
+type MultiResourceAvailabilityRequest struct {
+	ResourceIDs []int32   `json:"resource_ids"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+}
+
+// MultiResourceAvailabilityResponse holds each requested resource's schedule
+// entries, keyed by resource ID
+type MultiResourceAvailabilityResponse struct {
+	Resources map[int32][]ScheduleEntry `json:"resources"`
+}
+
+// Granularity is the bucket size used for availability summaries
+type Granularity string
+
+const (
+	GranularityHour    Granularity = "hour"
+	GranularityHalfDay Granularity = "halfday"
+	GranularityDay     Granularity = "day"
+)
+
+// AvailabilitySummaryRequest represents a request for bucketed availability
+type AvailabilitySummaryRequest struct {
+	ResourceID  int32       `json:"resource_id"`
+	StartDate   time.Time   `json:"start_date"`
+	EndDate     time.Time   `json:"end_date"`
+	Granularity Granularity `json:"granularity"`
+	ReportOptions
+}
+
+// AvailabilityBucket is the share of a bucket during which the resource is booked
+type AvailabilityBucket struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	BusyMinutes  int       `json:"busy_minutes"`
+	BusyFraction float64   `json:"busy_fraction"`
+}
+
+// AvailabilitySummaryResponse represents bucketed availability for a resource
+type AvailabilitySummaryResponse struct {
+	ResourceID  int32                `json:"resource_id"`
+	Granularity Granularity          `json:"granularity"`
+	Buckets     []AvailabilityBucket `json:"buckets"`
+}
+
+// SoonestAvailableRequest represents a search for the resource of a type that
+// can start a slot of the given duration soonest
+type SoonestAvailableRequest struct {
+	Type     ResourceType  `json:"type"`
+	Duration time.Duration `json:"duration"`
+}
+
+// SoonestAvailableResponse is the resource with the earliest free slot
+type SoonestAvailableResponse struct {
+	Resource  Resource  `json:"resource"`
+	SlotStart time.Time `json:"slot_start"`
+	SlotEnd   time.Time `json:"slot_end"`
+}
+
+// JointSlotRequest asks for the earliest window in which every resource is
+// free at once for the whole duration
+type JointSlotRequest struct {
+	ResourceIDs []int32       `json:"resource_ids"`
+	Duration    time.Duration `json:"duration"`
+	// From is where the search starts; zero means now
+	From time.Time `json:"from"`
+	// Until is where the search ends; zero means the 30-day search horizon
+	Until time.Time `json:"until"`
+}
+
+// JointSlotResponse is the earliest window every requested resource is free
+// for. FreeUntil is when the common gap holding the slot closes, capped at
+// the end of the search.
+type JointSlotResponse struct {
+	ResourceIDs []int32   `json:"resource_ids"`
+	SlotStart   time.Time `json:"slot_start"`
+	SlotEnd     time.Time `json:"slot_end"`
+	FreeUntil   time.Time `json:"free_until"`
+}
+
+// BookingsAroundResponse is a resource's closest bookings on either side of a
+// timestamp. Previous ended at or before At; Next starts at or after it. Either
+// is nil when no such booking exists.
+type BookingsAroundResponse struct {
+	ResourceID int32          `json:"resource_id"`
+	At         time.Time      `json:"at"`
+	Previous   *ScheduleEntry `json:"previous"`
+	Next       *ScheduleEntry `json:"next"`
+}
+
+// ActiveBookingsRequest asks for the bookings in progress at an instant,
+// optionally only for one resource type
+type ActiveBookingsRequest struct {
+	At           time.Time
+	ResourceType *ResourceType
+}
+
+// ActiveBooking is a booking in progress, with its resource and event names
+type ActiveBooking struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	ResourceType   ResourceType   `json:"resource_type"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	TaskID         *int32         `json:"task_id,omitempty"`
+	TaskTitle      *string        `json:"task_title,omitempty"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// ActiveBookingsResponse lists every booking whose range contains At, ordered
+// by resource name
+type ActiveBookingsResponse struct {
+	At           time.Time       `json:"at"`
+	ResourceType *ResourceType   `json:"resource_type,omitempty"`
+	Bookings     []ActiveBooking `json:"bookings"`
+}
+
+// ConflictClustersRequest asks for groups of overlapping bookings of a resource
+type ConflictClustersRequest struct {
+	ResourceID int32     `json:"resource_id"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+}
+
+// ConflictCluster is a set of bookings connected by overlaps: each entry
+// overlaps at least one other, possibly through a chain. Start and End span
+// the whole cluster.
+type ConflictCluster struct {
+	Start   time.Time       `json:"start"`
+	End     time.Time       `json:"end"`
+	Entries []ScheduleEntry `json:"entries"`
+}
+
+// ConflictClustersResponse lists a resource's overlapping-booking clusters in
+// chronological order. Bookings that overlap nothing are not reported.
+type ConflictClustersResponse struct {
+	ResourceID int32             `json:"resource_id"`
+	StartDate  time.Time         `json:"start_date"`
+	EndDate    time.Time         `json:"end_date"`
+	Clusters   []ConflictCluster `json:"clusters"`
+}
+
+// AllConflictsRequest asks for one page of every overlapping booking pair
+// across all resources within a window
+type AllConflictsRequest struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Limit     int
+	Offset    int
+}
+
+// ConflictPairBooking is one side of an overlapping booking pair
+type ConflictPairBooking struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// ConflictPair is two live bookings of the same resource that overlap,
+// counting the resource's release grace. Booking was created first.
+type ConflictPair struct {
+	ResourceID    int32               `json:"resource_id"`
+	ResourceName  string              `json:"resource_name"`
+	ResourceType  ResourceType        `json:"resource_type"`
+	Booking       ConflictPairBooking `json:"booking"`
+	ConflictsWith ConflictPairBooking `json:"conflicts_with"`
+}
+
+// AllConflictsResponse is one page of overlapping booking pairs, ordered by
+// the start of the earlier-created booking. Total counts pairs on all pages.
+type AllConflictsResponse struct {
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Total     int64          `json:"total"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+	Pairs     []ConflictPair `json:"pairs"`
+}
+
+// RevalidateScope names what a revalidation covers
+type RevalidateScope string
+
+const (
+	// RevalidateScopeEvent covers every booking of one event
+	RevalidateScopeEvent RevalidateScope = "event"
+	// RevalidateScopeResource covers every booking on one resource
+	RevalidateScopeResource RevalidateScope = "resource"
+)
+
+// RevalidateRequest asks for the current conflicts of one event or one
+// resource; exactly one of the IDs must be set
+type RevalidateRequest struct {
+	EventID    *int32
+	ResourceID *int32
+}
+
+// RevalidateResponse is the conflict state of the scope as read at CheckedAt.
+// Pairs are ordered as in the global conflict scan. Truncated is set when
+// there were more pairs than a single response returns.
+type RevalidateResponse struct {
+	Scope         RevalidateScope `json:"scope"`
+	ScopeID       int32           `json:"scope_id"`
+	CheckedAt     time.Time       `json:"checked_at"`
+	HasConflicts  bool            `json:"has_conflicts"`
+	ConflictCount int             `json:"conflict_count"`
+	Truncated     bool            `json:"truncated"`
+	Pairs         []ConflictPair  `json:"pairs"`
+}
+
+// AlternativeResourcesResponse lists resources that could stand in for a
+// conflicted one over the requested range
+type AlternativeResourcesResponse struct {
+	ResourceID   int32      `json:"resource_id"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      time.Time  `json:"end_time"`
+	Alternatives []Resource `json:"alternatives"`
+}
+
+// BatchItemError is why one check of a batch failed
+type BatchItemError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// BatchConflictResult is the outcome of one check of a batch. Index is the
+// check's position in the request; exactly one of Result and Error is set.
+type BatchConflictResult struct {
+	Index  int                     `json:"index"`
+	Result *CheckConflictsResponse `json:"result,omitempty"`
+	Error  *BatchItemError         `json:"error,omitempty"`
+}
+
+// CheckConflictsBatchResponse holds a batch's results in request order
+type CheckConflictsBatchResponse struct {
+	Results []BatchConflictResult `json:"results"`
 }
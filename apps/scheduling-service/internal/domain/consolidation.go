@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ConsolidationSuggestion is a run of a resource's bookings for the same event,
+// task, and approval status that touch or overlap, and could be replaced by a
+// single booking covering Start to End
+type ConsolidationSuggestion struct {
+	EventID        int32          `json:"event_id"`
+	TaskID         *int32         `json:"task_id,omitempty"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	ScheduleIDs    []int32        `json:"schedule_ids"`
+	Start          time.Time      `json:"start"`
+	End            time.Time      `json:"end"`
+}
+
+// ConsolidationSuggestionsRequest asks for mergeable bookings of a resource,
+// optionally limited to one event
+type ConsolidationSuggestionsRequest struct {
+	ResourceID int32
+	EventID    *int32
+}
+
+// ConsolidationSuggestionsResponse lists a resource's mergeable bookings in
+// chronological order
+type ConsolidationSuggestionsResponse struct {
+	ResourceID  int32                     `json:"resource_id"`
+	Suggestions []ConsolidationSuggestion `json:"suggestions"`
+}
+
+// ConsolidateRequest merges the listed bookings of a resource into one
+type ConsolidateRequest struct {
+	ResourceID  int32   `json:"-"`
+	ScheduleIDs []int32 `json:"schedule_ids"`
+}
+
+// ConsolidateResponse is the booking that now covers the merged range, along
+// with the bookings that were removed to make room for it
+type ConsolidateResponse struct {
+	Entry      ScheduleEntry `json:"entry"`
+	RemovedIDs []int32       `json:"removed_ids"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// ClientCostRequest represents a request for a client's booked resource cost
+type ClientCostRequest struct {
+	ClientID  int32     `json:"client_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	ReportOptions
+}
+
+// ResourceCost is the booked time and cost of a single resource for a client.
+// HourlyRate and Cost are nil when the resource has no rate configured.
+type ResourceCost struct {
+	ResourceID   int32   `json:"resource_id"`
+	ResourceName string  `json:"resource_name"`
+	HourlyRate   *string `json:"hourly_rate,omitempty"`
+	BookedHours  float64 `json:"booked_hours"`
+	Cost         *string `json:"cost,omitempty"`
+}
+
+// ClientCostResponse summarizes booked resource cost across a client's events.
+// Monetary amounts are decimal strings to avoid floating point rounding.
+type ClientCostResponse struct {
+	ClientID        int32          `json:"client_id"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         time.Time      `json:"end_date"`
+	TotalCost       string         `json:"total_cost"`
+	BillableHours   float64        `json:"billable_hours"`
+	UnbillableHours float64        `json:"unbillable_hours"`
+	Resources       []ResourceCost `json:"resources"`
+}
+
+// MarginalCostRequest describes a hypothetical booking to price for an event
+type MarginalCostRequest struct {
+	EventID    int32     `json:"event_id"`
+	ResourceID int32     `json:"resource_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+// MarginalCostResponse previews the budget impact of a booking. HourlyRate and
+// MarginalCost are nil when the resource has no rate, leaving the total unchanged.
+type MarginalCostResponse struct {
+	EventID          int32   `json:"event_id"`
+	ResourceID       int32   `json:"resource_id"`
+	ResourceName     string  `json:"resource_name"`
+	HourlyRate       *string `json:"hourly_rate,omitempty"`
+	Hours            float64 `json:"hours"`
+	MarginalCost     *string `json:"marginal_cost,omitempty"`
+	CurrentTotalCost string  `json:"current_total_cost"`
+	NewTotalCost     string  `json:"new_total_cost"`
+}
+
+// OvertimeCostRequest represents a request for a resource's booked cost with
+// overtime premiums applied
+type OvertimeCostRequest struct {
+	ResourceID int32     `json:"resource_id"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+}
+
+// OvertimeDay is the booked time on one local calendar day and how much of it
+// exceeded the daily overtime threshold
+type OvertimeDay struct {
+	Date          string  `json:"date"`
+	BookedHours   float64 `json:"booked_hours"`
+	OvertimeHours float64 `json:"overtime_hours"`
+}
+
+// OvertimeCostResponse prices a resource's bookings with hours beyond the daily
+// threshold billed at the overtime multiplier. HourlyRate and the monetary
+// amounts are nil when the resource has no rate configured.
+type OvertimeCostResponse struct {
+	ResourceID      int32         `json:"resource_id"`
+	ResourceName    string        `json:"resource_name"`
+	Timezone        string        `json:"timezone"`
+	StartDate       time.Time     `json:"start_date"`
+	EndDate         time.Time     `json:"end_date"`
+	HourlyRate      *string       `json:"hourly_rate,omitempty"`
+	ThresholdHours  float64       `json:"threshold_hours"`
+	Multiplier      string        `json:"multiplier"`
+	BookedHours     float64       `json:"booked_hours"`
+	OvertimeHours   float64       `json:"overtime_hours"`
+	BaseCost        *string       `json:"base_cost,omitempty"`
+	OvertimePremium *string       `json:"overtime_premium,omitempty"`
+	TotalCost       *string       `json:"total_cost,omitempty"`
+	Days            []OvertimeDay `json:"days"`
+}
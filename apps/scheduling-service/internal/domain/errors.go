@@ -9,12 +9,16 @@ const (
 	ErrCodeValidation ErrorCode = "VALIDATION"
 	ErrCodeNotFound   ErrorCode = "NOT_FOUND"
 	ErrCodeInternal   ErrorCode = "INTERNAL"
+	ErrCodeTimeout    ErrorCode = "TIMEOUT"
+	ErrCodeForbidden  ErrorCode = "FORBIDDEN"
 )
 
 type DomainError struct {
 	Code    ErrorCode
 	Message string
 	Err     error
+	// Conflicts lists the bookings that caused a conflict error, if any
+	Conflicts []Conflict
 }
 
 func (e *DomainError) Error() string {
@@ -24,6 +28,19 @@ func (e *DomainError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// Unwrap returns the underlying cause, so errors.Is and errors.As can reach
+// wrapped errors such as sql.ErrNoRows or a driver error
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a *DomainError with the same code, so callers
+// can write errors.Is(err, &DomainError{Code: ErrCodeNotFound})
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
 func NewConflictError(message string) *DomainError {
 	return &DomainError{
 		Code:    ErrCodeConflict,
@@ -31,6 +48,16 @@ func NewConflictError(message string) *DomainError {
 	}
 }
 
+// NewBookingConflictError reports a write refused because the requested time
+// collides with the given conflicts
+func NewBookingConflictError(message string, conflicts []Conflict) *DomainError {
+	return &DomainError{
+		Code:      ErrCodeConflict,
+		Message:   message,
+		Conflicts: conflicts,
+	}
+}
+
 func NewValidationError(message string) *DomainError {
 	return &DomainError{
 		Code:    ErrCodeValidation,
@@ -52,3 +79,22 @@ func NewInternalError(message string, err error) *DomainError {
 		Err:     err,
 	}
 }
+
+// NewTimeoutError reports an operation abandoned because it ran too long, such
+// as a query cancelled by the database's statement timeout
+func NewTimeoutError(message string, err error) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeTimeout,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewForbiddenError reports a request refused because the caller's role
+// doesn't permit it
+func NewForbiddenError(message string) *DomainError {
+	return &DomainError{
+		Code:    ErrCodeForbidden,
+		Message: message,
+	}
+}
@@ -1,10 +1,13 @@
 package domain
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDomainError_String_WithWrappedError(t *testing.T) {
@@ -55,6 +58,13 @@ func TestErrorConstructors(t *testing.T) {
 			},
 			expectedCode: ErrCodeInternal,
 		},
+		{
+			name: "TimeoutError",
+			constructor: func() *DomainError {
+				return NewTimeoutError("timed out", errors.New("wrapped"))
+			},
+			expectedCode: ErrCodeTimeout,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -72,6 +82,7 @@ func TestErrorCode_Values(t *testing.T) {
 	assert.Equal(t, ErrorCode("VALIDATION"), ErrCodeValidation)
 	assert.Equal(t, ErrorCode("NOT_FOUND"), ErrCodeNotFound)
 	assert.Equal(t, ErrorCode("INTERNAL"), ErrCodeInternal)
+	assert.Equal(t, ErrorCode("TIMEOUT"), ErrCodeTimeout)
 }
 
 func TestDomainError_ImplementsError(t *testing.T) {
@@ -83,3 +94,30 @@ func TestDomainError_ImplementsError(t *testing.T) {
 	assert.NotNil(t, genericErr)
 	assert.Equal(t, err.Error(), genericErr.Error())
 }
+
+func TestDomainError_IsMatchesCode(t *testing.T) {
+	err := NewNotFoundError("resource not found")
+
+	assert.True(t, errors.Is(err, &DomainError{Code: ErrCodeNotFound}))
+	assert.False(t, errors.Is(err, &DomainError{Code: ErrCodeConflict}))
+	assert.False(t, errors.Is(err, errors.New("resource not found")))
+
+	// Still matches when wrapped by a caller
+	wrapped := fmt.Errorf("loading resource 7: %w", err)
+	assert.True(t, errors.Is(wrapped, &DomainError{Code: ErrCodeNotFound}))
+}
+
+func TestDomainError_UnwrapReachesCause(t *testing.T) {
+	err := NewInternalError("failed to get resource", sql.ErrNoRows)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.Equal(t, sql.ErrNoRows, errors.Unwrap(err))
+
+	var domainErr *DomainError
+	require.ErrorAs(t, fmt.Errorf("wrapped: %w", err), &domainErr)
+	assert.Equal(t, ErrCodeInternal, domainErr.Code)
+
+	// Errors built without a cause unwrap to nothing
+	assert.Nil(t, errors.Unwrap(NewValidationError("invalid")))
+	assert.False(t, errors.Is(NewValidationError("invalid"), sql.ErrNoRows))
+}
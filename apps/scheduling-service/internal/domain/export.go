@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ScheduleExportRequest selects the entries of a resource's schedule to export
+type ScheduleExportRequest struct {
+	ResourceID int32
+	StartDate  time.Time
+	EndDate    time.Time
+}
+
+// ScheduleExportEntry is one exported schedule entry
+type ScheduleExportEntry struct {
+	ID        int32
+	EventName string
+	TaskTitle *string
+	StartTime time.Time
+	EndTime   time.Time
+	Notes     *string
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// FreeSlotsResponse lists the gaps of at least MinMinutes between a resource's
+// bookings within [StartDate, EndDate)
+type FreeSlotsResponse struct {
+	ResourceID int32       `json:"resource_id"`
+	StartDate  time.Time   `json:"start_date"`
+	EndDate    time.Time   `json:"end_date"`
+	MinMinutes int32       `json:"min_minutes"`
+	Slots      []TimeRange `json:"slots"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// GanttBar is one booking drawn on a Gantt row
+type GanttBar struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	Start          time.Time      `json:"start"`
+	End            time.Time      `json:"end"`
+	TaskTitle      *string        `json:"task_title,omitempty"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	ColorHint      string         `json:"color_hint"`
+}
+
+// GanttRow is one resource and its bookings, in chronological order
+type GanttRow struct {
+	ResourceID   int32        `json:"resource_id"`
+	ResourceName string       `json:"resource_name"`
+	ResourceType ResourceType `json:"resource_type"`
+	Bars         []GanttBar   `json:"bars"`
+}
+
+// GanttResponse is an event's schedule laid out for a Gantt chart. The axis
+// spans the earliest start to the latest end of all bars and is omitted when
+// the event has no bookings.
+type GanttResponse struct {
+	EventID   int32      `json:"event_id"`
+	EventName string     `json:"event_name"`
+	AxisStart *time.Time `json:"axis_start,omitempty"`
+	AxisEnd   *time.Time `json:"axis_end,omitempty"`
+	Rows      []GanttRow `json:"rows"`
+}
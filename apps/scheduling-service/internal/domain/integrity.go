@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// TaskEventMismatch is a schedule entry whose task belongs to a different event
+// than the entry itself, which indicates corrupted data
+type TaskEventMismatch struct {
+	ScheduleID      int32     `json:"schedule_id"`
+	ResourceID      int32     `json:"resource_id"`
+	TaskID          int32     `json:"task_id"`
+	ScheduleEventID int32     `json:"schedule_event_id"`
+	TaskEventID     int32     `json:"task_event_id"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+}
+
+// TaskEventMismatchResponse lists all inconsistent task/event schedule entries
+type TaskEventMismatchResponse struct {
+	Count   int                 `json:"count"`
+	Entries []TaskEventMismatch `json:"entries"`
+}
+
+// InvertedRange is a schedule entry that doesn't end after it starts. Conflict
+// detection assumes this never happens, so such rows come from data written
+// around the service.
+type InvertedRange struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	ResourceID     int32          `json:"resource_id"`
+	EventID        int32          `json:"event_id"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	Cancelled      bool           `json:"cancelled"`
+}
+
+// InvertedRangesResponse lists all schedule entries with inverted time ranges
+type InvertedRangesResponse struct {
+	Count   int             `json:"count"`
+	Entries []InvertedRange `json:"entries"`
+}
+
+// InvertedRangeRepairResponse reports a repair of inverted time ranges.
+// Zero-length entries can't be repaired by swapping and remain; they have to
+// be fixed or deleted by hand.
+type InvertedRangeRepairResponse struct {
+	SwappedIDs   []int32 `json:"swapped_ids"`
+	RemainingIDs []int32 `json:"remaining_ids"`
+}
+
+// UnavailableBooking is a schedule entry created while its resource was marked
+// unavailable
+type UnavailableBooking struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	EventID        int32          `json:"event_id"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	Cancelled      bool           `json:"cancelled"`
+}
+
+// UnavailableBookingsResponse lists all schedule entries created while their
+// resource was unavailable
+type UnavailableBookingsResponse struct {
+	Count   int                  `json:"count"`
+	Entries []UnavailableBooking `json:"entries"`
+}
+
+// StaleTaskBooking is a live booking linked to a completed task that hasn't
+// ended yet, and so is likely no longer needed
+type StaleTaskBooking struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	EventID        int32          `json:"event_id"`
+	TaskID         int32          `json:"task_id"`
+	TaskTitle      string         `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// StaleTaskBookingsResponse lists all bookings left over from completed tasks
+type StaleTaskBookingsResponse struct {
+	Count   int                `json:"count"`
+	Entries []StaleTaskBooking `json:"entries"`
+}
+
+// StaleTaskCancelResponse reports the bookings cancelled because their task
+// was completed
+type StaleTaskCancelResponse struct {
+	CancelledIDs []int32 `json:"cancelled_ids"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// EventMergeConflict is a pair of overlapping bookings of one resource, one
+// from each event, that a merge would turn into a double booking within the
+// target event
+type EventMergeConflict struct {
+	ResourceID       int32     `json:"resource_id"`
+	ResourceName     string    `json:"resource_name"`
+	SourceScheduleID int32     `json:"source_schedule_id"`
+	SourceStartTime  time.Time `json:"source_start_time"`
+	SourceEndTime    time.Time `json:"source_end_time"`
+	TargetScheduleID int32     `json:"target_schedule_id"`
+	TargetStartTime  time.Time `json:"target_start_time"`
+	TargetEndTime    time.Time `json:"target_end_time"`
+}
+
+// EventMergeResponse reports the outcome of moving one event's bookings onto
+// another. When Merged is false nothing was moved and Conflicts lists what has
+// to be resolved first.
+type EventMergeResponse struct {
+	SourceEventID int32                `json:"source_event_id"`
+	TargetEventID int32                `json:"target_event_id"`
+	Merged        bool                 `json:"merged"`
+	MovedCount    int64                `json:"moved_count"`
+	Conflicts     []EventMergeConflict `json:"conflicts"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// PlanEntry is one booking of an event's schedule plan. ScheduleID names the
+// persisted entry a proposed booking replaces; without it the booking is
+// matched by resource and task.
+type PlanEntry struct {
+	ScheduleID *int32    `json:"schedule_id,omitempty"`
+	ResourceID int32     `json:"resource_id"`
+	TaskID     *int32    `json:"task_id,omitempty"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Notes      *string   `json:"notes,omitempty"`
+	Quantity   int32     `json:"quantity"`
+}
+
+// SchedulePlanRequest is the full set of bookings an event should end up with
+type SchedulePlanRequest struct {
+	EventID int32
+	Entries []PlanEntry
+}
+
+// PlanUpdate is a persisted entry the plan changes, with the fields that
+// differ
+type PlanUpdate struct {
+	ScheduleID    int32     `json:"schedule_id"`
+	Current       PlanEntry `json:"current"`
+	Proposed      PlanEntry `json:"proposed"`
+	ChangedFields []string  `json:"changed_fields"`
+}
+
+// SchedulePlanDiff lists what applying a plan would create, update, and
+// delete. Nothing in it has been applied.
+type SchedulePlanDiff struct {
+	EventID      int32        `json:"event_id"`
+	Create       []PlanEntry  `json:"create"`
+	Update       []PlanUpdate `json:"update"`
+	Delete       []PlanEntry  `json:"delete"`
+	UnchangedIDs []int32      `json:"unchanged_ids"`
+}
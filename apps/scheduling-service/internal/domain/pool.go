@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// TypeBusyWindowsRequest asks when every available resource of a type is
+// booked at once within [StartDate, EndDate)
+type TypeBusyWindowsRequest struct {
+	Type      ResourceType
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// TypeBusyWindowsResponse lists the windows during which the whole pool of a
+// resource type is booked, leaving no free capacity. ResourceCount is the size
+// of the pool; an empty pool has no windows.
+type TypeBusyWindowsResponse struct {
+	Type          ResourceType `json:"type"`
+	StartDate     time.Time    `json:"start_date"`
+	EndDate       time.Time    `json:"end_date"`
+	ResourceCount int          `json:"resource_count"`
+	Windows       []TimeRange  `json:"windows"`
+}
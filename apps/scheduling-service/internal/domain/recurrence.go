@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// FrequencyWeekly repeats a booking every Interval weeks
+const FrequencyWeekly = "weekly"
+
+// RecurrenceRule describes when a booking repeats. Occurrences keep the first
+// booking's wall-clock times in the resource's timezone, on each of ByWeekday
+// (the first booking's weekday when empty) of every Interval-th week, and
+// stop with the last one starting no later than Until.
+type RecurrenceRule struct {
+	Frequency string         `json:"frequency"`
+	Interval  int            `json:"interval"`
+	Until     time.Time      `json:"until"`
+	ByWeekday []time.Weekday `json:"by_weekday,omitempty"`
+}
+
+// RecurringEntryRequest books the first occurrence described by Entry and
+// repeats it by Rule
+type RecurringEntryRequest struct {
+	Entry ScheduleEntryRequest `json:"entry"`
+	Rule  RecurrenceRule       `json:"rule"`
+}
+
+// RecurrenceFailure is an occurrence that couldn't be booked, and why
+type RecurrenceFailure struct {
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	Error     BatchItemError `json:"error"`
+}
+
+// RecurringEntryResponse lists the occurrences booked under RecurrenceGroupID
+// and the ones refused
+type RecurringEntryResponse struct {
+	RecurrenceGroupID int32               `json:"recurrence_group_id"`
+	Created           []ScheduleEntry     `json:"created"`
+	Failed            []RecurrenceFailure `json:"failed"`
+}
+
+// RecurrenceCancelResponse reports the entries of a recurring booking that
+// were cancelled together
+type RecurrenceCancelResponse struct {
+	RecurrenceGroupID int32   `json:"recurrence_group_id"`
+	CancelledIDs      []int32 `json:"cancelled_ids"`
+}
+
+// RecurrenceDeleteResponse reports how many entries of a recurring booking
+// were deleted
+type RecurrenceDeleteResponse struct {
+	RecurrenceGroupID int32 `json:"recurrence_group_id"`
+	DeletedCount      int64 `json:"deleted_count"`
+}
@@ -0,0 +1,198 @@
+package domain
+
+import "time"
+
+// ReportOptions are the filters every reporting endpoint accepts, so reports
+// agree on which entries they count
+type ReportOptions struct {
+	// IncludeCancelled counts cancelled entries, which reports skip by default
+	IncludeCancelled bool `json:"include_cancelled,omitempty"`
+}
+
+// DurationHistogramRequest represents a request for the booking duration distribution
+type DurationHistogramRequest struct {
+	StartDate    time.Time     `json:"start_date"`
+	EndDate      time.Time     `json:"end_date"`
+	ResourceType *ResourceType `json:"resource_type,omitempty"`
+	ReportOptions
+}
+
+// DurationBucket counts bookings whose duration falls in [MinMinutes, MaxMinutes).
+// MaxMinutes is nil for the open-ended last bucket.
+type DurationBucket struct {
+	Label      string `json:"label"`
+	MinMinutes int    `json:"min_minutes"`
+	MaxMinutes *int   `json:"max_minutes,omitempty"`
+	Count      int64  `json:"count"`
+}
+
+// DurationHistogramResponse is the distribution of booking durations in a window
+type DurationHistogramResponse struct {
+	StartDate    time.Time        `json:"start_date"`
+	EndDate      time.Time        `json:"end_date"`
+	ResourceType *ResourceType    `json:"resource_type,omitempty"`
+	Total        int64            `json:"total"`
+	Buckets      []DurationBucket `json:"buckets"`
+}
+
+// PeakDemandRequest represents a request for peak resource concurrency in a window
+type PeakDemandRequest struct {
+	StartDate    time.Time     `json:"start_date"`
+	EndDate      time.Time     `json:"end_date"`
+	ResourceType *ResourceType `json:"resource_type,omitempty"`
+	ReportOptions
+}
+
+// PeakDemandResponse is the largest number of distinct resources booked at the
+// same moment, and the first stretch of time that peak holds for. PeakStart and
+// PeakEnd are nil when nothing is booked in the window.
+type PeakDemandResponse struct {
+	StartDate    time.Time     `json:"start_date"`
+	EndDate      time.Time     `json:"end_date"`
+	ResourceType *ResourceType `json:"resource_type,omitempty"`
+	PeakCount    int           `json:"peak_count"`
+	PeakStart    *time.Time    `json:"peak_start"`
+	PeakEnd      *time.Time    `json:"peak_end"`
+}
+
+// UtilizationTrendRequest represents a request for a resource's booked hours
+// per week
+type UtilizationTrendRequest struct {
+	ResourceID int32     `json:"resource_id"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	ReportOptions
+}
+
+// UtilizationWeek is the booked time in one week against the capacity for the
+// part of the week inside the window. WeekStart is the Monday the week starts
+// on, even when the window starts later.
+type UtilizationWeek struct {
+	WeekStart          time.Time `json:"week_start"`
+	BookedHours        float64   `json:"booked_hours"`
+	CapacityHours      float64   `json:"capacity_hours"`
+	UtilizationPercent float64   `json:"utilization_percent"`
+}
+
+// UtilizationTrendResponse lists every week of the window in order, weeks
+// without bookings included
+type UtilizationTrendResponse struct {
+	ResourceID          int32             `json:"resource_id"`
+	ResourceName        string            `json:"resource_name"`
+	Timezone            string            `json:"timezone"`
+	StartDate           time.Time         `json:"start_date"`
+	EndDate             time.Time         `json:"end_date"`
+	WeeklyCapacityHours float64           `json:"weekly_capacity_hours"`
+	Weeks               []UtilizationWeek `json:"weeks"`
+}
+
+// UtilizationReportRequest asks for a utilization report across all resources,
+// optionally limited to one resource type
+type UtilizationReportRequest struct {
+	StartDate    time.Time     `json:"start_date"`
+	EndDate      time.Time     `json:"end_date"`
+	ResourceType *ResourceType `json:"resource_type,omitempty"`
+	ReportOptions
+}
+
+// UtilizationAssumptions states the capacity baseline a utilization report was
+// computed against, so a rendered report can print it alongside the numbers
+type UtilizationAssumptions struct {
+	WeeklyCapacityHours      float64 `json:"weekly_capacity_hours"`
+	WorkingDaysPerWeek       int     `json:"working_days_per_week"`
+	HoursPerWorkingDay       float64 `json:"hours_per_working_day"`
+	CapacityHoursPerResource float64 `json:"capacity_hours_per_resource"`
+	Description              string  `json:"description"`
+}
+
+// UtilizationReportRow is one resource's booked time over the report period
+type UtilizationReportRow struct {
+	ResourceID         int32        `json:"resource_id"`
+	ResourceName       string       `json:"resource_name"`
+	ResourceType       ResourceType `json:"resource_type"`
+	BookingCount       int64        `json:"booking_count"`
+	BookedHours        float64      `json:"booked_hours"`
+	CapacityHours      float64      `json:"capacity_hours"`
+	UtilizationPercent float64      `json:"utilization_percent"`
+}
+
+// UtilizationReportTotals adds up every row of a utilization report
+type UtilizationReportTotals struct {
+	ResourceCount      int     `json:"resource_count"`
+	BookingCount       int64   `json:"booking_count"`
+	BookedHours        float64 `json:"booked_hours"`
+	CapacityHours      float64 `json:"capacity_hours"`
+	UtilizationPercent float64 `json:"utilization_percent"`
+}
+
+// ResourceUtilization names a resource and its utilization
+type ResourceUtilization struct {
+	ResourceID         int32   `json:"resource_id"`
+	ResourceName       string  `json:"resource_name"`
+	UtilizationPercent float64 `json:"utilization_percent"`
+}
+
+// UtilizationReportSummary holds the headline figures of a utilization report.
+// MostUtilized and LeastUtilized are nil when the report has no rows.
+type UtilizationReportSummary struct {
+	AverageUtilizationPercent float64              `json:"average_utilization_percent"`
+	MedianUtilizationPercent  float64              `json:"median_utilization_percent"`
+	MostUtilized              *ResourceUtilization `json:"most_utilized"`
+	LeastUtilized             *ResourceUtilization `json:"least_utilized"`
+	IdleResourceCount         int                  `json:"idle_resource_count"`
+	OverCapacityCount         int                  `json:"over_capacity_count"`
+}
+
+// UtilizationReport is a self-describing utilization report meant to be
+// rendered to a document downstream: it carries its own title, period,
+// generation time, and the assumptions behind the figures
+type UtilizationReport struct {
+	Title        string                   `json:"title"`
+	Period       TimeRange                `json:"period"`
+	GeneratedAt  time.Time                `json:"generated_at"`
+	ResourceType *ResourceType            `json:"resource_type,omitempty"`
+	Assumptions  UtilizationAssumptions   `json:"assumptions"`
+	Rows         []UtilizationReportRow   `json:"rows"`
+	Totals       UtilizationReportTotals  `json:"totals"`
+	Summary      UtilizationReportSummary `json:"summary"`
+}
+
+// ResourceSharingRequest asks how the events booked within a window are linked
+// by the resources they share
+type ResourceSharingRequest struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	ReportOptions
+}
+
+// SharingEvent is an event booked within a resource-sharing window, with how
+// many distinct resources it booked there
+type SharingEvent struct {
+	EventID       int32  `json:"event_id"`
+	EventName     string `json:"event_name"`
+	ResourceCount int    `json:"resource_count"`
+}
+
+// SharedResource is a resource booked by more than one event of a group
+type SharedResource struct {
+	ResourceID   int32   `json:"resource_id"`
+	ResourceName string  `json:"resource_name"`
+	EventIDs     []int32 `json:"event_ids"`
+}
+
+// ResourceSharingGroup is a connected component of events: each is linked to
+// the others through a chain of shared resources
+type ResourceSharingGroup struct {
+	Events          []SharingEvent   `json:"events"`
+	SharedResources []SharedResource `json:"shared_resources"`
+}
+
+// ResourceSharingResponse splits the events booked within the window into
+// groups that share resources and isolated events that share none
+type ResourceSharingResponse struct {
+	StartDate      time.Time              `json:"start_date"`
+	EndDate        time.Time              `json:"end_date"`
+	EventCount     int                    `json:"event_count"`
+	Groups         []ResourceSharingGroup `json:"groups"`
+	IsolatedEvents []SharingEvent         `json:"isolated_events"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// ResolutionKind says how a proposed resolution clears a conflict
+type ResolutionKind string
+
+const (
+	// ResolutionSubstitute moves the booking to another resource of the same
+	// type at the same time
+	ResolutionSubstitute ResolutionKind = "substitute"
+	// ResolutionReschedule keeps the resource and moves the booking to its next
+	// free slot
+	ResolutionReschedule ResolutionKind = "reschedule"
+	// ResolutionKeep means earlier proposals in the plan already clear the
+	// conflict, so the booking can stay as it is
+	ResolutionKeep ResolutionKind = "keep"
+	// ResolutionUnresolved means no substitute or free slot was found
+	ResolutionUnresolved ResolutionKind = "unresolved"
+)
+
+// ResolutionConflict is a booking that overlaps the one being resolved
+type ResolutionConflict struct {
+	ScheduleID     int32          `json:"schedule_id"`
+	EventID        int32          `json:"event_id"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// ConflictResolution proposes how to clear the conflicts of one of the event's
+// bookings. The substitute fields are set for a substitute and the proposed
+// times for a reschedule.
+type ConflictResolution struct {
+	ScheduleID             int32                `json:"schedule_id"`
+	ResourceID             int32                `json:"resource_id"`
+	ResourceName           string               `json:"resource_name"`
+	StartTime              time.Time            `json:"start_time"`
+	EndTime                time.Time            `json:"end_time"`
+	ConflictsWith          []ResolutionConflict `json:"conflicts_with"`
+	Kind                   ResolutionKind       `json:"kind"`
+	SubstituteResourceID   *int32               `json:"substitute_resource_id,omitempty"`
+	SubstituteResourceName *string              `json:"substitute_resource_name,omitempty"`
+	ProposedStart          *time.Time           `json:"proposed_start,omitempty"`
+	ProposedEnd            *time.Time           `json:"proposed_end,omitempty"`
+}
+
+// ResolutionPlanResponse is a preview of how every conflict of an event could
+// be resolved. Nothing in it has been applied.
+type ResolutionPlanResponse struct {
+	EventID         int32                `json:"event_id"`
+	Resolutions     []ConflictResolution `json:"resolutions"`
+	UnresolvedCount int                  `json:"unresolved_count"`
+}
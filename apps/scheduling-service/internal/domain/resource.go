@@ -13,6 +13,15 @@ const (
 	ResourceTypeMaterials ResourceType = "materials"
 )
 
+// IsValid reports whether t is one of the known resource types
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeStaff, ResourceTypeEquipment, ResourceTypeMaterials:
+		return true
+	}
+	return false
+}
+
 // Resource represents a staff member, equipment, or material that can be assigned to tasks
 type Resource struct {
 	ID          int32        `json:"id"`
@@ -21,23 +30,94 @@ type Resource struct {
 	HourlyRate  *string      `json:"hourly_rate,omitempty"`
 	IsAvailable bool         `json:"is_available"`
 	Notes       *string      `json:"notes,omitempty"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
+	// ReleaseGraceMinutes extends each booking's effective end during conflict
+	// checks, covering cleanup that runs past the nominal end time
+	ReleaseGraceMinutes int32 `json:"release_grace_minutes"`
+	// Timezone is the IANA zone the resource operates in, if it has one
+	Timezone  *string   `json:"timezone,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ApprovalStatus is the approval workflow state of a schedule entry
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
 // ScheduleEntry represents a time slot when a resource is assigned
 type ScheduleEntry struct {
-	ID          int32     `json:"id"`
-	ResourceID  int32     `json:"resource_id"`
-	EventID     int32     `json:"event_id"`
-	EventName   string    `json:"event_name,omitempty"`
-	TaskID      *int32    `json:"task_id,omitempty"`
-	TaskTitle   *string   `json:"task_title,omitempty"`
-	StartTime   time.Time `json:"start_time"`
-	EndTime     time.Time `json:"end_time"`
-	Notes       *string   `json:"notes,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name,omitempty"`
+	TaskID         *int32         `json:"task_id,omitempty"`
+	TaskTitle      *string        `json:"task_title,omitempty"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	Notes          *string        `json:"notes,omitempty"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	// CancelledAt is set once the entry is cancelled; cancelled entries are
+	// kept for history but no longer block the resource
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CancellationReason *string    `json:"cancellation_reason,omitempty"`
+	// RecurrenceGroupID is shared by the entries of one recurring booking
+	RecurrenceGroupID *int32 `json:"recurrence_group_id,omitempty"`
+	// CreatedBy is the user who booked the entry, or null when it was
+	// written without an authenticated user, such as by an internal job
+	CreatedBy *int32    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// RemainingQuantity is set on create and update responses for equipment
+	// and materials: the units still free at the busiest moment of the range
+	RemainingQuantity *int32 `json:"remaining_quantity,omitempty"`
+	// Warnings lists soft conflicts found on create and update that didn't
+	// block the write, such as time outside the resource's working hours
+	Warnings []Conflict `json:"warnings,omitempty"`
+}
+
+// ScheduleEntryRequest holds the editable fields of a schedule entry, for
+// creating one or replacing an existing one
+type ScheduleEntryRequest struct {
+	ResourceID int32     `json:"resource_id"`
+	EventID    int32     `json:"event_id"`
+	TaskID     *int32    `json:"task_id,omitempty"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Notes      *string   `json:"notes,omitempty"`
+	// Quantity is the number of units booked, for equipment and materials.
+	// Zero means one.
+	Quantity int32 `json:"quantity,omitempty"`
+	// CreatedBy is the authenticated user making the booking. It is set by
+	// the handler, never read from the request body.
+	CreatedBy *int32 `json:"-"`
+	// RejectPast refuses a booking that starts in the past
+	RejectPast bool `json:"reject_past,omitempty"`
+}
+
+// CancelEntryRequest carries the optional reason for cancelling an entry
+type CancelEntryRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// ScheduleChange is how a schedule entry changed
+type ScheduleChange string
+
+const (
+	ScheduleChangeCreated ScheduleChange = "created"
+	ScheduleChangeUpdated ScheduleChange = "updated"
+	ScheduleChangeDeleted ScheduleChange = "deleted"
+)
+
+// ScheduleChangedEvent is published whenever the service changes a schedule
+// entry. Entry is the entry after the change, or as it was before deletion.
+type ScheduleChangedEvent struct {
+	Change     ScheduleChange `json:"change"`
+	Entry      ScheduleEntry  `json:"entry"`
+	OccurredAt time.Time      `json:"occurred_at"`
 }
 
 // TimeRange represents a time period
@@ -51,3 +131,130 @@ type DateRange struct {
 	StartDate time.Time `json:"start_date"`
 	EndDate   time.Time `json:"end_date"`
 }
+
+// BulkAvailabilityRequest represents a request to set is_available on many resources
+type BulkAvailabilityRequest struct {
+	ResourceIDs []int32 `json:"resource_ids"`
+	IsAvailable *bool   `json:"is_available"`
+}
+
+// FutureBookingWarning flags a resource marked unavailable that still has bookings
+type FutureBookingWarning struct {
+	ResourceID         int32 `json:"resource_id"`
+	FutureBookingCount int64 `json:"future_booking_count"`
+}
+
+// BulkAvailabilityResponse reports how many resources were updated
+type BulkAvailabilityResponse struct {
+	UpdatedCount int64                  `json:"updated_count"`
+	Warnings     []FutureBookingWarning `json:"warnings"`
+}
+
+// DeleteImpactEvent is an event that would lose bookings if a resource were
+// deleted
+type DeleteImpactEvent struct {
+	EventID      int32     `json:"event_id"`
+	EventName    string    `json:"event_name"`
+	EventDate    time.Time `json:"event_date"`
+	EventStatus  string    `json:"event_status"`
+	BookingCount int64     `json:"booking_count"`
+	FirstStart   time.Time `json:"first_start"`
+}
+
+// ResourceDeleteImpact lists the future bookings deleting a resource would
+// remove, grouped by event
+type ResourceDeleteImpact struct {
+	ResourceID         int32               `json:"resource_id"`
+	ResourceName       string              `json:"resource_name"`
+	FutureBookingCount int64               `json:"future_booking_count"`
+	Events             []DeleteImpactEvent `json:"events"`
+}
+
+// WorkingHours is one weekly shift set on a resource. Times are HH:MM wall
+// clock in the resource's timezone; an end not after the start runs past
+// midnight into the next day.
+type WorkingHours struct {
+	ID         int32  `json:"id"`
+	ResourceID int32  `json:"resource_id"`
+	DayOfWeek  int32  `json:"day_of_week"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+}
+
+// WorkingHoursRequest holds the editable fields of a working-hours shift.
+// DayOfWeek runs from 0 (Sunday) to 6 (Saturday).
+type WorkingHoursRequest struct {
+	DayOfWeek int32  `json:"day_of_week"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// Downtime is a stretch of time a resource is out of service, for maintenance
+// or repairs, without belonging to any event. It can't be booked over.
+type Downtime struct {
+	ID         int32     `json:"id"`
+	ResourceID int32     `json:"resource_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Reason     *string   `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DowntimeRequest takes a resource out of service from StartTime to EndTime
+type DowntimeRequest struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    *string
+}
+
+// ResourceSort names the field a resource list is ordered by
+type ResourceSort string
+
+const (
+	// ResourceSortName orders resources by name; it is the default
+	ResourceSortName ResourceSort = "name"
+	// ResourceSortType orders resources by type, alphabetically
+	ResourceSortType ResourceSort = "type"
+	// ResourceSortHourlyRate orders resources by hourly rate, those without
+	// a rate last
+	ResourceSortHourlyRate ResourceSort = "hourly_rate"
+	// ResourceSortCreatedAt orders resources by when they were added
+	ResourceSortCreatedAt ResourceSort = "created_at"
+)
+
+// SortOrder is the direction of a sort
+type SortOrder string
+
+const (
+	// SortOrderAsc sorts smallest first; it is the default
+	SortOrderAsc SortOrder = "asc"
+	// SortOrderDesc sorts largest first
+	SortOrderDesc SortOrder = "desc"
+)
+
+// ResourceListRequest filters, sorts, and pages the resources list. Rates are
+// decimal strings like the hourly_rate they bound, and both bounds are
+// inclusive.
+type ResourceListRequest struct {
+	Type        *ResourceType
+	IsAvailable *bool
+	MinRate     *string
+	MaxRate     *string
+	Sort        ResourceSort
+	Order       SortOrder
+	Limit       int
+	Offset      int
+}
+
+// ResourceListResponse is one page of the resources list
+type ResourceListResponse struct {
+	Total     int64      `json:"total"`
+	Limit     int        `json:"limit"`
+	Offset    int        `json:"offset"`
+	Resources []Resource `json:"resources"`
+}
+
+// SetTimezoneRequest sets or clears (nil) a resource's operating timezone
+type SetTimezoneRequest struct {
+	Timezone *string `json:"timezone"`
+}
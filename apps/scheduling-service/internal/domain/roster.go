@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// WeeklyRosterRequest asks for a resource's Monday to Sunday schedule. Only the
+// calendar date of WeekStart is used; it is moved back to the Monday of its week.
+type WeeklyRosterRequest struct {
+	ResourceID int32
+	WeekStart  time.Time
+}
+
+// RosterDay is one day of a roster. An entry that runs past midnight appears on
+// every day it touches, but each day's BookedHours only counts time on that day.
+type RosterDay struct {
+	Date        string          `json:"date"`
+	Weekday     string          `json:"weekday"`
+	Entries     []ScheduleEntry `json:"entries"`
+	BookedHours float64         `json:"booked_hours"`
+}
+
+// WeeklyRosterResponse is a resource's bookings for one week, laid out by day in
+// the resource's timezone (UTC when it has none)
+type WeeklyRosterResponse struct {
+	ResourceID int32       `json:"resource_id"`
+	Timezone   string      `json:"timezone"`
+	WeekStart  string      `json:"week_start"`
+	Days       []RosterDay `json:"days"`
+	TotalHours float64     `json:"total_hours"`
+}
@@ -0,0 +1,182 @@
+// Package events publishes notices of changes made by this service, so other
+// services can react to them
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// PublisherEnv names the environment variable choosing where events are
+// published: "none" (the default), "nats" or "postgres"
+const PublisherEnv = "EVENT_PUBLISHER"
+
+const (
+	// publishTimeout bounds a single publish by the buffered publisher
+	publishTimeout = 5 * time.Second
+
+	// DefaultBufferSize is how many events may wait to be published before
+	// new ones are dropped
+	DefaultBufferSize = 256
+)
+
+// Publisher sends an event, encoded as JSON, to topic
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload interface{}) error
+}
+
+// PublishCloser is a Publisher that may hold events until it is closed. Close
+// publishes whatever it still holds, giving up when ctx ends.
+type PublishCloser interface {
+	Publisher
+	Close(ctx context.Context) error
+}
+
+// Noop discards every event. It is the publisher when none is configured.
+type Noop struct{}
+
+func (Noop) Publish(context.Context, string, interface{}) error { return nil }
+
+func (Noop) Close(context.Context) error { return nil }
+
+// Postgres publishes events with NOTIFY on a channel named after the topic,
+// so any service connected to the database can LISTEN for them. Postgres caps
+// a payload at 8000 bytes, and a listener that isn't connected when an event
+// is sent never sees it; use NATS where consumers must not miss events.
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres creates a publisher notifying through db
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+func (p *Postgres) Publish(ctx context.Context, topic string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if _, err := p.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", topic, string(body)); err != nil {
+		return fmt.Errorf("failed to notify %s: %w", topic, err)
+	}
+	return nil
+}
+
+// event is a publish waiting in a Buffered queue. ctx is the publishing
+// request's context without its cancellation, so the publish and its logs
+// keep the request's values, such as its ID.
+type event struct {
+	ctx     context.Context
+	topic   string
+	payload interface{}
+}
+
+// Buffered hands events to another publisher from a bounded queue on its own
+// goroutine, so publishing never holds up a request. Publish returns at once;
+// an event that doesn't fit in the queue is dropped and logged, and failures
+// to publish are logged rather than returned. Close it before closing what
+// next publishes through, so queued events aren't lost.
+type Buffered struct {
+	next  Publisher
+	queue chan event
+	done  chan struct{}
+
+	// mu guards closed, so Publish never sends on a closed queue
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBuffered starts publishing through next, with at most size events waiting
+func NewBuffered(next Publisher, size int) *Buffered {
+	b := &Buffered{
+		next:  next,
+		queue: make(chan event, size),
+		done:  make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Publish queues the event. The caller must not change payload afterwards.
+// Events published after Close are dropped and logged.
+func (b *Buffered) Publish(ctx context.Context, topic string, payload interface{}) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		logger.Get().WithRequestID(ctx).Warn().Str("topic", topic).Msg("Event publisher closed, event dropped")
+		return nil
+	}
+	select {
+	case b.queue <- event{ctx: context.WithoutCancel(ctx), topic: topic, payload: payload}:
+	default:
+		logger.Get().WithRequestID(ctx).Warn().Str("topic", topic).Msg("Event queue full, event dropped")
+	}
+	return nil
+}
+
+// Close stops taking events and waits for the queued ones to be published,
+// then closes next if it is a PublishCloser. If ctx ends first, the events
+// still queued are abandoned and ctx's error is returned. Closing again only
+// waits again.
+func (b *Buffered) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.done:
+		if next, ok := b.next.(PublishCloser); ok {
+			return next.Close(ctx)
+		}
+		return nil
+	case <-ctx.Done():
+		logger.Get().Error().Int("queued", len(b.queue)).Msg("Gave up waiting for queued events to publish")
+		return ctx.Err()
+	}
+}
+
+// run publishes queued events in order until the queue is closed and empty.
+// The request that raised an event is usually over by now, so each publish
+// gets its own deadline.
+func (b *Buffered) run() {
+	defer close(b.done)
+	for e := range b.queue {
+		ctx, cancel := context.WithTimeout(e.ctx, publishTimeout)
+		if err := b.next.Publish(ctx, e.topic, e.payload); err != nil {
+			logger.Get().WithRequestID(ctx).Error().Err(err).Str("topic", e.topic).Msg("Failed to publish event")
+		}
+		cancel()
+	}
+}
+
+// LoadPublisher returns the publisher EVENT_PUBLISHER selects, buffered so
+// publishing stays off the request path. Call it once at startup; an unknown
+// publisher is returned as an error so the service can refuse to start. Close
+// the publisher before db.
+func LoadPublisher(db *sql.DB) (PublishCloser, error) {
+	switch v := os.Getenv(PublisherEnv); v {
+	case "", "none":
+		return Noop{}, nil
+	case "nats":
+		n, err := ParseNATSURL(os.Getenv(NATSURLEnv))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", NATSURLEnv, err)
+		}
+		return NewBuffered(n, DefaultBufferSize), nil
+	case "postgres":
+		return NewBuffered(NewPostgres(db), DefaultBufferSize), nil
+	default:
+		return nil, fmt.Errorf("invalid %s: must be none, nats or postgres, got %q", PublisherEnv, v)
+	}
+}
@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// recorder is a publisher that keeps what it is sent, failing when told to
+type recorder struct {
+	mu     sync.Mutex
+	topics []string
+	// requestIDs holds the request ID each publish's context carried
+	requestIDs []string
+	fail       bool
+	block      chan struct{}
+}
+
+func (r *recorder) Publish(ctx context.Context, topic string, _ interface{}) error {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.topics = append(r.topics, topic)
+	r.requestIDs = append(r.requestIDs, logger.RequestID(ctx))
+	if r.fail {
+		return errors.New("broker unavailable")
+	}
+	return nil
+}
+
+func (r *recorder) published() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.topics...)
+}
+
+func TestBuffered_DeliversInOrder(t *testing.T) {
+	rec := &recorder{fail: true}
+	b := NewBuffered(rec, 10)
+
+	// Failures are logged, not returned, and don't stop later events
+	for _, topic := range []string{"a", "b", "c"} {
+		require.NoError(t, b.Publish(context.Background(), topic, map[string]int{"n": 1}))
+	}
+
+	assert.Eventually(t, func() bool { return len(rec.published()) == 3 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"a", "b", "c"}, rec.published())
+}
+
+func TestBuffered_KeepsRequestContext(t *testing.T) {
+	rec := &recorder{}
+	b := NewBuffered(rec, 10)
+
+	// The request is over by the time the event is published
+	ctx, cancel := context.WithCancel(logger.ContextWithRequestID(context.Background(), "req-1"))
+	require.NoError(t, b.Publish(ctx, "a", nil))
+	cancel()
+	require.NoError(t, b.Close(context.Background()))
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	assert.Equal(t, []string{"req-1"}, rec.requestIDs)
+}
+
+func TestBuffered_DropsWhenFull(t *testing.T) {
+	rec := &recorder{block: make(chan struct{})}
+	b := NewBuffered(rec, 1)
+
+	// The first event is taken by the worker, which then blocks; the second
+	// fills the queue and the third is dropped
+	require.NoError(t, b.Publish(context.Background(), "first", nil))
+	assert.Eventually(t, func() bool { return len(b.queue) == 0 }, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, b.Publish(context.Background(), "second", nil))
+	require.NoError(t, b.Publish(context.Background(), "third", nil))
+
+	close(rec.block)
+	assert.Eventually(t, func() bool { return len(rec.published()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, rec.published())
+}
+
+func TestBuffered_CloseDrainsQueue(t *testing.T) {
+	rec := &recorder{block: make(chan struct{})}
+	b := NewBuffered(rec, 10)
+
+	for _, topic := range []string{"a", "b", "c"} {
+		require.NoError(t, b.Publish(context.Background(), topic, nil))
+	}
+	go close(rec.block)
+
+	require.NoError(t, b.Close(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c"}, rec.published())
+
+	// Later events are dropped rather than sent on the closed queue
+	require.NoError(t, b.Publish(context.Background(), "late", nil))
+	require.NoError(t, b.Close(context.Background()))
+	assert.Equal(t, []string{"a", "b", "c"}, rec.published())
+}
+
+// closingRecorder is a recorder that notes being closed
+type closingRecorder struct {
+	recorder
+	closed bool
+}
+
+func (r *closingRecorder) Close(context.Context) error {
+	r.closed = true
+	return nil
+}
+
+func TestBuffered_CloseClosesNextAfterDraining(t *testing.T) {
+	rec := &closingRecorder{}
+	b := NewBuffered(rec, 10)
+	require.NoError(t, b.Publish(context.Background(), "a", nil))
+
+	require.NoError(t, b.Close(context.Background()))
+	assert.Equal(t, []string{"a"}, rec.published())
+	assert.True(t, rec.closed)
+}
+
+func TestBuffered_CloseGivesUpWhenContextEnds(t *testing.T) {
+	rec := &recorder{block: make(chan struct{})}
+	defer close(rec.block)
+	b := NewBuffered(rec, 10)
+	require.NoError(t, b.Publish(context.Background(), "stuck", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+}
+
+func TestLoadPublisher(t *testing.T) {
+	t.Setenv(PublisherEnv, "")
+	require.NoError(t, os.Unsetenv(PublisherEnv))
+	p, err := LoadPublisher(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Noop{}, p)
+
+	t.Setenv(PublisherEnv, "none")
+	p, err = LoadPublisher(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Noop{}, p)
+
+	t.Setenv(PublisherEnv, "postgres")
+	p, err = LoadPublisher(nil)
+	require.NoError(t, err)
+	assert.IsType(t, &Buffered{}, p)
+
+	t.Setenv(PublisherEnv, "nats")
+	t.Setenv(NATSURLEnv, "nats://broker:4222")
+	p, err = LoadPublisher(nil)
+	require.NoError(t, err)
+	assert.IsType(t, &Buffered{}, p)
+
+	t.Setenv(NATSURLEnv, "broker:4222")
+	_, err = LoadPublisher(nil)
+	assert.ErrorContains(t, err, NATSURLEnv)
+
+	t.Setenv(PublisherEnv, "kafka")
+	_, err = LoadPublisher(nil)
+	assert.ErrorContains(t, err, PublisherEnv)
+}
@@ -0,0 +1,337 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSURLEnv names the environment variable holding the NATS server events
+// are published to when EVENT_PUBLISHER is "nats", as
+// nats://[user:password@]host[:port] or nats://token@host[:port]
+const NATSURLEnv = "NATS_URL"
+
+// defaultNATSPort is the port NATS listens on when the URL names none
+const defaultNATSPort = "4222"
+
+// NATS publishes events to a NATS JetStream stream, on a subject named after
+// the topic. The stream keeps each event for consumers that aren't connected
+// when it is published, and Publish succeeds only once the stream has stored
+// it: a subject no stream captures is an error rather than an event lost.
+//
+// Each event carries a Nats-Msg-Id, so if a connection that had been idle
+// turns out to be broken and the event is sent again on a new one, the stream
+// stores it once. It speaks the NATS client protocol over plain TCP.
+type NATS struct {
+	addr  string
+	user  string
+	pass  string
+	token string
+
+	// mu serializes publishes, which share one connection and wait for
+	// their acks in turn
+	mu    sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+	inbox string
+	acks  int
+}
+
+// ParseNATSURL validates a NATS server URL and returns a publisher for it
+func ParseNATSURL(s string) (*NATS, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" || u.Hostname() == "" {
+		return nil, errors.New("must be a nats:// URL")
+	}
+	n := &NATS{addr: u.Host}
+	if u.Port() == "" {
+		n.addr = net.JoinHostPort(u.Hostname(), defaultNATSPort)
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			n.user, n.pass = u.User.Username(), pass
+		} else {
+			n.token = u.User.Username()
+		}
+	}
+	return n, nil
+}
+
+// natsAck is JetStream's reply to a publish
+type natsAck struct {
+	Stream string `json:"stream"`
+	Seq    uint64 `json:"seq"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// Publish sends the event and waits for the stream to acknowledge it, up to
+// ctx's deadline or publishTimeout
+func (n *NATS) Publish(ctx context.Context, topic string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	msgID, err := newMsgID()
+	if err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, publishTimeout)
+		defer cancel()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	reused := n.conn != nil
+	err = n.publish(ctx, topic, msgID, body)
+	if err != nil && reused && ctx.Err() == nil && !errors.Is(err, errNotStored) {
+		// The idle connection may have been dropped by the server; the
+		// stream discards the copy if the first one did arrive
+		err = n.publish(ctx, topic, msgID, body)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// errNotStored wraps a publish the server answered but the stream refused
+var errNotStored = errors.New("event not stored")
+
+// publish sends one event on the connection, connecting first if need be,
+// and reads its ack. The connection is dropped on any failure.
+func (n *NATS) publish(ctx context.Context, topic, msgID string, body []byte) (err error) {
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if err != nil {
+			n.disconnect()
+		}
+	}()
+
+	deadline, _ := ctx.Deadline()
+	if err := n.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { n.conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	n.acks++
+	reply := n.inbox + "." + strconv.Itoa(n.acks)
+	header := "NATS/1.0\r\nNats-Msg-Id: " + msgID + "\r\n\r\n"
+	msg := fmt.Sprintf("HPUB %s %s %d %d\r\n%s%s\r\n", topic, reply, len(header), len(header)+len(body), header, body)
+	if _, err := io.WriteString(n.conn, msg); err != nil {
+		return err
+	}
+
+	status, data, err := n.awaitReply(reply)
+	if err != nil {
+		return err
+	}
+	if status != "" {
+		// 503 is the server saying nothing answered: no stream captures the
+		// subject
+		return fmt.Errorf("%w: server replied %q, is a stream capturing the subject?", errNotStored, status)
+	}
+	var ack natsAck
+	if err := json.Unmarshal(data, &ack); err != nil {
+		return fmt.Errorf("unexpected reply %q: %w", data, err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("%w: %s (%d)", errNotStored, ack.Error.Description, ack.Error.Code)
+	}
+	if ack.Stream == "" {
+		return fmt.Errorf("unexpected reply %q", data)
+	}
+	return nil
+}
+
+// connect dials the server, introduces the client, and subscribes to the
+// inbox acks are sent to
+func (n *NATS) connect(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	n.conn, n.r = conn, bufio.NewReader(conn)
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	line, err := n.readLine()
+	if err == nil && !strings.HasPrefix(line, "INFO ") {
+		err = fmt.Errorf("unexpected greeting %q", line)
+	}
+	if err == nil {
+		var info struct {
+			TLSRequired bool `json:"tls_required"`
+		}
+		if json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info) == nil && info.TLSRequired {
+			err = errors.New("server requires TLS, which isn't supported")
+		}
+	}
+	if err != nil {
+		n.disconnect()
+		return err
+	}
+
+	id, err := newMsgID()
+	if err != nil {
+		n.disconnect()
+		return err
+	}
+	n.inbox = "_INBOX." + id
+	n.acks = 0
+	options, _ := json.Marshal(map[string]interface{}{
+		"verbose":       false,
+		"pedantic":      false,
+		"headers":       true,
+		"no_responders": true,
+		"name":          "scheduling-service",
+		"lang":          "go",
+		"protocol":      1,
+		"user":          n.user,
+		"pass":          n.pass,
+		"auth_token":    n.token,
+	})
+	// The PONG confirms the server accepted the connection; a refusal, such
+	// as bad credentials, comes back as -ERR instead
+	hello := fmt.Sprintf("CONNECT %s\r\nSUB %s.* 1\r\nPING\r\n", options, n.inbox)
+	if _, err := io.WriteString(conn, hello); err != nil {
+		n.disconnect()
+		return err
+	}
+	for {
+		line, err := n.readLine()
+		if err == nil {
+			switch {
+			case line == "PONG":
+				return nil
+			case line == "PING":
+				_, err = io.WriteString(conn, "PONG\r\n")
+			case strings.HasPrefix(line, "-ERR"):
+				err = fmt.Errorf("server refused connection: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+			}
+		}
+		if err != nil {
+			n.disconnect()
+			return err
+		}
+	}
+}
+
+// awaitReply reads until the message sent to subject arrives, answering the
+// server's pings on the way. It returns the status from the message's header,
+// if it has one, and its body.
+func (n *NATS) awaitReply(subject string) (status string, data []byte, err error) {
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return "", nil, err
+		}
+		fields := strings.Fields(line)
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(n.conn, "PONG\r\n"); err != nil {
+				return "", nil, err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return "", nil, fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case len(fields) >= 4 && fields[0] == "MSG":
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return "", nil, fmt.Errorf("malformed message %q", line)
+			}
+			body, err := n.readPayload(size)
+			if err != nil {
+				return "", nil, err
+			}
+			if fields[1] == subject {
+				return "", body, nil
+			}
+		case len(fields) >= 5 && fields[0] == "HMSG":
+			headerSize, err1 := strconv.Atoi(fields[len(fields)-2])
+			size, err2 := strconv.Atoi(fields[len(fields)-1])
+			if err1 != nil || err2 != nil || headerSize > size {
+				return "", nil, fmt.Errorf("malformed message %q", line)
+			}
+			body, err := n.readPayload(size)
+			if err != nil {
+				return "", nil, err
+			}
+			if fields[1] == subject {
+				// The header opens with "NATS/1.0", then a status if any
+				first, _, _ := strings.Cut(string(body[:headerSize]), "\r\n")
+				return strings.TrimSpace(strings.TrimPrefix(first, "NATS/1.0")), body[headerSize:], nil
+			}
+		}
+		// Anything else, such as +OK, PONG or a new INFO, needs no answer
+	}
+}
+
+// readLine reads one protocol line without its CRLF
+func (n *NATS) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readPayload reads a message body of size bytes and the CRLF after it
+func (n *NATS) readPayload(size int) ([]byte, error) {
+	buf := make([]byte, size+2)
+	if _, err := io.ReadFull(n.r, buf); err != nil {
+		return nil, err
+	}
+	return buf[:size], nil
+}
+
+// disconnect drops the connection; the next publish opens a new one
+func (n *NATS) disconnect() {
+	if n.conn != nil {
+		n.conn.Close()
+		n.conn, n.r = nil, nil
+	}
+}
+
+// Close closes the connection to the server
+func (n *NATS) Close(context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.disconnect()
+	return nil
+}
+
+// newMsgID returns a random ID for an event, by which the stream recognizes
+// a copy sent again
+func newMsgID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,190 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATS is a NATS server holding a JetStream stream over the subjects in
+// stream. It records what it stores and the CONNECT options of each client.
+type fakeNATS struct {
+	ln     net.Listener
+	stream map[string]bool
+	// dropAfter closes each connection once it has stored that many events
+	dropAfter int
+
+	mu       sync.Mutex
+	connects []string
+	stored   []string
+	msgIDs   map[string]bool
+}
+
+func startFakeNATS(t *testing.T, subjects ...string) *fakeNATS {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeNATS{ln: ln, stream: map[string]bool{}, msgIDs: map[string]bool{}}
+	for _, s := range subjects {
+		f.stream[s] = true
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeNATS) url(userinfo string) string {
+	if userinfo != "" {
+		userinfo += "@"
+	}
+	return "nats://" + userinfo + f.ln.Addr().String()
+}
+
+func (f *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"headers\":true}\r\n")
+	var inboxSID string
+	stored := 0
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			f.mu.Lock()
+			f.connects = append(f.connects, strings.TrimSpace(strings.TrimPrefix(line, "CONNECT")))
+			f.mu.Unlock()
+		case "SUB":
+			inboxSID = fields[2]
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "HPUB":
+			subject, reply := fields[1], fields[2]
+			headerSize, _ := strconv.Atoi(fields[3])
+			size, _ := strconv.Atoi(fields[4])
+			buf := make([]byte, size+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			if !f.stream[subject] {
+				status := "NATS/1.0 503\r\n\r\n"
+				fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", reply, inboxSID, len(status), len(status), status)
+				continue
+			}
+			header := string(buf[:headerSize])
+			f.mu.Lock()
+			msgID := header[strings.Index(header, "Nats-Msg-Id: ")+len("Nats-Msg-Id: "):]
+			msgID = msgID[:strings.Index(msgID, "\r\n")]
+			if !f.msgIDs[msgID] {
+				f.msgIDs[msgID] = true
+				f.stored = append(f.stored, subject+" "+string(buf[headerSize:size]))
+			}
+			seq := len(f.stored)
+			f.mu.Unlock()
+			ack := fmt.Sprintf(`{"stream":"SCHEDULE","seq":%d}`, seq)
+			fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", reply, inboxSID, len(ack), ack)
+			stored++
+			if f.dropAfter > 0 && stored >= f.dropAfter {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeNATS) storedEvents() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.stored...)
+}
+
+func TestNATS_PublishWaitsForStream(t *testing.T) {
+	server := startFakeNATS(t, "schedule.changed")
+	n, err := ParseNATSURL(server.url("svc:s3cret"))
+	require.NoError(t, err)
+	defer n.Close(context.Background())
+
+	require.NoError(t, n.Publish(context.Background(), "schedule.changed", map[string]int{"id": 1}))
+	require.NoError(t, n.Publish(context.Background(), "schedule.changed", map[string]int{"id": 2}))
+
+	assert.Equal(t, []string{`schedule.changed {"id":1}`, `schedule.changed {"id":2}`}, server.storedEvents())
+	require.Len(t, server.connects, 1, "the connection is reused")
+	assert.Contains(t, server.connects[0], `"user":"svc"`)
+	assert.Contains(t, server.connects[0], `"pass":"s3cret"`)
+	assert.Contains(t, server.connects[0], `"no_responders":true`)
+}
+
+func TestNATS_FailsWhenNoStreamCapturesSubject(t *testing.T) {
+	server := startFakeNATS(t, "schedule.changed")
+	n, err := ParseNATSURL(server.url(""))
+	require.NoError(t, err)
+	defer n.Close(context.Background())
+
+	err = n.Publish(context.Background(), "other.topic", map[string]int{"id": 1})
+	assert.ErrorIs(t, err, errNotStored)
+	assert.Empty(t, server.storedEvents())
+}
+
+func TestNATS_ReconnectsAfterServerDropsConnection(t *testing.T) {
+	server := startFakeNATS(t, "schedule.changed")
+	server.dropAfter = 1
+	n, err := ParseNATSURL(server.url("tok"))
+	require.NoError(t, err)
+	defer n.Close(context.Background())
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, n.Publish(context.Background(), "schedule.changed", i), "event %d", i)
+	}
+	assert.Equal(t, []string{"schedule.changed 1", "schedule.changed 2", "schedule.changed 3"}, server.storedEvents())
+	assert.Contains(t, server.connects[0], `"auth_token":"tok"`)
+}
+
+func TestNATS_GivesUpWhenServerUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	n, err := ParseNATSURL("nats://" + addr)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, n.Publish(ctx, "schedule.changed", 1))
+}
+
+func TestParseNATSURL(t *testing.T) {
+	n, err := ParseNATSURL("nats://broker")
+	require.NoError(t, err)
+	assert.Equal(t, "broker:4222", n.addr)
+
+	n, err = ParseNATSURL("nats://u:p@broker:4333")
+	require.NoError(t, err)
+	assert.Equal(t, "broker:4333", n.addr)
+	assert.Equal(t, "u", n.user)
+	assert.Equal(t, "p", n.pass)
+
+	for _, v := range []string{"", "broker:4222", "http://broker", "nats://"} {
+		_, err := ParseNATSURL(v)
+		assert.Error(t, err, v)
+	}
+}
@@ -0,0 +1,161 @@
+// Package ical serializes schedules as iCalendar (RFC 5545) files
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ContentType is the MIME type of an iCalendar file
+const ContentType = "text/calendar; charset=utf-8"
+
+// prodID identifies this service as the calendar producer
+const prodID = "-//Catering Event Manager//Scheduling Service//EN"
+
+// Calendar is a named collection of events. Timezone, an IANA name, is the
+// zone event times are written in, with a VTIMEZONE describing it; without
+// one, or for UTC, they are written in UTC.
+type Calendar struct {
+	Name     string
+	Timezone string
+	Events   []Event
+}
+
+// Event is a single VEVENT. Stamp is when the underlying record last changed;
+// Tentative marks events that have not been confirmed yet.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	Tentative   bool
+}
+
+// Encode writes the calendar to w. Event times are written in the calendar's
+// timezone, stamps in UTC, text is escaped and long lines are folded as
+// RFC 5545 requires. An unknown timezone is an error.
+func Encode(w io.Writer, cal Calendar) error {
+	var loc *time.Location
+	if cal.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(cal.Timezone)
+		if err != nil {
+			return fmt.Errorf("unknown timezone %q: %w", cal.Timezone, err)
+		}
+		if loc == time.UTC {
+			loc = nil
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	write := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+	writeTime := func(name string, t time.Time) {
+		if loc == nil {
+			write(name, formatTime(t))
+			return
+		}
+		write(name+";TZID="+loc.String(), formatLocalTime(t.In(loc)))
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", prodID)
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		write("X-WR-CALNAME", escapeText(cal.Name))
+	}
+	if cal.Timezone != "" {
+		write("X-WR-TIMEZONE", cal.Timezone)
+	}
+	if loc != nil && len(cal.Events) > 0 {
+		from, to := cal.Events[0].Start, cal.Events[0].End
+		for _, e := range cal.Events[1:] {
+			if e.Start.Before(from) {
+				from = e.Start
+			}
+			if e.End.After(to) {
+				to = e.End
+			}
+		}
+		writeTimezone(write, loc, from, to)
+	}
+	for _, e := range cal.Events {
+		write("BEGIN", "VEVENT")
+		write("UID", e.UID)
+		write("DTSTAMP", formatTime(e.Stamp))
+		writeTime("DTSTART", e.Start)
+		writeTime("DTEND", e.End)
+		write("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			write("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Tentative {
+			write("STATUS", "TENTATIVE")
+		} else {
+			write("STATUS", "CONFIRMED")
+		}
+		write("END", "VEVENT")
+	}
+	write("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+// formatTime renders a UTC date-time value
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatLocalTime renders a date-time value in t's own zone, for a property
+// naming that zone with TZID
+func formatLocalTime(t time.Time) string {
+	return t.Format("20060102T150405")
+}
+
+// textEscaper escapes the characters RFC 5545 reserves in TEXT values
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// maxLineOctets is the longest content line allowed before folding
+const maxLineOctets = 75
+
+// writeFolded writes a content line terminated by CRLF, folding it onto
+// continuation lines (prefixed with a space) so none exceeds 75 octets.
+// Folds never split a multi-byte UTF-8 character.
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
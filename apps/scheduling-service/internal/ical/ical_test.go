@@ -0,0 +1,115 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode_CalendarStructure(t *testing.T) {
+	start := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	cal := Calendar{
+		Name: "Smith Wedding",
+		Events: []Event{
+			{UID: "a@test", Summary: "Chef", Start: start, End: start.Add(time.Hour), Stamp: start},
+			{UID: "b@test", Summary: "Oven", Start: start, End: start.Add(2 * time.Hour), Stamp: start, Tentative: true},
+		},
+	}
+
+	var sb strings.Builder
+	require.NoError(t, Encode(&sb, cal))
+	out := sb.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:Smith Wedding\r\n")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, out, "DTSTART:20250615T100000Z\r\n")
+	assert.Contains(t, out, "DTEND:20250615T120000Z\r\n")
+	assert.Contains(t, out, "STATUS:TENTATIVE\r\n")
+}
+
+func TestEncode_Timezone(t *testing.T) {
+	// Chicago moves to CST on 2 November 2025, between the two events
+	summer := time.Date(2025, 10, 30, 15, 0, 0, 0, time.UTC)
+	winter := time.Date(2025, 11, 10, 15, 0, 0, 0, time.UTC)
+	cal := Calendar{
+		Name:     "Head Chef",
+		Timezone: "America/Chicago",
+		Events: []Event{
+			{UID: "a@test", Summary: "Gala", Start: summer, End: summer.Add(time.Hour), Stamp: summer},
+			{UID: "b@test", Summary: "Wedding", Start: winter, End: winter.Add(time.Hour), Stamp: winter},
+		},
+	}
+
+	var sb strings.Builder
+	require.NoError(t, Encode(&sb, cal))
+	out := sb.String()
+
+	assert.Contains(t, out, "X-WR-TIMEZONE:America/Chicago\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=America/Chicago:20251030T100000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=America/Chicago:20251030T110000\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=America/Chicago:20251110T090000\r\n")
+	assert.Contains(t, out, "DTSTAMP:20251030T150000Z\r\n", "stamps stay in UTC")
+
+	// The zone is defined before the events that use it, with the daylight
+	// time in effect at the first event and the change back to standard time
+	vtimezone := out[strings.Index(out, "BEGIN:VTIMEZONE"):strings.Index(out, "BEGIN:VEVENT")]
+	assert.Contains(t, vtimezone, "TZID:America/Chicago\r\n")
+	assert.Contains(t, vtimezone, "BEGIN:DAYLIGHT\r\nDTSTART:20250309T020000\r\nTZOFFSETFROM:-0600\r\nTZOFFSETTO:-0500\r\nTZNAME:CDT\r\nEND:DAYLIGHT\r\n")
+	assert.Contains(t, vtimezone, "BEGIN:STANDARD\r\nDTSTART:20251102T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0600\r\nTZNAME:CST\r\nEND:STANDARD\r\n")
+	assert.Equal(t, 2, strings.Count(vtimezone, "DTSTART:"), "no transitions past the last event")
+}
+
+func TestEncode_UTC(t *testing.T) {
+	start := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	events := []Event{{UID: "a@test", Summary: "Chef", Start: start, End: start.Add(time.Hour), Stamp: start}}
+
+	for _, tz := range []string{"", "UTC"} {
+		var sb strings.Builder
+		require.NoError(t, Encode(&sb, Calendar{Name: "Head Chef", Timezone: tz, Events: events}))
+		assert.Contains(t, sb.String(), "DTSTART:20250615T100000Z\r\n", tz)
+		assert.NotContains(t, sb.String(), "VTIMEZONE", tz)
+	}
+
+	var sb strings.Builder
+	assert.Error(t, Encode(&sb, Calendar{Name: "Head Chef", Timezone: "Mars/Olympus", Events: events}))
+}
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "+0000", formatOffset(0))
+	assert.Equal(t, "-0500", formatOffset(-5*3600))
+	assert.Equal(t, "+0530", formatOffset(5*3600+30*60))
+	assert.Equal(t, "-001644", formatOffset(-(16*60 + 44)))
+}
+
+func TestEncode_EscapesText(t *testing.T) {
+	cal := Calendar{Name: "Dinner; Drinks, Dessert\nand more"}
+
+	var sb strings.Builder
+	require.NoError(t, Encode(&sb, cal))
+
+	assert.Contains(t, sb.String(), `X-WR-CALNAME:Dinner\; Drinks\, Dessert\nand more`+"\r\n")
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	cal := Calendar{Name: strings.Repeat("é", 100)}
+
+	var sb strings.Builder
+	require.NoError(t, Encode(&sb, cal))
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n")
+	var unfolded strings.Builder
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 75)
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	assert.Contains(t, unfolded.String(), "X-WR-CALNAME:"+strings.Repeat("é", 100))
+}
@@ -0,0 +1,61 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// writeTimezone writes a VTIMEZONE for loc covering from to to: one
+// observance for the offset in effect at from, and one for each change of
+// offset after it up to to. Each observance is written as the single onset
+// it has, rather than as a rule, since Go's zone data gives transitions and
+// not the rules behind them.
+func writeTimezone(write func(name, value string), loc *time.Location, from, to time.Time) {
+	write("BEGIN", "VTIMEZONE")
+	write("TZID", loc.String())
+	t := from.In(loc)
+	for {
+		name, offset := t.Zone()
+		start, end := t.ZoneBounds()
+		// A zone with no known start has been in effect since before any
+		// event; 1970 stands in for its onset
+		fromOffset, onset := offset, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+		if !start.IsZero() {
+			_, fromOffset = start.Add(-time.Second).Zone()
+			onset = start.In(time.FixedZone("", fromOffset))
+		}
+
+		kind := "STANDARD"
+		if t.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		write("BEGIN", kind)
+		// The onset is given in the local time that was in effect before it
+		write("DTSTART", formatLocalTime(onset))
+		write("TZOFFSETFROM", formatOffset(fromOffset))
+		write("TZOFFSETTO", formatOffset(offset))
+		write("TZNAME", escapeText(name))
+		write("END", kind)
+
+		if end.IsZero() || !end.Before(to) {
+			break
+		}
+		t = end
+	}
+	write("END", "VTIMEZONE")
+}
+
+// formatOffset renders a UTC offset in seconds as a UTC-OFFSET value, such as
+// -0500 or +0530, adding seconds only when there are any
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
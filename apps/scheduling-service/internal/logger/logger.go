@@ -1,8 +1,11 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"time"
 )
@@ -26,6 +29,8 @@ type LogEntry struct {
 type Logger struct {
 	logger   *log.Logger
 	minLevel int
+	// fields are copied into the context of every entry, such as request_id
+	fields map[string]interface{}
 }
 
 // LogEvent provides a fluent interface for building log entries
@@ -43,14 +48,26 @@ var levelOrder = map[LogLevel]int{
 	ErrorLevel: 3,
 }
 
+// New returns a logger writing to stdout at the level named by LOG_LEVEL
 func New() *Logger {
-	level := os.Getenv("LOG_LEVEL")
-	minLevel := 1 // default: info
-	if l, ok := levelOrder[LogLevel(level)]; ok {
+	return NewWithLevel(LogLevel(os.Getenv("LOG_LEVEL")))
+}
+
+// NewWithLevel returns a logger writing to stdout that drops entries below
+// level. An empty or unknown level means info.
+func NewWithLevel(level LogLevel) *Logger {
+	return NewWithOutput(os.Stdout, level)
+}
+
+// NewWithOutput returns a logger writing to w that drops entries below level,
+// as NewWithLevel does. Tests use it to read what is logged.
+func NewWithOutput(w io.Writer, level LogLevel) *Logger {
+	minLevel := levelOrder[InfoLevel]
+	if l, ok := levelOrder[level]; ok {
 		minLevel = l
 	}
 	return &Logger{
-		logger:   log.New(os.Stdout, "", 0),
+		logger:   log.New(w, "", 0),
 		minLevel: minLevel,
 	}
 }
@@ -60,8 +77,50 @@ func Get() *Logger {
 	return Default
 }
 
+// WithFields returns a child logger that adds fields to the context of every
+// entry it writes, on top of any the receiver already binds. Fields set on an
+// entry itself win over bound ones with the same key. The receiver is left
+// unchanged.
+func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
+	bound := make(map[string]interface{}, len(l.fields)+len(fields))
+	maps.Copy(bound, l.fields)
+	maps.Copy(bound, fields)
+	return &Logger{
+		logger:   l.logger,
+		minLevel: l.minLevel,
+		fields:   bound,
+	}
+}
+
+// requestIDKey is the context.Context key the request ID is stored under
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the ID of the request it
+// serves, for WithRequestID to find
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID ctx carries, or "" if it has none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithRequestID returns a logger that adds the request ID ctx carries as
+// request_id to the context of every entry it writes, so all lines logged for
+// one request can be found together. Without one it returns the receiver.
+func (l *Logger) WithRequestID(ctx context.Context) *Logger {
+	id := RequestID(ctx)
+	if id == "" {
+		return l
+	}
+	return l.WithFields(map[string]interface{}{"request_id": id})
+}
+
 func (l *Logger) log(level LogLevel, message string, context map[string]interface{}) {
-	if order, ok := levelOrder[level]; ok && order < l.minLevel {
+	// Errors are always written, whatever the threshold
+	if order, ok := levelOrder[level]; ok && level != ErrorLevel && order < l.minLevel {
 		return
 	}
 	entry := LogEntry{
@@ -80,40 +139,35 @@ func (l *Logger) log(level LogLevel, message string, context map[string]interfac
 	l.logger.Println(string(jsonBytes))
 }
 
-// Info starts an info level log event
-func (l *Logger) Info() *LogEvent {
+// event starts a log event whose context holds the logger's fields
+func (l *Logger) event(level LogLevel) *LogEvent {
+	context := make(map[string]interface{}, len(l.fields))
+	maps.Copy(context, l.fields)
 	return &LogEvent{
 		logger:  l,
-		level:   InfoLevel,
-		context: make(map[string]interface{}),
+		level:   level,
+		context: context,
 	}
 }
 
+// Info starts an info level log event
+func (l *Logger) Info() *LogEvent {
+	return l.event(InfoLevel)
+}
+
 // Warn starts a warn level log event
 func (l *Logger) Warn() *LogEvent {
-	return &LogEvent{
-		logger:  l,
-		level:   WarnLevel,
-		context: make(map[string]interface{}),
-	}
+	return l.event(WarnLevel)
 }
 
 // Error starts an error level log event
 func (l *Logger) Error() *LogEvent {
-	return &LogEvent{
-		logger:  l,
-		level:   ErrorLevel,
-		context: make(map[string]interface{}),
-	}
+	return l.event(ErrorLevel)
 }
 
 // Debug starts a debug level log event
 func (l *Logger) Debug() *LogEvent {
-	return &LogEvent{
-		logger:  l,
-		level:   DebugLevel,
-		context: make(map[string]interface{}),
-	}
+	return l.event(DebugLevel)
 }
 
 // Err adds an error to the log event
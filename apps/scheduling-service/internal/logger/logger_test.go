@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	base := &Logger{logger: log.New(&buf, "", 0), minLevel: levelOrder[DebugLevel]}
+	reqLog := base.WithRequestID(ContextWithRequestID(context.Background(), "req-1"))
+
+	reqLog.Info().Str("path", "/health").Msg("first")
+	reqLog.Warn().Msg("second")
+	base.Info().Msg("untagged")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	var entries []LogEntry
+	for _, line := range lines {
+		var entry LogEntry
+		require.NoError(t, json.Unmarshal(line, &entry))
+		entries = append(entries, entry)
+	}
+
+	assert.Equal(t, "req-1", entries[0].Context["request_id"])
+	assert.Equal(t, "/health", entries[0].Context["path"])
+	assert.Equal(t, "req-1", entries[1].Context["request_id"])
+	// The logger it was derived from is unchanged
+	assert.NotContains(t, entries[2].Context, "request_id")
+
+	// A context without a request ID adds nothing
+	assert.Same(t, base, base.WithRequestID(context.Background()))
+}
+
+func TestNewWithLevel_DropsEntriesBelowThreshold(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithLevel(WarnLevel)
+	l.logger = log.New(&buf, "", 0)
+
+	l.Debug().Msg("debug")
+	l.Info().Msg("info")
+	l.Warn().Msg("warn")
+	l.Error().Msg("error")
+
+	var levels []LogLevel
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var entry LogEntry
+		require.NoError(t, json.Unmarshal(line, &entry))
+		levels = append(levels, entry.Level)
+	}
+	assert.Equal(t, []LogLevel{WarnLevel, ErrorLevel}, levels)
+}
+
+func TestNewWithLevel_Thresholds(t *testing.T) {
+	tests := []struct {
+		level    LogLevel
+		minLevel int
+	}{
+		{DebugLevel, 0},
+		{InfoLevel, 1},
+		{WarnLevel, 2},
+		{ErrorLevel, 3},
+		{"", 1},
+		{"verbose", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.minLevel, NewWithLevel(tt.level).minLevel, string(tt.level))
+	}
+}
+
+func TestNew_ReadsLogLevel(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "error")
+	assert.Equal(t, levelOrder[ErrorLevel], New().minLevel)
+}
+
+func TestWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	base := &Logger{logger: log.New(&buf, "", 0), minLevel: levelOrder[DebugLevel]}
+	eventLog := base.WithFields(map[string]interface{}{"event_id": 7, "resource_id": 3})
+	// Children keep their parent's fields
+	childLog := eventLog.WithFields(map[string]interface{}{"service": "schedule"})
+
+	childLog.Error().Str("action", "book").Int("resource_id", 4).Msg("booking failed")
+
+	var entry LogEntry
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry))
+	assert.Equal(t, ErrorLevel, entry.Level)
+	assert.Equal(t, map[string]interface{}{
+		"event_id": float64(7),
+		"service":  "schedule",
+		"action":   "book",
+		// set on the entry, so it wins over the bound value
+		"resource_id": float64(4),
+	}, entry.Context)
+
+	// Binding fields never changes the parent
+	buf.Reset()
+	eventLog.Info().Msg("parent")
+	var parent LogEntry
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &parent))
+	assert.NotContains(t, parent.Context, "service")
+	assert.Equal(t, float64(3), parent.Context["resource_id"])
+}
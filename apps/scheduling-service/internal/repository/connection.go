@@ -4,11 +4,112 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	_ "github.com/lib/pq"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// Environment variables that override the connection pool limits. The
+// durations are Go durations such as "30m".
+const (
+	MaxOpenConnsEnv    = "DB_MAX_OPEN_CONNS"
+	MaxIdleConnsEnv    = "DB_MAX_IDLE_CONNS"
+	ConnMaxLifetimeEnv = "DB_CONN_MAX_LIFETIME"
+	ConnMaxIdleTimeEnv = "DB_CONN_MAX_IDLE_TIME"
 )
 
+// PoolSettings are the connection pool limits applied to the database handle
+type PoolSettings struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultPool is the pool configuration used when the environment doesn't
+// override it.
+// Total pool budget: 200 connections across all services
+// TypeScript (CRUD): 150 connections (75%) - handles majority of read/write operations
+// Go (Scheduling): 50 connections (25%) - handles conflict detection queries
+var DefaultPool = PoolSettings{
+	MaxOpenConns:    50,               // 25% of 200 total for scheduling
+	MaxIdleConns:    10,               // Keep 10 idle for quick reuse
+	ConnMaxLifetime: 30 * time.Minute, // Recycle connections
+	ConnMaxIdleTime: 5 * time.Minute,  // Close idle connections
+}
+
+// Pool is the connection pool configuration NewDB applies. It is replaced at
+// most once, by LoadPoolSettings at startup.
+var Pool = DefaultPool
+
+// LoadPoolSettings applies the DB_* pool variables that are set, keeping the
+// default for any that are not, and logs the settings in effect. Call it once
+// at startup, before NewDB. An invalid value is logged and replaced by its
+// default rather than stopping the service, since a pool that is merely
+// mis-sized can still serve. MaxIdleConns is capped at MaxOpenConns.
+func LoadPoolSettings() PoolSettings {
+	l := logger.Get()
+	pool := DefaultPool
+
+	pool.MaxOpenConns = lookupPositiveInt(l, MaxOpenConnsEnv, pool.MaxOpenConns)
+	pool.MaxIdleConns = lookupPositiveInt(l, MaxIdleConnsEnv, pool.MaxIdleConns)
+	pool.ConnMaxLifetime = lookupPositiveDuration(l, ConnMaxLifetimeEnv, pool.ConnMaxLifetime)
+	pool.ConnMaxIdleTime = lookupPositiveDuration(l, ConnMaxIdleTimeEnv, pool.ConnMaxIdleTime)
+
+	if pool.MaxIdleConns > pool.MaxOpenConns {
+		l.Warn().
+			Int("max_idle_conns", pool.MaxIdleConns).
+			Int("max_open_conns", pool.MaxOpenConns).
+			Msg("Idle connection limit exceeds open connection limit, capping it")
+		pool.MaxIdleConns = pool.MaxOpenConns
+	}
+
+	l.Info().
+		Int("max_open_conns", pool.MaxOpenConns).
+		Int("max_idle_conns", pool.MaxIdleConns).
+		Dur("conn_max_lifetime_ms", pool.ConnMaxLifetime).
+		Dur("conn_max_idle_time_ms", pool.ConnMaxIdleTime).
+		Msg("Database pool configured")
+
+	Pool = pool
+	return pool
+}
+
+// lookupPositiveInt returns the environment variable name as a positive whole
+// number, or def when it is unset or invalid
+func lookupPositiveInt(l *logger.Logger, name string, def int) int {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		l.Warn().Str("variable", name).Str("value", v).Int("default", def).
+			Msg("Invalid pool setting, must be a positive whole number; using the default")
+		return def
+	}
+	return n
+}
+
+// lookupPositiveDuration returns the environment variable name as a positive
+// duration, or def when it is unset or invalid
+func lookupPositiveDuration(l *logger.Logger, name string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		l.Warn().Str("variable", name).Str("value", v).Dur("default_ms", def).
+			Msg("Invalid pool setting, must be a positive duration; using the default")
+		return def
+	}
+	return d
+}
+
 func NewDB() (*sql.DB, error) {
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
@@ -21,13 +122,10 @@ func NewDB() (*sql.DB, error) {
 	}
 
 	// Configure connection pool
-	// Total pool budget: 200 connections across all services
-	// TypeScript (CRUD): 150 connections (75%) - handles majority of read/write operations
-	// Go (Scheduling): 50 connections (25%) - handles conflict detection queries
-	db.SetMaxOpenConns(50)           // 25% of 200 total for scheduling
-	db.SetMaxIdleConns(10)           // Keep 10 idle for quick reuse
-	db.SetConnMaxLifetime(30 * time.Minute) // Recycle connections
-	db.SetConnMaxIdleTime(5 * time.Minute)  // Close idle connections
+	db.SetMaxOpenConns(Pool.MaxOpenConns)
+	db.SetMaxIdleConns(Pool.MaxIdleConns)
+	db.SetConnMaxLifetime(Pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(Pool.ConnMaxIdleTime)
 
 	// Test connection
 	if err := db.Ping(); err != nil {
@@ -36,3 +134,11 @@ func NewDB() (*sql.DB, error) {
 
 	return db, nil
 }
+
+// NewQueries returns queries for a service to run against the pool: each
+// statement is bounded by QUERY_TIMEOUT and retried if it fails with a
+// transient error. Statements inside a transaction must not be retried on
+// their own, so use New(WithQueryTimeout(tx)) for those.
+func NewQueries(db *sql.DB) *Queries {
+	return New(WithRetry(WithQueryTimeout(db)))
+}
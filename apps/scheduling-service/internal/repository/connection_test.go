@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setPoolEnv sets each pool variable given, and unsets the rest, for the
+// duration of the test. LoadPoolSettings replaces Pool, so it is restored too.
+func setPoolEnv(t *testing.T, values map[string]string) {
+	t.Helper()
+	for _, name := range []string{MaxOpenConnsEnv, MaxIdleConnsEnv, ConnMaxLifetimeEnv, ConnMaxIdleTimeEnv} {
+		t.Setenv(name, "")
+		if v, ok := values[name]; ok {
+			t.Setenv(name, v)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+	saved := Pool
+	t.Cleanup(func() { Pool = saved })
+}
+
+func TestLoadPoolSettings_Defaults(t *testing.T) {
+	setPoolEnv(t, nil)
+
+	assert.Equal(t, DefaultPool, LoadPoolSettings())
+	assert.Equal(t, DefaultPool, Pool)
+}
+
+func TestLoadPoolSettings_FromEnv(t *testing.T) {
+	setPoolEnv(t, map[string]string{
+		MaxOpenConnsEnv:    "20",
+		MaxIdleConnsEnv:    "4",
+		ConnMaxLifetimeEnv: "1h",
+		ConnMaxIdleTimeEnv: "90s",
+	})
+
+	want := PoolSettings{
+		MaxOpenConns:    20,
+		MaxIdleConns:    4,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 90 * time.Second,
+	}
+	assert.Equal(t, want, LoadPoolSettings())
+	assert.Equal(t, want, Pool)
+}
+
+func TestLoadPoolSettings_InvalidFallsBackToDefault(t *testing.T) {
+	for _, v := range []string{"many", "0", "-5", "2.5"} {
+		setPoolEnv(t, map[string]string{MaxOpenConnsEnv: v, MaxIdleConnsEnv: v})
+		assert.Equal(t, DefaultPool, LoadPoolSettings(), v)
+	}
+	for _, v := range []string{"30", "soon", "0s", "-1m"} {
+		setPoolEnv(t, map[string]string{ConnMaxLifetimeEnv: v, ConnMaxIdleTimeEnv: v})
+		assert.Equal(t, DefaultPool, LoadPoolSettings(), v)
+	}
+}
+
+func TestLoadPoolSettings_IdleCappedAtOpen(t *testing.T) {
+	setPoolEnv(t, map[string]string{
+		MaxOpenConnsEnv: "5",
+	})
+
+	pool := LoadPoolSettings()
+	assert.Equal(t, 5, pool.MaxOpenConns)
+	assert.Equal(t, 5, pool.MaxIdleConns)
+}
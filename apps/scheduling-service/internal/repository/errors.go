@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// SQLSTATE codes the service gives a meaning beyond "query failed"
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	// pqExclusionViolation is the SQLSTATE for a row rejected by an EXCLUDE
+	// constraint
+	pqExclusionViolation = "23P01"
+	pqQueryCanceled      = "57014"
+)
+
+// IsExclusionViolation reports whether err is a write rejected by an exclusion
+// constraint, such as one forbidding overlapping bookings of a resource
+func IsExclusionViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqExclusionViolation
+}
+
+// MapError translates a Postgres error into the domain error it stands for:
+// unique and exclusion violations are conflicts, foreign key violations are
+// validation errors (the request referenced something that doesn't exist), and
+// a cancelled query, such as one hitting the statement timeout or
+// QUERY_TIMEOUT, is a timeout. The original error is kept as the cause. Any
+// other error, including nil, is returned unchanged.
+func MapError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return domain.NewTimeoutError("database query timed out", err)
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	var mapped *domain.DomainError
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		mapped = domain.NewConflictError("record already exists")
+	case pqForeignKeyViolation:
+		mapped = domain.NewValidationError("referenced record does not exist")
+	case pqExclusionViolation:
+		mapped = domain.NewConflictError("record conflicts with an existing one")
+	case pqQueryCanceled:
+		return domain.NewTimeoutError("database query timed out", err)
+	default:
+		return err
+	}
+	mapped.Err = err
+	return mapped
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestIsExclusionViolation(t *testing.T) {
+	exclusion := &pq.Error{Code: "23P01", Constraint: "resource_schedule_no_overlap"}
+
+	assert.True(t, IsExclusionViolation(exclusion))
+	assert.True(t, IsExclusionViolation(fmt.Errorf("insert failed: %w", exclusion)))
+	assert.False(t, IsExclusionViolation(&pq.Error{Code: "23505"}))
+	assert.False(t, IsExclusionViolation(sql.ErrNoRows))
+	assert.False(t, IsExclusionViolation(nil))
+}
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode domain.ErrorCode
+	}{
+		{name: "unique violation", err: &pq.Error{Code: "23505"}, wantCode: domain.ErrCodeConflict},
+		{name: "foreign key violation", err: &pq.Error{Code: "23503"}, wantCode: domain.ErrCodeValidation},
+		{name: "exclusion violation", err: &pq.Error{Code: "23P01"}, wantCode: domain.ErrCodeConflict},
+		{name: "query canceled", err: &pq.Error{Code: "57014"}, wantCode: domain.ErrCodeTimeout},
+		{name: "deadline exceeded", err: fmt.Errorf("query failed: %w", context.DeadlineExceeded), wantCode: domain.ErrCodeTimeout},
+		{name: "wrapped", err: fmt.Errorf("insert failed: %w", &pq.Error{Code: "23505"}), wantCode: domain.ErrCodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var domainErr *domain.DomainError
+			require.ErrorAs(t, MapError(tt.err), &domainErr)
+			assert.Equal(t, tt.wantCode, domainErr.Code)
+			assert.NotEmpty(t, domainErr.Message)
+			assert.Equal(t, tt.err, domainErr.Err, "the database error is kept as the cause")
+		})
+	}
+
+	t.Run("other errors pass through", func(t *testing.T) {
+		syntax := &pq.Error{Code: "42601"}
+
+		assert.Same(t, syntax, MapError(syntax))
+		assert.Equal(t, sql.ErrNoRows, MapError(sql.ErrNoRows))
+		assert.NoError(t, MapError(nil))
+	})
+}
+
+func TestNoOverlapConstraint_RejectsDirectInsert(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	dishes := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// insert writes a row as another service would, leaving whole_resource
+	// for the database to fill in
+	insert := func(resourceID int32, startHour, endHour int, status string) (int32, error) {
+		var id int32
+		err := testDB.DB.QueryRow(`
+			INSERT INTO resource_schedule (resource_id, event_id, start_time, end_time, approval_status)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, resourceID, eventID, baseDay.Add(time.Duration(startHour)*time.Hour),
+			baseDay.Add(time.Duration(endHour)*time.Hour), status).Scan(&id)
+		return id, err
+	}
+
+	_, err := insert(chef, 9, 12, "approved")
+	require.NoError(t, err)
+
+	_, err = insert(chef, 10, 11, "approved")
+	require.Error(t, err)
+	assert.True(t, IsExclusionViolation(err))
+	assert.ErrorIs(t, MapError(err), &domain.DomainError{Code: domain.ErrCodeConflict})
+
+	// Back-to-back bookings don't overlap
+	_, err = insert(chef, 12, 13, "approved")
+	require.NoError(t, err)
+
+	// Pending entries only soft-conflict, until they are approved
+	pendingID, err := insert(chef, 10, 11, "pending")
+	require.NoError(t, err)
+	_, err = testDB.DB.Exec(`UPDATE resource_schedule SET approval_status = 'approved' WHERE id = $1`, pendingID)
+	assert.True(t, IsExclusionViolation(err))
+
+	// A cancelled entry no longer blocks its slot
+	_, err = testDB.DB.Exec(`UPDATE resource_schedule SET cancelled_at = NOW() WHERE resource_id = $1 AND start_time = $2`,
+		chef, baseDay.Add(9*time.Hour))
+	require.NoError(t, err)
+	_, err = insert(chef, 10, 11, "approved")
+	require.NoError(t, err)
+
+	// Equipment bookings share units, so they may overlap
+	_, err = insert(dishes, 9, 12, "approved")
+	require.NoError(t, err)
+	_, err = insert(dishes, 10, 11, "approved")
+	require.NoError(t, err)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Queries here hand each row to a callback as it is read, for exports too
+// large to collect in memory. sqlc only generates queries that collect every
+// row, so these are written by hand and kept out of queries.sql.
+
+const resourceScheduleExport = `
+SELECT
+    rs.id,
+    e.event_name,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.start_time >= $2
+  AND rs.end_time <= $3
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+`
+
+type ResourceScheduleExportParams struct {
+	ResourceID int32     `json:"resource_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+type ResourceScheduleExportRow struct {
+	ID        int32          `json:"id"`
+	EventName string         `json:"event_name"`
+	TaskTitle sql.NullString `json:"task_title"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	Notes     sql.NullString `json:"notes"`
+}
+
+// EachResourceScheduleExportRow calls fn with each of a resource's live
+// entries within the range, with the same range rules as GetResourceSchedule,
+// in chronological order. It stops at the first error fn returns.
+func (q *Queries) EachResourceScheduleExportRow(ctx context.Context, arg ResourceScheduleExportParams, fn func(ResourceScheduleExportRow) error) error {
+	rows, err := q.db.QueryContext(ctx, resourceScheduleExport, arg.ResourceID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i ResourceScheduleExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventName,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
@@ -11,6 +11,49 @@ import (
 	"time"
 )
 
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
+func (e *ApprovalStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ApprovalStatus(s)
+	case string:
+		*e = ApprovalStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ApprovalStatus: %T", src)
+	}
+	return nil
+}
+
+type NullApprovalStatus struct {
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	Valid          bool           `json:"valid"` // Valid is true if ApprovalStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullApprovalStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ApprovalStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ApprovalStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullApprovalStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ApprovalStatus), nil
+}
+
 type CommunicationType string
 
 const (
@@ -292,6 +335,14 @@ type ArchivedEvent struct {
 	ArchivedByName     sql.NullString `json:"archived_by_name"`
 }
 
+type BlackoutDate struct {
+	ID        int32          `json:"id"`
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Reason    sql.NullString `json:"reason"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
 type Client struct {
 	ID          int32          `json:"id"`
 	CompanyName string         `json:"company_name"`
@@ -331,27 +382,65 @@ type EventStatusLog struct {
 	ChangedAt time.Time       `json:"changed_at"`
 }
 
-type Resource struct {
-	ID          int32          `json:"id"`
-	Name        string         `json:"name"`
-	Type        ResourceType   `json:"type"`
-	HourlyRate  sql.NullString `json:"hourly_rate"`
-	IsAvailable bool           `json:"is_available"`
-	Notes       sql.NullString `json:"notes"`
-	CreatedAt   time.Time      `json:"created_at"`
+type FeatureFlag struct {
+	Key         string         `json:"key"`
+	Enabled     bool           `json:"enabled"`
+	Description sql.NullString `json:"description"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
-type ResourceSchedule struct {
+type IdempotencyKey struct {
+	Key             string    `json:"key"`
+	RequestHash     string    `json:"request_hash"`
+	ScheduleEntryID int32     `json:"schedule_entry_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type Resource struct {
+	ID                  int32          `json:"id"`
+	Name                string         `json:"name"`
+	Type                ResourceType   `json:"type"`
+	HourlyRate          sql.NullString `json:"hourly_rate"`
+	IsAvailable         bool           `json:"is_available"`
+	Notes               sql.NullString `json:"notes"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	ReleaseGraceMinutes int32          `json:"release_grace_minutes"`
+	Timezone            sql.NullString `json:"timezone"`
+}
+
+type ResourceDowntime struct {
 	ID         int32          `json:"id"`
 	ResourceID int32          `json:"resource_id"`
-	EventID    int32          `json:"event_id"`
-	TaskID     sql.NullInt32  `json:"task_id"`
 	StartTime  time.Time      `json:"start_time"`
 	EndTime    time.Time      `json:"end_time"`
-	Notes      sql.NullString `json:"notes"`
+	Reason     sql.NullString `json:"reason"`
 	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+type ResourceSchedule struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+}
+
+type ResourceWorkingHour struct {
+	ID         int32     `json:"id"`
+	ResourceID int32     `json:"resource_id"`
+	DayOfWeek  int32     `json:"day_of_week"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type Task struct {
@@ -7,19 +7,231 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// Cancel every entry of a recurring booking that isn't cancelled yet
+	CancelRecurrenceGroup(ctx context.Context, arg CancelRecurrenceGroupParams) ([]int32, error)
+	// Mark an entry cancelled, keeping it for history. Returns no rows if the entry
+	// doesn't exist or is already cancelled.
+	CancelScheduleEntry(ctx context.Context, arg CancelScheduleEntryParams) (ResourceSchedule, error)
+	// Cancel the bookings ListStaleTaskBookings finds, recording the completed
+	// task as the reason
+	CancelStaleTaskBookings(ctx context.Context, after time.Time) ([]int32, error)
 	// Find all existing schedule entries that overlap with the requested time range
-	// for any of the specified resources
+	// for any of the specified resources. Each existing entry's end is extended by
+	// the resource's release grace period so cleanup time is treated as busy.
+	// Rejected and cancelled entries never conflict.
 	CheckConflicts(ctx context.Context, arg CheckConflictsParams) ([]CheckConflictsRow, error)
+	ClientExists(ctx context.Context, id int32) (bool, error)
+	// Count the pairs ListAllConflictPairs would return without paging.
+	CountAllConflictPairs(ctx context.Context, arg CountAllConflictPairsParams) (int64, error)
+	// Count the entries CheckConflicts would report, without loading them. Uses the
+	// same release grace and exclusion rules, and applies the minimum overlap in SQL.
+	CountConflicts(ctx context.Context, arg CountConflictsParams) (CountConflictsRow, error)
+	// Count bookings that haven't finished yet for each of the given resources
+	CountFutureBookingsByResource(ctx context.Context, arg CountFutureBookingsByResourceParams) ([]CountFutureBookingsByResourceRow, error)
+	// How many entries GetResourceSchedule pages through
+	CountResourceSchedule(ctx context.Context, arg CountResourceScheduleParams) (int64, error)
+	// Count the resources matching the filters of ListResourcesPage
+	CountResourcesPage(ctx context.Context, arg CountResourcesPageParams) (int64, error)
+	CreateBlackoutDate(ctx context.Context, arg CreateBlackoutDateParams) (BlackoutDate, error)
+	// Store a key for the entry it created, replacing the key if it expired at or
+	// before expired_before. Nothing is stored, and no row affected, if the key is
+	// still held.
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (int64, error)
+	// Insert one occurrence of a recurring booking
+	CreateRecurringScheduleEntry(ctx context.Context, arg CreateRecurringScheduleEntryParams) (int32, error)
+	CreateResourceDowntime(ctx context.Context, arg CreateResourceDowntimeParams) (ResourceDowntime, error)
+	CreateResourceWorkingHours(ctx context.Context, arg CreateResourceWorkingHoursParams) (ResourceWorkingHour, error)
 	CreateScheduleEntry(ctx context.Context, arg CreateScheduleEntryParams) (ResourceSchedule, error)
+	DeleteBlackoutDate(ctx context.Context, id int32) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore time.Time) error
+	// Delete the entries of a recurring booking, or only those starting at or
+	// after from when it is set
+	DeleteRecurrenceGroup(ctx context.Context, arg DeleteRecurrenceGroupParams) (int64, error)
+	DeleteResourceDowntime(ctx context.Context, arg DeleteResourceDowntimeParams) (int64, error)
+	DeleteResourceWorkingHours(ctx context.Context, arg DeleteResourceWorkingHoursParams) (int64, error)
 	DeleteScheduleEntriesByTask(ctx context.Context, taskID sql.NullInt32) error
 	DeleteScheduleEntry(ctx context.Context, id int32) error
+	EventExists(ctx context.Context, id int32) (bool, error)
+	// Count bookings starting in the window, bucketed by duration
+	GetBookingDurationHistogram(ctx context.Context, arg GetBookingDurationHistogramParams) ([]GetBookingDurationHistogramRow, error)
+	// Sum booked seconds per resource across all of a client's events. Bookings
+	// that straddle the window are clipped so only time inside it is counted.
+	GetClientResourceUsage(ctx context.Context, arg GetClientResourceUsageParams) ([]GetClientResourceUsageRow, error)
+	GetEventName(ctx context.Context, id int32) (string, error)
+	// Sum booked seconds per resource across all of an event's live bookings
+	GetEventResourceUsage(ctx context.Context, eventID int32) ([]GetEventResourceUsageRow, error)
+	// A stored idempotency key, unless it was created at or before expired_before
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	// Schedule entries for several resources at once, with the same range rules as
+	// GetResourceSchedule
+	GetMultiResourceSchedule(ctx context.Context, arg GetMultiResourceScheduleParams) ([]GetMultiResourceScheduleRow, error)
+	// Earliest non-rejected entry for a resource that starts at or after the given time
+	GetNextScheduleEntry(ctx context.Context, arg GetNextScheduleEntryParams) (GetNextScheduleEntryRow, error)
+	// Latest non-rejected entry for a resource that ended at or before the given time
+	GetPreviousScheduleEntry(ctx context.Context, arg GetPreviousScheduleEntryParams) (GetPreviousScheduleEntryRow, error)
 	GetResourceByID(ctx context.Context, id int32) (Resource, error)
+	// Resolve the code an integration uses for a resource to its internal ID
+	GetResourceIDByExternalCode(ctx context.Context, externalCode sql.NullString) (int32, error)
+	GetResourceQuantity(ctx context.Context, id int32) (int32, error)
+	// One page of a resource's entries within the range, optionally only those of
+	// one event or task, in start_time, id order, reversed when sort_desc is set.
+	// A page after the first starts past the (after_start, after_id) cursor.
 	GetResourceSchedule(ctx context.Context, arg GetResourceScheduleParams) ([]GetResourceScheduleRow, error)
+	// Sum a resource's booked seconds per week of the window, every week included.
+	// Weeks start on Monday at midnight in the given timezone. A booking is split
+	// at week boundaries and clipped to the window, so each week counts only its
+	// own time; overlapping bookings each count.
+	GetResourceWeeklyBookedSeconds(ctx context.Context, arg GetResourceWeeklyBookedSecondsParams) ([]GetResourceWeeklyBookedSecondsRow, error)
 	GetScheduleEntryByID(ctx context.Context, id int32) (GetScheduleEntryByIDRow, error)
+	// Units of its resource a schedule entry takes
+	GetScheduleEntryQuantity(ctx context.Context, id int32) (int32, error)
+	GetTaskEventID(ctx context.Context, id int32) (int32, error)
+	// List live bookings in progress at an instant, optionally for one resource
+	// type. Plain comparisons on start_time and end_time keep the btree indexes
+	// usable.
+	ListActiveBookings(ctx context.Context, arg ListActiveBookingsParams) ([]ListActiveBookingsRow, error)
+	// Pair every live booking with each later-created live booking of the same
+	// resource it overlaps, counting the resource's release grace, across all
+	// resources. Both bookings must overlap the window. Each pair is listed once.
+	ListAllConflictPairs(ctx context.Context, arg ListAllConflictPairsParams) ([]ListAllConflictPairsRow, error)
+	ListBlackoutDates(ctx context.Context) ([]BlackoutDate, error)
+	// List the booked ranges overlapping the window, optionally for one resource type
+	ListBookedRangesByType(ctx context.Context, arg ListBookedRangesByTypeParams) ([]ListBookedRangesByTypeRow, error)
+	// Live bookings of a resource starting within the range, in order, each paired
+	// with the booking that follows it within the range
+	ListChangeovers(ctx context.Context, arg ListChangeoversParams) ([]ListChangeoversRow, error)
+	// The entries CountConflicts counts, sorted and cut to one page of a large
+	// conflict check. sort is 'overlap_desc' (longest overlap with the requested
+	// range first) or 'start_asc'; ties fall back to start time, then id, so pages
+	// are stable. A NULL row_limit returns every row.
+	ListConflictsPage(ctx context.Context, arg ListConflictsPageParams) ([]ListConflictsPageRow, error)
+	// Live entries for a resource, optionally limited to one event, ordered so that
+	// entries for the same event, task, and approval status are contiguous and
+	// chronological
+	ListConsolidationCandidates(ctx context.Context, arg ListConsolidationCandidatesParams) ([]ListConsolidationCandidatesRow, error)
+	ListEnabledFeatureFlags(ctx context.Context) ([]string, error)
+	// Find schedule entries booked while their resource was marked unavailable,
+	// going by the availability snapshot taken when each entry was inserted
+	ListEntriesCreatedWhileUnavailable(ctx context.Context) ([]ListEntriesCreatedWhileUnavailableRow, error)
+	// Pair each live booking of an event with the live bookings of the same
+	// resource it overlaps, counting each side's release grace. When both bookings
+	// belong to the event, the pair is listed once, under the later-created one.
+	ListEventBookingConflicts(ctx context.Context, eventID int32) ([]ListEventBookingConflictsRow, error)
+	// List an event's live schedule entries with their resources, grouped by
+	// resource and in chronological order within each
+	ListEventGanttEntries(ctx context.Context, eventID int32) ([]ListEventGanttEntriesRow, error)
+	// List the editable fields of an event's live entries, those neither rejected
+	// nor cancelled, in chronological order
+	ListEventLiveEntries(ctx context.Context, eventID int32) ([]ListEventLiveEntriesRow, error)
+	// Pairs of live bookings of the same resource, one from each event, that
+	// overlap and would become a double booking within one event after a merge
+	ListEventMergeConflicts(ctx context.Context, arg ListEventMergeConflictsParams) ([]ListEventMergeConflictsRow, error)
+	// List each distinct event and resource booked together within the window,
+	// for grouping events by the resources they share
+	ListEventResourcePairs(ctx context.Context, arg ListEventResourcePairsParams) ([]ListEventResourcePairsRow, error)
+	// List an event's schedule entries that are neither rejected nor cancelled,
+	// with their resource names, in chronological order
+	ListEventScheduleEntries(ctx context.Context, eventID int32) ([]ListEventScheduleEntriesRow, error)
+	// Available resources of a type, other than the excluded one, with no live
+	// booking overlapping the range, counting release grace
+	ListFreeAlternativeResources(ctx context.Context, arg ListFreeAlternativeResourcesParams) ([]Resource, error)
+	// Find schedule entries that don't end after they start. The CHECK constraint
+	// prevents new ones, but rows from before it was added are not validated.
+	ListInvertedScheduleRanges(ctx context.Context) ([]ListInvertedScheduleRangesRow, error)
+	// Blackouts covering any day from first_day to last_day, both inclusive
+	ListOverlappingBlackoutDates(ctx context.Context, arg ListOverlappingBlackoutDatesParams) ([]BlackoutDate, error)
+	// Downtime of any of the given resources overlapping the range, with each
+	// resource's name for conflict messages
+	ListOverlappingDowntime(ctx context.Context, arg ListOverlappingDowntimeParams) ([]ListOverlappingDowntimeRow, error)
+	// Non-rejected entries for a resource that overlap the range, including entries
+	// that only partially fall inside it
+	ListOverlappingResourceSchedule(ctx context.Context, arg ListOverlappingResourceScheduleParams) ([]ListOverlappingResourceScheduleRow, error)
+	// Find all schedule entries for the given resources that overlap the range,
+	// including entries that only partially fall inside it. An entry whose release
+	// grace period reaches into the range is included as well.
+	ListOverlappingScheduleEntries(ctx context.Context, arg ListOverlappingScheduleEntriesParams) ([]ListOverlappingScheduleEntriesRow, error)
+	// The given resources whose bookings share a pool of interchangeable units:
+	// equipment and materials owning more than one
+	ListPooledResources(ctx context.Context, resourceIds []int32) ([]ListPooledResourcesRow, error)
+	// ListResourceUnitUsage for several resources at once
+	ListPooledUnitUsage(ctx context.Context, arg ListPooledUnitUsageParams) ([]ListPooledUnitUsageRow, error)
+	// Sum each resource's booked seconds and bookings within the window, with
+	// bookings clipped to it. Resources without bookings are included at zero;
+	// overlapping bookings each count.
+	ListResourceBookedSeconds(ctx context.Context, arg ListResourceBookedSecondsParams) ([]ListResourceBookedSecondsRow, error)
+	// List a resource's live schedule entries that haven't finished yet, with
+	// their event names and task titles, in chronological order
+	ListResourceCalendarEntries(ctx context.Context, arg ListResourceCalendarEntriesParams) ([]ListResourceCalendarEntriesRow, error)
+	// Group by event the bookings of a resource that haven't finished yet, which
+	// deleting the resource would cascade away
+	ListResourceDeleteImpact(ctx context.Context, arg ListResourceDeleteImpactParams) ([]ListResourceDeleteImpactRow, error)
+	// A resource's downtime overlapping the range, earliest first
+	ListResourceDowntime(ctx context.Context, arg ListResourceDowntimeParams) ([]ResourceDowntime, error)
+	// The working hours set on a resource itself, without its user's shifts
+	ListResourceOwnWorkingHours(ctx context.Context, resourceID int32) ([]ResourceWorkingHour, error)
+	// Units taken by a resource's live bookings that overlap the range, with each
+	// booking's end extended by the resource's release grace
+	ListResourceUnitUsage(ctx context.Context, arg ListResourceUnitUsageParams) ([]ListResourceUnitUsageRow, error)
+	// Weekly working-hours shifts of a resource: its own, or if it has none, those
+	// of its linked user. Resources with neither return no rows.
+	ListResourceWorkingHours(ctx context.Context, id int32) ([]ListResourceWorkingHoursRow, error)
 	ListResources(ctx context.Context, arg ListResourcesParams) ([]Resource, error)
+	// List the given resources that don't hold the certification, or whose
+	// certification expires before the booking ends.
+	ListResourcesMissingCertification(ctx context.Context, arg ListResourcesMissingCertificationParams) ([]ListResourcesMissingCertificationRow, error)
+	// A page of resources filtered by type, availability, and hourly rate, ordered
+	// by sort_field ('name', 'type', 'hourly_rate', or 'created_at'), descending
+	// when sort_desc is set, with name then id breaking ties. A rate bound leaves
+	// out resources without a rate; sorting by rate puts them last either way.
+	// The CASE arms not selected fold to constants once the arguments are bound,
+	// so the default name order can still walk idx_resources_name.
+	ListResourcesPage(ctx context.Context, arg ListResourcesPageParams) ([]Resource, error)
+	// The pairs ListAllConflictPairs finds, over all time and limited to those
+	// involving a booking of the event or a booking on the resource. Either scope
+	// may be NULL.
+	ListScopedConflictPairs(ctx context.Context, arg ListScopedConflictPairsParams) ([]ListScopedConflictPairsRow, error)
+	// Find live bookings linked to a completed task that haven't ended by the
+	// given time. Finishing the task usually makes them stale.
+	ListStaleTaskBookings(ctx context.Context, after time.Time) ([]ListStaleTaskBookingsRow, error)
+	// Available resources listed in resource_substitutes as able to cover each of
+	// the given resources. A substitute must also be of the same type.
+	ListSubstituteCandidates(ctx context.Context, resourceIds []int32) ([]ListSubstituteCandidatesRow, error)
+	// Find schedule entries whose task belongs to a different event than the entry
+	ListTaskEventMismatches(ctx context.Context) ([]ListTaskEventMismatchesRow, error)
+	// Lock the given events for the rest of the transaction. New bookings take a
+	// key share lock on their event, so they wait until the transaction ends.
+	LockEvents(ctx context.Context, ids []int32) ([]int32, error)
+	// Lock the entries of a recurring booking that DeleteRecurrenceGroup would
+	// delete with the same arguments, returning their IDs chronologically
+	LockRecurrenceGroup(ctx context.Context, arg LockRecurrenceGroupParams) ([]int32, error)
+	// Lock a resource for the rest of the transaction, so its bookings are checked
+	// and written one transaction at a time
+	LockResource(ctx context.Context, id int32) (int32, error)
+	// Load the given entries chronologically and lock them for the rest of the
+	// transaction
+	LockScheduleEntries(ctx context.Context, ids []int32) ([]ResourceSchedule, error)
+	// Move every entry of one event onto another, returning the moved entries' IDs
+	MoveEventScheduleEntries(ctx context.Context, arg MoveEventScheduleEntriesParams) ([]int32, error)
+	// Allocate the id shared by the entries of one recurring booking
+	NextRecurrenceGroupID(ctx context.Context) (int32, error)
+	RecurrenceGroupExists(ctx context.Context, recurrenceGroupID sql.NullInt32) (bool, error)
+	SetResourceTimezone(ctx context.Context, arg SetResourceTimezoneParams) (Resource, error)
+	SetResourcesAvailability(ctx context.Context, arg SetResourcesAvailabilityParams) (int64, error)
+	// Swap the start and end of entries that end before they start. Zero-length
+	// entries can't be fixed this way and are left alone.
+	SwapInvertedScheduleRanges(ctx context.Context) ([]int32, error)
+	UpdateResourceWorkingHours(ctx context.Context, arg UpdateResourceWorkingHoursParams) (ResourceWorkingHour, error)
+	// Move a pending entry to approved or rejected. Returns no rows if the entry
+	// doesn't exist or has already been decided.
+	UpdateScheduleApprovalStatus(ctx context.Context, arg UpdateScheduleApprovalStatusParams) (ResourceSchedule, error)
+	// Replace the editable fields of an entry. Approval and cancellation state are
+	// left alone.
+	UpdateScheduleEntry(ctx context.Context, arg UpdateScheduleEntryParams) (ResourceSchedule, error)
+	UpdateScheduleEntryRange(ctx context.Context, arg UpdateScheduleEntryRangeParams) (ResourceSchedule, error)
 }
 
 var _ Querier = (*Queries)(nil)
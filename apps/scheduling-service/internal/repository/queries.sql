@@ -1,10 +1,15 @@
 -- name: GetResourceByID :one
-SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
 FROM resources
 WHERE id = $1;
 
+-- name: GetResourceIDByExternalCode :one
+-- Resolve the code an integration uses for a resource to its internal ID
+SELECT id FROM resources
+WHERE external_code = $1;
+
 -- name: ListResources :many
-SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
 FROM resources
 WHERE (sqlc.narg('type')::resource_type IS NULL OR type = sqlc.narg('type')::resource_type)
   AND (sqlc.narg('is_available')::boolean IS NULL OR is_available = sqlc.narg('is_available')::boolean)
@@ -12,7 +17,64 @@ ORDER BY name
 LIMIT sqlc.arg('limit_count')
 OFFSET sqlc.arg('offset_count');
 
+-- name: ListResourcesPage :many
+-- A page of resources filtered by type, availability, and hourly rate, ordered
+-- by sort_field ('name', 'type', 'hourly_rate', or 'created_at'), descending
+-- when sort_desc is set, with name then id breaking ties. A rate bound leaves
+-- out resources without a rate; sorting by rate puts them last either way.
+-- The CASE arms not selected fold to constants once the arguments are bound,
+-- so the default name order can still walk idx_resources_name.
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
+FROM resources
+WHERE (sqlc.narg('type')::resource_type IS NULL OR type = sqlc.narg('type')::resource_type)
+  AND (sqlc.narg('is_available')::boolean IS NULL OR is_available = sqlc.narg('is_available')::boolean)
+  AND (sqlc.narg('min_rate')::numeric IS NULL OR hourly_rate >= sqlc.narg('min_rate')::numeric)
+  AND (sqlc.narg('max_rate')::numeric IS NULL OR hourly_rate <= sqlc.narg('max_rate')::numeric)
+ORDER BY
+    CASE WHEN sqlc.arg('sort_field')::text = 'type' AND NOT sqlc.arg('sort_desc')::boolean THEN type::text END ASC,
+    CASE WHEN sqlc.arg('sort_field')::text = 'type' AND sqlc.arg('sort_desc')::boolean THEN type::text END DESC,
+    CASE WHEN sqlc.arg('sort_field')::text = 'hourly_rate' AND NOT sqlc.arg('sort_desc')::boolean THEN hourly_rate END ASC NULLS LAST,
+    CASE WHEN sqlc.arg('sort_field')::text = 'hourly_rate' AND sqlc.arg('sort_desc')::boolean THEN hourly_rate END DESC NULLS LAST,
+    CASE WHEN sqlc.arg('sort_field')::text = 'created_at' AND NOT sqlc.arg('sort_desc')::boolean THEN created_at END ASC,
+    CASE WHEN sqlc.arg('sort_field')::text = 'created_at' AND sqlc.arg('sort_desc')::boolean THEN created_at END DESC,
+    CASE WHEN sqlc.arg('sort_field')::text = 'name' AND sqlc.arg('sort_desc')::boolean THEN name END DESC,
+    name,
+    id
+LIMIT sqlc.arg('limit_count')
+OFFSET sqlc.arg('offset_count');
+
+-- name: CountResourcesPage :one
+-- Count the resources matching the filters of ListResourcesPage
+SELECT COUNT(*)
+FROM resources
+WHERE (sqlc.narg('type')::resource_type IS NULL OR type = sqlc.narg('type')::resource_type)
+  AND (sqlc.narg('is_available')::boolean IS NULL OR is_available = sqlc.narg('is_available')::boolean)
+  AND (sqlc.narg('min_rate')::numeric IS NULL OR hourly_rate >= sqlc.narg('min_rate')::numeric)
+  AND (sqlc.narg('max_rate')::numeric IS NULL OR hourly_rate <= sqlc.narg('max_rate')::numeric);
+
+-- name: ListFreeAlternativeResources :many
+-- Available resources of a type, other than the excluded one, with no live
+-- booking overlapping the range, counting release grace
+SELECT r.id, r.name, r.type, r.hourly_rate, r.is_available, r.notes, r.created_at, r.updated_at, r.release_grace_minutes, r.timezone
+FROM resources r
+WHERE r.type = sqlc.arg('type')
+  AND r.is_available
+  AND r.id <> sqlc.arg('exclude_id')
+  AND NOT EXISTS (
+    SELECT 1 FROM resource_schedule rs
+    WHERE rs.resource_id = r.id
+      AND rs.start_time < sqlc.arg('end_time')::timestamptz
+      AND rs.end_time + make_interval(mins => r.release_grace_minutes) > sqlc.arg('start_time')::timestamptz
+      AND rs.approval_status <> 'rejected'
+      AND rs.cancelled_at IS NULL
+  )
+ORDER BY r.name, r.id
+LIMIT sqlc.arg('limit_count');
+
 -- name: GetResourceSchedule :many
+-- One page of a resource's entries within the range, optionally only those of
+-- one event or task, in start_time, id order, reversed when sort_desc is set.
+-- A page after the first starts past the (after_start, after_id) cursor.
 SELECT
     rs.id,
     rs.resource_id,
@@ -24,18 +86,70 @@ SELECT
     rs.end_time,
     rs.notes,
     rs.created_at,
-    rs.updated_at
+    rs.updated_at,
+    rs.approval_status
 FROM resource_schedule rs
 JOIN events e ON rs.event_id = e.id
 LEFT JOIN tasks t ON rs.task_id = t.id
-WHERE rs.resource_id = $1
-  AND rs.start_time >= $2
-  AND rs.end_time <= $3
-ORDER BY rs.start_time;
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.start_time >= sqlc.arg('start_time')
+  AND rs.end_time <= sqlc.arg('end_time')
+  AND rs.cancelled_at IS NULL
+  AND (sqlc.narg('event_id')::int IS NULL OR rs.event_id = sqlc.narg('event_id')::int)
+  AND (sqlc.narg('task_id')::int IS NULL OR rs.task_id = sqlc.narg('task_id')::int)
+  AND (sqlc.narg('after_start')::timestamptz IS NULL
+       OR (NOT sqlc.arg('sort_desc')::boolean
+           AND (rs.start_time, rs.id) > (sqlc.narg('after_start')::timestamptz, sqlc.narg('after_id')::int))
+       OR (sqlc.arg('sort_desc')::boolean
+           AND (rs.start_time, rs.id) < (sqlc.narg('after_start')::timestamptz, sqlc.narg('after_id')::int)))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('sort_desc')::boolean THEN rs.start_time END ASC,
+    CASE WHEN NOT sqlc.arg('sort_desc')::boolean THEN rs.id END ASC,
+    rs.start_time DESC,
+    rs.id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountResourceSchedule :one
+-- How many entries GetResourceSchedule pages through
+SELECT COUNT(*)
+FROM resource_schedule
+WHERE resource_id = sqlc.arg('resource_id')
+  AND start_time >= sqlc.arg('start_time')
+  AND end_time <= sqlc.arg('end_time')
+  AND cancelled_at IS NULL
+  AND (sqlc.narg('event_id')::int IS NULL OR event_id = sqlc.narg('event_id')::int)
+  AND (sqlc.narg('task_id')::int IS NULL OR task_id = sqlc.narg('task_id')::int);
+
+-- name: GetMultiResourceSchedule :many
+-- Schedule entries for several resources at once, with the same range rules as
+-- GetResourceSchedule
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND rs.start_time >= sqlc.arg('start_time')
+  AND rs.end_time <= sqlc.arg('end_time')
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.resource_id, rs.start_time;
 
 -- name: CheckConflicts :many
 -- Find all existing schedule entries that overlap with the requested time range
--- for any of the specified resources
+-- for any of the specified resources. Each existing entry's end is extended by
+-- the resource's release grace period so cleanup time is treated as busy.
+-- Rejected and cancelled entries never conflict.
 SELECT
     rs.id,
     rs.resource_id,
@@ -45,20 +159,24 @@ SELECT
     rs.task_id,
     t.title as task_title,
     rs.start_time as existing_start_time,
-    rs.end_time as existing_end_time
+    rs.end_time as existing_end_time,
+    r.release_grace_minutes,
+    rs.approval_status
 FROM resource_schedule rs
 JOIN resources r ON rs.resource_id = r.id
 JOIN events e ON rs.event_id = e.id
 LEFT JOIN tasks t ON rs.task_id = t.id
 WHERE rs.resource_id = ANY($1::int[])
-  AND tstzrange(rs.start_time, rs.end_time, '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
   AND (sqlc.narg('exclude_schedule_id')::int IS NULL OR rs.id != sqlc.narg('exclude_schedule_id')::int)
 ORDER BY rs.resource_id, rs.start_time;
 
 -- name: CreateScheduleEntry :one
-INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at;
+INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes, quantity, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason;
 
 -- name: DeleteScheduleEntry :exec
 DELETE FROM resource_schedule
@@ -80,8 +198,1059 @@ SELECT
     rs.end_time,
     rs.notes,
     rs.created_at,
-    rs.updated_at
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
 FROM resource_schedule rs
 JOIN events e ON rs.event_id = e.id
 LEFT JOIN tasks t ON rs.task_id = t.id
 WHERE rs.id = $1;
+
+-- name: ClientExists :one
+SELECT EXISTS(SELECT 1 FROM clients WHERE id = $1);
+
+-- name: GetClientResourceUsage :many
+-- Sum booked seconds per resource across all of a client's events. Bookings
+-- that straddle the window are clipped so only time inside it is counted.
+SELECT
+    r.id as resource_id,
+    r.name as resource_name,
+    r.hourly_rate,
+    SUM(EXTRACT(EPOCH FROM (
+        LEAST(rs.end_time, sqlc.arg('end_date')::timestamptz)
+        - GREATEST(rs.start_time, sqlc.arg('start_date')::timestamptz)
+    )))::bigint as booked_seconds
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE e.client_id = sqlc.arg('client_id')
+  AND rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND rs.end_time > sqlc.arg('start_date')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+GROUP BY r.id, r.name, r.hourly_rate
+ORDER BY r.name, r.id;
+
+-- name: ListOverlappingScheduleEntries :many
+-- Find all schedule entries for the given resources that overlap the range,
+-- including entries that only partially fall inside it. An entry whose release
+-- grace period reaches into the range is included as well.
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    rs.start_time,
+    rs.end_time,
+    r.release_grace_minutes
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND rs.start_time < sqlc.arg('end_time')::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > sqlc.arg('start_time')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.resource_id, rs.start_time;
+
+-- name: SetResourcesAvailability :execrows
+UPDATE resources
+SET is_available = sqlc.arg('is_available'), updated_at = NOW()
+WHERE id = ANY(sqlc.arg('resource_ids')::int[]);
+
+-- name: CountFutureBookingsByResource :many
+-- Count bookings that haven't finished yet for each of the given resources
+SELECT resource_id, COUNT(*) as booking_count
+FROM resource_schedule
+WHERE resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND end_time > sqlc.arg('after')::timestamptz
+  AND approval_status <> 'rejected'
+  AND cancelled_at IS NULL
+GROUP BY resource_id
+ORDER BY resource_id;
+
+-- name: ListResourceDeleteImpact :many
+-- Group by event the bookings of a resource that haven't finished yet, which
+-- deleting the resource would cascade away
+SELECT
+    e.id as event_id,
+    e.event_name,
+    e.event_date,
+    e.status,
+    COUNT(*) as booking_count,
+    MIN(rs.start_time)::timestamptz as first_start
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.end_time > sqlc.arg('after')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+GROUP BY e.id
+ORDER BY MIN(rs.start_time), e.id;
+
+-- name: GetBookingDurationHistogram :many
+-- Count bookings starting in the window, bucketed by duration
+SELECT
+    CASE
+        WHEN rs.end_time - rs.start_time < interval '1 hour' THEN '<1h'
+        WHEN rs.end_time - rs.start_time < interval '4 hours' THEN '1-4h'
+        WHEN rs.end_time - rs.start_time < interval '8 hours' THEN '4-8h'
+        ELSE '>8h'
+    END::text as bucket,
+    COUNT(*) as booking_count
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time >= sqlc.arg('start_date')::timestamptz
+  AND rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND (sqlc.narg('resource_type')::resource_type IS NULL OR r.type = sqlc.narg('resource_type')::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+GROUP BY 1;
+
+-- name: GetResourceWeeklyBookedSeconds :many
+-- Sum a resource's booked seconds per week of the window, every week included.
+-- Weeks start on Monday at midnight in the given timezone. A booking is split
+-- at week boundaries and clipped to the window, so each week counts only its
+-- own time; overlapping bookings each count.
+WITH weeks AS (
+    SELECT
+        week_start,
+        date_add(week_start, interval '1 week', sqlc.arg('timezone')::text) AS week_end
+    FROM generate_series(
+        date_trunc('week', sqlc.arg('start_date')::timestamptz, sqlc.arg('timezone')::text),
+        sqlc.arg('end_date')::timestamptz - interval '1 microsecond',
+        interval '1 week',
+        sqlc.arg('timezone')::text
+    ) AS week_start
+)
+SELECT
+    w.week_start::timestamptz as week_start,
+    COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(rs.end_time, w.week_end, sqlc.arg('end_date')::timestamptz)
+        - GREATEST(rs.start_time, w.week_start, sqlc.arg('start_date')::timestamptz)
+    )) FILTER (WHERE rs.id IS NOT NULL), 0)::bigint as booked_seconds
+FROM weeks w
+LEFT JOIN resource_schedule rs
+    ON rs.resource_id = sqlc.arg('resource_id')
+   AND rs.start_time < LEAST(w.week_end, sqlc.arg('end_date')::timestamptz)
+   AND rs.end_time > GREATEST(w.week_start, sqlc.arg('start_date')::timestamptz)
+   AND rs.approval_status <> 'rejected'
+   AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+GROUP BY w.week_start
+ORDER BY w.week_start;
+
+-- name: ListResourceBookedSeconds :many
+-- Sum each resource's booked seconds and bookings within the window, with
+-- bookings clipped to it. Resources without bookings are included at zero;
+-- overlapping bookings each count.
+SELECT
+    r.id,
+    r.name,
+    r.type,
+    COUNT(rs.id) as booking_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(rs.end_time, sqlc.arg('end_date')::timestamptz)
+        - GREATEST(rs.start_time, sqlc.arg('start_date')::timestamptz)
+    )), 0)::bigint as booked_seconds
+FROM resources r
+LEFT JOIN resource_schedule rs
+    ON rs.resource_id = r.id
+   AND rs.start_time < sqlc.arg('end_date')::timestamptz
+   AND rs.end_time > sqlc.arg('start_date')::timestamptz
+   AND rs.approval_status <> 'rejected'
+   AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+WHERE (sqlc.narg('resource_type')::resource_type IS NULL OR r.type = sqlc.narg('resource_type')::resource_type)
+GROUP BY r.id, r.name, r.type
+ORDER BY r.name, r.id;
+
+-- name: ListEventResourcePairs :many
+-- List each distinct event and resource booked together within the window,
+-- for grouping events by the resources they share
+SELECT DISTINCT
+    rs.event_id,
+    e.event_name,
+    rs.resource_id,
+    r.name as resource_name
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND rs.end_time > sqlc.arg('start_date')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.event_id, rs.resource_id;
+
+-- name: ListTaskEventMismatches :many
+-- Find schedule entries whose task belongs to a different event than the entry
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    t.id as task_id,
+    t.event_id as task_event_id,
+    rs.start_time,
+    rs.end_time
+FROM resource_schedule rs
+JOIN tasks t ON rs.task_id = t.id
+WHERE t.event_id <> rs.event_id
+ORDER BY rs.id;
+
+-- name: UpdateScheduleApprovalStatus :one
+-- Move a pending entry to approved or rejected. Returns no rows if the entry
+-- doesn't exist or has already been decided.
+UPDATE resource_schedule
+SET approval_status = sqlc.arg('approval_status'), updated_at = NOW()
+WHERE id = sqlc.arg('id') AND approval_status = 'pending'
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason;
+
+-- name: EventExists :one
+SELECT EXISTS(SELECT 1 FROM events WHERE id = $1);
+
+-- name: GetEventResourceUsage :many
+-- Sum booked seconds per resource across all of an event's live bookings
+SELECT
+    r.id as resource_id,
+    r.name as resource_name,
+    r.hourly_rate,
+    SUM(EXTRACT(EPOCH FROM (rs.end_time - rs.start_time)))::bigint as booked_seconds
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+GROUP BY r.id, r.name, r.hourly_rate
+ORDER BY r.name, r.id;
+
+-- name: GetEventName :one
+SELECT event_name FROM events WHERE id = $1;
+
+-- name: ListEventScheduleEntries :many
+-- List an event's schedule entries that are neither rejected nor cancelled,
+-- with their resource names, in chronological order
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.approval_status,
+    rs.updated_at
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id;
+
+-- name: ListResourceCalendarEntries :many
+-- List a resource's live schedule entries that haven't finished yet, with
+-- their event names and task titles, in chronological order
+SELECT
+    rs.id,
+    e.event_name,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.approval_status,
+    rs.updated_at
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.end_time > sqlc.arg('after')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id;
+
+-- name: ListEventLiveEntries :many
+-- List the editable fields of an event's live entries, those neither rejected
+-- nor cancelled, in chronological order
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.task_id,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.quantity
+FROM resource_schedule rs
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id;
+
+-- name: ListEnabledFeatureFlags :many
+SELECT key FROM feature_flags WHERE enabled = true;
+
+-- name: GetPreviousScheduleEntry :one
+-- Latest non-rejected entry for a resource that ended at or before the given time
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.end_time <= sqlc.arg('at')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.end_time DESC, rs.id DESC
+LIMIT 1;
+
+-- name: GetNextScheduleEntry :one
+-- Earliest non-rejected entry for a resource that starts at or after the given time
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.start_time >= sqlc.arg('at')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+LIMIT 1;
+
+-- name: ListOverlappingResourceSchedule :many
+-- Non-rejected entries for a resource that overlap the range, including entries
+-- that only partially fall inside it
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.start_time < sqlc.arg('range_end')::timestamptz
+  AND rs.end_time > sqlc.arg('range_start')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id;
+
+-- name: SetResourceTimezone :one
+UPDATE resources
+SET timezone = sqlc.narg('timezone'), updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone;
+
+-- name: ListBookedRangesByType :many
+-- List the booked ranges overlapping the window, optionally for one resource type
+SELECT
+    rs.resource_id,
+    rs.start_time,
+    rs.end_time
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND rs.end_time > sqlc.arg('start_date')::timestamptz
+  AND (sqlc.narg('resource_type')::resource_type IS NULL OR r.type = sqlc.narg('resource_type')::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND (sqlc.arg('include_cancelled')::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.resource_id, rs.start_time;
+
+-- name: ListConsolidationCandidates :many
+-- Live entries for a resource, optionally limited to one event, ordered so that
+-- entries for the same event, task, and approval status are contiguous and
+-- chronological
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND (sqlc.narg('event_id')::int IS NULL OR rs.event_id = sqlc.narg('event_id')::int)
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.event_id, rs.task_id NULLS FIRST, rs.approval_status, rs.start_time, rs.id;
+
+-- name: LockScheduleEntries :many
+-- Load the given entries chronologically and lock them for the rest of the
+-- transaction
+SELECT id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+FROM resource_schedule
+WHERE id = ANY(sqlc.arg('ids')::int[])
+ORDER BY start_time, id
+FOR UPDATE;
+
+-- name: UpdateScheduleEntryRange :one
+UPDATE resource_schedule
+SET start_time = sqlc.arg('start_time'), end_time = sqlc.arg('end_time'), updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason;
+
+-- name: CancelScheduleEntry :one
+-- Mark an entry cancelled, keeping it for history. Returns no rows if the entry
+-- doesn't exist or is already cancelled.
+UPDATE resource_schedule
+SET cancelled_at = NOW(), cancellation_reason = sqlc.narg('cancellation_reason'), updated_at = NOW()
+WHERE id = sqlc.arg('id') AND cancelled_at IS NULL
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason;
+
+-- name: CountConflicts :one
+-- Count the entries CheckConflicts would report, without loading them. Uses the
+-- same release grace and exclusion rules, and applies the minimum overlap in SQL.
+SELECT
+    COUNT(*) as conflict_count,
+    COUNT(*) FILTER (WHERE rs.approval_status = 'pending') as pending_count
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange(sqlc.arg('start_time')::timestamptz, sqlc.arg('end_time')::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND (sqlc.narg('exclude_schedule_id')::int IS NULL OR rs.id != sqlc.narg('exclude_schedule_id')::int)
+  AND LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), sqlc.arg('end_time')::timestamptz)
+      - GREATEST(rs.start_time, sqlc.arg('start_time')::timestamptz) >= make_interval(mins => sqlc.arg('min_overlap_minutes')::int);
+
+-- name: LockEvents :many
+-- Lock the given events for the rest of the transaction. New bookings take a
+-- key share lock on their event, so they wait until the transaction ends.
+SELECT id
+FROM events
+WHERE id = ANY(sqlc.arg('ids')::int[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: ListEventMergeConflicts :many
+-- Pairs of live bookings of the same resource, one from each event, that
+-- overlap and would become a double booking within one event after a merge
+SELECT
+    s.id as source_schedule_id,
+    t.id as target_schedule_id,
+    s.resource_id,
+    r.name as resource_name,
+    s.start_time as source_start_time,
+    s.end_time as source_end_time,
+    t.start_time as target_start_time,
+    t.end_time as target_end_time
+FROM resource_schedule s
+JOIN resource_schedule t ON t.resource_id = s.resource_id
+JOIN resources r ON s.resource_id = r.id
+WHERE s.event_id = sqlc.arg('source_event_id')
+  AND t.event_id = sqlc.arg('target_event_id')
+  AND s.start_time < t.end_time
+  AND s.end_time > t.start_time
+  AND s.approval_status <> 'rejected'
+  AND t.approval_status <> 'rejected'
+  AND s.cancelled_at IS NULL
+  AND t.cancelled_at IS NULL
+ORDER BY s.resource_id, s.start_time, t.start_time;
+
+-- name: MoveEventScheduleEntries :many
+-- Move every entry of one event onto another, returning the moved entries' IDs
+UPDATE resource_schedule
+SET event_id = sqlc.arg('target_event_id'), updated_at = NOW()
+WHERE event_id = sqlc.arg('source_event_id')
+RETURNING id;
+
+-- name: ListChangeovers :many
+-- Live bookings of a resource starting within the range, in order, each paired
+-- with the booking that follows it within the range
+SELECT
+    rs.id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    LEAD(rs.id) OVER w as next_schedule_id,
+    LEAD(rs.event_id) OVER w as next_event_id,
+    LEAD(rs.start_time) OVER w as next_start_time
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.start_time >= sqlc.arg('range_start')::timestamptz
+  AND rs.start_time < sqlc.arg('range_end')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+WINDOW w AS (ORDER BY rs.start_time, rs.id)
+ORDER BY rs.start_time, rs.id;
+
+-- name: ListEventGanttEntries :many
+-- List an event's live schedule entries with their resources, grouped by
+-- resource and in chronological order within each
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY r.name, r.id, rs.start_time, rs.id;
+
+-- name: ListEventBookingConflicts :many
+-- Pair each live booking of an event with the live bookings of the same
+-- resource it overlaps, counting each side's release grace. When both bookings
+-- belong to the event, the pair is listed once, under the later-created one.
+SELECT
+    rs.id as schedule_id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    r.release_grace_minutes,
+    rs.start_time,
+    rs.end_time,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id <> rs.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND (other.event_id <> rs.event_id OR other.id < rs.id)
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+ORDER BY rs.start_time, rs.id, other.start_time, other.id;
+
+-- name: ListActiveBookings :many
+-- List live bookings in progress at an instant, optionally for one resource
+-- type. Plain comparisons on start_time and end_time keep the btree indexes
+-- usable.
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.start_time <= sqlc.arg('at')::timestamptz
+  AND rs.end_time > sqlc.arg('at')::timestamptz
+  AND (sqlc.narg('resource_type')::resource_type IS NULL OR r.type = sqlc.narg('resource_type')::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY r.name, r.id, rs.start_time, rs.id;
+
+-- name: ListInvertedScheduleRanges :many
+-- Find schedule entries that don't end after they start. The CHECK constraint
+-- prevents new ones, but rows from before it was added are not validated.
+SELECT id, resource_id, event_id, start_time, end_time, approval_status, cancelled_at
+FROM resource_schedule
+WHERE end_time <= start_time
+ORDER BY id;
+
+-- name: ListEntriesCreatedWhileUnavailable :many
+-- Find schedule entries booked while their resource was marked unavailable,
+-- going by the availability snapshot taken when each entry was inserted
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    rs.start_time,
+    rs.end_time,
+    rs.created_at,
+    rs.approval_status,
+    rs.cancelled_at
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE NOT rs.resource_was_available
+ORDER BY rs.id;
+
+-- name: ListStaleTaskBookings :many
+-- Find live bookings linked to a completed task that haven't ended by the
+-- given time. Finishing the task usually makes them stale.
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN tasks t ON rs.task_id = t.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE t.status = 'completed'
+  AND rs.end_time > sqlc.arg('after')::timestamptz
+  AND rs.cancelled_at IS NULL
+  AND rs.approval_status <> 'rejected'
+ORDER BY rs.start_time, rs.id;
+
+-- name: CancelStaleTaskBookings :many
+-- Cancel the bookings ListStaleTaskBookings finds, recording the completed
+-- task as the reason
+UPDATE resource_schedule rs
+SET cancelled_at = NOW(), cancellation_reason = 'Task completed', updated_at = NOW()
+FROM tasks t
+WHERE rs.task_id = t.id
+  AND t.status = 'completed'
+  AND rs.end_time > sqlc.arg('after')::timestamptz
+  AND rs.cancelled_at IS NULL
+  AND rs.approval_status <> 'rejected'
+RETURNING rs.id;
+
+-- name: SwapInvertedScheduleRanges :many
+-- Swap the start and end of entries that end before they start. Zero-length
+-- entries can't be fixed this way and are left alone.
+UPDATE resource_schedule
+SET start_time = end_time, end_time = start_time, updated_at = NOW()
+WHERE end_time < start_time
+RETURNING id;
+
+-- name: ListResourceWorkingHours :many
+-- Weekly working-hours shifts of a resource: its own, or if it has none, those
+-- of its linked user. Resources with neither return no rows.
+SELECT wh.day_of_week, wh.start_time, wh.end_time
+FROM resource_working_hours wh
+WHERE wh.resource_id = $1
+UNION ALL
+SELECT sa.day_of_week, sa.start_time, sa.end_time
+FROM staff_availability sa
+JOIN resources r ON r.user_id = sa.user_id
+WHERE r.id = $1
+  AND NOT EXISTS (SELECT 1 FROM resource_working_hours WHERE resource_id = $1)
+ORDER BY day_of_week, start_time;
+
+-- name: ListResourcesMissingCertification :many
+-- List the given resources that don't hold the certification, or whose
+-- certification expires before the booking ends.
+SELECT r.id, r.name
+FROM resources r
+LEFT JOIN resource_certifications rc
+    ON rc.resource_id = r.id
+   AND rc.certification = sqlc.arg('certification')
+   AND (rc.expires_at IS NULL OR rc.expires_at >= sqlc.arg('valid_until')::timestamptz)
+WHERE r.id = ANY(sqlc.arg('resource_ids')::int[])
+  AND rc.resource_id IS NULL
+ORDER BY r.id;
+
+-- name: ListAllConflictPairs :many
+-- Pair every live booking with each later-created live booking of the same
+-- resource it overlaps, counting the resource's release grace, across all
+-- resources. Both bookings must overlap the window. Each pair is listed once.
+SELECT
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.id as schedule_id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    oe.event_name as conflicting_event_name,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+JOIN events oe ON other.event_id = oe.id
+WHERE rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND rs.end_time > sqlc.arg('start_date')::timestamptz
+  AND other.start_time < sqlc.arg('end_date')::timestamptz
+  AND other.end_time > sqlc.arg('start_date')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+ORDER BY rs.start_time, rs.id, other.id
+LIMIT sqlc.arg('limit_count')
+OFFSET sqlc.arg('offset_count');
+
+-- name: ListScopedConflictPairs :many
+-- The pairs ListAllConflictPairs finds, over all time and limited to those
+-- involving a booking of the event or a booking on the resource. Either scope
+-- may be NULL.
+SELECT
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.id as schedule_id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    oe.event_name as conflicting_event_name,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+JOIN events oe ON other.event_id = oe.id
+WHERE rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+  AND (sqlc.narg('event_id')::int IS NULL OR rs.event_id = sqlc.narg('event_id')::int OR other.event_id = sqlc.narg('event_id')::int)
+  AND (sqlc.narg('resource_id')::int IS NULL OR rs.resource_id = sqlc.narg('resource_id')::int)
+ORDER BY rs.start_time, rs.id, other.id
+LIMIT sqlc.arg('limit_count');
+
+-- name: CountAllConflictPairs :one
+-- Count the pairs ListAllConflictPairs would return without paging.
+SELECT COUNT(*)
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < sqlc.arg('end_date')::timestamptz
+  AND rs.end_time > sqlc.arg('start_date')::timestamptz
+  AND other.start_time < sqlc.arg('end_date')::timestamptz
+  AND other.end_time > sqlc.arg('start_date')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time;
+
+-- name: UpdateScheduleEntry :one
+-- Replace the editable fields of an entry. Approval and cancellation state are
+-- left alone.
+UPDATE resource_schedule
+SET resource_id = sqlc.arg('resource_id'),
+    event_id = sqlc.arg('event_id'),
+    task_id = sqlc.narg('task_id'),
+    start_time = sqlc.arg('start_time'),
+    end_time = sqlc.arg('end_time'),
+    notes = sqlc.narg('notes'),
+    quantity = sqlc.arg('quantity'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason;
+
+-- name: GetTaskEventID :one
+SELECT event_id FROM tasks WHERE id = $1;
+
+-- name: GetResourceQuantity :one
+SELECT quantity FROM resources WHERE id = $1;
+
+-- name: GetScheduleEntryQuantity :one
+-- Units of its resource a schedule entry takes
+SELECT quantity FROM resource_schedule WHERE id = $1;
+
+-- name: ListResourceUnitUsage :many
+-- Units taken by a resource's live bookings that overlap the range, with each
+-- booking's end extended by the resource's release grace
+SELECT
+    rs.start_time,
+    rs.end_time + make_interval(mins => r.release_grace_minutes) as occupied_until,
+    rs.quantity,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = sqlc.arg('resource_id')
+  AND rs.start_time < sqlc.arg('end_time')::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > sqlc.arg('start_time')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND (sqlc.narg('exclude_schedule_id')::int IS NULL OR rs.id != sqlc.narg('exclude_schedule_id')::int)
+ORDER BY rs.start_time;
+
+-- name: ListPooledResources :many
+-- The given resources whose bookings share a pool of interchangeable units:
+-- equipment and materials owning more than one
+SELECT id, name, quantity
+FROM resources
+WHERE id = ANY(sqlc.arg('resource_ids')::int[])
+  AND type IN ('equipment', 'materials')
+  AND quantity > 1
+ORDER BY id;
+
+-- name: ListPooledUnitUsage :many
+-- ListResourceUnitUsage for several resources at once
+SELECT
+    rs.resource_id,
+    rs.start_time,
+    rs.end_time + make_interval(mins => r.release_grace_minutes) as occupied_until,
+    rs.quantity,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND rs.start_time < sqlc.arg('end_time')::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > sqlc.arg('start_time')::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND (sqlc.narg('exclude_schedule_id')::int IS NULL OR rs.id != sqlc.narg('exclude_schedule_id')::int)
+ORDER BY rs.resource_id, rs.start_time;
+
+-- name: LockResource :one
+-- Lock a resource for the rest of the transaction, so its bookings are checked
+-- and written one transaction at a time
+SELECT id FROM resources WHERE id = $1 FOR UPDATE;
+
+-- name: ListConflictsPage :many
+-- The entries CountConflicts counts, sorted and cut to one page of a large
+-- conflict check. sort is 'overlap_desc' (longest overlap with the requested
+-- range first) or 'start_asc'; ties fall back to start time, then id, so pages
+-- are stable. A NULL row_limit returns every row.
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time as existing_start_time,
+    rs.end_time as existing_end_time,
+    r.release_grace_minutes,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange(sqlc.arg('start_time')::timestamptz, sqlc.arg('end_time')::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND (sqlc.narg('exclude_schedule_id')::int IS NULL OR rs.id != sqlc.narg('exclude_schedule_id')::int)
+  AND LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), sqlc.arg('end_time')::timestamptz)
+      - GREATEST(rs.start_time, sqlc.arg('start_time')::timestamptz) >= make_interval(mins => sqlc.arg('min_overlap_minutes')::int)
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'overlap_desc' THEN
+        LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), sqlc.arg('end_time')::timestamptz)
+        - GREATEST(rs.start_time, sqlc.arg('start_time')::timestamptz)
+    END DESC,
+    rs.start_time,
+    rs.id
+LIMIT sqlc.narg('row_limit')::int;
+
+-- name: ListSubstituteCandidates :many
+-- Available resources listed in resource_substitutes as able to cover each of
+-- the given resources. A substitute must also be of the same type.
+SELECT
+    s.resource_id,
+    sub.id,
+    sub.name,
+    sub.release_grace_minutes
+FROM resource_substitutes s
+JOIN resources orig ON orig.id = s.resource_id
+JOIN resources sub ON sub.id = s.substitute_resource_id
+WHERE s.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND sub.is_available = true
+  AND sub.type = orig.type
+ORDER BY s.resource_id, sub.name, sub.id;
+
+-- name: NextRecurrenceGroupID :one
+-- Allocate the id shared by the entries of one recurring booking
+SELECT nextval('resource_schedule_recurrence_group_seq')::integer AS recurrence_group_id;
+
+-- name: CreateRecurringScheduleEntry :one
+-- Insert one occurrence of a recurring booking
+INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes, quantity, recurrence_group_id, created_by)
+VALUES (sqlc.arg('resource_id'), sqlc.arg('event_id'), sqlc.narg('task_id'), sqlc.arg('start_time'), sqlc.arg('end_time'), sqlc.narg('notes'), sqlc.arg('quantity'), sqlc.arg('recurrence_group_id'), sqlc.narg('created_by'))
+RETURNING id;
+
+-- name: RecurrenceGroupExists :one
+SELECT EXISTS(SELECT 1 FROM resource_schedule WHERE recurrence_group_id = $1);
+
+-- name: CancelRecurrenceGroup :many
+-- Cancel every entry of a recurring booking that isn't cancelled yet
+UPDATE resource_schedule
+SET cancelled_at = NOW(), cancellation_reason = sqlc.narg('cancellation_reason'), updated_at = NOW()
+WHERE recurrence_group_id = sqlc.arg('recurrence_group_id') AND cancelled_at IS NULL
+RETURNING id;
+
+-- name: LockRecurrenceGroup :many
+-- Lock the entries of a recurring booking that DeleteRecurrenceGroup would
+-- delete with the same arguments, returning their IDs chronologically
+SELECT id
+FROM resource_schedule
+WHERE recurrence_group_id = sqlc.arg('recurrence_group_id')
+  AND (sqlc.narg('from')::timestamptz IS NULL OR start_time >= sqlc.narg('from')::timestamptz)
+ORDER BY start_time, id
+FOR UPDATE;
+
+-- name: DeleteRecurrenceGroup :execrows
+-- Delete the entries of a recurring booking, or only those starting at or
+-- after from when it is set
+DELETE FROM resource_schedule
+WHERE recurrence_group_id = sqlc.arg('recurrence_group_id')
+  AND (sqlc.narg('from')::timestamptz IS NULL OR start_time >= sqlc.narg('from')::timestamptz);
+
+-- name: ListResourceOwnWorkingHours :many
+-- The working hours set on a resource itself, without its user's shifts
+SELECT id, resource_id, day_of_week, start_time, end_time, created_at, updated_at
+FROM resource_working_hours
+WHERE resource_id = $1
+ORDER BY day_of_week, start_time, id;
+
+-- name: CreateResourceWorkingHours :one
+INSERT INTO resource_working_hours (resource_id, day_of_week, start_time, end_time)
+VALUES ($1, $2, $3, $4)
+RETURNING id, resource_id, day_of_week, start_time, end_time, created_at, updated_at;
+
+-- name: UpdateResourceWorkingHours :one
+UPDATE resource_working_hours
+SET day_of_week = sqlc.arg('day_of_week'), start_time = sqlc.arg('start_time'), end_time = sqlc.arg('end_time'), updated_at = NOW()
+WHERE id = sqlc.arg('id') AND resource_id = sqlc.arg('resource_id')
+RETURNING id, resource_id, day_of_week, start_time, end_time, created_at, updated_at;
+
+-- name: DeleteResourceWorkingHours :execrows
+DELETE FROM resource_working_hours
+WHERE id = $1 AND resource_id = $2;
+
+-- name: CreateResourceDowntime :one
+INSERT INTO resource_downtime (resource_id, start_time, end_time, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, resource_id, start_time, end_time, reason, created_at;
+
+-- name: ListResourceDowntime :many
+-- A resource's downtime overlapping the range, earliest first
+SELECT id, resource_id, start_time, end_time, reason, created_at
+FROM resource_downtime
+WHERE resource_id = sqlc.arg('resource_id')
+  AND start_time < sqlc.arg('end_time')::timestamptz
+  AND end_time > sqlc.arg('start_time')::timestamptz
+ORDER BY start_time, id;
+
+-- name: ListOverlappingDowntime :many
+-- Downtime of any of the given resources overlapping the range, with each
+-- resource's name for conflict messages
+SELECT d.id, d.resource_id, r.name as resource_name, d.start_time, d.end_time, d.reason
+FROM resource_downtime d
+JOIN resources r ON d.resource_id = r.id
+WHERE d.resource_id = ANY(sqlc.arg('resource_ids')::int[])
+  AND d.start_time < sqlc.arg('end_time')::timestamptz
+  AND d.end_time > sqlc.arg('start_time')::timestamptz
+ORDER BY d.resource_id, d.start_time, d.id;
+
+-- name: DeleteResourceDowntime :execrows
+DELETE FROM resource_downtime
+WHERE id = $1 AND resource_id = $2;
+
+-- name: CreateBlackoutDate :one
+INSERT INTO blackout_dates (start_date, end_date, reason)
+VALUES ($1, $2, $3)
+RETURNING id, start_date, end_date, reason, created_at;
+
+-- name: ListBlackoutDates :many
+SELECT id, start_date, end_date, reason, created_at
+FROM blackout_dates
+ORDER BY start_date, id;
+
+-- name: ListOverlappingBlackoutDates :many
+-- Blackouts covering any day from first_day to last_day, both inclusive
+SELECT id, start_date, end_date, reason, created_at
+FROM blackout_dates
+WHERE start_date <= sqlc.arg('last_day')::date
+  AND end_date >= sqlc.arg('first_day')::date
+ORDER BY start_date, id;
+
+-- name: DeleteBlackoutDate :execrows
+DELETE FROM blackout_dates
+WHERE id = $1;
+
+-- name: GetIdempotencyKey :one
+-- A stored idempotency key, unless it was created at or before expired_before
+SELECT key, request_hash, schedule_entry_id, created_at
+FROM idempotency_keys
+WHERE key = sqlc.arg('key') AND created_at > sqlc.arg('expired_before');
+
+-- name: CreateIdempotencyKey :execrows
+-- Store a key for the entry it created, replacing the key if it expired at or
+-- before expired_before. Nothing is stored, and no row affected, if the key is
+-- still held.
+INSERT INTO idempotency_keys (key, request_hash, schedule_entry_id, created_at)
+VALUES (sqlc.arg('key'), sqlc.arg('request_hash'), sqlc.arg('schedule_entry_id'), sqlc.arg('created_at'))
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    schedule_entry_id = EXCLUDED.schedule_entry_id,
+    created_at = EXCLUDED.created_at
+WHERE idempotency_keys.created_at <= sqlc.arg('expired_before');
+
+-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE created_at <= sqlc.arg('expired_before');
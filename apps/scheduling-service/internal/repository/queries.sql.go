@@ -13,6 +13,113 @@ import (
 	"github.com/lib/pq"
 )
 
+const cancelRecurrenceGroup = `-- name: CancelRecurrenceGroup :many
+UPDATE resource_schedule
+SET cancelled_at = NOW(), cancellation_reason = $1, updated_at = NOW()
+WHERE recurrence_group_id = $2 AND cancelled_at IS NULL
+RETURNING id
+`
+
+type CancelRecurrenceGroupParams struct {
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+}
+
+// Cancel every entry of a recurring booking that isn't cancelled yet
+func (q *Queries) CancelRecurrenceGroup(ctx context.Context, arg CancelRecurrenceGroupParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, cancelRecurrenceGroup, arg.CancellationReason, arg.RecurrenceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cancelScheduleEntry = `-- name: CancelScheduleEntry :one
+UPDATE resource_schedule
+SET cancelled_at = NOW(), cancellation_reason = $1, updated_at = NOW()
+WHERE id = $2 AND cancelled_at IS NULL
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+`
+
+type CancelScheduleEntryParams struct {
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	ID                 int32          `json:"id"`
+}
+
+// Mark an entry cancelled, keeping it for history. Returns no rows if the entry
+// doesn't exist or is already cancelled.
+func (q *Queries) CancelScheduleEntry(ctx context.Context, arg CancelScheduleEntryParams) (ResourceSchedule, error) {
+	row := q.db.QueryRowContext(ctx, cancelScheduleEntry, arg.CancellationReason, arg.ID)
+	var i ResourceSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.TaskID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+	)
+	return i, err
+}
+
+const cancelStaleTaskBookings = `-- name: CancelStaleTaskBookings :many
+UPDATE resource_schedule rs
+SET cancelled_at = NOW(), cancellation_reason = 'Task completed', updated_at = NOW()
+FROM tasks t
+WHERE rs.task_id = t.id
+  AND t.status = 'completed'
+  AND rs.end_time > $1::timestamptz
+  AND rs.cancelled_at IS NULL
+  AND rs.approval_status <> 'rejected'
+RETURNING rs.id
+`
+
+// Cancel the bookings ListStaleTaskBookings finds, recording the completed
+// task as the reason
+func (q *Queries) CancelStaleTaskBookings(ctx context.Context, after time.Time) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, cancelStaleTaskBookings, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const checkConflicts = `-- name: CheckConflicts :many
 SELECT
     rs.id,
@@ -23,13 +130,17 @@ SELECT
     rs.task_id,
     t.title as task_title,
     rs.start_time as existing_start_time,
-    rs.end_time as existing_end_time
+    rs.end_time as existing_end_time,
+    r.release_grace_minutes,
+    rs.approval_status
 FROM resource_schedule rs
 JOIN resources r ON rs.resource_id = r.id
 JOIN events e ON rs.event_id = e.id
 LEFT JOIN tasks t ON rs.task_id = t.id
 WHERE rs.resource_id = ANY($1::int[])
-  AND tstzrange(rs.start_time, rs.end_time, '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
   AND ($4::int IS NULL OR rs.id != $4::int)
 ORDER BY rs.resource_id, rs.start_time
 `
@@ -42,19 +153,23 @@ type CheckConflictsParams struct {
 }
 
 type CheckConflictsRow struct {
-	ID                int32          `json:"id"`
-	ResourceID        int32          `json:"resource_id"`
-	ResourceName      string         `json:"resource_name"`
-	EventID           int32          `json:"event_id"`
-	EventName         string         `json:"event_name"`
-	TaskID            sql.NullInt32  `json:"task_id"`
-	TaskTitle         sql.NullString `json:"task_title"`
-	ExistingStartTime time.Time      `json:"existing_start_time"`
-	ExistingEndTime   time.Time      `json:"existing_end_time"`
+	ID                  int32          `json:"id"`
+	ResourceID          int32          `json:"resource_id"`
+	ResourceName        string         `json:"resource_name"`
+	EventID             int32          `json:"event_id"`
+	EventName           string         `json:"event_name"`
+	TaskID              sql.NullInt32  `json:"task_id"`
+	TaskTitle           sql.NullString `json:"task_title"`
+	ExistingStartTime   time.Time      `json:"existing_start_time"`
+	ExistingEndTime     time.Time      `json:"existing_end_time"`
+	ReleaseGraceMinutes int32          `json:"release_grace_minutes"`
+	ApprovalStatus      ApprovalStatus `json:"approval_status"`
 }
 
 // Find all existing schedule entries that overlap with the requested time range
-// for any of the specified resources
+// for any of the specified resources. Each existing entry's end is extended by
+// the resource's release grace period so cleanup time is treated as busy.
+// Rejected and cancelled entries never conflict.
 func (q *Queries) CheckConflicts(ctx context.Context, arg CheckConflictsParams) ([]CheckConflictsRow, error) {
 	rows, err := q.db.QueryContext(ctx, checkConflicts,
 		pq.Array(arg.Column1),
@@ -79,6 +194,8 @@ func (q *Queries) CheckConflicts(ctx context.Context, arg CheckConflictsParams)
 			&i.TaskTitle,
 			&i.ExistingStartTime,
 			&i.ExistingEndTime,
+			&i.ReleaseGraceMinutes,
+			&i.ApprovalStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -93,151 +210,122 @@ func (q *Queries) CheckConflicts(ctx context.Context, arg CheckConflictsParams)
 	return items, nil
 }
 
-const createScheduleEntry = `-- name: CreateScheduleEntry :one
-INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at
+const clientExists = `-- name: ClientExists :one
+SELECT EXISTS(SELECT 1 FROM clients WHERE id = $1)
 `
 
-type CreateScheduleEntryParams struct {
-	ResourceID int32          `json:"resource_id"`
-	EventID    int32          `json:"event_id"`
-	TaskID     sql.NullInt32  `json:"task_id"`
-	StartTime  time.Time      `json:"start_time"`
-	EndTime    time.Time      `json:"end_time"`
-	Notes      sql.NullString `json:"notes"`
-}
-
-func (q *Queries) CreateScheduleEntry(ctx context.Context, arg CreateScheduleEntryParams) (ResourceSchedule, error) {
-	row := q.db.QueryRowContext(ctx, createScheduleEntry,
-		arg.ResourceID,
-		arg.EventID,
-		arg.TaskID,
-		arg.StartTime,
-		arg.EndTime,
-		arg.Notes,
-	)
-	var i ResourceSchedule
-	err := row.Scan(
-		&i.ID,
-		&i.ResourceID,
-		&i.EventID,
-		&i.TaskID,
-		&i.StartTime,
-		&i.EndTime,
-		&i.Notes,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) ClientExists(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, clientExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
 }
 
-const deleteScheduleEntriesByTask = `-- name: DeleteScheduleEntriesByTask :exec
-DELETE FROM resource_schedule
-WHERE task_id = $1
+const countAllConflictPairs = `-- name: CountAllConflictPairs :one
+SELECT COUNT(*)
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < $1::timestamptz
+  AND rs.end_time > $2::timestamptz
+  AND other.start_time < $1::timestamptz
+  AND other.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
 `
 
-func (q *Queries) DeleteScheduleEntriesByTask(ctx context.Context, taskID sql.NullInt32) error {
-	_, err := q.db.ExecContext(ctx, deleteScheduleEntriesByTask, taskID)
-	return err
+type CountAllConflictPairsParams struct {
+	EndDate   time.Time `json:"end_date"`
+	StartDate time.Time `json:"start_date"`
 }
 
-const deleteScheduleEntry = `-- name: DeleteScheduleEntry :exec
-DELETE FROM resource_schedule
-WHERE id = $1
+// Count the pairs ListAllConflictPairs would return without paging.
+func (q *Queries) CountAllConflictPairs(ctx context.Context, arg CountAllConflictPairsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAllConflictPairs, arg.EndDate, arg.StartDate)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countConflicts = `-- name: CountConflicts :one
+SELECT
+    COUNT(*) as conflict_count,
+    COUNT(*) FILTER (WHERE rs.approval_status = 'pending') as pending_count
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY($1::int[])
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND ($4::int IS NULL OR rs.id != $4::int)
+  AND LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), $3::timestamptz)
+      - GREATEST(rs.start_time, $2::timestamptz) >= make_interval(mins => $5::int)
 `
 
-func (q *Queries) DeleteScheduleEntry(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteScheduleEntry, id)
-	return err
+type CountConflictsParams struct {
+	ResourceIds       []int32       `json:"resource_ids"`
+	StartTime         time.Time     `json:"start_time"`
+	EndTime           time.Time     `json:"end_time"`
+	ExcludeScheduleID sql.NullInt32 `json:"exclude_schedule_id"`
+	MinOverlapMinutes int32         `json:"min_overlap_minutes"`
 }
 
-const getResourceByID = `-- name: GetResourceByID :one
-SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at
-FROM resources
-WHERE id = $1
-`
+type CountConflictsRow struct {
+	ConflictCount int64 `json:"conflict_count"`
+	PendingCount  int64 `json:"pending_count"`
+}
 
-func (q *Queries) GetResourceByID(ctx context.Context, id int32) (Resource, error) {
-	row := q.db.QueryRowContext(ctx, getResourceByID, id)
-	var i Resource
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Type,
-		&i.HourlyRate,
-		&i.IsAvailable,
-		&i.Notes,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+// Count the entries CheckConflicts would report, without loading them. Uses the
+// same release grace and exclusion rules, and applies the minimum overlap in SQL.
+func (q *Queries) CountConflicts(ctx context.Context, arg CountConflictsParams) (CountConflictsRow, error) {
+	row := q.db.QueryRowContext(ctx, countConflicts,
+		pq.Array(arg.ResourceIds),
+		arg.StartTime,
+		arg.EndTime,
+		arg.ExcludeScheduleID,
+		arg.MinOverlapMinutes,
 	)
+	var i CountConflictsRow
+	err := row.Scan(&i.ConflictCount, &i.PendingCount)
 	return i, err
 }
 
-const getResourceSchedule = `-- name: GetResourceSchedule :many
-SELECT
-    rs.id,
-    rs.resource_id,
-    rs.event_id,
-    e.event_name,
-    rs.task_id,
-    t.title as task_title,
-    rs.start_time,
-    rs.end_time,
-    rs.notes,
-    rs.created_at,
-    rs.updated_at
-FROM resource_schedule rs
-JOIN events e ON rs.event_id = e.id
-LEFT JOIN tasks t ON rs.task_id = t.id
-WHERE rs.resource_id = $1
-  AND rs.start_time >= $2
-  AND rs.end_time <= $3
-ORDER BY rs.start_time
+const countFutureBookingsByResource = `-- name: CountFutureBookingsByResource :many
+SELECT resource_id, COUNT(*) as booking_count
+FROM resource_schedule
+WHERE resource_id = ANY($1::int[])
+  AND end_time > $2::timestamptz
+  AND approval_status <> 'rejected'
+  AND cancelled_at IS NULL
+GROUP BY resource_id
+ORDER BY resource_id
 `
 
-type GetResourceScheduleParams struct {
-	ResourceID int32     `json:"resource_id"`
-	StartTime  time.Time `json:"start_time"`
-	EndTime    time.Time `json:"end_time"`
+type CountFutureBookingsByResourceParams struct {
+	ResourceIds []int32   `json:"resource_ids"`
+	After       time.Time `json:"after"`
 }
 
-type GetResourceScheduleRow struct {
-	ID         int32          `json:"id"`
-	ResourceID int32          `json:"resource_id"`
-	EventID    int32          `json:"event_id"`
-	EventName  string         `json:"event_name"`
-	TaskID     sql.NullInt32  `json:"task_id"`
-	TaskTitle  sql.NullString `json:"task_title"`
-	StartTime  time.Time      `json:"start_time"`
-	EndTime    time.Time      `json:"end_time"`
-	Notes      sql.NullString `json:"notes"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+type CountFutureBookingsByResourceRow struct {
+	ResourceID   int32 `json:"resource_id"`
+	BookingCount int64 `json:"booking_count"`
 }
 
-func (q *Queries) GetResourceSchedule(ctx context.Context, arg GetResourceScheduleParams) ([]GetResourceScheduleRow, error) {
-	rows, err := q.db.QueryContext(ctx, getResourceSchedule, arg.ResourceID, arg.StartTime, arg.EndTime)
+// Count bookings that haven't finished yet for each of the given resources
+func (q *Queries) CountFutureBookingsByResource(ctx context.Context, arg CountFutureBookingsByResourceParams) ([]CountFutureBookingsByResourceRow, error) {
+	rows, err := q.db.QueryContext(ctx, countFutureBookingsByResource, pq.Array(arg.ResourceIds), arg.After)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetResourceScheduleRow
+	var items []CountFutureBookingsByResourceRow
 	for rows.Next() {
-		var i GetResourceScheduleRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.ResourceID,
-			&i.EventID,
-			&i.EventName,
-			&i.TaskID,
-			&i.TaskTitle,
-			&i.StartTime,
-			&i.EndTime,
-			&i.Notes,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var i CountFutureBookingsByResourceRow
+		if err := rows.Scan(&i.ResourceID, &i.BookingCount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -251,98 +339,3675 @@ func (q *Queries) GetResourceSchedule(ctx context.Context, arg GetResourceSchedu
 	return items, nil
 }
 
-const getScheduleEntryByID = `-- name: GetScheduleEntryByID :one
-SELECT
-    rs.id,
-    rs.resource_id,
-    rs.event_id,
-    e.event_name,
-    rs.task_id,
-    t.title as task_title,
-    rs.start_time,
-    rs.end_time,
-    rs.notes,
-    rs.created_at,
-    rs.updated_at
-FROM resource_schedule rs
-JOIN events e ON rs.event_id = e.id
-LEFT JOIN tasks t ON rs.task_id = t.id
-WHERE rs.id = $1
+const countResourceSchedule = `-- name: CountResourceSchedule :one
+SELECT COUNT(*)
+FROM resource_schedule
+WHERE resource_id = $1
+  AND start_time >= $2
+  AND end_time <= $3
+  AND cancelled_at IS NULL
+  AND ($4::int IS NULL OR event_id = $4::int)
+  AND ($5::int IS NULL OR task_id = $5::int)
 `
 
-type GetScheduleEntryByIDRow struct {
-	ID         int32          `json:"id"`
-	ResourceID int32          `json:"resource_id"`
-	EventID    int32          `json:"event_id"`
-	EventName  string         `json:"event_name"`
-	TaskID     sql.NullInt32  `json:"task_id"`
-	TaskTitle  sql.NullString `json:"task_title"`
-	StartTime  time.Time      `json:"start_time"`
-	EndTime    time.Time      `json:"end_time"`
-	Notes      sql.NullString `json:"notes"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
+type CountResourceScheduleParams struct {
+	ResourceID int32         `json:"resource_id"`
+	StartTime  time.Time     `json:"start_time"`
+	EndTime    time.Time     `json:"end_time"`
+	EventID    sql.NullInt32 `json:"event_id"`
+	TaskID     sql.NullInt32 `json:"task_id"`
 }
 
-func (q *Queries) GetScheduleEntryByID(ctx context.Context, id int32) (GetScheduleEntryByIDRow, error) {
-	row := q.db.QueryRowContext(ctx, getScheduleEntryByID, id)
-	var i GetScheduleEntryByIDRow
-	err := row.Scan(
-		&i.ID,
-		&i.ResourceID,
-		&i.EventID,
-		&i.EventName,
-		&i.TaskID,
-		&i.TaskTitle,
-		&i.StartTime,
-		&i.EndTime,
-		&i.Notes,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+// How many entries GetResourceSchedule pages through
+func (q *Queries) CountResourceSchedule(ctx context.Context, arg CountResourceScheduleParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResourceSchedule,
+		arg.ResourceID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.EventID,
+		arg.TaskID,
 	)
-	return i, err
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const listResources = `-- name: ListResources :many
-SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at
+const countResourcesPage = `-- name: CountResourcesPage :one
+SELECT COUNT(*)
 FROM resources
 WHERE ($1::resource_type IS NULL OR type = $1::resource_type)
   AND ($2::boolean IS NULL OR is_available = $2::boolean)
-ORDER BY name
-LIMIT $4
-OFFSET $3
+  AND ($3::numeric IS NULL OR hourly_rate >= $3::numeric)
+  AND ($4::numeric IS NULL OR hourly_rate <= $4::numeric)
 `
 
-type ListResourcesParams struct {
+type CountResourcesPageParams struct {
 	Type        NullResourceType `json:"type"`
 	IsAvailable sql.NullBool     `json:"is_available"`
-	OffsetCount int32            `json:"offset_count"`
-	LimitCount  int32            `json:"limit_count"`
+	MinRate     sql.NullString   `json:"min_rate"`
+	MaxRate     sql.NullString   `json:"max_rate"`
 }
 
-func (q *Queries) ListResources(ctx context.Context, arg ListResourcesParams) ([]Resource, error) {
-	rows, err := q.db.QueryContext(ctx, listResources,
+// Count the resources matching the filters of ListResourcesPage
+func (q *Queries) CountResourcesPage(ctx context.Context, arg CountResourcesPageParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countResourcesPage,
 		arg.Type,
 		arg.IsAvailable,
-		arg.OffsetCount,
-		arg.LimitCount,
+		arg.MinRate,
+		arg.MaxRate,
 	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Resource
-	for rows.Next() {
-		var i Resource
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Type,
-			&i.HourlyRate,
-			&i.IsAvailable,
-			&i.Notes,
-			&i.CreatedAt,
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBlackoutDate = `-- name: CreateBlackoutDate :one
+INSERT INTO blackout_dates (start_date, end_date, reason)
+VALUES ($1, $2, $3)
+RETURNING id, start_date, end_date, reason, created_at
+`
+
+type CreateBlackoutDateParams struct {
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Reason    sql.NullString `json:"reason"`
+}
+
+func (q *Queries) CreateBlackoutDate(ctx context.Context, arg CreateBlackoutDateParams) (BlackoutDate, error) {
+	row := q.db.QueryRowContext(ctx, createBlackoutDate, arg.StartDate, arg.EndDate, arg.Reason)
+	var i BlackoutDate
+	err := row.Scan(
+		&i.ID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :execrows
+INSERT INTO idempotency_keys (key, request_hash, schedule_entry_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    schedule_entry_id = EXCLUDED.schedule_entry_id,
+    created_at = EXCLUDED.created_at
+WHERE idempotency_keys.created_at <= $5
+`
+
+type CreateIdempotencyKeyParams struct {
+	Key             string    `json:"key"`
+	RequestHash     string    `json:"request_hash"`
+	ScheduleEntryID int32     `json:"schedule_entry_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiredBefore   time.Time `json:"expired_before"`
+}
+
+// Store a key for the entry it created, replacing the key if it expired at or
+// before expired_before. Nothing is stored, and no row affected, if the key is
+// still held.
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createIdempotencyKey,
+		arg.Key,
+		arg.RequestHash,
+		arg.ScheduleEntryID,
+		arg.CreatedAt,
+		arg.ExpiredBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createRecurringScheduleEntry = `-- name: CreateRecurringScheduleEntry :one
+INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes, quantity, recurrence_group_id, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id
+`
+
+type CreateRecurringScheduleEntryParams struct {
+	ResourceID        int32          `json:"resource_id"`
+	EventID           int32          `json:"event_id"`
+	TaskID            sql.NullInt32  `json:"task_id"`
+	StartTime         time.Time      `json:"start_time"`
+	EndTime           time.Time      `json:"end_time"`
+	Notes             sql.NullString `json:"notes"`
+	Quantity          int32          `json:"quantity"`
+	RecurrenceGroupID sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy         sql.NullInt32  `json:"created_by"`
+}
+
+// Insert one occurrence of a recurring booking
+func (q *Queries) CreateRecurringScheduleEntry(ctx context.Context, arg CreateRecurringScheduleEntryParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, createRecurringScheduleEntry,
+		arg.ResourceID,
+		arg.EventID,
+		arg.TaskID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Notes,
+		arg.Quantity,
+		arg.RecurrenceGroupID,
+		arg.CreatedBy,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createResourceDowntime = `-- name: CreateResourceDowntime :one
+INSERT INTO resource_downtime (resource_id, start_time, end_time, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, resource_id, start_time, end_time, reason, created_at
+`
+
+type CreateResourceDowntimeParams struct {
+	ResourceID int32          `json:"resource_id"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Reason     sql.NullString `json:"reason"`
+}
+
+func (q *Queries) CreateResourceDowntime(ctx context.Context, arg CreateResourceDowntimeParams) (ResourceDowntime, error) {
+	row := q.db.QueryRowContext(ctx, createResourceDowntime,
+		arg.ResourceID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Reason,
+	)
+	var i ResourceDowntime
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createResourceWorkingHours = `-- name: CreateResourceWorkingHours :one
+INSERT INTO resource_working_hours (resource_id, day_of_week, start_time, end_time)
+VALUES ($1, $2, $3, $4)
+RETURNING id, resource_id, day_of_week, start_time, end_time, created_at, updated_at
+`
+
+type CreateResourceWorkingHoursParams struct {
+	ResourceID int32  `json:"resource_id"`
+	DayOfWeek  int32  `json:"day_of_week"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+}
+
+func (q *Queries) CreateResourceWorkingHours(ctx context.Context, arg CreateResourceWorkingHoursParams) (ResourceWorkingHour, error) {
+	row := q.db.QueryRowContext(ctx, createResourceWorkingHours,
+		arg.ResourceID,
+		arg.DayOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i ResourceWorkingHour
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.DayOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createScheduleEntry = `-- name: CreateScheduleEntry :one
+INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes, quantity, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+`
+
+type CreateScheduleEntryParams struct {
+	ResourceID int32          `json:"resource_id"`
+	EventID    int32          `json:"event_id"`
+	TaskID     sql.NullInt32  `json:"task_id"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Notes      sql.NullString `json:"notes"`
+	Quantity   int32          `json:"quantity"`
+	CreatedBy  sql.NullInt32  `json:"created_by"`
+}
+
+func (q *Queries) CreateScheduleEntry(ctx context.Context, arg CreateScheduleEntryParams) (ResourceSchedule, error) {
+	row := q.db.QueryRowContext(ctx, createScheduleEntry,
+		arg.ResourceID,
+		arg.EventID,
+		arg.TaskID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Notes,
+		arg.Quantity,
+		arg.CreatedBy,
+	)
+	var i ResourceSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.TaskID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+	)
+	return i, err
+}
+
+const deleteBlackoutDate = `-- name: DeleteBlackoutDate :execrows
+DELETE FROM blackout_dates
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBlackoutDate(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBlackoutDate, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE created_at <= $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiredBefore time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredIdempotencyKeys, expiredBefore)
+	return err
+}
+
+const deleteRecurrenceGroup = `-- name: DeleteRecurrenceGroup :execrows
+DELETE FROM resource_schedule
+WHERE recurrence_group_id = $1
+  AND ($2::timestamptz IS NULL OR start_time >= $2::timestamptz)
+`
+
+type DeleteRecurrenceGroupParams struct {
+	RecurrenceGroupID sql.NullInt32 `json:"recurrence_group_id"`
+	From              sql.NullTime  `json:"from"`
+}
+
+// Delete the entries of a recurring booking, or only those starting at or
+// after from when it is set
+func (q *Queries) DeleteRecurrenceGroup(ctx context.Context, arg DeleteRecurrenceGroupParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRecurrenceGroup, arg.RecurrenceGroupID, arg.From)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteResourceDowntime = `-- name: DeleteResourceDowntime :execrows
+DELETE FROM resource_downtime
+WHERE id = $1 AND resource_id = $2
+`
+
+type DeleteResourceDowntimeParams struct {
+	ID         int32 `json:"id"`
+	ResourceID int32 `json:"resource_id"`
+}
+
+func (q *Queries) DeleteResourceDowntime(ctx context.Context, arg DeleteResourceDowntimeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteResourceDowntime, arg.ID, arg.ResourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteResourceWorkingHours = `-- name: DeleteResourceWorkingHours :execrows
+DELETE FROM resource_working_hours
+WHERE id = $1 AND resource_id = $2
+`
+
+type DeleteResourceWorkingHoursParams struct {
+	ID         int32 `json:"id"`
+	ResourceID int32 `json:"resource_id"`
+}
+
+func (q *Queries) DeleteResourceWorkingHours(ctx context.Context, arg DeleteResourceWorkingHoursParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteResourceWorkingHours, arg.ID, arg.ResourceID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteScheduleEntriesByTask = `-- name: DeleteScheduleEntriesByTask :exec
+DELETE FROM resource_schedule
+WHERE task_id = $1
+`
+
+func (q *Queries) DeleteScheduleEntriesByTask(ctx context.Context, taskID sql.NullInt32) error {
+	_, err := q.db.ExecContext(ctx, deleteScheduleEntriesByTask, taskID)
+	return err
+}
+
+const deleteScheduleEntry = `-- name: DeleteScheduleEntry :exec
+DELETE FROM resource_schedule
+WHERE id = $1
+`
+
+func (q *Queries) DeleteScheduleEntry(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteScheduleEntry, id)
+	return err
+}
+
+const eventExists = `-- name: EventExists :one
+SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)
+`
+
+func (q *Queries) EventExists(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, eventExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const getBookingDurationHistogram = `-- name: GetBookingDurationHistogram :many
+SELECT
+    CASE
+        WHEN rs.end_time - rs.start_time < interval '1 hour' THEN '<1h'
+        WHEN rs.end_time - rs.start_time < interval '4 hours' THEN '1-4h'
+        WHEN rs.end_time - rs.start_time < interval '8 hours' THEN '4-8h'
+        ELSE '>8h'
+    END::text as bucket,
+    COUNT(*) as booking_count
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time >= $1::timestamptz
+  AND rs.start_time < $2::timestamptz
+  AND ($3::resource_type IS NULL OR r.type = $3::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND ($4::boolean OR rs.cancelled_at IS NULL)
+GROUP BY 1
+`
+
+type GetBookingDurationHistogramParams struct {
+	StartDate        time.Time        `json:"start_date"`
+	EndDate          time.Time        `json:"end_date"`
+	ResourceType     NullResourceType `json:"resource_type"`
+	IncludeCancelled bool             `json:"include_cancelled"`
+}
+
+type GetBookingDurationHistogramRow struct {
+	Bucket       string `json:"bucket"`
+	BookingCount int64  `json:"booking_count"`
+}
+
+// Count bookings starting in the window, bucketed by duration
+func (q *Queries) GetBookingDurationHistogram(ctx context.Context, arg GetBookingDurationHistogramParams) ([]GetBookingDurationHistogramRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBookingDurationHistogram, arg.StartDate, arg.EndDate, arg.ResourceType, arg.IncludeCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBookingDurationHistogramRow
+	for rows.Next() {
+		var i GetBookingDurationHistogramRow
+		if err := rows.Scan(&i.Bucket, &i.BookingCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClientResourceUsage = `-- name: GetClientResourceUsage :many
+SELECT
+    r.id as resource_id,
+    r.name as resource_name,
+    r.hourly_rate,
+    SUM(EXTRACT(EPOCH FROM (
+        LEAST(rs.end_time, $1::timestamptz)
+        - GREATEST(rs.start_time, $2::timestamptz)
+    )))::bigint as booked_seconds
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE e.client_id = $3
+  AND rs.start_time < $1::timestamptz
+  AND rs.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND ($4::boolean OR rs.cancelled_at IS NULL)
+GROUP BY r.id, r.name, r.hourly_rate
+ORDER BY r.name, r.id
+`
+
+type GetClientResourceUsageParams struct {
+	EndDate          time.Time `json:"end_date"`
+	StartDate        time.Time `json:"start_date"`
+	ClientID         int32     `json:"client_id"`
+	IncludeCancelled bool      `json:"include_cancelled"`
+}
+
+type GetClientResourceUsageRow struct {
+	ResourceID    int32          `json:"resource_id"`
+	ResourceName  string         `json:"resource_name"`
+	HourlyRate    sql.NullString `json:"hourly_rate"`
+	BookedSeconds int64          `json:"booked_seconds"`
+}
+
+// Sum booked seconds per resource across all of a client's events. Bookings
+// that straddle the window are clipped so only time inside it is counted.
+func (q *Queries) GetClientResourceUsage(ctx context.Context, arg GetClientResourceUsageParams) ([]GetClientResourceUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getClientResourceUsage, arg.EndDate, arg.StartDate, arg.ClientID, arg.IncludeCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetClientResourceUsageRow
+	for rows.Next() {
+		var i GetClientResourceUsageRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.HourlyRate,
+			&i.BookedSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEventName = `-- name: GetEventName :one
+SELECT event_name FROM events WHERE id = $1
+`
+
+func (q *Queries) GetEventName(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRowContext(ctx, getEventName, id)
+	var event_name string
+	err := row.Scan(&event_name)
+	return event_name, err
+}
+
+const getEventResourceUsage = `-- name: GetEventResourceUsage :many
+SELECT
+    r.id as resource_id,
+    r.name as resource_name,
+    r.hourly_rate,
+    SUM(EXTRACT(EPOCH FROM (rs.end_time - rs.start_time)))::bigint as booked_seconds
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+GROUP BY r.id, r.name, r.hourly_rate
+ORDER BY r.name, r.id
+`
+
+type GetEventResourceUsageRow struct {
+	ResourceID    int32          `json:"resource_id"`
+	ResourceName  string         `json:"resource_name"`
+	HourlyRate    sql.NullString `json:"hourly_rate"`
+	BookedSeconds int64          `json:"booked_seconds"`
+}
+
+// Sum booked seconds per resource across all of an event's live bookings
+func (q *Queries) GetEventResourceUsage(ctx context.Context, eventID int32) ([]GetEventResourceUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEventResourceUsage, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEventResourceUsageRow
+	for rows.Next() {
+		var i GetEventResourceUsageRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.HourlyRate,
+			&i.BookedSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT key, request_hash, schedule_entry_id, created_at
+FROM idempotency_keys
+WHERE key = $1 AND created_at > $2
+`
+
+type GetIdempotencyKeyParams struct {
+	Key           string    `json:"key"`
+	ExpiredBefore time.Time `json:"expired_before"`
+}
+
+// A stored idempotency key, unless it was created at or before expired_before
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.Key, arg.ExpiredBefore)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.RequestHash,
+		&i.ScheduleEntryID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMultiResourceSchedule = `-- name: GetMultiResourceSchedule :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = ANY($1::int[])
+  AND rs.start_time >= $2
+  AND rs.end_time <= $3
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.resource_id, rs.start_time
+`
+
+type GetMultiResourceScheduleParams struct {
+	ResourceIds []int32   `json:"resource_ids"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+type GetMultiResourceScheduleRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	TaskID         sql.NullInt32  `json:"task_id"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	Notes          sql.NullString `json:"notes"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// Schedule entries for several resources at once, with the same range rules as
+// GetResourceSchedule
+func (q *Queries) GetMultiResourceSchedule(ctx context.Context, arg GetMultiResourceScheduleParams) ([]GetMultiResourceScheduleRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMultiResourceSchedule, pq.Array(arg.ResourceIds), arg.StartTime, arg.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMultiResourceScheduleRow
+	for rows.Next() {
+		var i GetMultiResourceScheduleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextScheduleEntry = `-- name: GetNextScheduleEntry :one
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.start_time >= $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+LIMIT 1
+`
+
+type GetNextScheduleEntryParams struct {
+	ResourceID int32     `json:"resource_id"`
+	At         time.Time `json:"at"`
+}
+
+type GetNextScheduleEntryRow struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	TaskTitle          sql.NullString `json:"task_title"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy          sql.NullInt32  `json:"created_by"`
+}
+
+// Earliest non-rejected entry for a resource that starts at or after the given time
+func (q *Queries) GetNextScheduleEntry(ctx context.Context, arg GetNextScheduleEntryParams) (GetNextScheduleEntryRow, error) {
+	row := q.db.QueryRowContext(ctx, getNextScheduleEntry, arg.ResourceID, arg.At)
+	var i GetNextScheduleEntryRow
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.EventName,
+		&i.TaskID,
+		&i.TaskTitle,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+		&i.RecurrenceGroupID,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const getPreviousScheduleEntry = `-- name: GetPreviousScheduleEntry :one
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.end_time <= $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.end_time DESC, rs.id DESC
+LIMIT 1
+`
+
+type GetPreviousScheduleEntryParams struct {
+	ResourceID int32     `json:"resource_id"`
+	At         time.Time `json:"at"`
+}
+
+type GetPreviousScheduleEntryRow struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	TaskTitle          sql.NullString `json:"task_title"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy          sql.NullInt32  `json:"created_by"`
+}
+
+// Latest non-rejected entry for a resource that ended at or before the given time
+func (q *Queries) GetPreviousScheduleEntry(ctx context.Context, arg GetPreviousScheduleEntryParams) (GetPreviousScheduleEntryRow, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousScheduleEntry, arg.ResourceID, arg.At)
+	var i GetPreviousScheduleEntryRow
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.EventName,
+		&i.TaskID,
+		&i.TaskTitle,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+		&i.RecurrenceGroupID,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const getResourceByID = `-- name: GetResourceByID :one
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
+FROM resources
+WHERE id = $1
+`
+
+func (q *Queries) GetResourceByID(ctx context.Context, id int32) (Resource, error) {
+	row := q.db.QueryRowContext(ctx, getResourceByID, id)
+	var i Resource
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.HourlyRate,
+		&i.IsAvailable,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReleaseGraceMinutes,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const getResourceIDByExternalCode = `-- name: GetResourceIDByExternalCode :one
+SELECT id FROM resources
+WHERE external_code = $1
+`
+
+// Resolve the code an integration uses for a resource to its internal ID
+func (q *Queries) GetResourceIDByExternalCode(ctx context.Context, externalCode sql.NullString) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getResourceIDByExternalCode, externalCode)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getResourceQuantity = `-- name: GetResourceQuantity :one
+SELECT quantity FROM resources WHERE id = $1
+`
+
+func (q *Queries) GetResourceQuantity(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getResourceQuantity, id)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const getResourceSchedule = `-- name: GetResourceSchedule :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.start_time >= $2
+  AND rs.end_time <= $3
+  AND rs.cancelled_at IS NULL
+  AND ($4::int IS NULL OR rs.event_id = $4::int)
+  AND ($5::int IS NULL OR rs.task_id = $5::int)
+  AND ($6::timestamptz IS NULL
+       OR (NOT $7::boolean
+           AND (rs.start_time, rs.id) > ($6::timestamptz, $8::int))
+       OR ($7::boolean
+           AND (rs.start_time, rs.id) < ($6::timestamptz, $8::int)))
+ORDER BY
+    CASE WHEN NOT $7::boolean THEN rs.start_time END ASC,
+    CASE WHEN NOT $7::boolean THEN rs.id END ASC,
+    rs.start_time DESC,
+    rs.id DESC
+LIMIT $9
+`
+
+type GetResourceScheduleParams struct {
+	ResourceID int32         `json:"resource_id"`
+	StartTime  time.Time     `json:"start_time"`
+	EndTime    time.Time     `json:"end_time"`
+	EventID    sql.NullInt32 `json:"event_id"`
+	TaskID     sql.NullInt32 `json:"task_id"`
+	AfterStart sql.NullTime  `json:"after_start"`
+	SortDesc   bool          `json:"sort_desc"`
+	AfterID    sql.NullInt32 `json:"after_id"`
+	RowLimit   int32         `json:"row_limit"`
+}
+
+type GetResourceScheduleRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	TaskID         sql.NullInt32  `json:"task_id"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	Notes          sql.NullString `json:"notes"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// One page of a resource's entries within the range, optionally only those of
+// one event or task, in start_time, id order, reversed when sort_desc is set.
+// A page after the first starts past the (after_start, after_id) cursor.
+func (q *Queries) GetResourceSchedule(ctx context.Context, arg GetResourceScheduleParams) ([]GetResourceScheduleRow, error) {
+	rows, err := q.db.QueryContext(ctx, getResourceSchedule,
+		arg.ResourceID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.EventID,
+		arg.TaskID,
+		arg.AfterStart,
+		arg.SortDesc,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetResourceScheduleRow
+	for rows.Next() {
+		var i GetResourceScheduleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getResourceWeeklyBookedSeconds = `-- name: GetResourceWeeklyBookedSeconds :many
+WITH weeks AS (
+    SELECT
+        week_start,
+        date_add(week_start, interval '1 week', $1::text) AS week_end
+    FROM generate_series(
+        date_trunc('week', $2::timestamptz, $1::text),
+        $3::timestamptz - interval '1 microsecond',
+        interval '1 week',
+        $1::text
+    ) AS week_start
+)
+SELECT
+    w.week_start::timestamptz as week_start,
+    COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(rs.end_time, w.week_end, $3::timestamptz)
+        - GREATEST(rs.start_time, w.week_start, $2::timestamptz)
+    )) FILTER (WHERE rs.id IS NOT NULL), 0)::bigint as booked_seconds
+FROM weeks w
+LEFT JOIN resource_schedule rs
+    ON rs.resource_id = $4
+   AND rs.start_time < LEAST(w.week_end, $3::timestamptz)
+   AND rs.end_time > GREATEST(w.week_start, $2::timestamptz)
+   AND rs.approval_status <> 'rejected'
+   AND ($5::boolean OR rs.cancelled_at IS NULL)
+GROUP BY w.week_start
+ORDER BY w.week_start
+`
+
+type GetResourceWeeklyBookedSecondsParams struct {
+	Timezone         string    `json:"timezone"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	ResourceID       int32     `json:"resource_id"`
+	IncludeCancelled bool      `json:"include_cancelled"`
+}
+
+type GetResourceWeeklyBookedSecondsRow struct {
+	WeekStart     time.Time `json:"week_start"`
+	BookedSeconds int64     `json:"booked_seconds"`
+}
+
+// Sum a resource's booked seconds per week of the window, every week included.
+// Weeks start on Monday at midnight in the given timezone. A booking is split
+// at week boundaries and clipped to the window, so each week counts only its
+// own time; overlapping bookings each count.
+func (q *Queries) GetResourceWeeklyBookedSeconds(ctx context.Context, arg GetResourceWeeklyBookedSecondsParams) ([]GetResourceWeeklyBookedSecondsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getResourceWeeklyBookedSeconds,
+		arg.Timezone,
+		arg.StartDate,
+		arg.EndDate,
+		arg.ResourceID,
+		arg.IncludeCancelled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetResourceWeeklyBookedSecondsRow
+	for rows.Next() {
+		var i GetResourceWeeklyBookedSecondsRow
+		if err := rows.Scan(&i.WeekStart, &i.BookedSeconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getScheduleEntryByID = `-- name: GetScheduleEntryByID :one
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.id = $1
+`
+
+type GetScheduleEntryByIDRow struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	TaskTitle          sql.NullString `json:"task_title"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy          sql.NullInt32  `json:"created_by"`
+}
+
+func (q *Queries) GetScheduleEntryByID(ctx context.Context, id int32) (GetScheduleEntryByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getScheduleEntryByID, id)
+	var i GetScheduleEntryByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.EventName,
+		&i.TaskID,
+		&i.TaskTitle,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+		&i.RecurrenceGroupID,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const getScheduleEntryQuantity = `-- name: GetScheduleEntryQuantity :one
+SELECT quantity FROM resource_schedule WHERE id = $1
+`
+
+// Units of its resource a schedule entry takes
+func (q *Queries) GetScheduleEntryQuantity(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getScheduleEntryQuantity, id)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const getTaskEventID = `-- name: GetTaskEventID :one
+SELECT event_id FROM tasks WHERE id = $1
+`
+
+func (q *Queries) GetTaskEventID(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getTaskEventID, id)
+	var event_id int32
+	err := row.Scan(&event_id)
+	return event_id, err
+}
+
+const listActiveBookings = `-- name: ListActiveBookings :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.start_time <= $1::timestamptz
+  AND rs.end_time > $1::timestamptz
+  AND ($2::resource_type IS NULL OR r.type = $2::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY r.name, r.id, rs.start_time, rs.id
+`
+
+type ListActiveBookingsParams struct {
+	At           time.Time        `json:"at"`
+	ResourceType NullResourceType `json:"resource_type"`
+}
+
+type ListActiveBookingsRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	ResourceType   ResourceType   `json:"resource_type"`
+	EventID        int32          `json:"event_id"`
+	EventName      string         `json:"event_name"`
+	TaskID         sql.NullInt32  `json:"task_id"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// List live bookings in progress at an instant, optionally for one resource
+// type. Plain comparisons on start_time and end_time keep the btree indexes
+// usable.
+func (q *Queries) ListActiveBookings(ctx context.Context, arg ListActiveBookingsParams) ([]ListActiveBookingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveBookings, arg.At, arg.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActiveBookingsRow
+	for rows.Next() {
+		var i ListActiveBookingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.ResourceType,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllConflictPairs = `-- name: ListAllConflictPairs :many
+SELECT
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.id as schedule_id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    oe.event_name as conflicting_event_name,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+JOIN events oe ON other.event_id = oe.id
+WHERE rs.start_time < $1::timestamptz
+  AND rs.end_time > $2::timestamptz
+  AND other.start_time < $1::timestamptz
+  AND other.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+ORDER BY rs.start_time, rs.id, other.id
+LIMIT $3
+OFFSET $4
+`
+
+type ListAllConflictPairsParams struct {
+	EndDate     time.Time `json:"end_date"`
+	StartDate   time.Time `json:"start_date"`
+	LimitCount  int32     `json:"limit_count"`
+	OffsetCount int32     `json:"offset_count"`
+}
+
+type ListAllConflictPairsRow struct {
+	ResourceID                int32          `json:"resource_id"`
+	ResourceName              string         `json:"resource_name"`
+	ResourceType              ResourceType   `json:"resource_type"`
+	ScheduleID                int32          `json:"schedule_id"`
+	EventID                   int32          `json:"event_id"`
+	EventName                 string         `json:"event_name"`
+	StartTime                 time.Time      `json:"start_time"`
+	EndTime                   time.Time      `json:"end_time"`
+	ApprovalStatus            ApprovalStatus `json:"approval_status"`
+	ConflictingScheduleID     int32          `json:"conflicting_schedule_id"`
+	ConflictingEventID        int32          `json:"conflicting_event_id"`
+	ConflictingEventName      string         `json:"conflicting_event_name"`
+	ConflictingStartTime      time.Time      `json:"conflicting_start_time"`
+	ConflictingEndTime        time.Time      `json:"conflicting_end_time"`
+	ConflictingApprovalStatus ApprovalStatus `json:"conflicting_approval_status"`
+}
+
+// Pair every live booking with each later-created live booking of the same
+// resource it overlaps, counting the resource's release grace, across all
+// resources. Both bookings must overlap the window. Each pair is listed once.
+func (q *Queries) ListAllConflictPairs(ctx context.Context, arg ListAllConflictPairsParams) ([]ListAllConflictPairsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllConflictPairs,
+		arg.EndDate,
+		arg.StartDate,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllConflictPairsRow
+	for rows.Next() {
+		var i ListAllConflictPairsRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.ResourceType,
+			&i.ScheduleID,
+			&i.EventID,
+			&i.EventName,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+			&i.ConflictingScheduleID,
+			&i.ConflictingEventID,
+			&i.ConflictingEventName,
+			&i.ConflictingStartTime,
+			&i.ConflictingEndTime,
+			&i.ConflictingApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlackoutDates = `-- name: ListBlackoutDates :many
+SELECT id, start_date, end_date, reason, created_at
+FROM blackout_dates
+ORDER BY start_date, id
+`
+
+func (q *Queries) ListBlackoutDates(ctx context.Context) ([]BlackoutDate, error) {
+	rows, err := q.db.QueryContext(ctx, listBlackoutDates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BlackoutDate
+	for rows.Next() {
+		var i BlackoutDate
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookedRangesByType = `-- name: ListBookedRangesByType :many
+SELECT
+    rs.resource_id,
+    rs.start_time,
+    rs.end_time
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < $1::timestamptz
+  AND rs.end_time > $2::timestamptz
+  AND ($3::resource_type IS NULL OR r.type = $3::resource_type)
+  AND rs.approval_status <> 'rejected'
+  AND ($4::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.resource_id, rs.start_time
+`
+
+type ListBookedRangesByTypeParams struct {
+	EndDate          time.Time        `json:"end_date"`
+	StartDate        time.Time        `json:"start_date"`
+	ResourceType     NullResourceType `json:"resource_type"`
+	IncludeCancelled bool             `json:"include_cancelled"`
+}
+
+type ListBookedRangesByTypeRow struct {
+	ResourceID int32     `json:"resource_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+}
+
+// List the booked ranges overlapping the window, optionally for one resource type
+func (q *Queries) ListBookedRangesByType(ctx context.Context, arg ListBookedRangesByTypeParams) ([]ListBookedRangesByTypeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBookedRangesByType, arg.EndDate, arg.StartDate, arg.ResourceType, arg.IncludeCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookedRangesByTypeRow
+	for rows.Next() {
+		var i ListBookedRangesByTypeRow
+		if err := rows.Scan(&i.ResourceID, &i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangeovers = `-- name: ListChangeovers :many
+SELECT
+    rs.id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    LEAD(rs.id) OVER w as next_schedule_id,
+    LEAD(rs.event_id) OVER w as next_event_id,
+    LEAD(rs.start_time) OVER w as next_start_time
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+WHERE rs.resource_id = $1
+  AND rs.start_time >= $2::timestamptz
+  AND rs.start_time < $3::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+WINDOW w AS (ORDER BY rs.start_time, rs.id)
+ORDER BY rs.start_time, rs.id
+`
+
+type ListChangeoversParams struct {
+	ResourceID int32     `json:"resource_id"`
+	RangeStart time.Time `json:"range_start"`
+	RangeEnd   time.Time `json:"range_end"`
+}
+
+type ListChangeoversRow struct {
+	ID             int32         `json:"id"`
+	EventID        int32         `json:"event_id"`
+	EventName      string        `json:"event_name"`
+	StartTime      time.Time     `json:"start_time"`
+	EndTime        time.Time     `json:"end_time"`
+	NextScheduleID sql.NullInt32 `json:"next_schedule_id"`
+	NextEventID    sql.NullInt32 `json:"next_event_id"`
+	NextStartTime  sql.NullTime  `json:"next_start_time"`
+}
+
+// Live bookings of a resource starting within the range, in order, each paired
+// with the booking that follows it within the range
+func (q *Queries) ListChangeovers(ctx context.Context, arg ListChangeoversParams) ([]ListChangeoversRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChangeovers, arg.ResourceID, arg.RangeStart, arg.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListChangeoversRow
+	for rows.Next() {
+		var i ListChangeoversRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.EventName,
+			&i.StartTime,
+			&i.EndTime,
+			&i.NextScheduleID,
+			&i.NextEventID,
+			&i.NextStartTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConflictsPage = `-- name: ListConflictsPage :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time as existing_start_time,
+    rs.end_time as existing_end_time,
+    r.release_grace_minutes,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = ANY($1::int[])
+  AND tstzrange(rs.start_time, rs.end_time + make_interval(mins => r.release_grace_minutes), '[)') && tstzrange($2::timestamptz, $3::timestamptz, '[)')
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND ($4::int IS NULL OR rs.id != $4::int)
+  AND LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), $3::timestamptz)
+      - GREATEST(rs.start_time, $2::timestamptz) >= make_interval(mins => $5::int)
+ORDER BY
+    CASE WHEN $6::text = 'overlap_desc' THEN
+        LEAST(rs.end_time + make_interval(mins => r.release_grace_minutes), $3::timestamptz)
+        - GREATEST(rs.start_time, $2::timestamptz)
+    END DESC,
+    rs.start_time,
+    rs.id
+LIMIT $7::int
+`
+
+type ListConflictsPageParams struct {
+	ResourceIds       []int32       `json:"resource_ids"`
+	StartTime         time.Time     `json:"start_time"`
+	EndTime           time.Time     `json:"end_time"`
+	ExcludeScheduleID sql.NullInt32 `json:"exclude_schedule_id"`
+	MinOverlapMinutes int32         `json:"min_overlap_minutes"`
+	Sort              string        `json:"sort"`
+	RowLimit          sql.NullInt32 `json:"row_limit"`
+}
+
+type ListConflictsPageRow struct {
+	ID                  int32          `json:"id"`
+	ResourceID          int32          `json:"resource_id"`
+	ResourceName        string         `json:"resource_name"`
+	EventID             int32          `json:"event_id"`
+	EventName           string         `json:"event_name"`
+	TaskID              sql.NullInt32  `json:"task_id"`
+	TaskTitle           sql.NullString `json:"task_title"`
+	ExistingStartTime   time.Time      `json:"existing_start_time"`
+	ExistingEndTime     time.Time      `json:"existing_end_time"`
+	ReleaseGraceMinutes int32          `json:"release_grace_minutes"`
+	ApprovalStatus      ApprovalStatus `json:"approval_status"`
+}
+
+// The entries CountConflicts counts, sorted and cut to one page of a large
+// conflict check. sort is 'overlap_desc' (longest overlap with the requested
+// range first) or 'start_asc'; ties fall back to start time, then id, so pages
+// are stable. A NULL row_limit returns every row.
+func (q *Queries) ListConflictsPage(ctx context.Context, arg ListConflictsPageParams) ([]ListConflictsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listConflictsPage,
+		pq.Array(arg.ResourceIds),
+		arg.StartTime,
+		arg.EndTime,
+		arg.ExcludeScheduleID,
+		arg.MinOverlapMinutes,
+		arg.Sort,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListConflictsPageRow
+	for rows.Next() {
+		var i ListConflictsPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.ExistingStartTime,
+			&i.ExistingEndTime,
+			&i.ReleaseGraceMinutes,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConsolidationCandidates = `-- name: ListConsolidationCandidates :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND ($2::int IS NULL OR rs.event_id = $2::int)
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.event_id, rs.task_id NULLS FIRST, rs.approval_status, rs.start_time, rs.id
+`
+
+type ListConsolidationCandidatesParams struct {
+	ResourceID int32         `json:"resource_id"`
+	EventID    sql.NullInt32 `json:"event_id"`
+}
+
+type ListConsolidationCandidatesRow struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	TaskTitle          sql.NullString `json:"task_title"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy          sql.NullInt32  `json:"created_by"`
+}
+
+// Live entries for a resource, optionally limited to one event, ordered so that
+// entries for the same event, task, and approval status are contiguous and
+// chronological
+func (q *Queries) ListConsolidationCandidates(ctx context.Context, arg ListConsolidationCandidatesParams) ([]ListConsolidationCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listConsolidationCandidates, arg.ResourceID, arg.EventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListConsolidationCandidatesRow
+	for rows.Next() {
+		var i ListConsolidationCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApprovalStatus,
+			&i.CancelledAt,
+			&i.CancellationReason,
+			&i.RecurrenceGroupID,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEnabledFeatureFlags = `-- name: ListEnabledFeatureFlags :many
+SELECT key FROM feature_flags WHERE enabled = true
+`
+
+func (q *Queries) ListEnabledFeatureFlags(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listEnabledFeatureFlags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		items = append(items, key)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesCreatedWhileUnavailable = `-- name: ListEntriesCreatedWhileUnavailable :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    rs.start_time,
+    rs.end_time,
+    rs.created_at,
+    rs.approval_status,
+    rs.cancelled_at
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE NOT rs.resource_was_available
+ORDER BY rs.id
+`
+
+type ListEntriesCreatedWhileUnavailableRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	EventID        int32          `json:"event_id"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	CancelledAt    sql.NullTime   `json:"cancelled_at"`
+}
+
+// Find schedule entries booked while their resource was marked unavailable,
+// going by the availability snapshot taken when each entry was inserted
+func (q *Queries) ListEntriesCreatedWhileUnavailable(ctx context.Context) ([]ListEntriesCreatedWhileUnavailableRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesCreatedWhileUnavailable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEntriesCreatedWhileUnavailableRow
+	for rows.Next() {
+		var i ListEntriesCreatedWhileUnavailableRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.EventID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.ApprovalStatus,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventBookingConflicts = `-- name: ListEventBookingConflicts :many
+SELECT
+    rs.id as schedule_id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    r.release_grace_minutes,
+    rs.start_time,
+    rs.end_time,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id <> rs.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND (other.event_id <> rs.event_id OR other.id < rs.id)
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+ORDER BY rs.start_time, rs.id, other.start_time, other.id
+`
+
+type ListEventBookingConflictsRow struct {
+	ScheduleID                int32          `json:"schedule_id"`
+	ResourceID                int32          `json:"resource_id"`
+	ResourceName              string         `json:"resource_name"`
+	ResourceType              ResourceType   `json:"resource_type"`
+	ReleaseGraceMinutes       int32          `json:"release_grace_minutes"`
+	StartTime                 time.Time      `json:"start_time"`
+	EndTime                   time.Time      `json:"end_time"`
+	ConflictingScheduleID     int32          `json:"conflicting_schedule_id"`
+	ConflictingEventID        int32          `json:"conflicting_event_id"`
+	ConflictingStartTime      time.Time      `json:"conflicting_start_time"`
+	ConflictingEndTime        time.Time      `json:"conflicting_end_time"`
+	ConflictingApprovalStatus ApprovalStatus `json:"conflicting_approval_status"`
+}
+
+// Pair each live booking of an event with the live bookings of the same
+// resource it overlaps, counting each side's release grace. When both bookings
+// belong to the event, the pair is listed once, under the later-created one.
+func (q *Queries) ListEventBookingConflicts(ctx context.Context, eventID int32) ([]ListEventBookingConflictsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventBookingConflicts, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventBookingConflictsRow
+	for rows.Next() {
+		var i ListEventBookingConflictsRow
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.ResourceType,
+			&i.ReleaseGraceMinutes,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ConflictingScheduleID,
+			&i.ConflictingEventID,
+			&i.ConflictingStartTime,
+			&i.ConflictingEndTime,
+			&i.ConflictingApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventGanttEntries = `-- name: ListEventGanttEntries :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY r.name, r.id, rs.start_time, rs.id
+`
+
+type ListEventGanttEntriesRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	ResourceType   ResourceType   `json:"resource_type"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// List an event's live schedule entries with their resources, grouped by
+// resource and in chronological order within each
+func (q *Queries) ListEventGanttEntries(ctx context.Context, eventID int32) ([]ListEventGanttEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventGanttEntries, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventGanttEntriesRow
+	for rows.Next() {
+		var i ListEventGanttEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.ResourceType,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventLiveEntries = `-- name: ListEventLiveEntries :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.task_id,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.quantity
+FROM resource_schedule rs
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+`
+
+type ListEventLiveEntriesRow struct {
+	ID         int32          `json:"id"`
+	ResourceID int32          `json:"resource_id"`
+	TaskID     sql.NullInt32  `json:"task_id"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Notes      sql.NullString `json:"notes"`
+	Quantity   int32          `json:"quantity"`
+}
+
+// List the editable fields of an event's live entries, those neither rejected
+// nor cancelled, in chronological order
+func (q *Queries) ListEventLiveEntries(ctx context.Context, eventID int32) ([]ListEventLiveEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventLiveEntries, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventLiveEntriesRow
+	for rows.Next() {
+		var i ListEventLiveEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.TaskID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventMergeConflicts = `-- name: ListEventMergeConflicts :many
+SELECT
+    s.id as source_schedule_id,
+    t.id as target_schedule_id,
+    s.resource_id,
+    r.name as resource_name,
+    s.start_time as source_start_time,
+    s.end_time as source_end_time,
+    t.start_time as target_start_time,
+    t.end_time as target_end_time
+FROM resource_schedule s
+JOIN resource_schedule t ON t.resource_id = s.resource_id
+JOIN resources r ON s.resource_id = r.id
+WHERE s.event_id = $1
+  AND t.event_id = $2
+  AND s.start_time < t.end_time
+  AND s.end_time > t.start_time
+  AND s.approval_status <> 'rejected'
+  AND t.approval_status <> 'rejected'
+  AND s.cancelled_at IS NULL
+  AND t.cancelled_at IS NULL
+ORDER BY s.resource_id, s.start_time, t.start_time
+`
+
+type ListEventMergeConflictsParams struct {
+	SourceEventID int32 `json:"source_event_id"`
+	TargetEventID int32 `json:"target_event_id"`
+}
+
+type ListEventMergeConflictsRow struct {
+	SourceScheduleID int32     `json:"source_schedule_id"`
+	TargetScheduleID int32     `json:"target_schedule_id"`
+	ResourceID       int32     `json:"resource_id"`
+	ResourceName     string    `json:"resource_name"`
+	SourceStartTime  time.Time `json:"source_start_time"`
+	SourceEndTime    time.Time `json:"source_end_time"`
+	TargetStartTime  time.Time `json:"target_start_time"`
+	TargetEndTime    time.Time `json:"target_end_time"`
+}
+
+// Pairs of live bookings of the same resource, one from each event, that
+// overlap and would become a double booking within one event after a merge
+func (q *Queries) ListEventMergeConflicts(ctx context.Context, arg ListEventMergeConflictsParams) ([]ListEventMergeConflictsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventMergeConflicts, arg.SourceEventID, arg.TargetEventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventMergeConflictsRow
+	for rows.Next() {
+		var i ListEventMergeConflictsRow
+		if err := rows.Scan(
+			&i.SourceScheduleID,
+			&i.TargetScheduleID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.SourceStartTime,
+			&i.SourceEndTime,
+			&i.TargetStartTime,
+			&i.TargetEndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventResourcePairs = `-- name: ListEventResourcePairs :many
+SELECT DISTINCT
+    rs.event_id,
+    e.event_name,
+    rs.resource_id,
+    r.name as resource_name
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.start_time < $1::timestamptz
+  AND rs.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND ($3::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.event_id, rs.resource_id
+`
+
+type ListEventResourcePairsParams struct {
+	EndDate          time.Time `json:"end_date"`
+	StartDate        time.Time `json:"start_date"`
+	IncludeCancelled bool      `json:"include_cancelled"`
+}
+
+type ListEventResourcePairsRow struct {
+	EventID      int32  `json:"event_id"`
+	EventName    string `json:"event_name"`
+	ResourceID   int32  `json:"resource_id"`
+	ResourceName string `json:"resource_name"`
+}
+
+// List each distinct event and resource booked together within the window,
+// for grouping events by the resources they share
+func (q *Queries) ListEventResourcePairs(ctx context.Context, arg ListEventResourcePairsParams) ([]ListEventResourcePairsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventResourcePairs, arg.EndDate, arg.StartDate, arg.IncludeCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventResourcePairsRow
+	for rows.Next() {
+		var i ListEventResourcePairsRow
+		if err := rows.Scan(
+			&i.EventID,
+			&i.EventName,
+			&i.ResourceID,
+			&i.ResourceName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventScheduleEntries = `-- name: ListEventScheduleEntries :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.approval_status,
+    rs.updated_at
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.event_id = $1
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+`
+
+type ListEventScheduleEntriesRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	TaskID         sql.NullInt32  `json:"task_id"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	Notes          sql.NullString `json:"notes"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// List an event's schedule entries that are neither rejected nor cancelled,
+// with their resource names, in chronological order
+func (q *Queries) ListEventScheduleEntries(ctx context.Context, eventID int32) ([]ListEventScheduleEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEventScheduleEntries, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEventScheduleEntriesRow
+	for rows.Next() {
+		var i ListEventScheduleEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.ApprovalStatus,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFreeAlternativeResources = `-- name: ListFreeAlternativeResources :many
+SELECT r.id, r.name, r.type, r.hourly_rate, r.is_available, r.notes, r.created_at, r.updated_at, r.release_grace_minutes, r.timezone
+FROM resources r
+WHERE r.type = $1
+  AND r.is_available
+  AND r.id <> $2
+  AND NOT EXISTS (
+    SELECT 1 FROM resource_schedule rs
+    WHERE rs.resource_id = r.id
+      AND rs.start_time < $3::timestamptz
+      AND rs.end_time + make_interval(mins => r.release_grace_minutes) > $4::timestamptz
+      AND rs.approval_status <> 'rejected'
+      AND rs.cancelled_at IS NULL
+  )
+ORDER BY r.name, r.id
+LIMIT $5
+`
+
+type ListFreeAlternativeResourcesParams struct {
+	Type       ResourceType `json:"type"`
+	ExcludeID  int32        `json:"exclude_id"`
+	EndTime    time.Time    `json:"end_time"`
+	StartTime  time.Time    `json:"start_time"`
+	LimitCount int32        `json:"limit_count"`
+}
+
+// Available resources of a type, other than the excluded one, with no live
+// booking overlapping the range, counting release grace
+func (q *Queries) ListFreeAlternativeResources(ctx context.Context, arg ListFreeAlternativeResourcesParams) ([]Resource, error) {
+	rows, err := q.db.QueryContext(ctx, listFreeAlternativeResources,
+		arg.Type,
+		arg.ExcludeID,
+		arg.EndTime,
+		arg.StartTime,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Resource
+	for rows.Next() {
+		var i Resource
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.HourlyRate,
+			&i.IsAvailable,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReleaseGraceMinutes,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvertedScheduleRanges = `-- name: ListInvertedScheduleRanges :many
+SELECT id, resource_id, event_id, start_time, end_time, approval_status, cancelled_at
+FROM resource_schedule
+WHERE end_time <= start_time
+ORDER BY id
+`
+
+type ListInvertedScheduleRangesRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	EventID        int32          `json:"event_id"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	CancelledAt    sql.NullTime   `json:"cancelled_at"`
+}
+
+// Find schedule entries that don't end after they start. The CHECK constraint
+// prevents new ones, but rows from before it was added are not validated.
+func (q *Queries) ListInvertedScheduleRanges(ctx context.Context) ([]ListInvertedScheduleRangesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInvertedScheduleRanges)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInvertedScheduleRangesRow
+	for rows.Next() {
+		var i ListInvertedScheduleRangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverlappingBlackoutDates = `-- name: ListOverlappingBlackoutDates :many
+SELECT id, start_date, end_date, reason, created_at
+FROM blackout_dates
+WHERE start_date <= $1::date
+  AND end_date >= $2::date
+ORDER BY start_date, id
+`
+
+type ListOverlappingBlackoutDatesParams struct {
+	LastDay  time.Time `json:"last_day"`
+	FirstDay time.Time `json:"first_day"`
+}
+
+// Blackouts covering any day from first_day to last_day, both inclusive
+func (q *Queries) ListOverlappingBlackoutDates(ctx context.Context, arg ListOverlappingBlackoutDatesParams) ([]BlackoutDate, error) {
+	rows, err := q.db.QueryContext(ctx, listOverlappingBlackoutDates, arg.LastDay, arg.FirstDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BlackoutDate
+	for rows.Next() {
+		var i BlackoutDate
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverlappingDowntime = `-- name: ListOverlappingDowntime :many
+SELECT d.id, d.resource_id, r.name as resource_name, d.start_time, d.end_time, d.reason
+FROM resource_downtime d
+JOIN resources r ON d.resource_id = r.id
+WHERE d.resource_id = ANY($1::int[])
+  AND d.start_time < $2::timestamptz
+  AND d.end_time > $3::timestamptz
+ORDER BY d.resource_id, d.start_time, d.id
+`
+
+type ListOverlappingDowntimeParams struct {
+	ResourceIds []int32   `json:"resource_ids"`
+	EndTime     time.Time `json:"end_time"`
+	StartTime   time.Time `json:"start_time"`
+}
+
+type ListOverlappingDowntimeRow struct {
+	ID           int32          `json:"id"`
+	ResourceID   int32          `json:"resource_id"`
+	ResourceName string         `json:"resource_name"`
+	StartTime    time.Time      `json:"start_time"`
+	EndTime      time.Time      `json:"end_time"`
+	Reason       sql.NullString `json:"reason"`
+}
+
+// Downtime of any of the given resources overlapping the range, with each
+// resource's name for conflict messages
+func (q *Queries) ListOverlappingDowntime(ctx context.Context, arg ListOverlappingDowntimeParams) ([]ListOverlappingDowntimeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOverlappingDowntime, pq.Array(arg.ResourceIds), arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOverlappingDowntimeRow
+	for rows.Next() {
+		var i ListOverlappingDowntimeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverlappingResourceSchedule = `-- name: ListOverlappingResourceSchedule :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    e.event_name,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.created_at,
+    rs.updated_at,
+    rs.approval_status,
+    rs.cancelled_at,
+    rs.cancellation_reason,
+    rs.recurrence_group_id,
+    rs.created_by
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.start_time < $2::timestamptz
+  AND rs.end_time > $3::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+`
+
+type ListOverlappingResourceScheduleParams struct {
+	ResourceID int32     `json:"resource_id"`
+	RangeEnd   time.Time `json:"range_end"`
+	RangeStart time.Time `json:"range_start"`
+}
+
+type ListOverlappingResourceScheduleRow struct {
+	ID                 int32          `json:"id"`
+	ResourceID         int32          `json:"resource_id"`
+	EventID            int32          `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	TaskID             sql.NullInt32  `json:"task_id"`
+	TaskTitle          sql.NullString `json:"task_title"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Notes              sql.NullString `json:"notes"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	ApprovalStatus     ApprovalStatus `json:"approval_status"`
+	CancelledAt        sql.NullTime   `json:"cancelled_at"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+	RecurrenceGroupID  sql.NullInt32  `json:"recurrence_group_id"`
+	CreatedBy          sql.NullInt32  `json:"created_by"`
+}
+
+// Non-rejected entries for a resource that overlap the range, including entries
+// that only partially fall inside it
+func (q *Queries) ListOverlappingResourceSchedule(ctx context.Context, arg ListOverlappingResourceScheduleParams) ([]ListOverlappingResourceScheduleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOverlappingResourceSchedule, arg.ResourceID, arg.RangeEnd, arg.RangeStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOverlappingResourceScheduleRow
+	for rows.Next() {
+		var i ListOverlappingResourceScheduleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.EventName,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ApprovalStatus,
+			&i.CancelledAt,
+			&i.CancellationReason,
+			&i.RecurrenceGroupID,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverlappingScheduleEntries = `-- name: ListOverlappingScheduleEntries :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    rs.start_time,
+    rs.end_time,
+    r.release_grace_minutes
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY($1::int[])
+  AND rs.start_time < $2::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > $3::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND ($4::boolean OR rs.cancelled_at IS NULL)
+ORDER BY rs.resource_id, rs.start_time
+`
+
+type ListOverlappingScheduleEntriesParams struct {
+	ResourceIds      []int32   `json:"resource_ids"`
+	EndTime          time.Time `json:"end_time"`
+	StartTime        time.Time `json:"start_time"`
+	IncludeCancelled bool      `json:"include_cancelled"`
+}
+
+type ListOverlappingScheduleEntriesRow struct {
+	ID                  int32     `json:"id"`
+	ResourceID          int32     `json:"resource_id"`
+	EventID             int32     `json:"event_id"`
+	StartTime           time.Time `json:"start_time"`
+	EndTime             time.Time `json:"end_time"`
+	ReleaseGraceMinutes int32     `json:"release_grace_minutes"`
+}
+
+// Find all schedule entries for the given resources that overlap the range,
+// including entries that only partially fall inside it. An entry whose release
+// grace period reaches into the range is included as well.
+func (q *Queries) ListOverlappingScheduleEntries(ctx context.Context, arg ListOverlappingScheduleEntriesParams) ([]ListOverlappingScheduleEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOverlappingScheduleEntries, pq.Array(arg.ResourceIds), arg.EndTime, arg.StartTime, arg.IncludeCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOverlappingScheduleEntriesRow
+	for rows.Next() {
+		var i ListOverlappingScheduleEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ReleaseGraceMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPooledResources = `-- name: ListPooledResources :many
+SELECT id, name, quantity
+FROM resources
+WHERE id = ANY($1::int[])
+  AND type IN ('equipment', 'materials')
+  AND quantity > 1
+ORDER BY id
+`
+
+type ListPooledResourcesRow struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Quantity int32  `json:"quantity"`
+}
+
+// The given resources whose bookings share a pool of interchangeable units:
+// equipment and materials owning more than one
+func (q *Queries) ListPooledResources(ctx context.Context, resourceIds []int32) ([]ListPooledResourcesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPooledResources, pq.Array(resourceIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPooledResourcesRow
+	for rows.Next() {
+		var i ListPooledResourcesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPooledUnitUsage = `-- name: ListPooledUnitUsage :many
+SELECT
+    rs.resource_id,
+    rs.start_time,
+    rs.end_time + make_interval(mins => r.release_grace_minutes) as occupied_until,
+    rs.quantity,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = ANY($1::int[])
+  AND rs.start_time < $2::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > $3::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND ($4::int IS NULL OR rs.id != $4::int)
+ORDER BY rs.resource_id, rs.start_time
+`
+
+type ListPooledUnitUsageParams struct {
+	ResourceIds       []int32       `json:"resource_ids"`
+	EndTime           time.Time     `json:"end_time"`
+	StartTime         time.Time     `json:"start_time"`
+	ExcludeScheduleID sql.NullInt32 `json:"exclude_schedule_id"`
+}
+
+type ListPooledUnitUsageRow struct {
+	ResourceID     int32          `json:"resource_id"`
+	StartTime      time.Time      `json:"start_time"`
+	OccupiedUntil  time.Time      `json:"occupied_until"`
+	Quantity       int32          `json:"quantity"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// ListResourceUnitUsage for several resources at once
+func (q *Queries) ListPooledUnitUsage(ctx context.Context, arg ListPooledUnitUsageParams) ([]ListPooledUnitUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPooledUnitUsage,
+		pq.Array(arg.ResourceIds),
+		arg.EndTime,
+		arg.StartTime,
+		arg.ExcludeScheduleID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPooledUnitUsageRow
+	for rows.Next() {
+		var i ListPooledUnitUsageRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.StartTime,
+			&i.OccupiedUntil,
+			&i.Quantity,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceBookedSeconds = `-- name: ListResourceBookedSeconds :many
+SELECT
+    r.id,
+    r.name,
+    r.type,
+    COUNT(rs.id) as booking_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM
+        LEAST(rs.end_time, $1::timestamptz)
+        - GREATEST(rs.start_time, $2::timestamptz)
+    )), 0)::bigint as booked_seconds
+FROM resources r
+LEFT JOIN resource_schedule rs
+    ON rs.resource_id = r.id
+   AND rs.start_time < $1::timestamptz
+   AND rs.end_time > $2::timestamptz
+   AND rs.approval_status <> 'rejected'
+   AND ($3::boolean OR rs.cancelled_at IS NULL)
+WHERE ($4::resource_type IS NULL OR r.type = $4::resource_type)
+GROUP BY r.id, r.name, r.type
+ORDER BY r.name, r.id
+`
+
+type ListResourceBookedSecondsParams struct {
+	EndDate          time.Time        `json:"end_date"`
+	StartDate        time.Time        `json:"start_date"`
+	IncludeCancelled bool             `json:"include_cancelled"`
+	ResourceType     NullResourceType `json:"resource_type"`
+}
+
+type ListResourceBookedSecondsRow struct {
+	ID            int32        `json:"id"`
+	Name          string       `json:"name"`
+	Type          ResourceType `json:"type"`
+	BookingCount  int64        `json:"booking_count"`
+	BookedSeconds int64        `json:"booked_seconds"`
+}
+
+// Sum each resource's booked seconds and bookings within the window, with
+// bookings clipped to it. Resources without bookings are included at zero;
+// overlapping bookings each count.
+func (q *Queries) ListResourceBookedSeconds(ctx context.Context, arg ListResourceBookedSecondsParams) ([]ListResourceBookedSecondsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceBookedSeconds,
+		arg.EndDate,
+		arg.StartDate,
+		arg.IncludeCancelled,
+		arg.ResourceType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceBookedSecondsRow
+	for rows.Next() {
+		var i ListResourceBookedSecondsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.BookingCount,
+			&i.BookedSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceCalendarEntries = `-- name: ListResourceCalendarEntries :many
+SELECT
+    rs.id,
+    e.event_name,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.notes,
+    rs.approval_status,
+    rs.updated_at
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+LEFT JOIN tasks t ON rs.task_id = t.id
+WHERE rs.resource_id = $1
+  AND rs.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+ORDER BY rs.start_time, rs.id
+`
+
+type ListResourceCalendarEntriesParams struct {
+	ResourceID int32     `json:"resource_id"`
+	After      time.Time `json:"after"`
+}
+
+type ListResourceCalendarEntriesRow struct {
+	ID             int32          `json:"id"`
+	EventName      string         `json:"event_name"`
+	TaskTitle      sql.NullString `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	Notes          sql.NullString `json:"notes"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// List a resource's live schedule entries that haven't finished yet, with
+// their event names and task titles, in chronological order
+func (q *Queries) ListResourceCalendarEntries(ctx context.Context, arg ListResourceCalendarEntriesParams) ([]ListResourceCalendarEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceCalendarEntries, arg.ResourceID, arg.After)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceCalendarEntriesRow
+	for rows.Next() {
+		var i ListResourceCalendarEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventName,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.ApprovalStatus,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceDeleteImpact = `-- name: ListResourceDeleteImpact :many
+SELECT
+    e.id as event_id,
+    e.event_name,
+    e.event_date,
+    e.status,
+    COUNT(*) as booking_count,
+    MIN(rs.start_time)::timestamptz as first_start
+FROM resource_schedule rs
+JOIN events e ON rs.event_id = e.id
+WHERE rs.resource_id = $1
+  AND rs.end_time > $2::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+GROUP BY e.id
+ORDER BY MIN(rs.start_time), e.id
+`
+
+type ListResourceDeleteImpactParams struct {
+	ResourceID int32     `json:"resource_id"`
+	After      time.Time `json:"after"`
+}
+
+type ListResourceDeleteImpactRow struct {
+	EventID      int32       `json:"event_id"`
+	EventName    string      `json:"event_name"`
+	EventDate    time.Time   `json:"event_date"`
+	Status       EventStatus `json:"status"`
+	BookingCount int64       `json:"booking_count"`
+	FirstStart   time.Time   `json:"first_start"`
+}
+
+// Group by event the bookings of a resource that haven't finished yet, which
+// deleting the resource would cascade away
+func (q *Queries) ListResourceDeleteImpact(ctx context.Context, arg ListResourceDeleteImpactParams) ([]ListResourceDeleteImpactRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceDeleteImpact, arg.ResourceID, arg.After)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceDeleteImpactRow
+	for rows.Next() {
+		var i ListResourceDeleteImpactRow
+		if err := rows.Scan(
+			&i.EventID,
+			&i.EventName,
+			&i.EventDate,
+			&i.Status,
+			&i.BookingCount,
+			&i.FirstStart,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceDowntime = `-- name: ListResourceDowntime :many
+SELECT id, resource_id, start_time, end_time, reason, created_at
+FROM resource_downtime
+WHERE resource_id = $1
+  AND start_time < $2::timestamptz
+  AND end_time > $3::timestamptz
+ORDER BY start_time, id
+`
+
+type ListResourceDowntimeParams struct {
+	ResourceID int32     `json:"resource_id"`
+	EndTime    time.Time `json:"end_time"`
+	StartTime  time.Time `json:"start_time"`
+}
+
+// A resource's downtime overlapping the range, earliest first
+func (q *Queries) ListResourceDowntime(ctx context.Context, arg ListResourceDowntimeParams) ([]ResourceDowntime, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceDowntime, arg.ResourceID, arg.EndTime, arg.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResourceDowntime
+	for rows.Next() {
+		var i ResourceDowntime
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceOwnWorkingHours = `-- name: ListResourceOwnWorkingHours :many
+SELECT id, resource_id, day_of_week, start_time, end_time, created_at, updated_at
+FROM resource_working_hours
+WHERE resource_id = $1
+ORDER BY day_of_week, start_time, id
+`
+
+// The working hours set on a resource itself, without its user's shifts
+func (q *Queries) ListResourceOwnWorkingHours(ctx context.Context, resourceID int32) ([]ResourceWorkingHour, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceOwnWorkingHours, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResourceWorkingHour
+	for rows.Next() {
+		var i ResourceWorkingHour
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.DayOfWeek,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceUnitUsage = `-- name: ListResourceUnitUsage :many
+SELECT
+    rs.start_time,
+    rs.end_time + make_interval(mins => r.release_grace_minutes) as occupied_until,
+    rs.quantity,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN resources r ON rs.resource_id = r.id
+WHERE rs.resource_id = $1
+  AND rs.start_time < $2::timestamptz
+  AND rs.end_time + make_interval(mins => r.release_grace_minutes) > $3::timestamptz
+  AND rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND ($4::int IS NULL OR rs.id != $4::int)
+ORDER BY rs.start_time
+`
+
+type ListResourceUnitUsageParams struct {
+	ResourceID        int32         `json:"resource_id"`
+	EndTime           time.Time     `json:"end_time"`
+	StartTime         time.Time     `json:"start_time"`
+	ExcludeScheduleID sql.NullInt32 `json:"exclude_schedule_id"`
+}
+
+type ListResourceUnitUsageRow struct {
+	StartTime      time.Time      `json:"start_time"`
+	OccupiedUntil  time.Time      `json:"occupied_until"`
+	Quantity       int32          `json:"quantity"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// Units taken by a resource's live bookings that overlap the range, with each
+// booking's end extended by the resource's release grace
+func (q *Queries) ListResourceUnitUsage(ctx context.Context, arg ListResourceUnitUsageParams) ([]ListResourceUnitUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceUnitUsage,
+		arg.ResourceID,
+		arg.EndTime,
+		arg.StartTime,
+		arg.ExcludeScheduleID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceUnitUsageRow
+	for rows.Next() {
+		var i ListResourceUnitUsageRow
+		if err := rows.Scan(
+			&i.StartTime,
+			&i.OccupiedUntil,
+			&i.Quantity,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceWorkingHours = `-- name: ListResourceWorkingHours :many
+SELECT wh.day_of_week, wh.start_time, wh.end_time
+FROM resource_working_hours wh
+WHERE wh.resource_id = $1
+UNION ALL
+SELECT sa.day_of_week, sa.start_time, sa.end_time
+FROM staff_availability sa
+JOIN resources r ON r.user_id = sa.user_id
+WHERE r.id = $1
+  AND NOT EXISTS (SELECT 1 FROM resource_working_hours WHERE resource_id = $1)
+ORDER BY day_of_week, start_time
+`
+
+type ListResourceWorkingHoursRow struct {
+	DayOfWeek int32  `json:"day_of_week"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// Weekly working-hours shifts of a resource: its own, or if it has none, those
+// of its linked user. Resources with neither return no rows.
+func (q *Queries) ListResourceWorkingHours(ctx context.Context, id int32) ([]ListResourceWorkingHoursRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceWorkingHours, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceWorkingHoursRow
+	for rows.Next() {
+		var i ListResourceWorkingHoursRow
+		if err := rows.Scan(&i.DayOfWeek, &i.StartTime, &i.EndTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResources = `-- name: ListResources :many
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
+FROM resources
+WHERE ($1::resource_type IS NULL OR type = $1::resource_type)
+  AND ($2::boolean IS NULL OR is_available = $2::boolean)
+ORDER BY name
+LIMIT $4
+OFFSET $3
+`
+
+type ListResourcesParams struct {
+	Type        NullResourceType `json:"type"`
+	IsAvailable sql.NullBool     `json:"is_available"`
+	OffsetCount int32            `json:"offset_count"`
+	LimitCount  int32            `json:"limit_count"`
+}
+
+func (q *Queries) ListResources(ctx context.Context, arg ListResourcesParams) ([]Resource, error) {
+	rows, err := q.db.QueryContext(ctx, listResources,
+		arg.Type,
+		arg.IsAvailable,
+		arg.OffsetCount,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Resource
+	for rows.Next() {
+		var i Resource
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.HourlyRate,
+			&i.IsAvailable,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReleaseGraceMinutes,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourcesMissingCertification = `-- name: ListResourcesMissingCertification :many
+SELECT r.id, r.name
+FROM resources r
+LEFT JOIN resource_certifications rc
+    ON rc.resource_id = r.id
+   AND rc.certification = $1
+   AND (rc.expires_at IS NULL OR rc.expires_at >= $2::timestamptz)
+WHERE r.id = ANY($3::int[])
+  AND rc.resource_id IS NULL
+ORDER BY r.id
+`
+
+type ListResourcesMissingCertificationParams struct {
+	Certification string    `json:"certification"`
+	ValidUntil    time.Time `json:"valid_until"`
+	ResourceIds   []int32   `json:"resource_ids"`
+}
+
+type ListResourcesMissingCertificationRow struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+// List the given resources that don't hold the certification, or whose
+// certification expires before the booking ends.
+func (q *Queries) ListResourcesMissingCertification(ctx context.Context, arg ListResourcesMissingCertificationParams) ([]ListResourcesMissingCertificationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourcesMissingCertification, arg.Certification, arg.ValidUntil, pq.Array(arg.ResourceIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourcesMissingCertificationRow
+	for rows.Next() {
+		var i ListResourcesMissingCertificationRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourcesPage = `-- name: ListResourcesPage :many
+SELECT id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
+FROM resources
+WHERE ($1::resource_type IS NULL OR type = $1::resource_type)
+  AND ($2::boolean IS NULL OR is_available = $2::boolean)
+  AND ($3::numeric IS NULL OR hourly_rate >= $3::numeric)
+  AND ($4::numeric IS NULL OR hourly_rate <= $4::numeric)
+ORDER BY
+    CASE WHEN $5::text = 'type' AND NOT $6::boolean THEN type::text END ASC,
+    CASE WHEN $5::text = 'type' AND $6::boolean THEN type::text END DESC,
+    CASE WHEN $5::text = 'hourly_rate' AND NOT $6::boolean THEN hourly_rate END ASC NULLS LAST,
+    CASE WHEN $5::text = 'hourly_rate' AND $6::boolean THEN hourly_rate END DESC NULLS LAST,
+    CASE WHEN $5::text = 'created_at' AND NOT $6::boolean THEN created_at END ASC,
+    CASE WHEN $5::text = 'created_at' AND $6::boolean THEN created_at END DESC,
+    CASE WHEN $5::text = 'name' AND $6::boolean THEN name END DESC,
+    name,
+    id
+LIMIT $7
+OFFSET $8
+`
+
+type ListResourcesPageParams struct {
+	Type        NullResourceType `json:"type"`
+	IsAvailable sql.NullBool     `json:"is_available"`
+	MinRate     sql.NullString   `json:"min_rate"`
+	MaxRate     sql.NullString   `json:"max_rate"`
+	SortField   string           `json:"sort_field"`
+	SortDesc    bool             `json:"sort_desc"`
+	LimitCount  int32            `json:"limit_count"`
+	OffsetCount int32            `json:"offset_count"`
+}
+
+// A page of resources filtered by type, availability, and hourly rate, ordered
+// by sort_field ('name', 'type', 'hourly_rate', or 'created_at'), descending
+// when sort_desc is set, with name then id breaking ties. A rate bound leaves
+// out resources without a rate; sorting by rate puts them last either way.
+// The CASE arms not selected fold to constants once the arguments are bound,
+// so the default name order can still walk idx_resources_name.
+func (q *Queries) ListResourcesPage(ctx context.Context, arg ListResourcesPageParams) ([]Resource, error) {
+	rows, err := q.db.QueryContext(ctx, listResourcesPage,
+		arg.Type,
+		arg.IsAvailable,
+		arg.MinRate,
+		arg.MaxRate,
+		arg.SortField,
+		arg.SortDesc,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Resource
+	for rows.Next() {
+		var i Resource
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.HourlyRate,
+			&i.IsAvailable,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReleaseGraceMinutes,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScopedConflictPairs = `-- name: ListScopedConflictPairs :many
+SELECT
+    rs.resource_id,
+    r.name as resource_name,
+    r.type as resource_type,
+    rs.id as schedule_id,
+    rs.event_id,
+    e.event_name,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status,
+    other.id as conflicting_schedule_id,
+    other.event_id as conflicting_event_id,
+    oe.event_name as conflicting_event_name,
+    other.start_time as conflicting_start_time,
+    other.end_time as conflicting_end_time,
+    other.approval_status as conflicting_approval_status
+FROM resource_schedule rs
+JOIN resource_schedule other ON other.resource_id = rs.resource_id AND other.id > rs.id
+JOIN resources r ON rs.resource_id = r.id
+JOIN events e ON rs.event_id = e.id
+JOIN events oe ON other.event_id = oe.id
+WHERE rs.approval_status <> 'rejected'
+  AND rs.cancelled_at IS NULL
+  AND other.approval_status <> 'rejected'
+  AND other.cancelled_at IS NULL
+  AND other.start_time < rs.end_time + make_interval(mins => r.release_grace_minutes)
+  AND other.end_time + make_interval(mins => r.release_grace_minutes) > rs.start_time
+  AND ($1::int IS NULL OR rs.event_id = $1::int OR other.event_id = $1::int)
+  AND ($2::int IS NULL OR rs.resource_id = $2::int)
+ORDER BY rs.start_time, rs.id, other.id
+LIMIT $3
+`
+
+type ListScopedConflictPairsParams struct {
+	EventID    sql.NullInt32 `json:"event_id"`
+	ResourceID sql.NullInt32 `json:"resource_id"`
+	LimitCount int32         `json:"limit_count"`
+}
+
+type ListScopedConflictPairsRow struct {
+	ResourceID                int32          `json:"resource_id"`
+	ResourceName              string         `json:"resource_name"`
+	ResourceType              ResourceType   `json:"resource_type"`
+	ScheduleID                int32          `json:"schedule_id"`
+	EventID                   int32          `json:"event_id"`
+	EventName                 string         `json:"event_name"`
+	StartTime                 time.Time      `json:"start_time"`
+	EndTime                   time.Time      `json:"end_time"`
+	ApprovalStatus            ApprovalStatus `json:"approval_status"`
+	ConflictingScheduleID     int32          `json:"conflicting_schedule_id"`
+	ConflictingEventID        int32          `json:"conflicting_event_id"`
+	ConflictingEventName      string         `json:"conflicting_event_name"`
+	ConflictingStartTime      time.Time      `json:"conflicting_start_time"`
+	ConflictingEndTime        time.Time      `json:"conflicting_end_time"`
+	ConflictingApprovalStatus ApprovalStatus `json:"conflicting_approval_status"`
+}
+
+// The pairs ListAllConflictPairs finds, over all time and limited to those
+// involving a booking of the event or a booking on the resource. Either scope
+// may be NULL.
+func (q *Queries) ListScopedConflictPairs(ctx context.Context, arg ListScopedConflictPairsParams) ([]ListScopedConflictPairsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listScopedConflictPairs, arg.EventID, arg.ResourceID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListScopedConflictPairsRow
+	for rows.Next() {
+		var i ListScopedConflictPairsRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.ResourceType,
+			&i.ScheduleID,
+			&i.EventID,
+			&i.EventName,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+			&i.ConflictingScheduleID,
+			&i.ConflictingEventID,
+			&i.ConflictingEventName,
+			&i.ConflictingStartTime,
+			&i.ConflictingEndTime,
+			&i.ConflictingApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStaleTaskBookings = `-- name: ListStaleTaskBookings :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    r.name as resource_name,
+    rs.event_id,
+    rs.task_id,
+    t.title as task_title,
+    rs.start_time,
+    rs.end_time,
+    rs.approval_status
+FROM resource_schedule rs
+JOIN tasks t ON rs.task_id = t.id
+JOIN resources r ON rs.resource_id = r.id
+WHERE t.status = 'completed'
+  AND rs.end_time > $1::timestamptz
+  AND rs.cancelled_at IS NULL
+  AND rs.approval_status <> 'rejected'
+ORDER BY rs.start_time, rs.id
+`
+
+type ListStaleTaskBookingsRow struct {
+	ID             int32          `json:"id"`
+	ResourceID     int32          `json:"resource_id"`
+	ResourceName   string         `json:"resource_name"`
+	EventID        int32          `json:"event_id"`
+	TaskID         sql.NullInt32  `json:"task_id"`
+	TaskTitle      string         `json:"task_title"`
+	StartTime      time.Time      `json:"start_time"`
+	EndTime        time.Time      `json:"end_time"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// Find live bookings linked to a completed task that haven't ended by the
+// given time. Finishing the task usually makes them stale.
+func (q *Queries) ListStaleTaskBookings(ctx context.Context, after time.Time) ([]ListStaleTaskBookingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStaleTaskBookings, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStaleTaskBookingsRow
+	for rows.Next() {
+		var i ListStaleTaskBookingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.ResourceName,
+			&i.EventID,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ApprovalStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubstituteCandidates = `-- name: ListSubstituteCandidates :many
+SELECT
+    s.resource_id,
+    sub.id,
+    sub.name,
+    sub.release_grace_minutes
+FROM resource_substitutes s
+JOIN resources orig ON orig.id = s.resource_id
+JOIN resources sub ON sub.id = s.substitute_resource_id
+WHERE s.resource_id = ANY($1::int[])
+  AND sub.is_available = true
+  AND sub.type = orig.type
+ORDER BY s.resource_id, sub.name, sub.id
+`
+
+type ListSubstituteCandidatesRow struct {
+	ResourceID          int32  `json:"resource_id"`
+	ID                  int32  `json:"id"`
+	Name                string `json:"name"`
+	ReleaseGraceMinutes int32  `json:"release_grace_minutes"`
+}
+
+// Available resources listed in resource_substitutes as able to cover each of
+// the given resources. A substitute must also be of the same type.
+func (q *Queries) ListSubstituteCandidates(ctx context.Context, resourceIds []int32) ([]ListSubstituteCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSubstituteCandidates, pq.Array(resourceIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSubstituteCandidatesRow
+	for rows.Next() {
+		var i ListSubstituteCandidatesRow
+		if err := rows.Scan(
+			&i.ResourceID,
+			&i.ID,
+			&i.Name,
+			&i.ReleaseGraceMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskEventMismatches = `-- name: ListTaskEventMismatches :many
+SELECT
+    rs.id,
+    rs.resource_id,
+    rs.event_id,
+    t.id as task_id,
+    t.event_id as task_event_id,
+    rs.start_time,
+    rs.end_time
+FROM resource_schedule rs
+JOIN tasks t ON rs.task_id = t.id
+WHERE t.event_id <> rs.event_id
+ORDER BY rs.id
+`
+
+type ListTaskEventMismatchesRow struct {
+	ID          int32     `json:"id"`
+	ResourceID  int32     `json:"resource_id"`
+	EventID     int32     `json:"event_id"`
+	TaskID      int32     `json:"task_id"`
+	TaskEventID int32     `json:"task_event_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+// Find schedule entries whose task belongs to a different event than the entry
+func (q *Queries) ListTaskEventMismatches(ctx context.Context) ([]ListTaskEventMismatchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTaskEventMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskEventMismatchesRow
+	for rows.Next() {
+		var i ListTaskEventMismatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.TaskID,
+			&i.TaskEventID,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEvents = `-- name: LockEvents :many
+SELECT id
+FROM events
+WHERE id = ANY($1::int[])
+ORDER BY id
+FOR UPDATE
+`
+
+// Lock the given events for the rest of the transaction. New bookings take a
+// key share lock on their event, so they wait until the transaction ends.
+func (q *Queries) LockEvents(ctx context.Context, ids []int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, lockEvents, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockRecurrenceGroup = `-- name: LockRecurrenceGroup :many
+SELECT id
+FROM resource_schedule
+WHERE recurrence_group_id = $1
+  AND ($2::timestamptz IS NULL OR start_time >= $2::timestamptz)
+ORDER BY start_time, id
+FOR UPDATE
+`
+
+type LockRecurrenceGroupParams struct {
+	RecurrenceGroupID sql.NullInt32 `json:"recurrence_group_id"`
+	From              sql.NullTime  `json:"from"`
+}
+
+// Lock the entries of a recurring booking that DeleteRecurrenceGroup would
+// delete with the same arguments, returning their IDs chronologically
+func (q *Queries) LockRecurrenceGroup(ctx context.Context, arg LockRecurrenceGroupParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, lockRecurrenceGroup, arg.RecurrenceGroupID, arg.From)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockResource = `-- name: LockResource :one
+SELECT id FROM resources WHERE id = $1 FOR UPDATE
+`
+
+// Lock a resource for the rest of the transaction, so its bookings are checked
+// and written one transaction at a time
+func (q *Queries) LockResource(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockResource, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const lockScheduleEntries = `-- name: LockScheduleEntries :many
+SELECT id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+FROM resource_schedule
+WHERE id = ANY($1::int[])
+ORDER BY start_time, id
+FOR UPDATE
+`
+
+// Load the given entries chronologically and lock them for the rest of the
+// transaction
+func (q *Queries) LockScheduleEntries(ctx context.Context, ids []int32) ([]ResourceSchedule, error) {
+	rows, err := q.db.QueryContext(ctx, lockScheduleEntries, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ResourceSchedule
+	for rows.Next() {
+		var i ResourceSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.ResourceID,
+			&i.EventID,
+			&i.TaskID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Notes,
+			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ApprovalStatus,
+			&i.CancelledAt,
+			&i.CancellationReason,
 		); err != nil {
 			return nil, err
 		}
@@ -356,3 +4021,302 @@ func (q *Queries) ListResources(ctx context.Context, arg ListResourcesParams) ([
 	}
 	return items, nil
 }
+
+const moveEventScheduleEntries = `-- name: MoveEventScheduleEntries :many
+UPDATE resource_schedule
+SET event_id = $1, updated_at = NOW()
+WHERE event_id = $2
+RETURNING id
+`
+
+type MoveEventScheduleEntriesParams struct {
+	TargetEventID int32 `json:"target_event_id"`
+	SourceEventID int32 `json:"source_event_id"`
+}
+
+// Move every entry of one event onto another, returning the moved entries' IDs
+func (q *Queries) MoveEventScheduleEntries(ctx context.Context, arg MoveEventScheduleEntriesParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, moveEventScheduleEntries, arg.TargetEventID, arg.SourceEventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const nextRecurrenceGroupID = `-- name: NextRecurrenceGroupID :one
+SELECT nextval('resource_schedule_recurrence_group_seq')::integer AS recurrence_group_id
+`
+
+// Allocate the id shared by the entries of one recurring booking
+func (q *Queries) NextRecurrenceGroupID(ctx context.Context) (int32, error) {
+	row := q.db.QueryRowContext(ctx, nextRecurrenceGroupID)
+	var recurrence_group_id int32
+	err := row.Scan(&recurrence_group_id)
+	return recurrence_group_id, err
+}
+
+const recurrenceGroupExists = `-- name: RecurrenceGroupExists :one
+SELECT EXISTS(SELECT 1 FROM resource_schedule WHERE recurrence_group_id = $1)
+`
+
+func (q *Queries) RecurrenceGroupExists(ctx context.Context, recurrenceGroupID sql.NullInt32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, recurrenceGroupExists, recurrenceGroupID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const setResourceTimezone = `-- name: SetResourceTimezone :one
+UPDATE resources
+SET timezone = $1, updated_at = NOW()
+WHERE id = $2
+RETURNING id, name, type, hourly_rate, is_available, notes, created_at, updated_at, release_grace_minutes, timezone
+`
+
+type SetResourceTimezoneParams struct {
+	Timezone sql.NullString `json:"timezone"`
+	ID       int32          `json:"id"`
+}
+
+func (q *Queries) SetResourceTimezone(ctx context.Context, arg SetResourceTimezoneParams) (Resource, error) {
+	row := q.db.QueryRowContext(ctx, setResourceTimezone, arg.Timezone, arg.ID)
+	var i Resource
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.HourlyRate,
+		&i.IsAvailable,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReleaseGraceMinutes,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const setResourcesAvailability = `-- name: SetResourcesAvailability :execrows
+UPDATE resources
+SET is_available = $1, updated_at = NOW()
+WHERE id = ANY($2::int[])
+`
+
+type SetResourcesAvailabilityParams struct {
+	IsAvailable bool    `json:"is_available"`
+	ResourceIds []int32 `json:"resource_ids"`
+}
+
+func (q *Queries) SetResourcesAvailability(ctx context.Context, arg SetResourcesAvailabilityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setResourcesAvailability, arg.IsAvailable, pq.Array(arg.ResourceIds))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const swapInvertedScheduleRanges = `-- name: SwapInvertedScheduleRanges :many
+UPDATE resource_schedule
+SET start_time = end_time, end_time = start_time, updated_at = NOW()
+WHERE end_time < start_time
+RETURNING id
+`
+
+// Swap the start and end of entries that end before they start. Zero-length
+// entries can't be fixed this way and are left alone.
+func (q *Queries) SwapInvertedScheduleRanges(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, swapInvertedScheduleRanges)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateResourceWorkingHours = `-- name: UpdateResourceWorkingHours :one
+UPDATE resource_working_hours
+SET day_of_week = $1, start_time = $2, end_time = $3, updated_at = NOW()
+WHERE id = $4 AND resource_id = $5
+RETURNING id, resource_id, day_of_week, start_time, end_time, created_at, updated_at
+`
+
+type UpdateResourceWorkingHoursParams struct {
+	DayOfWeek  int32  `json:"day_of_week"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	ID         int32  `json:"id"`
+	ResourceID int32  `json:"resource_id"`
+}
+
+func (q *Queries) UpdateResourceWorkingHours(ctx context.Context, arg UpdateResourceWorkingHoursParams) (ResourceWorkingHour, error) {
+	row := q.db.QueryRowContext(ctx, updateResourceWorkingHours,
+		arg.DayOfWeek,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ID,
+		arg.ResourceID,
+	)
+	var i ResourceWorkingHour
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.DayOfWeek,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateScheduleApprovalStatus = `-- name: UpdateScheduleApprovalStatus :one
+UPDATE resource_schedule
+SET approval_status = $1, updated_at = NOW()
+WHERE id = $2 AND approval_status = 'pending'
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+`
+
+type UpdateScheduleApprovalStatusParams struct {
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+	ID             int32          `json:"id"`
+}
+
+// Move a pending entry to approved or rejected. Returns no rows if the entry
+// doesn't exist or has already been decided.
+func (q *Queries) UpdateScheduleApprovalStatus(ctx context.Context, arg UpdateScheduleApprovalStatusParams) (ResourceSchedule, error) {
+	row := q.db.QueryRowContext(ctx, updateScheduleApprovalStatus, arg.ApprovalStatus, arg.ID)
+	var i ResourceSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.TaskID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+	)
+	return i, err
+}
+
+const updateScheduleEntry = `-- name: UpdateScheduleEntry :one
+UPDATE resource_schedule
+SET resource_id = $1,
+    event_id = $2,
+    task_id = $3,
+    start_time = $4,
+    end_time = $5,
+    notes = $6,
+    quantity = $7,
+    updated_at = NOW()
+WHERE id = $8
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+`
+
+type UpdateScheduleEntryParams struct {
+	ResourceID int32          `json:"resource_id"`
+	EventID    int32          `json:"event_id"`
+	TaskID     sql.NullInt32  `json:"task_id"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	Notes      sql.NullString `json:"notes"`
+	Quantity   int32          `json:"quantity"`
+	ID         int32          `json:"id"`
+}
+
+// Replace the editable fields of an entry. Approval and cancellation state are
+// left alone.
+func (q *Queries) UpdateScheduleEntry(ctx context.Context, arg UpdateScheduleEntryParams) (ResourceSchedule, error) {
+	row := q.db.QueryRowContext(ctx, updateScheduleEntry,
+		arg.ResourceID,
+		arg.EventID,
+		arg.TaskID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Notes,
+		arg.Quantity,
+		arg.ID,
+	)
+	var i ResourceSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.TaskID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+	)
+	return i, err
+}
+
+const updateScheduleEntryRange = `-- name: UpdateScheduleEntryRange :one
+UPDATE resource_schedule
+SET start_time = $1, end_time = $2, updated_at = NOW()
+WHERE id = $3
+RETURNING id, resource_id, event_id, task_id, start_time, end_time, notes, created_at, updated_at, approval_status, cancelled_at, cancellation_reason
+`
+
+type UpdateScheduleEntryRangeParams struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	ID        int32     `json:"id"`
+}
+
+func (q *Queries) UpdateScheduleEntryRange(ctx context.Context, arg UpdateScheduleEntryRangeParams) (ResourceSchedule, error) {
+	row := q.db.QueryRowContext(ctx, updateScheduleEntryRange, arg.StartTime, arg.EndTime, arg.ID)
+	var i ResourceSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.ResourceID,
+		&i.EventID,
+		&i.TaskID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ApprovalStatus,
+		&i.CancelledAt,
+		&i.CancellationReason,
+	)
+	return i, err
+}
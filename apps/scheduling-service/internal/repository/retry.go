@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// Environment variables that override how transient database errors are
+// retried: how many times, and the wait before the first retry as a Go
+// duration such as "100ms". The wait doubles with each further retry.
+const (
+	RetryMaxEnv     = "DB_RETRY_MAX"
+	RetryBackoffEnv = "DB_RETRY_BACKOFF"
+)
+
+// SQLSTATE codes for errors a retry on a fresh connection can survive
+const (
+	// pqAdminShutdown is sent to every session when the server shuts down,
+	// as during a failover
+	pqAdminShutdown = "57P01"
+	// pqConnectionExceptionClass covers connection failures such as 08006
+	pqConnectionExceptionClass = "08"
+)
+
+// RetryPolicy is how statements failing with a transient error are retried
+type RetryPolicy struct {
+	// MaxRetries is how many times a statement is retried after its first
+	// attempt; 0 disables retrying
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled for each after it
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy retries twice, after 100ms and then 200ms, which rides
+// out a typical failover without holding a request much longer
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	Backoff:    100 * time.Millisecond,
+}
+
+// retryPolicy is the policy WithRetry applies. It is replaced at most once,
+// at startup, before any request is served.
+var retryPolicy = DefaultRetryPolicy
+
+// LoadRetryPolicy applies DB_RETRY_MAX and DB_RETRY_BACKOFF if they are set.
+// Call it once at startup; an invalid value is returned as an error so the
+// service can refuse to start.
+func LoadRetryPolicy() error {
+	policy := DefaultRetryPolicy
+	if v, ok := os.LookupEnv(RetryMaxEnv); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 10 {
+			return fmt.Errorf("invalid %s: must be a whole number from 0 to 10", RetryMaxEnv)
+		}
+		policy.MaxRetries = n
+	}
+	if v, ok := os.LookupEnv(RetryBackoffEnv); ok {
+		backoff, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", RetryBackoffEnv, err)
+		}
+		if backoff <= 0 {
+			return fmt.Errorf("invalid %s: must be positive", RetryBackoffEnv)
+		}
+		policy.Backoff = backoff
+	}
+	retryPolicy = policy
+	return nil
+}
+
+// IsTransient reports whether err is a connection failure that a retry on
+// another connection could survive: a reset connection, a server shutting
+// down, or any other connection exception. Constraint violations, timeouts,
+// and cancellations are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqAdminShutdown || pqErr.Code.Class() == pqConnectionExceptionClass
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, driver.ErrBadConn)
+}
+
+// isUnsent reports whether err means the statement never reached the server,
+// so running it again can't apply it twice. database/sql and lib/pq return
+// driver.ErrBadConn only for a connection known to be broken before use.
+func isUnsent(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// isReadOnly reports whether query is a plain SELECT, after any leading
+// comments such as sqlc's "-- name:" line. Anything else, including a WITH
+// that may hold a data-modifying statement, is treated as a write.
+func isReadOnly(query string) bool {
+	q := strings.TrimSpace(query)
+	for strings.HasPrefix(q, "--") {
+		end := strings.IndexByte(q, '\n')
+		if end < 0 {
+			return false
+		}
+		q = strings.TrimSpace(q[end+1:])
+	}
+	words := strings.Fields(q)
+	return len(words) > 0 && strings.EqualFold(words[0], "SELECT")
+}
+
+// Do calls fn, retrying it with exponential backoff while it fails with a
+// transient error, up to MaxRetries times. It stops as soon as ctx ends,
+// returning the last error from fn. fn must be safe to run twice: a
+// connection lost after a write was sent says nothing of whether the write
+// was applied.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	return p.do(ctx, IsTransient, fn)
+}
+
+// do is Do, retrying while retryable reports true of the error from fn
+func (p RetryPolicy) do(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	backoff := p.Backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if attempt >= p.MaxRetries || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		logger.Get().WithRequestID(ctx).Warn().
+			Err(err).
+			Int("attempt", attempt+1).
+			Dur("backoff_ms", backoff).
+			Msg("Transient database error, retrying")
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// WithRetry wraps db so statements failing with a transient error are retried
+// under DB_RETRY_MAX and DB_RETRY_BACKOFF. Only reads are retried after any
+// transient error. Writes are not: an INSERT, UPDATE or DELETE whose
+// connection reset may already have been applied, and sending it again could
+// book twice. They are retried only when the driver reports driver.ErrBadConn,
+// meaning the statement was never sent. Use it only outside transactions: a
+// transaction whose connection failed is gone, and retrying one of its
+// statements on another connection would run it on its own.
+func WithRetry(db DBTX) DBTX {
+	return retryDBTX{db: db, policy: retryPolicy}
+}
+
+// retryDBTX is a DBTX that retries each statement under policy
+type retryDBTX struct {
+	db     DBTX
+	policy RetryPolicy
+}
+
+// retry runs fn, which sends query, under the policy: a read is retried after
+// any transient error, a write only if it was never sent
+func (r retryDBTX) retry(ctx context.Context, query string, fn func() error) error {
+	if isReadOnly(query) {
+		return r.policy.Do(ctx, fn)
+	}
+	return r.policy.do(ctx, isUnsent, fn)
+}
+
+func (r retryDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := r.retry(ctx, query, func() error {
+		var err error
+		result, err = r.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// PrepareContext retries after any transient error, since preparing a
+// statement doesn't run it
+func (r retryDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	var stmt *sql.Stmt
+	err := r.policy.Do(ctx, func() error {
+		var err error
+		stmt, err = r.db.PrepareContext(ctx, query)
+		return err
+	})
+	return stmt, err
+}
+
+func (r retryDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := r.retry(ctx, query, func() error {
+		var err error
+		rows, err = r.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRowContext retries on the row's error, which is known before it is
+// scanned. A failure while scanning isn't retried.
+func (r retryDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	_ = r.retry(ctx, query, func() error {
+		row = r.db.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyDriver is a database/sql driver whose statements fail with the queued
+// errors, one per statement, and succeed once the queue is empty. Queries
+// return a single row holding 1.
+type flakyDriver struct {
+	mu    sync.Mutex
+	fails []error
+	calls int
+}
+
+func (d *flakyDriver) next() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls++
+	if len(d.fails) == 0 {
+		return nil
+	}
+	err := d.fails[0]
+	d.fails = d.fails[1:]
+	return err
+}
+
+func (d *flakyDriver) Open(string) (driver.Conn, error) { return flakyConn{d}, nil }
+
+type flakyConn struct{ d *flakyDriver }
+
+func (c flakyConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c flakyConn) Close() error                        { return nil }
+func (c flakyConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c flakyConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	if err := c.d.next(); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c flakyConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if err := c.d.next(); err != nil {
+		return nil, err
+	}
+	return &oneRow{}, nil
+}
+
+// oneRow is a result of one row with one column holding 1
+type oneRow struct{ done bool }
+
+func (r *oneRow) Columns() []string { return []string{"n"} }
+func (r *oneRow) Close() error      { return nil }
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+var flakyDriverCount int
+
+// openFlaky opens a pool on a new flakyDriver failing with fails
+func openFlaky(t *testing.T, fails ...error) (*sql.DB, *flakyDriver) {
+	t.Helper()
+	d := &flakyDriver{fails: fails}
+	flakyDriverCount++
+	name := fmt.Sprintf("flaky%d", flakyDriverCount)
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+var testRetryPolicy = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+func TestRetry_FailsOnceThenSucceeds(t *testing.T) {
+	db, d := openFlaky(t, &pq.Error{Code: "57P01"})
+
+	rows, err := retryDBTX{db: db, policy: testRetryPolicy}.QueryContext(context.Background(), "-- name: ListResources :many\nSELECT id FROM resources")
+	require.NoError(t, err)
+	defer rows.Close()
+	assert.True(t, rows.Next())
+	assert.Equal(t, 2, d.calls)
+}
+
+func TestRetry_WritesNotResentAfterTransientError(t *testing.T) {
+	reset := fmt.Errorf("read: %w", syscall.ECONNRESET)
+	for _, fail := range []error{reset, &pq.Error{Code: "57P01"}, &pq.Error{Code: "08006"}} {
+		t.Run(fmt.Sprint(fail), func(t *testing.T) {
+			retry := retryDBTX{db: nil, policy: testRetryPolicy}
+
+			db, d := openFlaky(t, fail)
+			retry.db = db
+			_, err := retry.ExecContext(context.Background(), "UPDATE resources SET is_available = true")
+			assert.ErrorIs(t, err, fail)
+			assert.Equal(t, 1, d.calls, "exec")
+
+			db, d = openFlaky(t, fail)
+			retry.db = db
+			err = retry.QueryRowContext(context.Background(), "-- name: CreateEntry :one\nINSERT INTO resource_schedule DEFAULT VALUES RETURNING id").Err()
+			assert.ErrorIs(t, err, fail)
+			assert.Equal(t, 1, d.calls, "query row")
+
+			db, d = openFlaky(t, fail)
+			retry.db = db
+			_, err = retry.QueryContext(context.Background(), "WITH moved AS (DELETE FROM resource_schedule RETURNING id) SELECT id FROM moved")
+			assert.ErrorIs(t, err, fail)
+			assert.Equal(t, 1, d.calls, "query")
+		})
+	}
+}
+
+func TestRetry_WriteRetriedWhenNeverSent(t *testing.T) {
+	// database/sql tries a bad connection a few times itself before
+	// returning driver.ErrBadConn, so queue enough to get past it
+	db, d := openFlaky(t, driver.ErrBadConn, driver.ErrBadConn, driver.ErrBadConn)
+
+	_, err := retryDBTX{db: db, policy: testRetryPolicy}.ExecContext(context.Background(), "INSERT INTO resources DEFAULT VALUES")
+	require.NoError(t, err)
+	assert.Empty(t, d.fails)
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"SELECT 1", true},
+		{"  select id FROM resources", true},
+		{"-- name: GetResourceByID :one\nSELECT id FROM resources WHERE id = $1", true},
+		{"SELECT\n  id\nFROM resources", true},
+		{"INSERT INTO resources DEFAULT VALUES", false},
+		{"-- name: UpdateResource :one\nUPDATE resources SET name = $2 RETURNING id", false},
+		{"DELETE FROM resource_schedule", false},
+		{"WITH moved AS (DELETE FROM resource_schedule RETURNING id) SELECT id FROM moved", false},
+		{"-- only a comment", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isReadOnly(tt.query), tt.query)
+	}
+}
+
+func TestRetry_QueryRow(t *testing.T) {
+	db, d := openFlaky(t, &pq.Error{Code: "08006"})
+
+	var n int
+	err := retryDBTX{db: db, policy: testRetryPolicy}.QueryRowContext(context.Background(), "SELECT 1").Scan(&n)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, d.calls)
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	reset := fmt.Errorf("read: %w", syscall.ECONNRESET)
+	db, d := openFlaky(t, reset, reset, reset, reset)
+
+	_, err := retryDBTX{db: db, policy: testRetryPolicy}.QueryContext(context.Background(), "SELECT 1")
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+	assert.Equal(t, 3, d.calls, "the first attempt and two retries")
+}
+
+func TestRetry_NotRetried(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"unique violation", &pq.Error{Code: "23505"}},
+		{"exclusion violation", &pq.Error{Code: "23P01"}},
+		{"query canceled", &pq.Error{Code: "57014"}},
+		{"deadline exceeded", context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, d := openFlaky(t, tt.err)
+
+			_, err := retryDBTX{db: db, policy: testRetryPolicy}.ExecContext(context.Background(), "INSERT INTO resources DEFAULT VALUES")
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, 1, d.calls)
+		})
+	}
+}
+
+func TestRetry_StopsWhenContextEnds(t *testing.T) {
+	db, d := openFlaky(t, &pq.Error{Code: "57P01"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := retryDBTX{db: db, policy: testRetryPolicy}.QueryContext(ctx, "SELECT 1")
+	assert.Error(t, err)
+	assert.Zero(t, d.calls, "a cancelled context never reaches the driver")
+}
+
+func TestLoadRetryPolicy(t *testing.T) {
+	// Unset for the default, restored when the test ends
+	t.Setenv(RetryMaxEnv, "")
+	t.Setenv(RetryBackoffEnv, "")
+	require.NoError(t, os.Unsetenv(RetryMaxEnv))
+	require.NoError(t, os.Unsetenv(RetryBackoffEnv))
+	t.Cleanup(func() { retryPolicy = DefaultRetryPolicy })
+
+	require.NoError(t, LoadRetryPolicy())
+	assert.Equal(t, DefaultRetryPolicy, retryPolicy)
+
+	t.Setenv(RetryMaxEnv, "0")
+	t.Setenv(RetryBackoffEnv, "250ms")
+	require.NoError(t, LoadRetryPolicy())
+	assert.Equal(t, RetryPolicy{MaxRetries: 0, Backoff: 250 * time.Millisecond}, retryPolicy)
+
+	t.Setenv(RetryBackoffEnv, "250ms")
+	for _, v := range []string{"-1", "11", "twice"} {
+		t.Setenv(RetryMaxEnv, v)
+		assert.Error(t, LoadRetryPolicy(), v)
+	}
+	t.Setenv(RetryMaxEnv, "2")
+	for _, v := range []string{"100", "0s", "-1s"} {
+		t.Setenv(RetryBackoffEnv, v)
+		assert.Error(t, LoadRetryPolicy(), v)
+	}
+}
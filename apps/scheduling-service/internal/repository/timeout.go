@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+// QueryTimeoutEnv names the environment variable that overrides how long a
+// single statement may run, as a Go duration such as "10s"
+const QueryTimeoutEnv = "QUERY_TIMEOUT"
+
+// DefaultQueryTimeout is how long a single statement may run when
+// QUERY_TIMEOUT is unset
+const DefaultQueryTimeout = 5 * time.Second
+
+// queryTimeout bounds each statement run through WithQueryTimeout. It is
+// replaced at most once, at startup, before any request is served.
+var queryTimeout = DefaultQueryTimeout
+
+// LoadQueryTimeout applies QUERY_TIMEOUT if it is set. Call it once at
+// startup; an invalid value is returned as an error so the service can refuse
+// to start.
+func LoadQueryTimeout() error {
+	v, ok := os.LookupEnv(QueryTimeoutEnv)
+	if !ok {
+		return nil
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", QueryTimeoutEnv, err)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("invalid %s: must be positive", QueryTimeoutEnv)
+	}
+	queryTimeout = timeout
+	return nil
+}
+
+// WithQueryTimeout wraps db so every statement run through it is given a
+// deadline of QUERY_TIMEOUT, on top of any the caller's context already has.
+// Cancelling the caller's context still aborts the statement straight away.
+// A statement cut off by the deadline fails with context.DeadlineExceeded or
+// Postgres's query_canceled, both of which MapError reports as a timeout.
+func WithQueryTimeout(db DBTX) DBTX {
+	return timeoutDBTX{db: db, timeout: queryTimeout}
+}
+
+// timeoutDBTX is a DBTX that bounds each statement by timeout
+type timeoutDBTX struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func (t timeoutDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.db.ExecContext(ctx, query, args...)
+}
+
+func (t timeoutDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.db.PrepareContext(ctx, query)
+}
+
+func (t timeoutDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.db.QueryContext(t.readContext(ctx), query, args...)
+}
+
+func (t timeoutDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.db.QueryRowContext(t.readContext(ctx), query, args...)
+}
+
+// readContext gives a query its deadline. The caller reads the results after
+// the query returns, so reading counts toward the timeout and the context
+// can't be cancelled on return; instead it is released once the deadline
+// passes or the caller's context ends.
+func (t timeoutDBTX) readContext(ctx context.Context) context.Context {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	context.AfterFunc(ctx, cancel)
+	return ctx
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestLoadQueryTimeout(t *testing.T) {
+	// Unset for the default, restored when the test ends
+	t.Setenv(QueryTimeoutEnv, "")
+	require.NoError(t, os.Unsetenv(QueryTimeoutEnv))
+	t.Cleanup(func() { queryTimeout = DefaultQueryTimeout })
+
+	require.NoError(t, LoadQueryTimeout())
+	assert.Equal(t, DefaultQueryTimeout, queryTimeout)
+
+	t.Setenv(QueryTimeoutEnv, "750ms")
+	require.NoError(t, LoadQueryTimeout())
+	assert.Equal(t, 750*time.Millisecond, queryTimeout)
+
+	for _, v := range []string{"5", "soon", "0s", "-1s"} {
+		t.Setenv(QueryTimeoutEnv, v)
+		assert.Error(t, LoadQueryTimeout(), v)
+	}
+	assert.Equal(t, 750*time.Millisecond, queryTimeout, "an invalid value changes nothing")
+}
+
+func TestWithQueryTimeout_AbortsSlowQuery(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	db := timeoutDBTX{db: testDB.DB, timeout: 200 * time.Millisecond}
+
+	start := time.Now()
+	_, err := db.ExecContext(context.Background(), "SELECT pg_sleep(5)")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.ErrorIs(t, MapError(err), &domain.DomainError{Code: domain.ErrCodeTimeout})
+
+	start = time.Now()
+	var slept int
+	err = db.QueryRowContext(context.Background(), "SELECT 1 FROM pg_sleep(5)").Scan(&slept)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.ErrorIs(t, MapError(err), &domain.DomainError{Code: domain.ErrCodeTimeout})
+
+	// A query inside the timeout is unaffected, and so is the pool
+	var one int
+	require.NoError(t, db.QueryRowContext(context.Background(), "SELECT 1").Scan(&one))
+	assert.Equal(t, 1, one)
+}
+
+func TestWithQueryTimeout_ParentCancellation(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	db := timeoutDBTX{db: testDB.DB, timeout: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := db.ExecContext(ctx, "SELECT pg_sleep(5)")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second, "the caller's cancellation aborts the query before the timeout")
+}
+
+func TestWithQueryTimeout_UsesConfiguredTimeout(t *testing.T) {
+	t.Cleanup(func() { queryTimeout = DefaultQueryTimeout })
+	queryTimeout = 3 * time.Second
+
+	wrapped, ok := WithQueryTimeout(nil).(timeoutDBTX)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, wrapped.timeout)
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxAlternativeResources caps how many alternatives one suggestion returns
+const maxAlternativeResources = 100
+
+// SuggestAlternativeResources returns the available resources of the same
+// type as the conflicted one that have no booking overlapping [start, end),
+// ordered by name. Release grace counts as booked, and pending bookings count
+// as well, so a suggestion never lands on a warning. The conflicted resource
+// itself is never suggested.
+func (s *ConflictService) SuggestAlternativeResources(ctx context.Context, conflictedResourceID int32, start, end time.Time) ([]domain.Resource, error) {
+	if !end.After(start) {
+		return nil, domain.NewValidationError("end_time must be after start_time")
+	}
+
+	conflicted, err := s.queries.GetResourceByID(ctx, conflictedResourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	rows, err := s.queries.ListFreeAlternativeResources(ctx, repository.ListFreeAlternativeResourcesParams{
+		Type:       conflicted.Type,
+		ExcludeID:  conflictedResourceID,
+		EndTime:    end,
+		StartTime:  start,
+		LimitCount: maxAlternativeResources,
+	})
+	if err != nil {
+		return nil, dbError("failed to list alternative resources", err)
+	}
+
+	alternatives := make([]domain.Resource, 0, len(rows))
+	for _, row := range rows {
+		alternatives = append(alternatives, toDomainResource(row))
+	}
+	return alternatives, nil
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestSuggestAlternativeResources_FreeStaffOnly(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	staff := func(name string, available bool) int32 {
+		return testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+			Name:        name,
+			Type:        testutil.ResourceTypeStaff,
+			IsAvailable: available,
+		})
+	}
+	requested := staff("Ana", true)
+	busy := staff("Ben", true)
+	free := staff("Cleo", true)
+	staff("Dev", false)
+	afterGrace := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:                "Eli",
+		Type:                testutil.ResourceTypeStaff,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+	backToBack := staff("Fay", true)
+	// Free, but not staff
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	start, end := day.Add(10*time.Hour), day.Add(12*time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, requested, eventID, start, end, nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, busy, eventID,
+		day.Add(11*time.Hour), day.Add(13*time.Hour), nil)
+	// Ends 15 minutes before the window, but its grace runs into it
+	testutil.CreateScheduleEntry(t, testDB.DB, afterGrace, eventID,
+		day.Add(8*time.Hour), day.Add(9*time.Hour+45*time.Minute), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, backToBack, eventID,
+		day.Add(8*time.Hour), start, nil)
+	// Cancelled bookings don't count
+	cancelledAt := day
+	testutil.CreateScheduleEntry(t, testDB.DB, free, eventID, start, end,
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	alternatives, err := service.SuggestAlternativeResources(context.Background(), requested, start, end)
+
+	require.NoError(t, err)
+	ids := make([]int32, 0, len(alternatives))
+	for _, r := range alternatives {
+		ids = append(ids, r.ID)
+		assert.Equal(t, domain.ResourceTypeStaff, r.Type)
+	}
+	assert.Equal(t, []int32{free, backToBack}, ids)
+}
+
+func TestSuggestAlternativeResources_Errors(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.SuggestAlternativeResources(context.Background(), 99999, day, day.Add(time.Hour))
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+
+	_, err = service.SuggestAlternativeResources(context.Background(), 1, day, day)
+	domainErr, ok = err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
@@ -0,0 +1,190 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxAssignTasks caps how many tasks a single assignment request may contain
+const maxAssignTasks = 200
+
+// AssignmentService matches batches of tasks to free staff
+type AssignmentService struct {
+	queries      *repository.Queries
+	availability *AvailabilityService
+}
+
+// NewAssignmentService creates a new task assignment service finding free
+// staff through availability
+func NewAssignmentService(db *sql.DB, availability *AvailabilityService) *AssignmentService {
+	return &AssignmentService{
+		queries:      repository.NewQueries(db),
+		availability: availability,
+	}
+}
+
+// AssignTasks assigns each task to a distinct available staff member who is
+// free for the task's whole window. The batch is treated as concurrent work, so
+// no staff member receives more than one task. The assignment covers as many
+// tasks as possible and, among those, minimizes total cost; staff without an
+// hourly rate are only used once priced staff are exhausted.
+func (s *AssignmentService) AssignTasks(ctx context.Context, req domain.AssignTasksRequest) (*domain.AssignTasksResponse, error) {
+	if err := validateTaskWindows(req.Tasks); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListResources(ctx, repository.ListResourcesParams{
+		Type:        repository.NullResourceType{ResourceType: repository.ResourceTypeStaff, Valid: true},
+		IsAvailable: sql.NullBool{Bool: true, Valid: true},
+		LimitCount:  maxSoonestCandidates,
+	})
+	if err != nil {
+		return nil, dbError("failed to list resources", err)
+	}
+
+	ids := make([]int32, 0, len(rows))
+	rates := make([]*int64, len(rows))
+	for j, row := range rows {
+		ids = append(ids, row.ID)
+		if row.HourlyRate.Valid {
+			cents, err := parseCents(row.HourlyRate.String)
+			if err != nil {
+				return nil, domain.NewInternalError("invalid hourly rate", err)
+			}
+			rates[j] = &cents
+		}
+	}
+
+	start, end := req.Tasks[0].StartTime, req.Tasks[0].EndTime
+	for _, task := range req.Tasks[1:] {
+		if task.StartTime.Before(start) {
+			start = task.StartTime
+		}
+		if task.EndTime.After(end) {
+			end = task.EndTime
+		}
+	}
+	busy := map[int32][]domain.TimeRange{}
+	if len(ids) > 0 {
+		busy, err = s.availability.loadBusy(ctx, ids, start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Price every feasible task/staff pair; unpriced staff rank behind the
+	// most expensive priced pair
+	costs := make([][]int64, len(req.Tasks))
+	var maxPriced int64
+	for i, task := range req.Tasks {
+		costs[i] = make([]int64, len(rows))
+		seconds := int64(task.EndTime.Sub(task.StartTime) / time.Second)
+		window := domain.TimeRange{Start: task.StartTime, End: task.EndTime}
+		for j, row := range rows {
+			switch {
+			case !isFree(busy[row.ID], window):
+				costs[i][j] = noEdge
+			case rates[j] == nil:
+				costs[i][j] = 0
+			default:
+				costs[i][j] = costCents(*rates[j], seconds)
+				if costs[i][j] > maxPriced {
+					maxPriced = costs[i][j]
+				}
+			}
+		}
+	}
+	for i := range costs {
+		for j := range costs[i] {
+			if rates[j] == nil && costs[i][j] != noEdge {
+				costs[i][j] = maxPriced + 1
+			}
+		}
+	}
+
+	resp := &domain.AssignTasksResponse{
+		Assignments: []domain.TaskAssignment{},
+		Unassigned:  []domain.UnassignedTask{},
+	}
+	var totalCents int64
+	for i, j := range minCostMatching(costs) {
+		task := req.Tasks[i]
+		if j < 0 {
+			resp.Unassigned = append(resp.Unassigned, domain.UnassignedTask{
+				TaskID: task.TaskID,
+				Reason: unassignedReason(costs[i]),
+			})
+			continue
+		}
+
+		row := rows[j]
+		assignment := domain.TaskAssignment{
+			TaskID:       task.TaskID,
+			ResourceID:   row.ID,
+			ResourceName: row.Name,
+		}
+		if rates[j] != nil {
+			cents := costs[i][j]
+			rate := row.HourlyRate.String
+			cost := formatCents(cents)
+			assignment.HourlyRate = &rate
+			assignment.Cost = &cost
+			totalCents += cents
+		}
+		resp.Assignments = append(resp.Assignments, assignment)
+	}
+	resp.TotalCost = formatCents(totalCents)
+
+	return resp, nil
+}
+
+// validateTaskWindows checks an assignment batch is non-empty, bounded, and
+// made of distinct tasks with well-formed windows
+func validateTaskWindows(tasks []domain.TaskWindow) error {
+	if len(tasks) == 0 {
+		return domain.NewValidationError("at least one task is required")
+	}
+	if len(tasks) > maxAssignTasks {
+		return domain.NewValidationError(fmt.Sprintf("at most %d tasks may be assigned at once", maxAssignTasks))
+	}
+
+	seen := make(map[int32]bool, len(tasks))
+	for _, task := range tasks {
+		if task.TaskID <= 0 {
+			return domain.NewValidationError("task_id must be a positive integer")
+		}
+		if seen[task.TaskID] {
+			return domain.NewValidationError(fmt.Sprintf("task %d is listed more than once", task.TaskID))
+		}
+		seen[task.TaskID] = true
+		if !task.EndTime.After(task.StartTime) {
+			return domain.NewValidationError(fmt.Sprintf("task %d: end_time must be after start_time", task.TaskID))
+		}
+	}
+	return nil
+}
+
+// isFree reports whether none of the merged busy ranges overlap the window
+func isFree(busy []domain.TimeRange, window domain.TimeRange) bool {
+	for _, b := range busy {
+		if overlapDuration(b, window) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// unassignedReason explains why a task's row of the cost matrix went unmatched
+func unassignedReason(row []int64) string {
+	for _, c := range row {
+		if c != noEdge {
+			return "all staff free for this window are assigned to other tasks"
+		}
+	}
+	return "no available staff is free for this window"
+}
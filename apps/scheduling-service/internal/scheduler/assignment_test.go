@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestAssignTasks_FeasiblePrefersCheaperStaff(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	cheapChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Cheap Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("20.00"),
+		IsAvailable: true,
+	})
+	midChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Mid Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("35.00"),
+		IsAvailable: true,
+	})
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Pricey Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("80.00"),
+		IsAvailable: true,
+	})
+	busyChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Busy Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("10.00"),
+		IsAvailable: true,
+	})
+
+	start := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, busyChef, eventID, start.Add(-time.Hour), start.Add(time.Hour), nil)
+
+	service := NewAssignmentService(testDB.DB, NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)))
+
+	result, err := service.AssignTasks(context.Background(), domain.AssignTasksRequest{
+		Tasks: []domain.TaskWindow{
+			{TaskID: 1, StartTime: start, EndTime: end},
+			{TaskID: 2, StartTime: start, EndTime: end},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Unassigned)
+	require.Len(t, result.Assignments, 2)
+
+	assigned := []int32{result.Assignments[0].ResourceID, result.Assignments[1].ResourceID}
+	assert.ElementsMatch(t, []int32{cheapChef, midChef}, assigned)
+	// 2h at 20.00 + 2h at 35.00
+	assert.Equal(t, "110.00", result.TotalCost)
+}
+
+func TestAssignTasks_TooFewStaff(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Only Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("30.00"),
+		IsAvailable: true,
+	})
+	// Equipment never counts as staff
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	start := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	service := NewAssignmentService(testDB.DB, NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)))
+
+	result, err := service.AssignTasks(context.Background(), domain.AssignTasksRequest{
+		Tasks: []domain.TaskWindow{
+			{TaskID: 1, StartTime: start, EndTime: start.Add(time.Hour)},
+			{TaskID: 2, StartTime: start, EndTime: start.Add(time.Hour)},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Assignments, 1)
+	assert.Equal(t, chef, result.Assignments[0].ResourceID)
+	require.Len(t, result.Unassigned, 1)
+	assert.NotEqual(t, result.Assignments[0].TaskID, result.Unassigned[0].TaskID)
+	assert.Contains(t, result.Unassigned[0].Reason, "assigned to other tasks")
+}
+
+func TestAssignTasks_Validation(t *testing.T) {
+	service := &AssignmentService{}
+	start := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		tasks []domain.TaskWindow
+	}{
+		{name: "empty batch", tasks: nil},
+		{name: "inverted window", tasks: []domain.TaskWindow{{TaskID: 1, StartTime: start, EndTime: start}}},
+		{name: "duplicate task", tasks: []domain.TaskWindow{
+			{TaskID: 1, StartTime: start, EndTime: start.Add(time.Hour)},
+			{TaskID: 1, StartTime: start, EndTime: start.Add(time.Hour)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AssignTasks(context.Background(), domain.AssignTasksRequest{Tasks: tt.tasks})
+
+			var domainErr *domain.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+		})
+	}
+}
@@ -3,6 +3,9 @@ package scheduler
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/catering-event-manager/scheduling-service/internal/domain"
 	"github.com/catering-event-manager/scheduling-service/internal/repository"
@@ -11,63 +14,156 @@ import (
 // AvailabilityService handles resource availability queries
 type AvailabilityService struct {
 	queries *repository.Queries
+	flags   *FlagService
 }
 
-// NewAvailabilityService creates a new availability service
-func NewAvailabilityService(db *sql.DB) *AvailabilityService {
+// NewAvailabilityService creates a new availability service reading feature
+// flags from flags
+func NewAvailabilityService(db *sql.DB, flags *FlagService) *AvailabilityService {
 	return &AvailabilityService{
-		queries: repository.New(db),
+		queries: repository.NewQueries(db),
+		flags:   flags,
 	}
 }
 
-// GetResourceAvailability returns all schedule entries for a resource within the given date range
+// GetResourceAvailability returns one page of the schedule entries for a
+// resource within the given date range, in start time order. The downtime and
+// capacity timeline always cover the whole range.
 func (s *AvailabilityService) GetResourceAvailability(ctx context.Context, req domain.ResourceAvailabilityRequest) (*domain.ResourceAvailabilityResponse, error) {
 	// Validate request
 	if req.EndDate.Before(req.StartDate) {
 		return nil, domain.NewValidationError("end_date must be after start_date")
 	}
 
-	// Query schedule entries
-	rows, err := s.queries.GetResourceSchedule(ctx, repository.GetResourceScheduleParams{
+	resp, err := s.availabilityPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.CapacityTimeline, err = s.capacityTimeline(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	downRows, err := s.queries.ListResourceDowntime(ctx, repository.ListResourceDowntimeParams{
 		ResourceID: req.ResourceID,
 		StartTime:  req.StartDate,
 		EndTime:    req.EndDate,
 	})
 	if err != nil {
-		return nil, domain.NewInternalError("failed to get resource schedule", err)
+		return nil, dbError("failed to get resource downtime", err)
+	}
+	resp.Downtime = make([]domain.Downtime, 0, len(downRows))
+	for _, row := range downRows {
+		resp.Downtime = append(resp.Downtime, toDomainDowntime(row))
 	}
 
-	// Convert rows to domain entries
-	entries := make([]domain.ScheduleEntry, 0, len(rows))
-	for _, row := range rows {
-		entry := domain.ScheduleEntry{
-			ID:         row.ID,
-			ResourceID: row.ResourceID,
-			EventID:    row.EventID,
-			EventName:  row.EventName,
-			StartTime:  row.StartTime,
-			EndTime:    row.EndTime,
-			CreatedAt:  row.CreatedAt,
-			UpdatedAt:  row.UpdatedAt,
+	if req.UseResourceTZ {
+		loc, err := s.resourceLocation(ctx, req.ResourceID)
+		if err != nil {
+			return nil, err
 		}
-
-		if row.TaskID.Valid {
-			entry.TaskID = &row.TaskID.Int32
+		for i := range resp.Entries {
+			resp.Entries[i].StartTime = resp.Entries[i].StartTime.In(loc)
+			resp.Entries[i].EndTime = resp.Entries[i].EndTime.In(loc)
 		}
-		if row.TaskTitle.Valid {
-			entry.TaskTitle = &row.TaskTitle.String
+		for i := range resp.Downtime {
+			resp.Downtime[i].StartTime = resp.Downtime[i].StartTime.In(loc)
+			resp.Downtime[i].EndTime = resp.Downtime[i].EndTime.In(loc)
 		}
-		if row.Notes.Valid {
-			entry.Notes = &row.Notes.String
+		for i := range resp.CapacityTimeline {
+			r := &resp.CapacityTimeline[i].Range
+			r.Start, r.End = r.Start.In(loc), r.End.In(loc)
 		}
+		resp.Timezone = loc.String()
+	}
+
+	return resp, nil
+}
 
-		entries = append(entries, entry)
+// GetMultiResourceAvailability returns the schedule entries of several
+// resources within the date range, loaded with one query. Every requested
+// resource has a key in the result, with an empty list if it has no entries.
+func (s *AvailabilityService) GetMultiResourceAvailability(ctx context.Context, req domain.MultiResourceAvailabilityRequest) (map[int32][]domain.ScheduleEntry, error) {
+	if len(req.ResourceIDs) == 0 {
+		return nil, domain.NewValidationError("resource_ids must not be empty")
+	}
+	if len(req.ResourceIDs) > maxBulkResources {
+		return nil, domain.NewValidationError("too many resource_ids in a single request")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
 	}
 
-	return &domain.ResourceAvailabilityResponse{
-		ResourceID: req.ResourceID,
-		Entries:    entries,
-	}, nil
+	rows, err := s.queries.GetMultiResourceSchedule(ctx, repository.GetMultiResourceScheduleParams{
+		ResourceIds: req.ResourceIDs,
+		StartTime:   req.StartDate,
+		EndTime:     req.EndDate,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedules", err)
+	}
+
+	entries := make(map[int32][]domain.ScheduleEntry, len(req.ResourceIDs))
+	for _, id := range req.ResourceIDs {
+		entries[id] = []domain.ScheduleEntry{}
+	}
+	for _, row := range rows {
+		entries[row.ResourceID] = append(entries[row.ResourceID], scheduleEntryFromRow(repository.GetResourceScheduleRow(row)))
+	}
+	return entries, nil
+}
+
+// scheduleEntryFromRow converts a resource schedule row to a domain entry
+func scheduleEntryFromRow(row repository.GetResourceScheduleRow) domain.ScheduleEntry {
+	entry := domain.ScheduleEntry{
+		ID:             row.ID,
+		ResourceID:     row.ResourceID,
+		EventID:        row.EventID,
+		EventName:      row.EventName,
+		StartTime:      row.StartTime,
+		EndTime:        row.EndTime,
+		ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+
+	if row.TaskID.Valid {
+		entry.TaskID = &row.TaskID.Int32
+	}
+	if row.TaskTitle.Valid {
+		entry.TaskTitle = &row.TaskTitle.String
+	}
+	if row.Notes.Valid {
+		entry.Notes = &row.Notes.String
+	}
+	return entry
+}
+
+// resourceLocation loads the resource's operating timezone, defaulting to UTC
+// when it has none
+func (s *AvailabilityService) resourceLocation(ctx context.Context, resourceID int32) (*time.Location, error) {
+	resource, err := s.GetResourceByID(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return resourceZone(resource)
+}
+
+// resourceZone returns a loaded resource's operating timezone, defaulting to
+// UTC when it has none
+func resourceZone(resource *domain.Resource) (*time.Location, error) {
+	if resource.Timezone == nil {
+		return time.UTC, nil
+	}
+
+	// A stored zone was validated when it was set, so failing here means the
+	// data is bad rather than the request
+	loc, zoneErr := parseTimezone(*resource.Timezone)
+	if zoneErr != nil {
+		return nil, domain.NewInternalError("resource has an invalid timezone", zoneErr)
+	}
+	return loc, nil
 }
 
 // GetResourceByID retrieves a resource by its ID
@@ -77,16 +173,109 @@ func (s *AvailabilityService) GetResourceByID(ctx context.Context, id int32) (*d
 		if err == sql.ErrNoRows {
 			return nil, domain.NewNotFoundError("resource not found")
 		}
-		return nil, domain.NewInternalError("failed to get resource", err)
+		return nil, dbError("failed to get resource", err)
+	}
+
+	resource := toDomainResource(row)
+	return &resource, nil
+}
+
+// GetBookingsAround returns the resource's booking that most recently ended at
+// or before at, and the next booking starting at or after it. A booking in
+// progress at that moment is neither.
+func (s *AvailabilityService) GetBookingsAround(ctx context.Context, resourceID int32, at time.Time) (*domain.BookingsAroundResponse, error) {
+	if _, err := s.GetResourceByID(ctx, resourceID); err != nil {
+		return nil, err
 	}
 
-	resource := &domain.Resource{
-		ID:          row.ID,
-		Name:        row.Name,
-		Type:        domain.ResourceType(row.Type),
-		IsAvailable: row.IsAvailable,
-		CreatedAt:   row.CreatedAt,
-		UpdatedAt:   row.UpdatedAt,
+	resp := &domain.BookingsAroundResponse{
+		ResourceID: resourceID,
+		At:         at,
+	}
+
+	prev, err := s.queries.GetPreviousScheduleEntry(ctx, repository.GetPreviousScheduleEntryParams{
+		ResourceID: resourceID,
+		At:         at,
+	})
+	switch {
+	case err == nil:
+		entry := toDomainScheduleEntry(repository.GetScheduleEntryByIDRow(prev))
+		resp.Previous = &entry
+	case err != sql.ErrNoRows:
+		return nil, dbError("failed to get previous booking", err)
+	}
+
+	next, err := s.queries.GetNextScheduleEntry(ctx, repository.GetNextScheduleEntryParams{
+		ResourceID: resourceID,
+		At:         at,
+	})
+	switch {
+	case err == nil:
+		entry := toDomainScheduleEntry(repository.GetScheduleEntryByIDRow(next))
+		resp.Next = &entry
+	case err != sql.ErrNoRows:
+		return nil, dbError("failed to get next booking", err)
+	}
+
+	return resp, nil
+}
+
+// GetActiveBookings lists the bookings in progress at req.At across all
+// resources. A booking is in progress from its start up to, but not including,
+// its end.
+func (s *AvailabilityService) GetActiveBookings(ctx context.Context, req domain.ActiveBookingsRequest) (*domain.ActiveBookingsResponse, error) {
+	params := repository.ListActiveBookingsParams{At: req.At}
+	if req.ResourceType != nil {
+		if !req.ResourceType.IsValid() {
+			return nil, domain.NewValidationError("type must be one of staff, equipment, materials")
+		}
+		params.ResourceType = repository.NullResourceType{ResourceType: repository.ResourceType(*req.ResourceType), Valid: true}
+	}
+
+	rows, err := s.queries.ListActiveBookings(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to list active bookings", err)
+	}
+
+	bookings := make([]domain.ActiveBooking, 0, len(rows))
+	for _, row := range rows {
+		booking := domain.ActiveBooking{
+			ScheduleID:     row.ID,
+			ResourceID:     row.ResourceID,
+			ResourceName:   row.ResourceName,
+			ResourceType:   domain.ResourceType(row.ResourceType),
+			EventID:        row.EventID,
+			EventName:      row.EventName,
+			StartTime:      row.StartTime,
+			EndTime:        row.EndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+		}
+		if row.TaskID.Valid {
+			booking.TaskID = &row.TaskID.Int32
+		}
+		if row.TaskTitle.Valid {
+			booking.TaskTitle = &row.TaskTitle.String
+		}
+		bookings = append(bookings, booking)
+	}
+
+	return &domain.ActiveBookingsResponse{
+		At:           req.At,
+		ResourceType: req.ResourceType,
+		Bookings:     bookings,
+	}, nil
+}
+
+// toDomainResource converts a resource row to its domain representation
+func toDomainResource(row repository.Resource) domain.Resource {
+	resource := domain.Resource{
+		ID:                  row.ID,
+		Name:                row.Name,
+		Type:                domain.ResourceType(row.Type),
+		IsAvailable:         row.IsAvailable,
+		ReleaseGraceMinutes: row.ReleaseGraceMinutes,
+		CreatedAt:           row.CreatedAt,
+		UpdatedAt:           row.UpdatedAt,
 	}
 
 	if row.HourlyRate.Valid {
@@ -95,6 +284,265 @@ func (s *AvailabilityService) GetResourceByID(ctx context.Context, id int32) (*d
 	if row.Notes.Valid {
 		resource.Notes = &row.Notes.String
 	}
+	if row.Timezone.Valid {
+		resource.Timezone = &row.Timezone.String
+	}
+
+	return resource
+}
+
+// maxSummaryBuckets caps how many buckets a single summary request may produce
+const maxSummaryBuckets = 1000
+
+// GetAvailabilitySummary returns the busy fraction of a resource per bucket of the
+// requested granularity. Buckets are aligned to UTC hour, half-day (00:00/12:00)
+// or day boundaries and clipped to the requested range; bookings spanning a
+// boundary contribute their minutes to each bucket they touch.
+func (s *AvailabilityService) GetAvailabilitySummary(ctx context.Context, req domain.AvailabilitySummaryRequest) (*domain.AvailabilitySummaryResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+
+	step, err := granularityStep(req.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	if req.EndDate.Sub(req.StartDate)/step >= maxSummaryBuckets {
+		return nil, domain.NewValidationError(fmt.Sprintf("date range produces more than %d buckets; use a coarser granularity", maxSummaryBuckets))
+	}
+
+	if _, err := s.GetResourceByID(ctx, req.ResourceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListOverlappingScheduleEntries(ctx, repository.ListOverlappingScheduleEntriesParams{
+		ResourceIds:      []int32{req.ResourceID},
+		StartTime:        req.StartDate,
+		EndTime:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	busy := make([]domain.TimeRange, 0, len(rows))
+	for _, row := range rows {
+		busy = append(busy, domain.TimeRange{Start: row.StartTime, End: row.EndTime})
+	}
+
+	return &domain.AvailabilitySummaryResponse{
+		ResourceID:  req.ResourceID,
+		Granularity: req.Granularity,
+		Buckets:     bucketBusy(mergeBusy(busy), req.StartDate, req.EndDate, step),
+	}, nil
+}
+
+// granularityStep returns the bucket length for a summary granularity
+func granularityStep(g domain.Granularity) (time.Duration, error) {
+	switch g {
+	case domain.GranularityHour:
+		return time.Hour, nil
+	case domain.GranularityHalfDay:
+		return 12 * time.Hour, nil
+	case domain.GranularityDay:
+		return 24 * time.Hour, nil
+	default:
+		return 0, domain.NewValidationError("granularity must be one of hour, halfday, day")
+	}
+}
+
+// bucketBusy splits [start, end) into UTC-aligned buckets of the given step and
+// distributes merged busy time across them
+func bucketBusy(busy []domain.TimeRange, start, end time.Time, step time.Duration) []domain.AvailabilityBucket {
+	start = start.UTC()
+	end = end.UTC()
+
+	var buckets []domain.AvailabilityBucket
+	i := 0
+	for boundary := start.Truncate(step); boundary.Before(end); boundary = boundary.Add(step) {
+		bucket := domain.TimeRange{Start: boundary, End: boundary.Add(step)}
+		if bucket.Start.Before(start) {
+			bucket.Start = start
+		}
+		if bucket.End.After(end) {
+			bucket.End = end
+		}
+
+		// Skip busy ranges that finished before this bucket; ranges are sorted
+		// and non-overlapping, so they can't touch any later bucket either
+		for i < len(busy) && !busy[i].End.After(bucket.Start) {
+			i++
+		}
+		var busyFor time.Duration
+		for j := i; j < len(busy) && busy[j].Start.Before(bucket.End); j++ {
+			busyFor += overlapDuration(busy[j], bucket)
+		}
+
+		buckets = append(buckets, domain.AvailabilityBucket{
+			Start:        bucket.Start,
+			End:          bucket.End,
+			BusyMinutes:  int(busyFor / time.Minute),
+			BusyFraction: math.Round(float64(busyFor)/float64(bucket.End.Sub(bucket.Start))*1000) / 1000,
+		})
+	}
+	return buckets
+}
+
+const (
+	// slotSearchHorizon bounds how far ahead slot searches look for free time
+	slotSearchHorizon = 30 * 24 * time.Hour
+	// maxSoonestCandidates caps how many resources a soonest-available search considers
+	maxSoonestCandidates = 500
+)
+
+// loadBusy returns the merged busy ranges of each resource within [start, end).
+// Each booking is extended by its resource's release grace period, and
+// downtime and blackout dates count as busy, matching what CheckConflicts
+// refuses.
+func (s *AvailabilityService) loadBusy(ctx context.Context, resourceIDs []int32, start, end time.Time) (map[int32][]domain.TimeRange, error) {
+	rows, err := s.queries.ListOverlappingScheduleEntries(ctx, repository.ListOverlappingScheduleEntriesParams{
+		ResourceIds: resourceIDs,
+		StartTime:   start,
+		EndTime:     end,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	busy := make(map[int32][]domain.TimeRange, len(resourceIDs))
+	for _, row := range rows {
+		busy[row.ResourceID] = append(busy[row.ResourceID], domain.TimeRange{
+			Start: row.StartTime,
+			End:   row.EndTime.Add(time.Duration(row.ReleaseGraceMinutes) * time.Minute),
+		})
+	}
+
+	downRows, err := s.queries.ListOverlappingDowntime(ctx, repository.ListOverlappingDowntimeParams{
+		ResourceIds: resourceIDs,
+		StartTime:   start,
+		EndTime:     end,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource downtime", err)
+	}
+	for _, row := range downRows {
+		busy[row.ResourceID] = append(busy[row.ResourceID], domain.TimeRange{Start: row.StartTime, End: row.EndTime})
+	}
 
-	return resource, nil
+	// Blackout dates close every resource
+	blackouts, err := blackoutRanges(ctx, s.queries, domain.TimeRange{Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+	if len(blackouts) > 0 {
+		for _, id := range resourceIDs {
+			busy[id] = append(busy[id], blackouts...)
+		}
+	}
+	for id, ranges := range busy {
+		busy[id] = mergeBusy(ranges)
+	}
+	return busy, nil
+}
+
+// FindNextAvailableSlot returns the earliest time at or after from at which the
+// resource is free for the whole duration. It returns nil if no such slot
+// starts within the search horizon.
+func (s *AvailabilityService) FindNextAvailableSlot(ctx context.Context, resourceID int32, from time.Time, duration time.Duration) (*time.Time, error) {
+	if err := validateSlotDuration(duration); err != nil {
+		return nil, err
+	}
+
+	until := from.Add(slotSearchHorizon + duration)
+	busy, err := s.loadBusy(ctx, []int32{resourceID}, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, ok := nextFreeSlot(busy[resourceID], from, until, duration)
+	if !ok {
+		return nil, nil
+	}
+	return &slot, nil
+}
+
+// FindSoonestAvailable finds, across all available resources of a type, the one
+// whose next free slot of the requested duration starts soonest from now. It
+// runs FindNextAvailableSlot for each resource in turn, stopping at the first
+// that is free right away.
+func (s *AvailabilityService) FindSoonestAvailable(ctx context.Context, req domain.SoonestAvailableRequest) (*domain.SoonestAvailableResponse, error) {
+	if !req.Type.IsValid() {
+		return nil, domain.NewValidationError("type must be one of staff, equipment, materials")
+	}
+	if err := validateSlotDuration(req.Duration); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListResources(ctx, repository.ListResourcesParams{
+		Type:        repository.NullResourceType{ResourceType: repository.ResourceType(req.Type), Valid: true},
+		IsAvailable: sql.NullBool{Bool: true, Valid: true},
+		LimitCount:  maxSoonestCandidates,
+	})
+	if err != nil {
+		return nil, dbError("failed to list resources", err)
+	}
+	if len(rows) == 0 {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("no available %s resources", req.Type))
+	}
+
+	now := time.Now().UTC()
+	var best *domain.SoonestAvailableResponse
+	for _, row := range rows {
+		slot, err := s.FindNextAvailableSlot(ctx, row.ID, now, req.Duration)
+		if err != nil {
+			return nil, err
+		}
+		if slot == nil || (best != nil && !slot.Before(best.SlotStart)) {
+			continue
+		}
+		best = &domain.SoonestAvailableResponse{
+			Resource:  toDomainResource(row),
+			SlotStart: *slot,
+			SlotEnd:   slot.Add(req.Duration),
+		}
+		// Nothing can start sooner than right now
+		if slot.Equal(now) {
+			break
+		}
+	}
+
+	if best == nil {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("no %s resource is free for %s within the search horizon", req.Type, req.Duration))
+	}
+	return best, nil
+}
+
+// validateSlotDuration checks a requested slot length is usable for searching
+func validateSlotDuration(d time.Duration) error {
+	if d <= 0 {
+		return domain.NewValidationError("duration must be positive")
+	}
+	if d > slotSearchHorizon {
+		return domain.NewValidationError("duration must not exceed 30 days")
+	}
+	return nil
+}
+
+// nextFreeSlot returns the earliest start in [from, until) where a slot of length
+// d fits between the merged, sorted busy ranges and ends by until
+func nextFreeSlot(busy []domain.TimeRange, from, until time.Time, d time.Duration) (time.Time, bool) {
+	candidate := from
+	for _, b := range busy {
+		if !b.End.After(candidate) {
+			continue
+		}
+		if !b.Start.Before(candidate.Add(d)) {
+			break
+		}
+		candidate = b.End
+	}
+	if candidate.Add(d).After(until) {
+		return time.Time{}, false
+	}
+	return candidate, true
 }
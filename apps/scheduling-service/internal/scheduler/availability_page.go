@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// defaultAvailabilityPageLimit is the page size of a resource's entries
+	// when the request doesn't set one
+	defaultAvailabilityPageLimit = 100
+	// maxAvailabilityPageLimit caps the page size a request may ask for
+	maxAvailabilityPageLimit = 500
+)
+
+// availabilityCursor marks the last entry of a page; the next page starts
+// after it in the page's start_time, id order. desc records that the order
+// was descending, since the same entry is followed by different ones in the
+// other direction.
+type availabilityCursor struct {
+	desc      bool
+	startTime time.Time
+	id        int32
+}
+
+// Cursor directions, as written in an encoded cursor
+const (
+	cursorAsc  = "asc"
+	cursorDesc = "desc"
+)
+
+// encode renders the cursor as an opaque, URL-safe token
+func (c availabilityCursor) encode() string {
+	dir := cursorAsc
+	if c.desc {
+		dir = cursorDesc
+	}
+	raw := dir + "," + c.startTime.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(int64(c.id), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeAvailabilityCursor parses a token made by encode
+func decodeAvailabilityCursor(token string) (availabilityCursor, error) {
+	invalid := domain.NewValidationError("cursor is invalid")
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return availabilityCursor{}, invalid
+	}
+	parts := strings.Split(string(raw), ",")
+	if len(parts) != 3 || (parts[0] != cursorAsc && parts[0] != cursorDesc) {
+		return availabilityCursor{}, invalid
+	}
+	startTime, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return availabilityCursor{}, invalid
+	}
+	n, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil || n <= 0 {
+		return availabilityCursor{}, invalid
+	}
+	return availabilityCursor{desc: parts[0] == cursorDesc, startTime: startTime, id: int32(n)}, nil
+}
+
+// availabilityPage loads the page of entries req asks for, filtered and
+// sorted as it asks, with the total across all pages and the cursor of the
+// next page, if there is one. An event or task that doesn't exist simply
+// matches nothing.
+func (s *AvailabilityService) availabilityPage(ctx context.Context, req domain.ResourceAvailabilityRequest) (*domain.ResourceAvailabilityResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultAvailabilityPageLimit
+	}
+	if limit < 0 || limit > maxAvailabilityPageLimit {
+		return nil, domain.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", maxAvailabilityPageLimit))
+	}
+	params := repository.GetResourceScheduleParams{
+		ResourceID: req.ResourceID,
+		StartTime:  req.StartDate,
+		EndTime:    req.EndDate,
+		EventID:    nullInt32(req.EventID),
+		TaskID:     nullInt32(req.TaskID),
+		// One row past the page tells whether another page follows
+		RowLimit: int32(limit + 1),
+	}
+	switch req.Sort {
+	case "", domain.AvailabilitySortStartAsc:
+	case domain.AvailabilitySortStartDesc:
+		params.SortDesc = true
+	default:
+		return nil, domain.NewValidationError("sort must be start_asc or start_desc")
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeAvailabilityCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.desc != params.SortDesc {
+			return nil, domain.NewValidationError("cursor was issued for the other sort order")
+		}
+		params.AfterStart = sql.NullTime{Time: cursor.startTime, Valid: true}
+		params.AfterID = sql.NullInt32{Int32: cursor.id, Valid: true}
+	}
+
+	total, err := s.queries.CountResourceSchedule(ctx, repository.CountResourceScheduleParams{
+		ResourceID: req.ResourceID,
+		StartTime:  req.StartDate,
+		EndTime:    req.EndDate,
+		EventID:    params.EventID,
+		TaskID:     params.TaskID,
+	})
+	if err != nil {
+		return nil, dbError("failed to count resource schedule", err)
+	}
+	rows, err := s.queries.GetResourceSchedule(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	resp := &domain.ResourceAvailabilityResponse{
+		ResourceID: req.ResourceID,
+		TotalCount: total,
+	}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		next := availabilityCursor{desc: params.SortDesc, startTime: last.StartTime, id: last.ID}.encode()
+		resp.NextCursor = &next
+	}
+	resp.Entries = make([]domain.ScheduleEntry, 0, len(rows))
+	for _, row := range rows {
+		resp.Entries = append(resp.Entries, scheduleEntryFromRow(row))
+	}
+	return resp, nil
+}
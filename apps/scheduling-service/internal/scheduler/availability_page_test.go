@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestGetResourceAvailability_Pages(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:     testutil.ResourceTypeEquipment,
+		Quantity: 5,
+	})
+
+	// Five entries, two pairs of them starting together, so only the ID
+	// keeps the order stable
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	var ids []int32
+	for _, hour := range []int{8, 8, 10, 12, 12} {
+		start := baseDay.Add(time.Duration(hour) * time.Hour)
+		ids = append(ids, testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, start, start.Add(time.Hour), nil))
+	}
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	req := domain.ResourceAvailabilityRequest{
+		ResourceID: oven,
+		StartDate:  baseDay,
+		EndDate:    baseDay.AddDate(0, 0, 1),
+		Limit:      2,
+	}
+	entryIDs := func(resp *domain.ResourceAvailabilityResponse) []int32 {
+		var got []int32
+		for _, e := range resp.Entries {
+			got = append(got, e.ID)
+		}
+		return got
+	}
+
+	first, err := service.GetResourceAvailability(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), first.TotalCount)
+	assert.Equal(t, ids[:2], entryIDs(first))
+	require.NotNil(t, first.NextCursor)
+
+	req.Cursor = *first.NextCursor
+	middle, err := service.GetResourceAvailability(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), middle.TotalCount)
+	assert.Equal(t, ids[2:4], entryIDs(middle))
+	require.NotNil(t, middle.NextCursor)
+
+	req.Cursor = *middle.NextCursor
+	last, err := service.GetResourceAvailability(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, ids[4:], entryIDs(last))
+	assert.Nil(t, last.NextCursor)
+
+	// Without a limit the default page holds them all
+	all, err := service.GetResourceAvailability(context.Background(), domain.ResourceAvailabilityRequest{
+		ResourceID: oven,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ids, entryIDs(all))
+	assert.Nil(t, all.NextCursor)
+}
+
+func TestGetResourceAvailability_PageValidation(t *testing.T) {
+	service := &AvailabilityService{}
+	start := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.ResourceAvailabilityRequest{ResourceID: 1, StartDate: start, EndDate: start.AddDate(0, 0, 1)}
+
+	for _, limit := range []int{-1, maxAvailabilityPageLimit + 1} {
+		req.Limit = limit
+		_, err := service.GetResourceAvailability(context.Background(), req)
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation}, "limit %d", limit)
+	}
+
+	req.Limit = 0
+	for _, cursor := range []string{"not base64!", "bm8tY29tbWE", "MjAyNS0wNi0xNVQwODowMDowMFosMA",
+		availabilityCursor{startTime: start, id: 0}.encode()} {
+		req.Cursor = cursor
+		_, err := service.GetResourceAvailability(context.Background(), req)
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation}, "cursor %q", cursor)
+	}
+
+	// A cursor only continues the order it was issued for
+	req.Cursor = availabilityCursor{desc: true, startTime: start, id: 7}.encode()
+	_, err := service.GetResourceAvailability(context.Background(), req)
+	assert.ErrorContains(t, err, "other sort order")
+	req.Cursor = availabilityCursor{startTime: start, id: 7}.encode()
+	req.Sort = domain.AvailabilitySortStartDesc
+	_, err = service.GetResourceAvailability(context.Background(), req)
+	assert.ErrorContains(t, err, "other sort order")
+}
+
+func TestAvailabilityCursor_RoundTrip(t *testing.T) {
+	cursor := availabilityCursor{
+		startTime: time.Date(2025, 6, 15, 8, 30, 0, 123456000, time.FixedZone("CDT", -5*60*60)),
+		id:        42,
+	}
+	got, err := decodeAvailabilityCursor(cursor.encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.startTime.Equal(got.startTime))
+	assert.Equal(t, int32(42), got.id)
+	assert.False(t, got.desc)
+
+	cursor.desc = true
+	got, err = decodeAvailabilityCursor(cursor.encode())
+	require.NoError(t, err)
+	assert.True(t, got.desc)
+}
@@ -30,7 +30,7 @@ func TestGetResourceAvailability_ValidRange(t *testing.T) {
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
 		baseDay.Add(14*time.Hour), baseDay.Add(17*time.Hour), nil)
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	req := domain.ResourceAvailabilityRequest{
 		ResourceID: resourceID,
@@ -45,11 +45,71 @@ func TestGetResourceAvailability_ValidRange(t *testing.T) {
 	assert.Len(t, result.Entries, 2)
 }
 
+func TestGetMultiResourceAvailability_GroupsByResource(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	idle := testutil.CreateResource(t, testDB.DB, nil)
+	other := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(17*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), nil)
+	// Not requested
+	testutil.CreateScheduleEntry(t, testDB.DB, other, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetMultiResourceAvailability(context.Background(), domain.MultiResourceAvailabilityRequest{
+		ResourceIDs: []int32{chef, oven, idle},
+		StartDate:   baseDay,
+		EndDate:     baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result, 3)
+	require.Len(t, result[chef], 2)
+	assert.True(t, baseDay.Add(9*time.Hour).Equal(result[chef][0].StartTime))
+	assert.Len(t, result[oven], 1)
+	assert.Equal(t, oven, result[oven][0].ResourceID)
+	assert.NotNil(t, result[idle])
+	assert.Empty(t, result[idle])
+}
+
+func TestGetMultiResourceAvailability_Validation(t *testing.T) {
+	service := &AvailabilityService{}
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetMultiResourceAvailability(context.Background(), domain.MultiResourceAvailabilityRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	})
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+
+	_, err = service.GetMultiResourceAvailability(context.Background(), domain.MultiResourceAvailabilityRequest{
+		ResourceIDs: make([]int32, maxBulkResources+1),
+		StartDate:   baseDay,
+		EndDate:     baseDay.Add(24 * time.Hour),
+	})
+	require.ErrorAs(t, err, &domainErr)
+	assert.Contains(t, domainErr.Message, "too many resource_ids")
+}
+
 func TestGetResourceAvailability_InvalidRange(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	now := time.Now()
 	req := domain.ResourceAvailabilityRequest{
@@ -77,7 +137,7 @@ func TestGetResourceAvailability_EmptyResult(t *testing.T) {
 	testutil.SetupBaseData(t, testDB.DB)
 	resourceID := testutil.CreateResource(t, testDB.DB, nil)
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	req := domain.ResourceAvailabilityRequest{
 		ResourceID: resourceID,
@@ -107,7 +167,7 @@ func TestGetResourceAvailability_WithTaskInfo(t *testing.T) {
 		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour),
 		&testutil.ScheduleEntryOpts{TaskID: &taskID})
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	req := domain.ResourceAvailabilityRequest{
 		ResourceID: resourceID,
@@ -137,7 +197,7 @@ func TestGetResourceByID_Found(t *testing.T) {
 		IsAvailable: true,
 	})
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	result, err := service.GetResourceByID(context.Background(), resourceID)
 
@@ -152,7 +212,7 @@ func TestGetResourceByID_NotFound(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	result, err := service.GetResourceByID(context.Background(), 99999)
 
@@ -179,7 +239,7 @@ func TestGetResourceByID_WithNullableFields(t *testing.T) {
 		Notes:       &notes,
 	})
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	result, err := service.GetResourceByID(context.Background(), resourceID)
 
@@ -204,7 +264,7 @@ func TestGetResourceByID_AllResourceTypes(t *testing.T) {
 		{"Materials", testutil.ResourceTypeMaterials, domain.ResourceTypeMaterials},
 	}
 
-	service := NewAvailabilityService(testDB.DB)
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -220,3 +280,388 @@ func TestGetResourceByID_AllResourceTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketBusy_HalfDay(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	busy := []domain.TimeRange{{Start: day.Add(8 * time.Hour), End: day.Add(12 * time.Hour)}}
+
+	buckets := bucketBusy(busy, day, day.Add(24*time.Hour), 12*time.Hour)
+
+	require.Len(t, buckets, 2)
+	assert.Equal(t, day, buckets[0].Start)
+	assert.Equal(t, 240, buckets[0].BusyMinutes)
+	assert.InDelta(t, 0.333, buckets[0].BusyFraction, 0.001)
+	assert.Equal(t, day.Add(12*time.Hour), buckets[1].Start)
+	assert.Equal(t, 0, buckets[1].BusyMinutes)
+	assert.Equal(t, 0.0, buckets[1].BusyFraction)
+}
+
+func TestBucketBusy_SpansBoundary(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// 22:00 - 02:00 splits across two day buckets
+	busy := []domain.TimeRange{{Start: day.Add(22 * time.Hour), End: day.Add(26 * time.Hour)}}
+
+	buckets := bucketBusy(busy, day, day.Add(48*time.Hour), 24*time.Hour)
+
+	require.Len(t, buckets, 2)
+	assert.Equal(t, 120, buckets[0].BusyMinutes)
+	assert.Equal(t, 120, buckets[1].BusyMinutes)
+}
+
+func TestBucketBusy_ClipsPartialBuckets(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	busy := []domain.TimeRange{{Start: day.Add(9 * time.Hour), End: day.Add(10 * time.Hour)}}
+
+	// Range starts mid-hour, so the first bucket is 09:30 - 10:00
+	buckets := bucketBusy(busy, day.Add(9*time.Hour+30*time.Minute), day.Add(11*time.Hour), time.Hour)
+
+	require.Len(t, buckets, 2)
+	assert.Equal(t, day.Add(9*time.Hour+30*time.Minute), buckets[0].Start)
+	assert.Equal(t, 30, buckets[0].BusyMinutes)
+	assert.Equal(t, 1.0, buckets[0].BusyFraction)
+	assert.Equal(t, 0, buckets[1].BusyMinutes)
+}
+
+func TestGetAvailabilitySummary_HalfDayMorningBooking(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name: "Chef",
+		Type: testutil.ResourceTypeStaff,
+	})
+
+	// Morning-only booking 06:00 - 12:00
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(6*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetAvailabilitySummary(context.Background(), domain.AvailabilitySummaryRequest{
+		ResourceID:  resourceID,
+		StartDate:   baseDay,
+		EndDate:     baseDay.Add(24 * time.Hour),
+		Granularity: domain.GranularityHalfDay,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Buckets, 2)
+	assert.Equal(t, 0.5, result.Buckets[0].BusyFraction)
+	assert.Equal(t, 360, result.Buckets[0].BusyMinutes)
+	assert.Equal(t, 0.0, result.Buckets[1].BusyFraction)
+}
+
+func TestGetAvailabilitySummary_InvalidGranularity(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.GetAvailabilitySummary(context.Background(), domain.AvailabilitySummaryRequest{
+		ResourceID:  1,
+		StartDate:   baseDay,
+		EndDate:     baseDay.Add(24 * time.Hour),
+		Granularity: "week",
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
+
+func TestNextFreeSlot(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	busy := []domain.TimeRange{
+		{Start: at(9), End: at(11)},
+		{Start: at(12), End: at(15)},
+	}
+
+	tests := []struct {
+		name   string
+		from   time.Time
+		d      time.Duration
+		want   time.Time
+		wantOK bool
+	}{
+		{"free immediately", at(6), 2 * time.Hour, at(6), true},
+		{"starts inside busy range", at(10), time.Hour, at(11), true},
+		{"gap too small", at(10), 2 * time.Hour, at(15), true},
+		{"does not fit before until", at(16), 9 * time.Hour, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextFreeSlot(busy, tt.from, at(24), tt.d)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindSoonestAvailable_PrefersResourceFreeNow(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	busyChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Busy Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	freeChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Free Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	now := time.Now().UTC()
+	testutil.CreateScheduleEntry(t, testDB.DB, busyChef, eventID,
+		now.Add(-time.Hour), now.Add(3*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.FindSoonestAvailable(context.Background(), domain.SoonestAvailableRequest{
+		Type:     domain.ResourceTypeStaff,
+		Duration: 2 * time.Hour,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, freeChef, result.Resource.ID)
+	assert.WithinDuration(t, now, result.SlotStart, 5*time.Second)
+	assert.Equal(t, result.SlotStart.Add(2*time.Hour), result.SlotEnd)
+}
+
+func TestFindSoonestAvailable_AllBusyPicksEarliestRelease(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	laterChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Later Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	soonerChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Sooner Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	// Equipment is never a candidate for a staff search
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	testutil.CreateScheduleEntry(t, testDB.DB, laterChef, eventID,
+		now.Add(-time.Hour), now.Add(5*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, soonerChef, eventID,
+		now.Add(-time.Hour), now.Add(2*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.FindSoonestAvailable(context.Background(), domain.SoonestAvailableRequest{
+		Type:     domain.ResourceTypeStaff,
+		Duration: 2 * time.Hour,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, soonerChef, result.Resource.ID)
+	assert.True(t, now.Add(2*time.Hour).Equal(result.SlotStart))
+}
+
+func TestFindSoonestAvailable_InvalidType(t *testing.T) {
+	service := NewAvailabilityService(nil, nil)
+
+	_, err := service.FindSoonestAvailable(context.Background(), domain.SoonestAvailableRequest{
+		Type:     "vehicles",
+		Duration: time.Hour,
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code)
+}
+
+func TestGetBookingsAround_BothSides(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(6*time.Hour), baseDay.Add(8*time.Hour), nil)
+	previousID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	nextID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(18*time.Hour), baseDay.Add(20*time.Hour), nil)
+	// A rejected entry closer to the timestamp is ignored
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(13*time.Hour), baseDay.Add(13*time.Hour+30*time.Minute),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetBookingsAround(context.Background(), resourceID, baseDay.Add(13*time.Hour))
+
+	require.NoError(t, err)
+	require.NotNil(t, result.Previous)
+	require.NotNil(t, result.Next)
+	assert.Equal(t, previousID, result.Previous.ID)
+	assert.Equal(t, nextID, result.Next.ID)
+}
+
+func TestGetBookingsAround_OnlyNext(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	nextID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetBookingsAround(context.Background(), resourceID, baseDay.Add(9*time.Hour))
+
+	require.NoError(t, err)
+	assert.Nil(t, result.Previous)
+	require.NotNil(t, result.Next)
+	assert.Equal(t, nextID, result.Next.ID)
+}
+
+func TestGetBookingsAround_OnlyPreviousAndBoundaries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	previousID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	// A booking that ends exactly at the timestamp counts as previous
+	result, err := service.GetBookingsAround(context.Background(), resourceID, baseDay.Add(12*time.Hour))
+
+	require.NoError(t, err)
+	require.NotNil(t, result.Previous)
+	assert.Equal(t, previousID, result.Previous.ID)
+	assert.Nil(t, result.Next)
+}
+
+func TestGetBookingsAround_ResourceNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	_, err := service.GetBookingsAround(context.Background(), 999999, time.Now())
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
+
+func TestGetResourceAvailability_UseResourceTZ(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Harbour Venue",
+		Type:     testutil.ResourceTypeEquipment,
+		Timezone: strPtr("Asia/Tokyo"),
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(3*time.Hour), baseDay.Add(5*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetResourceAvailability(context.Background(), domain.ResourceAvailabilityRequest{
+		ResourceID:    resourceID,
+		StartDate:     baseDay,
+		EndDate:       baseDay.Add(24 * time.Hour),
+		UseResourceTZ: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", result.Timezone)
+	require.Len(t, result.Entries, 1)
+	start := result.Entries[0].StartTime
+	assert.Equal(t, "Asia/Tokyo", start.Location().String())
+	assert.Equal(t, 12, start.Hour())
+	assert.True(t, start.Equal(baseDay.Add(3*time.Hour)))
+}
+
+func TestGetActiveBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := baseDay.Add(10 * time.Hour)
+	activeChef := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	activeOven := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), nil)
+	// Ends exactly at the instant, so it's no longer active
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(8*time.Hour), at, nil)
+	// Later in the day
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetActiveBookings(context.Background(), domain.ActiveBookingsRequest{At: at})
+	require.NoError(t, err)
+	ids := make([]int32, 0, len(result.Bookings))
+	for _, b := range result.Bookings {
+		ids = append(ids, b.ScheduleID)
+		assert.NotEmpty(t, b.ResourceName)
+		assert.NotEmpty(t, b.EventName)
+	}
+	assert.ElementsMatch(t, []int32{activeChef, activeOven}, ids)
+
+	equipment := domain.ResourceTypeEquipment
+	result, err = service.GetActiveBookings(context.Background(), domain.ActiveBookingsRequest{
+		At:           at,
+		ResourceType: &equipment,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Bookings, 1)
+	assert.Equal(t, activeOven, result.Bookings[0].ScheduleID)
+
+	invalid := domain.ResourceType("vehicle")
+	_, err = service.GetActiveBookings(context.Background(), domain.ActiveBookingsRequest{At: at, ResourceType: &invalid})
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// ConflictBatchConcurrencyEnv names the environment variable holding how
+	// many checks of one batch may run at once
+	ConflictBatchConcurrencyEnv = "CONFLICT_BATCH_CONCURRENCY"
+
+	// maxConflictBatchSize caps how many checks one batch may contain
+	maxConflictBatchSize = 100
+)
+
+// conflictBatchConcurrency bounds the checks of one batch that run at once,
+// so a large batch can't take the whole connection pool. It is replaced at
+// most once, at startup, before any request is served.
+var conflictBatchConcurrency = 8
+
+// ParseConflictBatchConcurrency parses and validates a batch concurrency. It
+// may not exceed the connection pool, or a single batch could still exhaust it.
+func ParseConflictBatchConcurrency(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("concurrency must be a whole number")
+	}
+	if n < 1 || n > repository.Pool.MaxOpenConns {
+		return 0, fmt.Errorf("concurrency must be between 1 and %d", repository.Pool.MaxOpenConns)
+	}
+	return n, nil
+}
+
+// LoadConflictBatchConcurrency applies CONFLICT_BATCH_CONCURRENCY if it is
+// set. Call it once at startup; an invalid value is returned as an error so
+// the service can refuse to start.
+func LoadConflictBatchConcurrency() error {
+	v, ok := os.LookupEnv(ConflictBatchConcurrencyEnv)
+	if !ok {
+		return nil
+	}
+	n, err := ParseConflictBatchConcurrency(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", ConflictBatchConcurrencyEnv, err)
+	}
+	conflictBatchConcurrency = n
+	return nil
+}
+
+// CheckConflictsBatch runs several independent conflict checks, at most
+// CONFLICT_BATCH_CONCURRENCY at a time, and returns their results in request
+// order. A check that fails reports its error in its own slot without failing
+// the rest of the batch. Each check that finds conflicts is posted to the
+// conflict webhook, as a single check would be.
+func (s *ConflictService) CheckConflictsBatch(ctx context.Context, reqs []domain.CheckConflictsRequest) ([]domain.BatchConflictResult, error) {
+	if len(reqs) == 0 {
+		return nil, domain.NewValidationError("checks must not be empty")
+	}
+	if len(reqs) > maxConflictBatchSize {
+		return nil, domain.NewValidationError(fmt.Sprintf("checks must not contain more than %d requests", maxConflictBatchSize))
+	}
+	// Best-effort checks within the batch check their resources one at a time,
+	// so the batch as a whole stays within its concurrency
+	check := func(ctx context.Context, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+		resp, err := s.checkConflictsLimited(ctx, req, 1)
+		if err != nil {
+			return nil, err
+		}
+		s.notifyConflicts(ctx, resp)
+		return resp, nil
+	}
+	return runConflictBatch(ctx, reqs, conflictBatchConcurrency, check), nil
+}
+
+// runConflictBatch runs check over every request with at most limit calls in
+// flight. Requests wait their turn in order; one still waiting when ctx ends
+// reports the context's error instead of running.
+func runConflictBatch(ctx context.Context, reqs []domain.CheckConflictsRequest, limit int, check func(context.Context, domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error)) []domain.BatchConflictResult {
+	results := make([]domain.BatchConflictResult, len(reqs))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i, req := range reqs {
+		results[i].Index = i
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = batchItemError(domain.NewTimeoutError("conflict check was not started before the request ended", ctx.Err()))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := check(ctx, req)
+			if err != nil {
+				results[i].Error = batchItemError(err)
+				return
+			}
+			results[i].Result = resp
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// checkEachResource answers a best-effort check by running check once per
+// resource, at most limit at a time, and merging the results. External busy
+// windows don't belong to any resource, so they get a check of their own.
+// A resource whose check fails is listed in Errors instead of failing the
+// rest. Validation errors apply to the whole request and still fail it, as
+// does every check failing. Best-effort checks can't be paged, since pages
+// of separate checks don't merge into one.
+func checkEachResource(ctx context.Context, req domain.CheckConflictsRequest, limit int, check func(context.Context, domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error)) (*domain.CheckConflictsResponse, error) {
+	if pagedCheck(req) {
+		return nil, domain.NewValidationError("best_effort can't be combined with limit, offset, or sort")
+	}
+
+	single := req
+	single.BestEffort = false
+	single.ExternalBusy = nil
+	subs := make([]domain.CheckConflictsRequest, 0, len(req.ResourceIDs)+1)
+	for _, id := range req.ResourceIDs {
+		sub := single
+		sub.ResourceIDs = []int32{id}
+		subs = append(subs, sub)
+	}
+	if len(req.ExternalBusy) > 0 {
+		sub := single
+		sub.ResourceIDs = nil
+		sub.ExternalBusy = req.ExternalBusy
+		subs = append(subs, sub)
+	}
+	if len(subs) == 0 {
+		return check(ctx, single)
+	}
+
+	resp := newCheckConflictsResponse([]domain.Conflict{})
+	var firstErr error
+	for i, result := range runConflictBatch(ctx, subs, limit, check) {
+		if result.Error != nil {
+			err := &domain.DomainError{Code: result.Error.Code, Message: result.Error.Message}
+			// Only a resource's own failure can be left out of the result
+			if err.Code == domain.ErrCodeValidation || i >= len(req.ResourceIDs) {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			resp.Errors = append(resp.Errors, domain.ResourceCheckError{
+				ResourceID: req.ResourceIDs[i],
+				Message:    err.Message,
+			})
+			continue
+		}
+		r := result.Result
+		resp.HasConflicts = resp.HasConflicts || r.HasConflicts
+		resp.HasHardConflicts = resp.HasHardConflicts || r.HasHardConflicts
+		resp.ConflictCount += r.ConflictCount
+		resp.TotalConflicts += r.TotalConflicts
+		resp.Conflicts = append(resp.Conflicts, r.Conflicts...)
+	}
+	if firstErr != nil && len(resp.Errors) == len(req.ResourceIDs) {
+		return nil, firstErr
+	}
+	return resp, nil
+}
+
+// batchItemError converts a failed check's error into its wire form. Errors
+// that aren't domain errors are reported as internal without their details.
+func batchItemError(err error) *domain.BatchItemError {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return &domain.BatchItemError{Code: domainErr.Code, Message: domainErr.Message}
+	}
+	return &domain.BatchItemError{Code: domain.ErrCodeInternal, Message: "failed to check conflicts"}
+}
@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+func TestRunConflictBatch_BoundsConcurrencyAndKeepsOrder(t *testing.T) {
+	const limit = 3
+	reqs := make([]domain.CheckConflictsRequest, 25)
+	for i := range reqs {
+		// Each request carries its position so the result can be traced back
+		reqs[i].MinOverlapMinutes = int32(i)
+	}
+
+	var inFlight, peak atomic.Int32
+	check := func(ctx context.Context, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Later requests finish first, so order can't come from completion
+		time.Sleep(time.Duration(len(reqs)-int(req.MinOverlapMinutes)) * 100 * time.Microsecond)
+		if req.MinOverlapMinutes%5 == 0 {
+			return nil, domain.NewValidationError("bad request " + strconv.Itoa(int(req.MinOverlapMinutes)))
+		}
+		return &domain.CheckConflictsResponse{ConflictCount: int(req.MinOverlapMinutes)}, nil
+	}
+
+	results := runConflictBatch(context.Background(), reqs, limit, check)
+
+	require.Len(t, results, len(reqs))
+	assert.LessOrEqual(t, peak.Load(), int32(limit))
+	assert.Equal(t, int32(0), inFlight.Load())
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		if i%5 == 0 {
+			require.NotNil(t, r.Error, "check %d", i)
+			assert.Nil(t, r.Result)
+			assert.Equal(t, domain.ErrCodeValidation, r.Error.Code)
+			assert.Equal(t, "bad request "+strconv.Itoa(i), r.Error.Message)
+			continue
+		}
+		require.NotNil(t, r.Result, "check %d", i)
+		assert.Nil(t, r.Error)
+		assert.Equal(t, i, r.Result.ConflictCount)
+	}
+}
+
+func TestRunConflictBatch_HidesInternalErrorDetails(t *testing.T) {
+	check := func(ctx context.Context, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+		return nil, errors.New("pq: connection refused")
+	}
+
+	results := runConflictBatch(context.Background(), make([]domain.CheckConflictsRequest, 1), 1, check)
+
+	require.NotNil(t, results[0].Error)
+	assert.Equal(t, domain.ErrCodeInternal, results[0].Error.Code)
+	assert.Equal(t, "failed to check conflicts", results[0].Error.Message)
+}
+
+func TestCheckConflictsBatch_Size(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	for _, n := range []int{0, maxConflictBatchSize + 1} {
+		_, err := service.CheckConflictsBatch(context.Background(), make([]domain.CheckConflictsRequest, n))
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr, "batch of %d", n)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	}
+}
+
+func TestParseConflictBatchConcurrency(t *testing.T) {
+	n, err := ParseConflictBatchConcurrency("4")
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	for _, v := range []string{"0", "-1", "four", "2.5", strconv.Itoa(repository.Pool.MaxOpenConns + 1)} {
+		_, err := ParseConflictBatchConcurrency(v)
+		assert.Error(t, err, "concurrency %q", v)
+	}
+}
+
+func TestLoadConflictBatchConcurrency(t *testing.T) {
+	original := conflictBatchConcurrency
+	t.Cleanup(func() { conflictBatchConcurrency = original })
+
+	t.Setenv(ConflictBatchConcurrencyEnv, "12")
+	require.NoError(t, LoadConflictBatchConcurrency())
+	assert.Equal(t, 12, conflictBatchConcurrency)
+
+	t.Setenv(ConflictBatchConcurrencyEnv, "0")
+	assert.Error(t, LoadConflictBatchConcurrency())
+	// A failed load leaves the previous value in place
+	assert.Equal(t, 12, conflictBatchConcurrency)
+}
+
+func TestCheckEachResource_ReportsFailedResource(t *testing.T) {
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		ResourceIDs:  []int32{1, 2, 3},
+		StartTime:    start,
+		EndTime:      start.Add(time.Hour),
+		ExternalBusy: []domain.TimeRange{{Start: start, End: start.Add(30 * time.Minute)}},
+		BestEffort:   true,
+	}
+
+	var checked sync.Map
+	check := func(ctx context.Context, sub domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+		assert.False(t, sub.BestEffort)
+		if len(sub.ResourceIDs) == 0 {
+			assert.Len(t, sub.ExternalBusy, 1)
+			return newCheckConflictsResponse(externalConflicts(sub)), nil
+		}
+		assert.Len(t, sub.ResourceIDs, 1)
+		assert.Empty(t, sub.ExternalBusy)
+		id := sub.ResourceIDs[0]
+		checked.Store(id, true)
+		// Resource 2's query fails as if the connection dropped
+		if id == 2 {
+			return nil, errors.New("pq: connection reset by peer")
+		}
+		return newCheckConflictsResponse([]domain.Conflict{{
+			Kind:       domain.ConflictKindBooking,
+			ResourceID: id,
+			Severity:   domain.ConflictSeveritySoft,
+		}}), nil
+	}
+
+	resp, err := checkEachResource(context.Background(), req, 2, check)
+
+	require.NoError(t, err)
+	for _, id := range req.ResourceIDs {
+		_, ok := checked.Load(id)
+		assert.True(t, ok, "resource %d", id)
+	}
+	require.Len(t, resp.Conflicts, 3)
+	assert.Equal(t, int32(1), resp.Conflicts[0].ResourceID)
+	assert.Equal(t, int32(3), resp.Conflicts[1].ResourceID)
+	assert.Equal(t, domain.ConflictKindExternal, resp.Conflicts[2].Kind)
+	assert.Equal(t, 3, resp.ConflictCount)
+	assert.True(t, resp.HasConflicts)
+	// Only the external window is hard
+	assert.True(t, resp.HasHardConflicts)
+	assert.Equal(t, []domain.ResourceCheckError{{ResourceID: 2, Message: "failed to check conflicts"}}, resp.Errors)
+}
+
+func TestCheckEachResource_Failures(t *testing.T) {
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		ResourceIDs: []int32{1, 2},
+		StartTime:   start,
+		EndTime:     start.Add(time.Hour),
+		BestEffort:  true,
+	}
+
+	t.Run("every resource failing fails the check", func(t *testing.T) {
+		_, err := checkEachResource(context.Background(), req, 2, func(ctx context.Context, sub domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+			return nil, domain.NewTimeoutError("database query timed out", context.DeadlineExceeded)
+		})
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeTimeout})
+	})
+
+	t.Run("validation errors fail the check", func(t *testing.T) {
+		_, err := checkEachResource(context.Background(), req, 2, func(ctx context.Context, sub domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+			return nil, domain.NewValidationError("end_time must be after start_time")
+		})
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+	})
+
+	t.Run("paging is refused", func(t *testing.T) {
+		paged := req
+		paged.Limit = 10
+		_, err := checkEachResource(context.Background(), paged, 2, func(ctx context.Context, sub domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+			t.Error("no check should run")
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+	})
+}
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// BusinessTimezoneEnv names the environment variable holding the IANA
+	// timezone blackout dates are read in
+	BusinessTimezoneEnv = "BUSINESS_TIMEZONE"
+
+	// blackoutDateLayout is the wire and storage form of a blackout date
+	blackoutDateLayout = "2006-01-02"
+
+	// maxBlackoutDays caps how many days one blackout may cover
+	maxBlackoutDays = 366
+
+	// maxBlackoutReasonLength caps the free-text reason stored with a blackout
+	maxBlackoutReasonLength = 500
+)
+
+// businessLocation is the timezone blackout days start and end in. It is
+// replaced at most once, at startup, before any request is served.
+var businessLocation = time.UTC
+
+// LoadBusinessTimezone applies BUSINESS_TIMEZONE if it is set. Call it once at
+// startup; a timezone a resource couldn't be given either is returned as an
+// error so the service can refuse to start.
+func LoadBusinessTimezone() error {
+	v, ok := os.LookupEnv(BusinessTimezoneEnv)
+	if !ok || v == "" {
+		return nil
+	}
+	loc, verr := parseTimezone(v)
+	if verr != nil {
+		return fmt.Errorf("invalid %s: %s", BusinessTimezoneEnv, verr.Message)
+	}
+	businessLocation = loc
+	return nil
+}
+
+// BlackoutService manages the company-wide blackout calendar
+type BlackoutService struct {
+	queries *repository.Queries
+}
+
+// NewBlackoutService creates a new blackout service
+func NewBlackoutService(db *sql.DB) *BlackoutService {
+	return &BlackoutService{
+		queries: repository.NewQueries(db),
+	}
+}
+
+// ListBlackouts returns every blackout, earliest first
+func (s *BlackoutService) ListBlackouts(ctx context.Context) (*domain.BlackoutListResponse, error) {
+	rows, err := s.queries.ListBlackoutDates(ctx)
+	if err != nil {
+		return nil, dbError("failed to list blackouts", err)
+	}
+	resp := &domain.BlackoutListResponse{
+		Timezone:  businessLocation.String(),
+		Blackouts: make([]domain.Blackout, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Blackouts = append(resp.Blackouts, toDomainBlackout(row))
+	}
+	return resp, nil
+}
+
+// CreateBlackout blacks out one day, or a run of days. Bookings already on
+// those days are left in place; only new bookings are refused.
+func (s *BlackoutService) CreateBlackout(ctx context.Context, req domain.BlackoutRequest) (*domain.Blackout, error) {
+	start, end, err := parseBlackoutRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CreateBlackoutDateParams{
+		StartDate: start,
+		EndDate:   end,
+	}
+	if req.Reason != nil {
+		params.Reason = sql.NullString{String: *req.Reason, Valid: true}
+	}
+	row, err := s.queries.CreateBlackoutDate(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to create blackout", err)
+	}
+	blackout := toDomainBlackout(row)
+	return &blackout, nil
+}
+
+// DeleteBlackout removes a blackout, making its days bookable again
+func (s *BlackoutService) DeleteBlackout(ctx context.Context, id int32) error {
+	deleted, err := s.queries.DeleteBlackoutDate(ctx, id)
+	if err != nil {
+		return dbError("failed to delete blackout", err)
+	}
+	if deleted == 0 {
+		return domain.NewNotFoundError("blackout not found")
+	}
+	return nil
+}
+
+// parseBlackoutRequest validates a blackout request and returns its first and
+// last day, as midnight UTC dates. A missing end date means a single day.
+func parseBlackoutRequest(req domain.BlackoutRequest) (time.Time, time.Time, error) {
+	start, err := time.Parse(blackoutDateLayout, req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.NewValidationError("start_date must be a date in YYYY-MM-DD format")
+	}
+	end := start
+	if req.EndDate != "" {
+		if end, err = time.Parse(blackoutDateLayout, req.EndDate); err != nil {
+			return time.Time{}, time.Time{}, domain.NewValidationError("end_date must be a date in YYYY-MM-DD format")
+		}
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, domain.NewValidationError("end_date must not be before start_date")
+	}
+	if end.Sub(start) >= maxBlackoutDays*24*time.Hour {
+		return time.Time{}, time.Time{}, domain.NewValidationError(fmt.Sprintf("a blackout must not cover more than %d days", maxBlackoutDays))
+	}
+	if req.Reason != nil && len(*req.Reason) > maxBlackoutReasonLength {
+		return time.Time{}, time.Time{}, domain.NewValidationError(fmt.Sprintf("reason must not exceed %d characters", maxBlackoutReasonLength))
+	}
+	return start, end, nil
+}
+
+func toDomainBlackout(row repository.BlackoutDate) domain.Blackout {
+	blackout := domain.Blackout{
+		ID:        row.ID,
+		StartDate: row.StartDate.Format(blackoutDateLayout),
+		EndDate:   row.EndDate.Format(blackoutDateLayout),
+		CreatedAt: row.CreatedAt,
+	}
+	if row.Reason.Valid {
+		blackout.Reason = &row.Reason.String
+	}
+	return blackout
+}
+
+// localDays returns the first and last calendar day in loc that the range
+// touches, as midnight UTC dates to compare with stored blackout dates. The
+// range is half-open, so one ending exactly at midnight doesn't touch the
+// day that starts then.
+func localDays(r domain.TimeRange, loc *time.Location) (time.Time, time.Time) {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	return day(r.Start), day(r.End.Add(-time.Nanosecond))
+}
+
+// blackoutRange is the span of time a blackout covers: from midnight at the
+// start of its first day to midnight at the end of its last, in loc
+func blackoutRange(row repository.BlackoutDate, loc *time.Location) domain.TimeRange {
+	y, m, d := row.StartDate.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	y, m, d = row.EndDate.Date()
+	return domain.TimeRange{Start: start, End: time.Date(y, m, d+1, 0, 0, 0, 0, loc)}
+}
+
+// blackoutRanges returns the spans of the blackouts touching any day of the range
+func blackoutRanges(ctx context.Context, q *repository.Queries, r domain.TimeRange) ([]domain.TimeRange, error) {
+	first, last := localDays(r, businessLocation)
+	rows, err := q.ListOverlappingBlackoutDates(ctx, repository.ListOverlappingBlackoutDatesParams{
+		FirstDay: first,
+		LastDay:  last,
+	})
+	if err != nil {
+		return nil, dbError("failed to check blackout dates", err)
+	}
+	ranges := make([]domain.TimeRange, 0, len(rows))
+	for _, row := range rows {
+		ranges = append(ranges, blackoutRange(row, businessLocation))
+	}
+	return ranges, nil
+}
+
+// checkBlackout refuses a booking that touches a blacked-out day in the
+// business timezone
+func checkBlackout(ctx context.Context, q *repository.Queries, r domain.TimeRange) error {
+	ranges, err := blackoutRanges(ctx, q, r)
+	if err != nil {
+		return err
+	}
+	if len(ranges) > 0 {
+		return blackedOutError()
+	}
+	return nil
+}
+
+// blackedOutError is the error refusing a booking on a blackout day
+func blackedOutError() *domain.DomainError {
+	return domain.NewValidationError("date is blacked out")
+}
@@ -0,0 +1,283 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestParseBlackoutRequest(t *testing.T) {
+	christmas := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	t.Run("single day", func(t *testing.T) {
+		start, end, err := parseBlackoutRequest(domain.BlackoutRequest{StartDate: "2025-12-25"})
+		require.NoError(t, err)
+		assert.Equal(t, christmas, start)
+		assert.Equal(t, christmas, end)
+	})
+
+	t.Run("multi day", func(t *testing.T) {
+		start, end, err := parseBlackoutRequest(domain.BlackoutRequest{StartDate: "2025-12-25", EndDate: "2025-12-26"})
+		require.NoError(t, err)
+		assert.Equal(t, christmas, start)
+		assert.Equal(t, christmas.AddDate(0, 0, 1), end)
+	})
+
+	invalid := []struct {
+		name    string
+		req     domain.BlackoutRequest
+		message string
+	}{
+		{"missing start", domain.BlackoutRequest{}, "start_date"},
+		{"timestamp start", domain.BlackoutRequest{StartDate: "2025-12-25T00:00:00Z"}, "start_date"},
+		{"malformed end", domain.BlackoutRequest{StartDate: "2025-12-25", EndDate: "26/12/2025"}, "end_date"},
+		{"end before start", domain.BlackoutRequest{StartDate: "2025-12-25", EndDate: "2025-12-24"}, "before"},
+		{"too long", domain.BlackoutRequest{StartDate: "2025-01-01", EndDate: "2026-01-02"}, "366"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseBlackoutRequest(tt.req)
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
+
+func TestLocalDays(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	dec24 := time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC)
+	dec25 := time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		start, end  time.Time
+		loc         *time.Location
+		first, last time.Time
+	}{
+		{
+			"spans midnight",
+			time.Date(2025, 12, 24, 22, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 2, 0, 0, 0, time.UTC),
+			time.UTC, dec24, dec25,
+		},
+		{
+			"ends at midnight",
+			time.Date(2025, 12, 24, 22, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+			time.UTC, dec24, dec24,
+		},
+		{
+			// 22:00 to 23:00 on the 24th in New York, though already the 25th in UTC
+			"read in the business timezone",
+			time.Date(2025, 12, 25, 3, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 4, 0, 0, 0, time.UTC),
+			newYork, dec24, dec24,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := localDays(domain.TimeRange{Start: tt.start, End: tt.end}, tt.loc)
+			assert.Equal(t, tt.first, first)
+			assert.Equal(t, tt.last, last)
+		})
+	}
+}
+
+func TestLoadBusinessTimezone(t *testing.T) {
+	original := businessLocation
+	t.Cleanup(func() { businessLocation = original })
+
+	t.Setenv(BusinessTimezoneEnv, "Europe/London")
+	require.NoError(t, LoadBusinessTimezone())
+	assert.Equal(t, "Europe/London", businessLocation.String())
+
+	// Names a resource's timezone couldn't be set to are refused too, such
+	// as the server's own zone
+	for _, v := range []string{"Mars/Olympus_Mons", "Local"} {
+		t.Setenv(BusinessTimezoneEnv, v)
+		assert.ErrorContains(t, LoadBusinessTimezone(), "IANA zone name", v)
+		// A failed load leaves the previous timezone in place
+		assert.Equal(t, "Europe/London", businessLocation.String())
+	}
+}
+
+func TestBlackout_CRUD(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewBlackoutService(testDB.DB)
+	ctx := context.Background()
+	reason := "Christmas"
+
+	created, err := service.CreateBlackout(ctx, domain.BlackoutRequest{StartDate: "2025-12-25", EndDate: "2025-12-26", Reason: &reason})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-12-25", created.StartDate)
+	assert.Equal(t, "2025-12-26", created.EndDate)
+
+	_, err = service.CreateBlackout(ctx, domain.BlackoutRequest{StartDate: "2025-11-27"})
+	require.NoError(t, err)
+
+	list, err := service.ListBlackouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, list.Blackouts, 2)
+	assert.Equal(t, "2025-11-27", list.Blackouts[0].StartDate)
+	assert.Equal(t, "2025-11-27", list.Blackouts[0].EndDate)
+	require.NotNil(t, list.Blackouts[1].Reason)
+	assert.Equal(t, reason, *list.Blackouts[1].Reason)
+
+	require.NoError(t, service.DeleteBlackout(ctx, created.ID))
+	assert.ErrorIs(t, service.DeleteBlackout(ctx, created.ID), &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestCreateEntryChecked_Blackout(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	original := businessLocation
+	t.Cleanup(func() { businessLocation = original })
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	businessLocation = newYork
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	_, err = NewBlackoutService(testDB.DB).CreateBlackout(context.Background(), domain.BlackoutRequest{StartDate: "2025-12-25"})
+	require.NoError(t, err)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	book := func(start, end time.Time) error {
+		_, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+			ResourceID: chef,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    end,
+		})
+		return err
+	}
+
+	t.Run("spanning midnight into the blackout day is refused", func(t *testing.T) {
+		err := book(time.Date(2025, 12, 24, 22, 0, 0, 0, newYork), time.Date(2025, 12, 25, 2, 0, 0, 0, newYork))
+		require.Error(t, err)
+		domainErr, ok := err.(*domain.DomainError)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+		assert.Equal(t, "date is blacked out", domainErr.Message)
+	})
+
+	t.Run("ending at midnight is allowed", func(t *testing.T) {
+		assert.NoError(t, book(time.Date(2025, 12, 24, 20, 0, 0, 0, newYork), time.Date(2025, 12, 25, 0, 0, 0, 0, newYork)))
+	})
+
+	t.Run("the blackout day is read in the business timezone", func(t *testing.T) {
+		// 01:00 to 04:00 UTC on the 26th is still the evening of the 25th in New York
+		err := book(time.Date(2025, 12, 26, 1, 0, 0, 0, time.UTC), time.Date(2025, 12, 26, 4, 0, 0, 0, time.UTC))
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+		// while 05:00 UTC on the 26th is already midnight the next day there
+		assert.NoError(t, book(time.Date(2025, 12, 26, 5, 0, 0, 0, time.UTC), time.Date(2025, 12, 26, 8, 0, 0, 0, time.UTC)))
+	})
+}
+
+func TestApproveEntry_Blackout(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	// Requested before the day was blacked out
+	pendingID := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		time.Date(2025, 12, 25, 15, 0, 0, 0, time.UTC), time.Date(2025, 12, 25, 18, 0, 0, 0, time.UTC),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+	_, err := NewBlackoutService(testDB.DB).CreateBlackout(context.Background(), domain.BlackoutRequest{StartDate: "2025-12-25"})
+	require.NoError(t, err)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	_, err = service.ApproveEntry(context.Background(), pendingID)
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	entry, err := service.GetEntry(context.Background(), pendingID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusPending, entry.ApprovalStatus)
+}
+
+func TestCreateRecurring_SkipsBlackoutDays(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	_, err := NewBlackoutService(testDB.DB).CreateBlackout(context.Background(), domain.BlackoutRequest{StartDate: "2025-12-25"})
+	require.NoError(t, err)
+
+	start := time.Date(2025, 12, 18, 9, 0, 0, 0, time.UTC)
+	result, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).CreateRecurring(context.Background(), domain.RecurringEntryRequest{
+		Entry: domain.ScheduleEntryRequest{
+			ResourceID: chef,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(4 * time.Hour),
+		},
+		Rule: domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 2)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, time.Date(2025, 12, 25, 9, 0, 0, 0, time.UTC).Equal(result.Failed[0].StartTime))
+	assert.Equal(t, "date is blacked out", result.Failed[0].Error.Message)
+}
+
+func TestFreeTime_ExcludesBlackoutDays(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, _ := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+		UserID:      &userID,
+	})
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Monday, "09:00", "17:00")
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Tuesday, "09:00", "17:00")
+	// Monday 2025-06-16 is blacked out
+	_, err := NewBlackoutService(testDB.DB).CreateBlackout(context.Background(), domain.BlackoutRequest{StartDate: "2025-06-16"})
+	require.NoError(t, err)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	slots, err := service.GetFreeSlots(context.Background(), oven, monday.Add(-12*time.Hour), tuesday.Add(12*time.Hour), 0)
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	assert.True(t, monday.Equal(slots[0].End))
+	assert.True(t, tuesday.Equal(slots[1].Start))
+
+	result, err := service.GetBookableWindows(context.Background(), domain.BookableWindowsRequest{
+		ResourceID:  chef,
+		StartDate:   monday,
+		EndDate:     monday.AddDate(0, 0, 2),
+		MinDuration: time.Hour,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Windows, 1)
+	assert.True(t, tuesday.Add(9*time.Hour).Equal(result.Windows[0].Start))
+	assert.True(t, tuesday.Add(17*time.Hour).Equal(result.Windows[0].End))
+}
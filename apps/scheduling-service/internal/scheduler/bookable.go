@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// GetBookableWindows returns the windows within the requested range where a
+// resource is inside its working hours, is not marked unavailable, and has no
+// bookings. Working hours come from the staff_availability shifts of the user
+// linked to the resource and are read in the resource's timezone; a resource
+// without shifts is treated as always working. Bookings include their release
+// grace, as in conflict checks. Windows shorter than MinDuration are dropped.
+func (s *AvailabilityService) GetBookableWindows(ctx context.Context, req domain.BookableWindowsRequest) (*domain.BookableWindowsResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > slotSearchHorizon {
+		return nil, domain.NewValidationError("range must not exceed 30 days")
+	}
+	if req.MinDuration < 0 {
+		return nil, domain.NewValidationError("min_duration must not be negative")
+	}
+
+	resource, err := s.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := resourceZone(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.BookableWindowsResponse{
+		ResourceID: req.ResourceID,
+		Timezone:   loc.String(),
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Windows:    []domain.TimeRange{},
+	}
+	if !resource.IsAvailable {
+		return resp, nil
+	}
+
+	rows, err := s.queries.ListResourceWorkingHours(ctx, req.ResourceID)
+	if err != nil {
+		return nil, dbError("failed to get working hours", err)
+	}
+	shifts, err := parseShifts(rows)
+	if err != nil {
+		return nil, domain.NewInternalError("resource has invalid working hours", err)
+	}
+
+	windows := []domain.TimeRange{{Start: req.StartDate, End: req.EndDate}}
+	if len(shifts) > 0 {
+		resp.WorkingHoursApplied = true
+		windows = workingWindows(shifts, loc, req.StartDate, req.EndDate)
+	}
+
+	busy, err := s.loadBusy(ctx, []int32{req.ResourceID}, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range subtractBusy(windows, busy[req.ResourceID]) {
+		if w.End.Sub(w.Start) < req.MinDuration {
+			continue
+		}
+		resp.Windows = append(resp.Windows, domain.TimeRange{Start: w.Start.In(loc), End: w.End.In(loc)})
+	}
+	return resp, nil
+}
+
+// shift is one weekly working-hours block in local wall-clock time. A shift
+// whose end is not after its start runs past midnight into the next day.
+type shift struct {
+	weekday        time.Weekday
+	startH, startM int
+	endH, endM     int
+}
+
+// parseShifts converts working-hours rows into shifts
+func parseShifts(rows []repository.ListResourceWorkingHoursRow) ([]shift, error) {
+	shifts := make([]shift, 0, len(rows))
+	for _, row := range rows {
+		if row.DayOfWeek < 0 || row.DayOfWeek > 6 {
+			return nil, fmt.Errorf("day_of_week %d out of range", row.DayOfWeek)
+		}
+		sh, sm, err := parseClock(row.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		eh, em, err := parseClock(row.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, shift{
+			weekday: time.Weekday(row.DayOfWeek),
+			startH:  sh,
+			startM:  sm,
+			endH:    eh,
+			endM:    em,
+		})
+	}
+	return shifts, nil
+}
+
+// parseClock parses an HH:MM wall-clock time. 24:00 is accepted as the end of
+// the day.
+func parseClock(s string) (int, int, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	digits := []byte{s[0], s[1], s[3], s[4]}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, 0, fmt.Errorf("invalid time of day %q", s)
+		}
+	}
+	h := int(digits[0]-'0')*10 + int(digits[1]-'0')
+	m := int(digits[2]-'0')*10 + int(digits[3]-'0')
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return h, m, nil
+}
+
+// workingWindows lays the weekly shifts over [start, end) in loc and returns
+// the merged working time. Shift times are built from calendar dates so a DST
+// change doesn't shift them. The day before start is included so an overnight
+// shift reaching into the range is not missed.
+func workingWindows(shifts []shift, loc *time.Location, start, end time.Time) []domain.TimeRange {
+	y, m, d := start.In(loc).Date()
+
+	var windows []domain.TimeRange
+	for i := -1; ; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		if !day.Before(end) {
+			break
+		}
+		for _, sh := range shifts {
+			if sh.weekday != day.Weekday() {
+				continue
+			}
+			ws := time.Date(y, m, d+i, sh.startH, sh.startM, 0, 0, loc)
+			we := time.Date(y, m, d+i, sh.endH, sh.endM, 0, 0, loc)
+			if !we.After(ws) {
+				we = time.Date(y, m, d+i+1, sh.endH, sh.endM, 0, 0, loc)
+			}
+			if ws.Before(start) {
+				ws = start
+			}
+			if we.After(end) {
+				we = end
+			}
+			if we.After(ws) {
+				windows = append(windows, domain.TimeRange{Start: ws, End: we})
+			}
+		}
+	}
+	return mergeBusy(windows)
+}
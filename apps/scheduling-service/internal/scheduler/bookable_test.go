@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestParseClock(t *testing.T) {
+	h, m, err := parseClock("09:30")
+	require.NoError(t, err)
+	assert.Equal(t, 9, h)
+	assert.Equal(t, 30, m)
+
+	h, _, err = parseClock("24:00")
+	require.NoError(t, err)
+	assert.Equal(t, 24, h)
+
+	for _, s := range []string{"9:30", "24:30", "12:60", "+9:00", "0930", ""} {
+		_, _, err := parseClock(s)
+		assert.Error(t, err, "%q", s)
+	}
+}
+
+func TestWorkingWindows(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	// Sunday 2025-06-15 to Tuesday 2025-06-17, local time
+	start := time.Date(2025, 6, 15, 0, 0, 0, 0, chicago)
+	end := time.Date(2025, 6, 17, 0, 0, 0, 0, chicago)
+	shifts := []shift{
+		{weekday: time.Monday, startH: 9, endH: 17},
+		// Saturday night into Sunday morning
+		{weekday: time.Saturday, startH: 22, endH: 2},
+	}
+
+	got := workingWindows(shifts, chicago, start, end)
+
+	require.Len(t, got, 2)
+	assert.True(t, start.Equal(got[0].Start))
+	assert.True(t, time.Date(2025, 6, 15, 2, 0, 0, 0, chicago).Equal(got[0].End))
+	assert.True(t, time.Date(2025, 6, 16, 9, 0, 0, 0, chicago).Equal(got[1].Start))
+	assert.True(t, time.Date(2025, 6, 16, 17, 0, 0, 0, chicago).Equal(got[1].End))
+}
+
+func TestGetBookableWindows_ExcludesFreeTimeOutsideWorkingHours(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+		UserID:      &userID,
+	})
+	// Monday 09:00-17:00 only
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Monday, "09:00", "17:00")
+
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		monday.Add(11*time.Hour), monday.Add(13*time.Hour), nil)
+	// A short booking leaves a 30 minute gap at the end of the day
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		monday.Add(14*time.Hour), monday.Add(16*time.Hour+30*time.Minute), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	// Monday evening and all of Tuesday are free but outside working hours
+	result, err := service.GetBookableWindows(context.Background(), domain.BookableWindowsRequest{
+		ResourceID:  chef,
+		StartDate:   monday,
+		EndDate:     monday.AddDate(0, 0, 2),
+		MinDuration: time.Hour,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.WorkingHoursApplied)
+	require.Len(t, result.Windows, 2)
+	assert.True(t, monday.Add(9*time.Hour).Equal(result.Windows[0].Start))
+	assert.True(t, monday.Add(11*time.Hour).Equal(result.Windows[0].End))
+	assert.True(t, monday.Add(13*time.Hour).Equal(result.Windows[1].Start))
+	assert.True(t, monday.Add(14*time.Hour).Equal(result.Windows[1].End))
+}
+
+func TestGetBookableWindows_NoWorkingHoursOrUnavailable(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+	retired := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: false,
+	})
+
+	day := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetBookableWindows(context.Background(), domain.BookableWindowsRequest{
+		ResourceID: oven,
+		StartDate:  day,
+		EndDate:    day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	assert.False(t, result.WorkingHoursApplied)
+	require.Len(t, result.Windows, 2)
+	assert.True(t, day.Equal(result.Windows[0].Start))
+	assert.True(t, day.Add(12*time.Hour).Equal(result.Windows[1].Start))
+
+	result, err = service.GetBookableWindows(context.Background(), domain.BookableWindowsRequest{
+		ResourceID: retired,
+		StartDate:  day,
+		EndDate:    day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Windows)
+}
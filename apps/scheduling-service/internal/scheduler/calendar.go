@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/ical"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// EventCalendar builds a calendar of an event's schedule with one event per
+// schedule entry that is neither rejected nor cancelled. Entries still pending
+// approval are marked tentative.
+func (s *ScheduleService) EventCalendar(ctx context.Context, eventID int32) (*ical.Calendar, error) {
+	eventName, err := s.queries.GetEventName(ctx, eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("event not found")
+		}
+		return nil, dbError("failed to get event", err)
+	}
+
+	rows, err := s.queries.ListEventScheduleEntries(ctx, eventID)
+	if err != nil {
+		return nil, dbError("failed to get event schedule", err)
+	}
+
+	cal := &ical.Calendar{
+		Name:   eventName,
+		Events: make([]ical.Event, 0, len(rows)),
+	}
+	for _, row := range rows {
+		summary := row.ResourceName
+		if row.TaskTitle.Valid {
+			summary += " - " + row.TaskTitle.String
+		}
+		event := ical.Event{
+			UID:       fmt.Sprintf("schedule-%d@catering-event-manager", row.ID),
+			Summary:   summary,
+			Start:     row.StartTime,
+			End:       row.EndTime,
+			Stamp:     row.UpdatedAt,
+			Tentative: row.ApprovalStatus == repository.ApprovalStatusPending,
+		}
+		if row.Notes.Valid {
+			event.Description = row.Notes.String
+		}
+		cal.Events = append(cal.Events, event)
+	}
+
+	return cal, nil
+}
+
+// ResourceCalendar builds a calendar of a resource's upcoming bookings, those
+// not yet finished, for staff to subscribe to. Each event is summarized by its
+// event name and task; rejected and cancelled bookings are left out, and those
+// pending approval are marked tentative. The calendar carries the resource's
+// timezone, or the business timezone when it has none.
+func (s *ScheduleService) ResourceCalendar(ctx context.Context, resourceID int32) (*ical.Calendar, error) {
+	resource, err := s.queries.GetResourceByID(ctx, resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	rows, err := s.queries.ListResourceCalendarEntries(ctx, repository.ListResourceCalendarEntriesParams{
+		ResourceID: resourceID,
+		After:      time.Now(),
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	cal := &ical.Calendar{
+		Name:     resource.Name,
+		Timezone: businessLocation.String(),
+		Events:   make([]ical.Event, 0, len(rows)),
+	}
+	if resource.Timezone.Valid {
+		cal.Timezone = resource.Timezone.String
+	}
+	for _, row := range rows {
+		summary := row.EventName
+		if row.TaskTitle.Valid {
+			summary += " - " + row.TaskTitle.String
+		}
+		event := ical.Event{
+			UID:       fmt.Sprintf("schedule-%d@catering-event-manager", row.ID),
+			Summary:   summary,
+			Start:     row.StartTime,
+			End:       row.EndTime,
+			Stamp:     row.UpdatedAt,
+			Tentative: row.ApprovalStatus == repository.ApprovalStatusPending,
+		}
+		if row.Notes.Valid {
+			event.Description = row.Notes.String
+		}
+		cal.Events = append(cal.Events, event)
+	}
+
+	return cal, nil
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestEventCalendar_LeavesOutRejectedAndCancelled(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef", IsAvailable: true})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	live := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	pending := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(13*time.Hour), baseDay.Add(14*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(15*time.Hour), baseDay.Add(16*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	cancelledAt := baseDay
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(17*time.Hour), baseDay.Add(18*time.Hour),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	cal, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).EventCalendar(context.Background(), eventID)
+
+	require.NoError(t, err)
+	require.Len(t, cal.Events, 2)
+	assert.Equal(t, fmt.Sprintf("schedule-%d@catering-event-manager", live), cal.Events[0].UID)
+	assert.False(t, cal.Events[0].Tentative)
+	assert.Equal(t, fmt.Sprintf("schedule-%d@catering-event-manager", pending), cal.Events[1].UID)
+	assert.True(t, cal.Events[1].Tentative)
+}
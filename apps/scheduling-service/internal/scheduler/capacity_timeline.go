@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// capacityTimeline returns how many units of a pooled resource are in use
+// across the requested range, or nil for a resource that isn't pooled.
+// Bookings are counted as entry writes count them: release grace is
+// included, and pending entries only take units when the
+// pending_bookings_block flag is on.
+func (s *AvailabilityService) capacityTimeline(ctx context.Context, req domain.ResourceAvailabilityRequest) ([]domain.CapacitySlot, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, nil
+	}
+
+	pooled, err := s.queries.ListPooledResources(ctx, []int32{req.ResourceID})
+	if err != nil {
+		return nil, dbError("failed to list pooled resources", err)
+	}
+	if len(pooled) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.queries.ListPooledUnitUsage(ctx, repository.ListPooledUnitUsageParams{
+		ResourceIds: []int32{req.ResourceID},
+		StartTime:   req.StartDate,
+		EndTime:     req.EndDate,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource usage", err)
+	}
+
+	pendingBlocks := len(rows) > 0 && s.flags.Enabled(ctx, FlagPendingBookingsBlock)
+	usage := make([]unitUsage, 0, len(rows))
+	for _, row := range rows {
+		if row.ApprovalStatus == repository.ApprovalStatusPending && !pendingBlocks {
+			continue
+		}
+		usage = append(usage, unitUsage{
+			occupied: domain.TimeRange{Start: row.StartTime, End: row.OccupiedUntil},
+			units:    row.Quantity,
+		})
+	}
+	return unitTimeline(usage, domain.TimeRange{Start: req.StartDate, End: req.EndDate}, pooled[0].Quantity), nil
+}
+
+// unitTimeline sweeps over bookings clipped to the window and splits the
+// window into consecutive slots with a constant number of units in use.
+// Neighbouring slots always differ, and back-to-back bookings don't leave a
+// zero-length slot between them.
+func unitTimeline(usage []unitUsage, window domain.TimeRange, quantity int32) []domain.CapacitySlot {
+	type edge struct {
+		at    time.Time
+		delta int32
+	}
+	edges := make([]edge, 0, 2*len(usage))
+	for _, u := range usage {
+		start, end := u.occupied.Start, u.occupied.End
+		if start.Before(window.Start) {
+			start = window.Start
+		}
+		if end.After(window.End) {
+			end = window.End
+		}
+		if !end.After(start) {
+			continue
+		}
+		edges = append(edges, edge{start, u.units}, edge{end, -u.units})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].at.Before(edges[j].at)
+	})
+
+	slots := []domain.CapacitySlot{}
+	add := func(start, end time.Time, used int32) {
+		if n := len(slots); n > 0 && slots[n-1].Used == int(used) {
+			slots[n-1].Range.End = end
+			return
+		}
+		slots = append(slots, domain.CapacitySlot{
+			Range:     domain.TimeRange{Start: start, End: end},
+			Used:      int(used),
+			Remaining: int(max(quantity-used, 0)),
+		})
+	}
+
+	var used int32
+	at := window.Start
+	for i := 0; i < len(edges); {
+		t := edges[i].at
+		if t.After(at) {
+			add(at, t, used)
+			at = t
+		}
+		for i < len(edges) && edges[i].at.Equal(t) {
+			used += edges[i].delta
+			i++
+		}
+	}
+	if window.End.After(at) {
+		add(at, window.End, used)
+	}
+	return slots
+}
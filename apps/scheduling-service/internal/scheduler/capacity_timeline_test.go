@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestUnitTimeline(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	slot := func(from, to, used, remaining int) domain.CapacitySlot {
+		return domain.CapacitySlot{Range: domain.TimeRange{Start: at(from), End: at(to)}, Used: used, Remaining: remaining}
+	}
+	booking := func(from, to int, units int32) unitUsage {
+		return unitUsage{occupied: domain.TimeRange{Start: at(from), End: at(to)}, units: units}
+	}
+	window := domain.TimeRange{Start: at(8), End: at(18)}
+
+	tests := []struct {
+		name  string
+		usage []unitUsage
+		want  []domain.CapacitySlot
+	}{
+		{
+			name: "no bookings",
+			want: []domain.CapacitySlot{slot(8, 18, 0, 3)},
+		},
+		{
+			name:  "overlapping bookings peak in the middle",
+			usage: []unitUsage{booking(9, 13, 1), booking(10, 12, 1), booking(11, 15, 1)},
+			want: []domain.CapacitySlot{
+				slot(8, 9, 0, 3),
+				slot(9, 10, 1, 2),
+				slot(10, 11, 2, 1),
+				slot(11, 12, 3, 0),
+				slot(12, 13, 2, 1),
+				slot(13, 15, 1, 2),
+				slot(15, 18, 0, 3),
+			},
+		},
+		{
+			name:  "back-to-back bookings merge into one slot",
+			usage: []unitUsage{booking(9, 12, 2), booking(12, 14, 2)},
+			want:  []domain.CapacitySlot{slot(8, 9, 0, 3), slot(9, 14, 2, 1), slot(14, 18, 0, 3)},
+		},
+		{
+			name:  "bookings are clipped to the window",
+			usage: []unitUsage{booking(6, 10, 1), booking(16, 20, 2)},
+			want:  []domain.CapacitySlot{slot(8, 10, 1, 2), slot(10, 16, 0, 3), slot(16, 18, 2, 1)},
+		},
+		{
+			name:  "overbooking leaves nothing remaining",
+			usage: []unitUsage{booking(8, 18, 2), booking(8, 18, 2)},
+			want:  []domain.CapacitySlot{slot(8, 18, 4, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unitTimeline(tt.usage, window, 3))
+		})
+	}
+}
+
+func TestGetResourceAvailability_CapacityTimeline(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	dishes := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Chafing Dishes",
+		Type:     testutil.ResourceTypeEquipment,
+		Quantity: 3,
+	})
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name: "Chef",
+		Type: testutil.ResourceTypeStaff,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(13*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(11*time.Hour), baseDay.Add(15*time.Hour), &testutil.ScheduleEntryOpts{Quantity: 2})
+	// Pending entries don't take units while pending_bookings_block is off
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(10*time.Hour), &testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(13*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	result, err := service.GetResourceAvailability(context.Background(), domain.ResourceAvailabilityRequest{
+		ResourceID: dishes,
+		StartDate:  baseDay.Add(8 * time.Hour),
+		EndDate:    baseDay.Add(16 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Entries, 3)
+	require.Len(t, result.CapacityTimeline, 5)
+	peak := result.CapacityTimeline[2]
+	assert.True(t, baseDay.Add(11*time.Hour).Equal(peak.Range.Start))
+	assert.True(t, baseDay.Add(13*time.Hour).Equal(peak.Range.End))
+	assert.Equal(t, 3, peak.Used)
+	assert.Equal(t, 0, peak.Remaining)
+	for _, s := range result.CapacityTimeline {
+		assert.LessOrEqual(t, s.Used, peak.Used)
+	}
+
+	// A single staff resource has no timeline
+	result, err = service.GetResourceAvailability(context.Background(), domain.ResourceAvailabilityRequest{
+		ResourceID: chef,
+		StartDate:  baseDay.Add(8 * time.Hour),
+		EndDate:    baseDay.Add(16 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result.CapacityTimeline)
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// defaultChainGapMinutes is the longest break that still keeps two bookings
+	// in the same chain
+	defaultChainGapMinutes = 30
+	// defaultMaxContinuousMinutes is the longest a chain may run before it is
+	// flagged
+	defaultMaxContinuousMinutes = 8 * 60
+)
+
+// GetBookingChains groups the bookings of a resource that start on the
+// requested day into back-to-back chains and flags chains that run longer than
+// the maximum continuous time. The day follows the resource's timezone.
+func (s *AvailabilityService) GetBookingChains(ctx context.Context, req domain.BookingChainsRequest) (*domain.BookingChainsResponse, error) {
+	maxGap := int32(defaultChainGapMinutes)
+	if req.MaxGapMinutes != nil {
+		if *req.MaxGapMinutes < 0 {
+			return nil, domain.NewValidationError("max_gap_minutes must not be negative")
+		}
+		maxGap = *req.MaxGapMinutes
+	}
+	maxContinuous := int32(defaultMaxContinuousMinutes)
+	if req.MaxContinuousMinutes != nil {
+		if *req.MaxContinuousMinutes <= 0 {
+			return nil, domain.NewValidationError("max_continuous_minutes must be positive")
+		}
+		maxContinuous = *req.MaxContinuousMinutes
+	}
+
+	loc, err := s.resourceLocation(ctx, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	y, m, d := req.Date.Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	rows, err := s.queries.ListChangeovers(ctx, repository.ListChangeoversParams{
+		ResourceID: req.ResourceID,
+		RangeStart: dayStart,
+		RangeEnd:   time.Date(y, m, d+1, 0, 0, 0, 0, loc),
+	})
+	if err != nil {
+		return nil, dbError("failed to list bookings", err)
+	}
+
+	chains, exceeding := buildChains(rows, loc,
+		time.Duration(maxGap)*time.Minute, time.Duration(maxContinuous)*time.Minute)
+	return &domain.BookingChainsResponse{
+		ResourceID:           req.ResourceID,
+		Date:                 dayStart.Format(time.DateOnly),
+		Timezone:             loc.String(),
+		MaxGapMinutes:        maxGap,
+		MaxContinuousMinutes: maxContinuous,
+		Chains:               chains,
+		ExceedingCount:       exceeding,
+	}, nil
+}
+
+// buildChains walks bookings in start order and closes a chain when the next
+// booking (from the LEAD columns) starts more than maxGap after the chain's
+// latest end. Measuring from the latest end rather than the row's own end
+// keeps a short booking nested inside a long one from breaking the chain.
+// Chains spanning longer than maxContinuous are counted as exceeding.
+func buildChains(rows []repository.ListChangeoversRow, loc *time.Location, maxGap, maxContinuous time.Duration) ([]domain.BookingChain, int) {
+	chains := []domain.BookingChain{}
+	exceeding := 0
+
+	var current *domain.BookingChain
+	for _, row := range rows {
+		if current == nil {
+			current = &domain.BookingChain{Start: row.StartTime.In(loc), End: row.EndTime.In(loc)}
+		}
+		current.Bookings = append(current.Bookings, domain.ChainBooking{
+			ScheduleID: row.ID,
+			EventID:    row.EventID,
+			EventName:  row.EventName,
+			StartTime:  row.StartTime.In(loc),
+			EndTime:    row.EndTime.In(loc),
+		})
+		if row.EndTime.After(current.End) {
+			current.End = row.EndTime.In(loc)
+		}
+
+		if row.NextStartTime.Valid && row.NextStartTime.Time.Sub(current.End) <= maxGap {
+			continue
+		}
+		span := current.End.Sub(current.Start)
+		current.SpanMinutes = span.Minutes()
+		current.ExceedsMax = span > maxContinuous
+		if current.ExceedsMax {
+			exceeding++
+		}
+		chains = append(chains, *current)
+		current = nil
+	}
+	return chains, exceeding
+}
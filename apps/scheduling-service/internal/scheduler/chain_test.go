@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestBuildChains(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	// rows links each row to the next with the LEAD columns, as the query does
+	rows := func(ranges ...[2]time.Time) []repository.ListChangeoversRow {
+		out := make([]repository.ListChangeoversRow, len(ranges))
+		for i, r := range ranges {
+			out[i] = repository.ListChangeoversRow{ID: int32(i + 1), EventID: 10, StartTime: r[0], EndTime: r[1]}
+			if i > 0 {
+				out[i-1].NextScheduleID = sql.NullInt32{Int32: out[i].ID, Valid: true}
+				out[i-1].NextEventID = sql.NullInt32{Int32: 10, Valid: true}
+				out[i-1].NextStartTime = sql.NullTime{Time: r[0], Valid: true}
+			}
+		}
+		return out
+	}
+
+	t.Run("continuous chain exceeding the threshold", func(t *testing.T) {
+		chains, exceeding := buildChains(rows(
+			[2]time.Time{at(7, 0), at(10, 0)},
+			[2]time.Time{at(10, 15), at(13, 0)},
+			[2]time.Time{at(13, 30), at(16, 0)},
+		), time.UTC, 30*time.Minute, 8*time.Hour)
+
+		require.Len(t, chains, 1)
+		assert.Len(t, chains[0].Bookings, 3)
+		assert.Equal(t, at(7, 0), chains[0].Start)
+		assert.Equal(t, at(16, 0), chains[0].End)
+		assert.Equal(t, 540.0, chains[0].SpanMinutes)
+		assert.True(t, chains[0].ExceedsMax)
+		assert.Equal(t, 1, exceeding)
+	})
+
+	t.Run("gap longer than the maximum breaks the chain", func(t *testing.T) {
+		chains, exceeding := buildChains(rows(
+			[2]time.Time{at(7, 0), at(10, 0)},
+			[2]time.Time{at(10, 15), at(13, 0)},
+			[2]time.Time{at(14, 0), at(16, 0)},
+		), time.UTC, 30*time.Minute, 8*time.Hour)
+
+		require.Len(t, chains, 2)
+		assert.Len(t, chains[0].Bookings, 2)
+		assert.Equal(t, 360.0, chains[0].SpanMinutes)
+		assert.False(t, chains[0].ExceedsMax)
+		assert.Equal(t, int32(3), chains[1].Bookings[0].ScheduleID)
+		assert.Equal(t, 120.0, chains[1].SpanMinutes)
+		assert.Equal(t, 0, exceeding)
+	})
+
+	t.Run("nested booking doesn't break the chain", func(t *testing.T) {
+		// The gap is measured from the long booking's end, not the nested one's
+		chains, _ := buildChains(rows(
+			[2]time.Time{at(8, 0), at(12, 0)},
+			[2]time.Time{at(9, 0), at(10, 0)},
+			[2]time.Time{at(12, 10), at(13, 0)},
+		), time.UTC, 30*time.Minute, 8*time.Hour)
+
+		require.Len(t, chains, 1)
+		assert.Equal(t, at(13, 0), chains[0].End)
+	})
+
+	t.Run("no bookings", func(t *testing.T) {
+		chains, exceeding := buildChains(nil, time.UTC, 30*time.Minute, 8*time.Hour)
+
+		assert.Empty(t, chains)
+		assert.Equal(t, 0, exceeding)
+	})
+}
+
+func TestGetBookingChains_ContinuousAndBroken(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// 06:00-15:00 with two short breaks: one 9 hour chain
+	first := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(6*time.Hour), day.Add(10*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(10*time.Hour+20*time.Minute), day.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(12*time.Hour+15*time.Minute), day.Add(15*time.Hour), nil)
+	// An hour's break starts a new chain
+	evening := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(16*time.Hour), day.Add(19*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	ctx := context.Background()
+
+	result, err := service.GetBookingChains(ctx, domain.BookingChainsRequest{ResourceID: chef, Date: day})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-15", result.Date)
+	assert.Equal(t, int32(defaultChainGapMinutes), result.MaxGapMinutes)
+	require.Len(t, result.Chains, 2)
+	assert.Equal(t, first, result.Chains[0].Bookings[0].ScheduleID)
+	assert.Len(t, result.Chains[0].Bookings, 3)
+	assert.Equal(t, 540.0, result.Chains[0].SpanMinutes)
+	assert.True(t, result.Chains[0].ExceedsMax)
+	assert.Equal(t, evening, result.Chains[1].Bookings[0].ScheduleID)
+	assert.False(t, result.Chains[1].ExceedsMax)
+	assert.Equal(t, 1, result.ExceedingCount)
+
+	// A 10 minute gap limit breaks the long chain at both breaks
+	maxGap := int32(10)
+	result, err = service.GetBookingChains(ctx, domain.BookingChainsRequest{
+		ResourceID:    chef,
+		Date:          day,
+		MaxGapMinutes: &maxGap,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Chains, 4)
+	assert.Equal(t, 0, result.ExceedingCount)
+}
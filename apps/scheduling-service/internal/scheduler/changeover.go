@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// GetChangeovers lists the bookings of an equipment resource that start on the
+// requested day, in order, with the changeover window before each next booking.
+// Windows shorter than the minimum gap are flagged as tight. The day follows
+// the resource's timezone.
+func (s *AvailabilityService) GetChangeovers(ctx context.Context, req domain.ChangeoverRequest) (*domain.ChangeoverResponse, error) {
+	resource, err := s.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Type != domain.ResourceTypeEquipment {
+		return nil, domain.NewValidationError("changeovers are only tracked for equipment")
+	}
+
+	minGap := resource.ReleaseGraceMinutes
+	if req.MinGapMinutes != nil {
+		if *req.MinGapMinutes < 0 {
+			return nil, domain.NewValidationError("min_gap_minutes must not be negative")
+		}
+		minGap = *req.MinGapMinutes
+	}
+
+	loc, err := resourceZone(resource)
+	if err != nil {
+		return nil, err
+	}
+	y, m, d := req.Date.Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+	rows, err := s.queries.ListChangeovers(ctx, repository.ListChangeoversParams{
+		ResourceID: req.ResourceID,
+		RangeStart: dayStart,
+		RangeEnd:   time.Date(y, m, d+1, 0, 0, 0, 0, loc),
+	})
+	if err != nil {
+		return nil, dbError("failed to list changeovers", err)
+	}
+
+	bookings, tight := buildChangeovers(rows, loc, time.Duration(minGap)*time.Minute)
+	return &domain.ChangeoverResponse{
+		ResourceID:    req.ResourceID,
+		Date:          dayStart.Format(time.DateOnly),
+		Timezone:      loc.String(),
+		MinGapMinutes: minGap,
+		Bookings:      bookings,
+		TightCount:    tight,
+	}, nil
+}
+
+// buildChangeovers converts ordered booking rows into changeover steps and
+// counts the windows shorter than minGap
+func buildChangeovers(rows []repository.ListChangeoversRow, loc *time.Location, minGap time.Duration) ([]domain.ChangeoverBooking, int) {
+	bookings := make([]domain.ChangeoverBooking, 0, len(rows))
+	tight := 0
+	for _, row := range rows {
+		booking := domain.ChangeoverBooking{
+			ScheduleID: row.ID,
+			EventID:    row.EventID,
+			EventName:  row.EventName,
+			StartTime:  row.StartTime.In(loc),
+			EndTime:    row.EndTime.In(loc),
+		}
+		if row.NextScheduleID.Valid {
+			gap := row.NextStartTime.Time.Sub(row.EndTime)
+			window := &domain.ChangeoverWindow{
+				NextScheduleID: row.NextScheduleID.Int32,
+				NextEventID:    row.NextEventID.Int32,
+				Start:          booking.EndTime,
+				End:            row.NextStartTime.Time.In(loc),
+				Minutes:        gap.Minutes(),
+				Tight:          gap < minGap,
+			}
+			if window.Tight {
+				tight++
+			}
+			booking.Changeover = window
+		}
+		bookings = append(bookings, booking)
+	}
+	return bookings, tight
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestBuildChangeovers(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	next := func(id int32, start time.Time) (sql.NullInt32, sql.NullInt32, sql.NullTime) {
+		return sql.NullInt32{Int32: id, Valid: true}, sql.NullInt32{Int32: 10, Valid: true}, sql.NullTime{Time: start, Valid: true}
+	}
+
+	rows := []repository.ListChangeoversRow{
+		{ID: 1, EventID: 10, StartTime: at(8, 0), EndTime: at(10, 0)},
+		{ID: 2, EventID: 10, StartTime: at(10, 15), EndTime: at(12, 0)},
+		{ID: 3, EventID: 10, StartTime: at(13, 0), EndTime: at(14, 0)},
+	}
+	rows[0].NextScheduleID, rows[0].NextEventID, rows[0].NextStartTime = next(2, at(10, 15))
+	rows[1].NextScheduleID, rows[1].NextEventID, rows[1].NextStartTime = next(3, at(13, 0))
+
+	bookings, tight := buildChangeovers(rows, time.UTC, 30*time.Minute)
+
+	require.Len(t, bookings, 3)
+	require.NotNil(t, bookings[0].Changeover)
+	assert.Equal(t, int32(2), bookings[0].Changeover.NextScheduleID)
+	assert.Equal(t, 15.0, bookings[0].Changeover.Minutes)
+	assert.True(t, bookings[0].Changeover.Tight)
+	require.NotNil(t, bookings[1].Changeover)
+	assert.Equal(t, 60.0, bookings[1].Changeover.Minutes)
+	assert.False(t, bookings[1].Changeover.Tight)
+	assert.Nil(t, bookings[2].Changeover)
+	assert.Equal(t, 1, tight)
+}
+
+func TestGetChangeovers_TightAndSufficient(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	first := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(8*time.Hour), day.Add(10*time.Hour), nil)
+	// 10 minute changeover, under the 30 minute grace
+	second := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(10*time.Hour+10*time.Minute), day.Add(12*time.Hour), nil)
+	// 90 minute changeover
+	third := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(13*time.Hour+30*time.Minute), day.Add(15*time.Hour), nil)
+	// Next day, not part of the result
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(32*time.Hour), day.Add(34*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetChangeovers(context.Background(), domain.ChangeoverRequest{
+		ResourceID: oven,
+		Date:       day,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-15", result.Date)
+	assert.Equal(t, int32(30), result.MinGapMinutes)
+	require.Len(t, result.Bookings, 3)
+	assert.Equal(t, first, result.Bookings[0].ScheduleID)
+	require.NotNil(t, result.Bookings[0].Changeover)
+	assert.Equal(t, second, result.Bookings[0].Changeover.NextScheduleID)
+	assert.Equal(t, 10.0, result.Bookings[0].Changeover.Minutes)
+	assert.True(t, result.Bookings[0].Changeover.Tight)
+	require.NotNil(t, result.Bookings[1].Changeover)
+	assert.Equal(t, third, result.Bookings[1].Changeover.NextScheduleID)
+	assert.Equal(t, 90.0, result.Bookings[1].Changeover.Minutes)
+	assert.False(t, result.Bookings[1].Changeover.Tight)
+	assert.Nil(t, result.Bookings[2].Changeover)
+	assert.Equal(t, 1, result.TightCount)
+
+	// A smaller minimum makes both changeovers sufficient
+	minGap := int32(5)
+	result, err = service.GetChangeovers(context.Background(), domain.ChangeoverRequest{
+		ResourceID:    oven,
+		Date:          day,
+		MinGapMinutes: &minGap,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TightCount)
+}
+
+func TestGetChangeovers_RejectsNonEquipment(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	_, err := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)).GetChangeovers(context.Background(), domain.ChangeoverRequest{
+		ResourceID: chef,
+		Date:       time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC),
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
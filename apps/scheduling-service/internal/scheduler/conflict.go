@@ -4,27 +4,91 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/catering-event-manager/scheduling-service/internal/domain"
 	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/webhook"
 )
 
 // ConflictService handles scheduling conflict detection
 type ConflictService struct {
 	queries *repository.Queries
+	flags   *FlagService
+	// notifier is sent every check that finds conflicts, or nil
+	notifier *webhook.Notifier
+	// clock tells the time past bookings are judged against
+	clock func() time.Time
 }
 
-// NewConflictService creates a new conflict detection service
-func NewConflictService(db *sql.DB) *ConflictService {
+// NewConflictService creates a new conflict detection service reading feature
+// flags from flags and posting conflicts found to notifier, which may be nil
+func NewConflictService(db *sql.DB, flags *FlagService, notifier *webhook.Notifier) *ConflictService {
 	return &ConflictService{
-		queries: repository.New(db),
+		queries:  repository.NewQueries(db),
+		flags:    flags,
+		notifier: notifier,
+		clock:    time.Now,
 	}
 }
 
-// CheckConflicts checks for scheduling conflicts for the given resources and time range
+// CheckConflicts checks for scheduling conflicts for the given resources and
+// time range. A check that finds conflicts is also posted to the conflict
+// webhook, if one is configured.
 func (s *ConflictService) CheckConflicts(ctx context.Context, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+	resp, err := s.checkConflictsLimited(ctx, req, conflictBatchConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyConflicts(ctx, resp)
+	return resp, nil
+}
+
+// checkConflictsLimited answers a conflict check, running at most limit
+// resource checks at once if it is best effort
+func (s *ConflictService) checkConflictsLimited(ctx context.Context, req domain.CheckConflictsRequest, limit int) (*domain.CheckConflictsResponse, error) {
+	if err := validateDurationFormat(req.DurationFormat); err != nil {
+		return nil, err
+	}
+	if req.GroupByResource && req.CountOnly {
+		return nil, domain.NewValidationError("group_by_resource can't be combined with count_only")
+	}
+	if req.RejectPast {
+		if err := checkNotPast(s.clock, req.StartTime); err != nil {
+			return nil, err
+		}
+	}
+	ids, err := uniqueResourceIDs(req.ResourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	req.ResourceIDs = ids
+
+	var resp *domain.CheckConflictsResponse
+	if req.BestEffort {
+		resp, err = checkEachResource(ctx, req, limit, func(ctx context.Context, sub domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+			return s.checkConflicts(ctx, s.queries, sub)
+		})
+	} else {
+		resp, err = s.checkConflicts(ctx, s.queries, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.DurationFormat == domain.DurationFormatISO8601 {
+		renderISODurations(resp, req)
+	}
+	if req.GroupByResource {
+		groupConflictsByResource(resp, req.ResourceIDs)
+	}
+	return resp, nil
+}
+
+// checkConflicts runs a conflict check through q, so callers holding a
+// transaction can check within it
+func (s *ConflictService) checkConflicts(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
 	// Validate request
-	if len(req.ResourceIDs) == 0 {
+	if len(req.ResourceIDs) == 0 && len(req.ExternalBusy) == 0 {
 		return &domain.CheckConflictsResponse{
 			HasConflicts: false,
 			Conflicts:    []domain.Conflict{},
@@ -35,6 +99,70 @@ func (s *ConflictService) CheckConflicts(ctx context.Context, req domain.CheckCo
 		return nil, domain.NewValidationError("end_time must be after start_time")
 	}
 
+	if req.MinOverlapMinutes < 0 {
+		return nil, domain.NewValidationError("min_overlap_minutes must not be negative")
+	}
+
+	if req.BufferMinutes < 0 || req.BufferMinutes > maxBufferMinutes {
+		return nil, domain.NewValidationError(fmt.Sprintf("buffer_minutes must be between 0 and %d", maxBufferMinutes))
+	}
+	if req.BufferMinutes > 0 {
+		return s.checkConflictsBuffered(ctx, q, req)
+	}
+
+	if err := validateExternalBusy(req.ExternalBusy); err != nil {
+		return nil, err
+	}
+
+	if len(req.RequiredCertification) > maxCertificationLength {
+		return nil, domain.NewValidationError(fmt.Sprintf("required_certification must not exceed %d characters", maxCertificationLength))
+	}
+
+	if err := validateConflictPage(req); err != nil {
+		return nil, err
+	}
+
+	if req.Quantity < 0 {
+		return nil, domain.NewValidationError("quantity must not be negative")
+	}
+
+	conflicts := externalConflicts(req)
+	if len(req.ResourceIDs) == 0 {
+		return inMemoryConflicts(req, conflicts), nil
+	}
+
+	certConflicts, err := s.certificationConflicts(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, certConflicts...)
+
+	downConflicts, err := s.downtimeConflicts(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, downConflicts...)
+
+	// Pooled resources are checked by counting units, not by overlap
+	poolConflicts, pooled, err := s.pooledConflicts(ctx, q, req)
+	if err != nil {
+		return nil, err
+	}
+	conflicts = append(conflicts, poolConflicts...)
+	if len(pooled) > 0 {
+		req.ResourceIDs = withoutPooled(req.ResourceIDs, pooled)
+		if len(req.ResourceIDs) == 0 {
+			return inMemoryConflicts(req, conflicts), nil
+		}
+	}
+
+	if req.CountOnly {
+		return s.countConflicts(ctx, q, req, conflicts)
+	}
+	if pagedCheck(req) {
+		return s.checkConflictsPage(ctx, q, req, conflicts)
+	}
+
 	// Build params for query
 	params := repository.CheckConflictsParams{
 		Column1: req.ResourceIDs,
@@ -47,38 +175,452 @@ func (s *ConflictService) CheckConflicts(ctx context.Context, req domain.CheckCo
 	}
 
 	// Execute conflict detection query
-	rows, err := s.queries.CheckConflicts(ctx, params)
+	rows, err := q.CheckConflicts(ctx, params)
 	if err != nil {
-		return nil, domain.NewInternalError("failed to check conflicts", err)
+		return nil, dbError("failed to check conflicts", err)
 	}
 
+	// Entries awaiting approval may still be rejected, so by default they only
+	// warn; the pending_bookings_block flag makes them block like any booking
+	pendingBlocks := len(rows) > 0 && s.flags.Enabled(ctx, FlagPendingBookingsBlock)
+
 	// Convert rows to domain conflicts
-	conflicts := make([]domain.Conflict, 0, len(rows))
 	for _, row := range rows {
-		conflict := domain.Conflict{
-			ResourceID:           row.ResourceID,
-			ResourceName:         row.ResourceName,
-			ConflictingEventID:   row.EventID,
-			ConflictingEventName: row.EventName,
-			ExistingStartTime:    row.ExistingStartTime,
-			ExistingEndTime:      row.ExistingEndTime,
-			RequestedStartTime:   req.StartTime,
-			RequestedEndTime:     req.EndTime,
-			Message:              fmt.Sprintf("Resource '%s' is already assigned to event '%s' from %s to %s", row.ResourceName, row.EventName, row.ExistingStartTime.Format("2006-01-02 15:04"), row.ExistingEndTime.Format("2006-01-02 15:04")),
+		if !meetsMinOverlap(occupiedRange(row), req) {
+			continue
 		}
+		conflicts = append(conflicts, bookingConflict(row, req, pendingBlocks))
+	}
+
+	return newCheckConflictsResponse(conflicts), nil
+}
 
-		if row.TaskID.Valid {
-			conflict.ConflictingTaskID = &row.TaskID.Int32
+// maxCheckResources caps how many distinct resources one conflict check may
+// cover, keeping the query's resource array bounded
+const maxCheckResources = 200
+
+// uniqueResourceIDs drops repeated resource IDs, keeping the first of each so
+// a resource listed twice isn't checked, and its conflicts reported, twice.
+// IDs must be positive.
+func uniqueResourceIDs(ids []int32) ([]int32, error) {
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for i, id := range ids {
+		if id <= 0 {
+			return nil, domain.NewValidationError(fmt.Sprintf("resource_ids[%d] must be a positive ID, got %d", i, id))
 		}
-		if row.TaskTitle.Valid {
-			conflict.ConflictingTaskTitle = &row.TaskTitle.String
+		if seen[id] {
+			continue
 		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) > maxCheckResources {
+		return nil, domain.NewValidationError(fmt.Sprintf("resource_ids must not contain more than %d resources, got %d", maxCheckResources, len(unique)))
+	}
+	return unique, nil
+}
 
-		conflicts = append(conflicts, conflict)
+// groupConflictsByResource fills in the per-resource view of resp's
+// conflicts, in the same order as the flat list. A page groups only the
+// conflicts on it.
+func groupConflictsByResource(resp *domain.CheckConflictsResponse, resourceIDs []int32) {
+	resp.ByResource = make(map[int32][]domain.Conflict, len(resourceIDs))
+	for _, id := range resourceIDs {
+		resp.ByResource[id] = []domain.Conflict{}
+	}
+	for _, c := range resp.Conflicts {
+		if c.Kind == domain.ConflictKindExternal {
+			continue
+		}
+		resp.ByResource[c.ResourceID] = append(resp.ByResource[c.ResourceID], c)
+	}
+	resp.ResourceCounts = make(map[int32]int, len(resp.ByResource))
+	for id, conflicts := range resp.ByResource {
+		resp.ResourceCounts[id] = len(conflicts)
+	}
+}
+
+// maxBufferMinutes caps the buffer a conflict check may ask for
+const maxBufferMinutes = 24 * 60
+
+// checkConflictsBuffered checks the requested range widened by the buffer on
+// both sides, so a booking that touches or comes within the buffer of the
+// request conflicts. Overlaps are measured against the widened range, but the
+// conflicts report the range that was actually requested. A conflict that only
+// overlaps the buffer is soft: the booking can go ahead with a warning. The
+// requested range is checked on its own, without paging, to tell which
+// conflicts are true double-bookings, and that check decides HasHardConflicts.
+func (s *ConflictService) checkConflictsBuffered(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest) (*domain.CheckConflictsResponse, error) {
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+	buffer := time.Duration(req.BufferMinutes) * time.Minute
+
+	widened := req
+	widened.StartTime = req.StartTime.Add(-buffer)
+	widened.EndTime = req.EndTime.Add(buffer)
+	widened.BufferMinutes = 0
+
+	resp, err := s.checkConflicts(ctx, q, widened)
+	if err != nil {
+		return nil, err
+	}
+
+	exact := req
+	exact.BufferMinutes = 0
+	exact.CountOnly = false
+	exact.Limit, exact.Offset, exact.Sort = 0, 0, ""
+	exactResp, err := s.checkConflicts(ctx, q, exact)
+	if err != nil {
+		return nil, err
+	}
+	overlapping := make(map[conflictKey]bool, len(exactResp.Conflicts))
+	for _, c := range exactResp.Conflicts {
+		overlapping[keyOf(c)] = true
+	}
+
+	for i := range resp.Conflicts {
+		c := &resp.Conflicts[i]
+		c.RequestedStartTime = requested.Start
+		c.RequestedEndTime = requested.End
+		if !overlapping[keyOf(*c)] {
+			c.Severity = domain.ConflictSeveritySoft
+			c.Message += " (within buffer)"
+		}
+	}
+	resp.HasHardConflicts = exactResp.HasHardConflicts
+	return resp, nil
+}
+
+// conflictKey identifies the booking, busy window, downtime, or missing certification
+// behind a conflict, independent of the range it was checked against. Two
+// bookings sharing every field occupy the same time, so they always conflict
+// alike.
+type conflictKey struct {
+	kind       domain.ConflictKind
+	resourceID int32
+	eventID    int32
+	start, end time.Time
+}
+
+// keyOf returns the conflict's key. Times are normalised to UTC so the same
+// instant read in different locations matches. A resource has at most one
+// capacity conflict, whose span depends on the range checked, so its times
+// are left out.
+func keyOf(c domain.Conflict) conflictKey {
+	if c.Kind == domain.ConflictKindCapacity {
+		return conflictKey{kind: c.Kind, resourceID: c.ResourceID}
+	}
+	return conflictKey{
+		kind:       c.Kind,
+		resourceID: c.ResourceID,
+		eventID:    c.ConflictingEventID,
+		start:      c.ExistingStartTime.UTC(),
+		end:        c.ExistingEndTime.UTC(),
+	}
+}
+
+// occupiedRange is the time a booking holds its resource, release grace included
+func occupiedRange(row repository.CheckConflictsRow) domain.TimeRange {
+	return domain.TimeRange{
+		Start: row.ExistingStartTime,
+		End:   row.ExistingEndTime.Add(time.Duration(row.ReleaseGraceMinutes) * time.Minute),
+	}
+}
+
+// bookingConflict converts a conflicting booking into a domain conflict.
+// Pending entries are soft conflicts unless pendingBlocks is set.
+func bookingConflict(row repository.CheckConflictsRow, req domain.CheckConflictsRequest, pendingBlocks bool) domain.Conflict {
+	message := conflictMessages.Render(row.ResourceName, row.EventName, row.ExistingStartTime, row.ExistingEndTime)
+	if row.ReleaseGraceMinutes > 0 {
+		message += fmt.Sprintf(" (plus %d min release grace)", row.ReleaseGraceMinutes)
+	}
+
+	severity := domain.ConflictSeverityHard
+	if row.ApprovalStatus == repository.ApprovalStatusPending {
+		message += " (pending approval)"
+		if !pendingBlocks {
+			severity = domain.ConflictSeveritySoft
+		}
+	}
+
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+	conflict := domain.Conflict{
+		Kind:                 domain.ConflictKindBooking,
+		Severity:             severity,
+		ResourceID:           row.ResourceID,
+		ResourceName:         row.ResourceName,
+		ConflictingEventID:   row.EventID,
+		ConflictingEventName: row.EventName,
+		ExistingStartTime:    row.ExistingStartTime,
+		ExistingEndTime:      row.ExistingEndTime,
+		RequestedStartTime:   req.StartTime,
+		RequestedEndTime:     req.EndTime,
+		Message:              message,
+	}
+	setOverlap(&conflict, occupiedRange(row), requested)
+
+	if row.TaskID.Valid {
+		conflict.ConflictingTaskID = &row.TaskID.Int32
+	}
+	if row.TaskTitle.Valid {
+		conflict.ConflictingTaskTitle = &row.TaskTitle.String
+	}
+	return conflict
+}
+
+// countConflicts answers a count-only check with a single aggregate query.
+// extra holds the external, certification, downtime, and capacity conflicts already
+// found, which are counted alongside the bookings.
+func (s *ConflictService) countConflicts(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest, extra []domain.Conflict) (*domain.CheckConflictsResponse, error) {
+	params := repository.CountConflictsParams{
+		ResourceIds:       req.ResourceIDs,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		MinOverlapMinutes: req.MinOverlapMinutes,
+	}
+	if req.ExcludeScheduleID != nil {
+		params.ExcludeScheduleID = sql.NullInt32{Int32: *req.ExcludeScheduleID, Valid: true}
 	}
 
+	counts, err := q.CountConflicts(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to count conflicts", err)
+	}
+
+	total := len(extra) + int(counts.ConflictCount)
+	hard := newCheckConflictsResponse(extra).HasHardConflicts || counts.ConflictCount > counts.PendingCount ||
+		(counts.PendingCount > 0 && s.flags.Enabled(ctx, FlagPendingBookingsBlock))
 	return &domain.CheckConflictsResponse{
-		HasConflicts: len(conflicts) > 0,
-		Conflicts:    conflicts,
+		HasConflicts:     total > 0,
+		HasHardConflicts: hard,
+		ConflictCount:    total,
+		TotalConflicts:   total,
+		Conflicts:        []domain.Conflict{},
+	}, nil
+}
+
+// GetConflictClusters groups a resource's bookings within the range into
+// clusters of chained overlaps, so heavily double-booked stretches show up as
+// a whole rather than as many pairwise conflicts
+func (s *ConflictService) GetConflictClusters(ctx context.Context, req domain.ConflictClustersRequest) (*domain.ConflictClustersResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+
+	if _, err := s.queries.GetResourceByID(ctx, req.ResourceID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	rows, err := s.queries.ListOverlappingResourceSchedule(ctx, repository.ListOverlappingResourceScheduleParams{
+		ResourceID: req.ResourceID,
+		RangeStart: req.StartDate,
+		RangeEnd:   req.EndDate,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	entries := make([]domain.ScheduleEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, toDomainScheduleEntry(repository.GetScheduleEntryByIDRow(row)))
+	}
+
+	return &domain.ConflictClustersResponse{
+		ResourceID: req.ResourceID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Clusters:   clusterOverlapping(entries),
 	}, nil
 }
+
+// ListAllConflicts scans every resource's bookings in the window and returns
+// one page of the overlapping pairs, so ops can audit double-bookings without
+// checking resources one by one
+func (s *ConflictService) ListAllConflicts(ctx context.Context, req domain.AllConflictsRequest) (*domain.AllConflictsResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.Limit <= 0 {
+		return nil, domain.NewValidationError("limit must be positive")
+	}
+	if req.Offset < 0 {
+		return nil, domain.NewValidationError("offset must not be negative")
+	}
+
+	total, err := s.queries.CountAllConflictPairs(ctx, repository.CountAllConflictPairsParams{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	})
+	if err != nil {
+		return nil, dbError("failed to count conflicts", err)
+	}
+
+	rows, err := s.queries.ListAllConflictPairs(ctx, repository.ListAllConflictPairsParams{
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		LimitCount:  int32(req.Limit),
+		OffsetCount: int32(req.Offset),
+	})
+	if err != nil {
+		return nil, dbError("failed to list conflicts", err)
+	}
+
+	pairs := make([]domain.ConflictPair, 0, len(rows))
+	for _, row := range rows {
+		pairs = append(pairs, conflictPairFromRow(row))
+	}
+
+	return &domain.AllConflictsResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Total:     total,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+		Pairs:     pairs,
+	}, nil
+}
+
+// conflictPairFromRow converts an overlapping booking pair row to a domain pair
+func conflictPairFromRow(row repository.ListAllConflictPairsRow) domain.ConflictPair {
+	return domain.ConflictPair{
+		ResourceID:   row.ResourceID,
+		ResourceName: row.ResourceName,
+		ResourceType: domain.ResourceType(row.ResourceType),
+		Booking: domain.ConflictPairBooking{
+			ScheduleID:     row.ScheduleID,
+			EventID:        row.EventID,
+			EventName:      row.EventName,
+			StartTime:      row.StartTime,
+			EndTime:        row.EndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+		},
+		ConflictsWith: domain.ConflictPairBooking{
+			ScheduleID:     row.ConflictingScheduleID,
+			EventID:        row.ConflictingEventID,
+			EventName:      row.ConflictingEventName,
+			StartTime:      row.ConflictingStartTime,
+			EndTime:        row.ConflictingEndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ConflictingApprovalStatus),
+		},
+	}
+}
+
+// inMemoryConflicts answers a check whose conflicts were all found without
+// querying bookings, applying the count-only and paging options to them
+func inMemoryConflicts(req domain.CheckConflictsRequest, conflicts []domain.Conflict) *domain.CheckConflictsResponse {
+	resp := newCheckConflictsResponse(conflicts)
+	if req.CountOnly {
+		resp.Conflicts = []domain.Conflict{}
+	} else if pagedCheck(req) {
+		resp.Conflicts = pageConflicts(sortConflicts(conflicts, req.Sort), req.Offset, req.Limit)
+	}
+	return resp
+}
+
+// newCheckConflictsResponse wraps conflicts in a response with summary flags set
+func newCheckConflictsResponse(conflicts []domain.Conflict) *domain.CheckConflictsResponse {
+	resp := &domain.CheckConflictsResponse{
+		HasConflicts:   len(conflicts) > 0,
+		ConflictCount:  len(conflicts),
+		TotalConflicts: len(conflicts),
+		Conflicts:      conflicts,
+	}
+	for _, c := range conflicts {
+		if c.Severity == domain.ConflictSeverityHard {
+			resp.HasHardConflicts = true
+			break
+		}
+	}
+	return resp
+}
+
+// maxCertificationLength matches the resource_certifications.certification column
+const maxCertificationLength = 100
+
+// certificationConflicts returns a hard conflict for each requested resource
+// that doesn't hold the required certification through the end of the range.
+// It applies even when the resource is free at that time.
+func (s *ConflictService) certificationConflicts(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest) ([]domain.Conflict, error) {
+	if req.RequiredCertification == "" {
+		return nil, nil
+	}
+
+	rows, err := q.ListResourcesMissingCertification(ctx, repository.ListResourcesMissingCertificationParams{
+		Certification: req.RequiredCertification,
+		ValidUntil:    req.EndTime,
+		ResourceIds:   req.ResourceIDs,
+	})
+	if err != nil {
+		return nil, dbError("failed to check certifications", err)
+	}
+
+	conflicts := make([]domain.Conflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, domain.Conflict{
+			Kind:               domain.ConflictKindCertification,
+			Severity:           domain.ConflictSeverityHard,
+			ResourceID:         row.ID,
+			ResourceName:       row.Name,
+			RequestedStartTime: req.StartTime,
+			RequestedEndTime:   req.EndTime,
+			OverlapStart:       req.StartTime,
+			OverlapEnd:         req.EndTime,
+			OverlapMinutes:     req.EndTime.Sub(req.StartTime).Minutes(),
+			Message:            fmt.Sprintf("Resource '%s' does not hold the required certification '%s'", row.Name, req.RequiredCertification),
+		})
+	}
+	return conflicts, nil
+}
+
+// maxExternalBusyWindows caps how many caller-supplied busy windows are accepted
+const maxExternalBusyWindows = 200
+
+// validateExternalBusy checks each caller-supplied busy window is a proper range
+func validateExternalBusy(windows []domain.TimeRange) error {
+	if len(windows) > maxExternalBusyWindows {
+		return domain.NewValidationError(fmt.Sprintf("external_busy must not contain more than %d windows", maxExternalBusyWindows))
+	}
+	for i, w := range windows {
+		if w.Start.IsZero() || w.End.IsZero() {
+			return domain.NewValidationError(fmt.Sprintf("external_busy[%d] must have start and end", i))
+		}
+		if !w.End.After(w.Start) {
+			return domain.NewValidationError(fmt.Sprintf("external_busy[%d] end must be after start", i))
+		}
+	}
+	return nil
+}
+
+// externalConflicts returns a conflict for each external busy window that
+// overlaps the requested range, using the same [start, end) semantics as bookings
+func externalConflicts(req domain.CheckConflictsRequest) []domain.Conflict {
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+	conflicts := []domain.Conflict{}
+	for _, w := range req.ExternalBusy {
+		if !w.Start.Before(req.EndTime) || !w.End.After(req.StartTime) || !meetsMinOverlap(w, req) {
+			continue
+		}
+		conflict := domain.Conflict{
+			Kind:               domain.ConflictKindExternal,
+			Severity:           domain.ConflictSeverityHard,
+			ExistingStartTime:  w.Start,
+			ExistingEndTime:    w.End,
+			RequestedStartTime: req.StartTime,
+			RequestedEndTime:   req.EndTime,
+			Message:            fmt.Sprintf("Requested time overlaps an external busy window from %s to %s", w.Start.Format("2006-01-02 15:04"), w.End.Format("2006-01-02 15:04")),
+		}
+		setOverlap(&conflict, w, requested)
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// meetsMinOverlap reports whether the busy range overlaps the requested range
+// for at least the request's minimum overlap
+func meetsMinOverlap(busy domain.TimeRange, req domain.CheckConflictsRequest) bool {
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+	return overlapDuration(busy, requested) >= time.Duration(req.MinOverlapMinutes)*time.Minute
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxConflictPageLimit caps how many conflicts one page of a check may return
+const maxConflictPageLimit = 500
+
+// pagedCheck reports whether a conflict check asked for sorted, paged results
+func pagedCheck(req domain.CheckConflictsRequest) bool {
+	return req.Limit != 0 || req.Offset != 0 || req.Sort != ""
+}
+
+// validateConflictPage checks the paging and sort options of a conflict check
+func validateConflictPage(req domain.CheckConflictsRequest) error {
+	if req.Limit < 0 || req.Limit > maxConflictPageLimit {
+		return domain.NewValidationError(fmt.Sprintf("limit must be between 0 and %d", maxConflictPageLimit))
+	}
+	if req.Offset < 0 {
+		return domain.NewValidationError("offset must not be negative")
+	}
+	switch req.Sort {
+	case "", domain.ConflictSortOverlapDesc, domain.ConflictSortStartAsc:
+		return nil
+	default:
+		return domain.NewValidationError("sort must be overlap_desc or start_asc")
+	}
+}
+
+// checkConflictsPage answers a paged check. Booking conflicts are sorted and
+// cut in SQL, fetching only as many as the page could need; the external,
+// certification, and capacity conflicts in extra are few, so they are merged
+// in memory.
+// Totals and hardness cover every conflict, not just the page.
+func (s *ConflictService) checkConflictsPage(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest, extra []domain.Conflict) (*domain.CheckConflictsResponse, error) {
+	resp, err := s.countConflicts(ctx, q, req, extra)
+	if err != nil {
+		return nil, err
+	}
+
+	order := req.Sort
+	if order == "" {
+		order = domain.ConflictSortStartAsc
+	}
+	params := repository.ListConflictsPageParams{
+		ResourceIds:       req.ResourceIDs,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		MinOverlapMinutes: req.MinOverlapMinutes,
+		Sort:              string(order),
+	}
+	if req.ExcludeScheduleID != nil {
+		params.ExcludeScheduleID = sql.NullInt32{Int32: *req.ExcludeScheduleID, Valid: true}
+	}
+	if req.Limit > 0 {
+		// A page can't need more rows than offset+limit; clamp so a huge
+		// offset doesn't overflow
+		rowLimit := min(int64(req.Offset)+int64(req.Limit), math.MaxInt32)
+		params.RowLimit = sql.NullInt32{Int32: int32(rowLimit), Valid: true}
+	}
+
+	rows, err := q.ListConflictsPage(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to list conflicts", err)
+	}
+
+	pendingBlocks := len(rows) > 0 && s.flags.Enabled(ctx, FlagPendingBookingsBlock)
+	conflicts := make([]domain.Conflict, 0, len(rows)+len(extra))
+	for _, row := range rows {
+		conflicts = append(conflicts, bookingConflict(repository.CheckConflictsRow(row), req, pendingBlocks))
+	}
+	conflicts = append(conflicts, extra...)
+
+	resp.Conflicts = pageConflicts(sortConflicts(conflicts, order), req.Offset, req.Limit)
+	return resp, nil
+}
+
+// sortConflicts orders conflicts the way ListConflictsPage does. The sort is
+// stable, so rows already in query order keep their tie-break by id.
+// Certification conflicts have no existing booking, so they sort as starting
+// at the zero time.
+func sortConflicts(conflicts []domain.Conflict, order domain.ConflictSort) []domain.Conflict {
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		a, b := conflicts[i], conflicts[j]
+		if order == domain.ConflictSortOverlapDesc && a.OverlapMinutes != b.OverlapMinutes {
+			return a.OverlapMinutes > b.OverlapMinutes
+		}
+		return a.ExistingStartTime.Before(b.ExistingStartTime)
+	})
+	return conflicts
+}
+
+// pageConflicts returns the page of sorted conflicts starting at offset; a
+// zero limit returns the rest
+func pageConflicts(conflicts []domain.Conflict, offset, limit int32) []domain.Conflict {
+	if int(offset) >= len(conflicts) {
+		return []domain.Conflict{}
+	}
+	conflicts = conflicts[offset:]
+	if limit > 0 && int(limit) < len(conflicts) {
+		conflicts = conflicts[:limit]
+	}
+	return conflicts
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
 	"github.com/catering-event-manager/scheduling-service/internal/testutil"
 )
 
@@ -16,7 +17,7 @@ func TestCheckConflicts_NoResourceIDs(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	req := domain.CheckConflictsRequest{
 		ResourceIDs: []int32{}, // Empty
@@ -35,7 +36,7 @@ func TestCheckConflicts_InvalidTimeRange_EndBeforeStart(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	now := time.Now()
 	req := domain.CheckConflictsRequest{
@@ -59,7 +60,7 @@ func TestCheckConflicts_InvalidTimeRange_EndEqualsStart(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	now := time.Now()
 	req := domain.CheckConflictsRequest{
@@ -96,7 +97,7 @@ func TestCheckConflicts_NoConflicts(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for conflicts BEFORE the existing entry (05:00 - 08:00)
 	req := domain.CheckConflictsRequest{
@@ -129,7 +130,7 @@ func TestCheckConflicts_NoConflicts_AfterExisting(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for conflicts AFTER the existing entry (18:00 - 21:00)
 	req := domain.CheckConflictsRequest{
@@ -162,7 +163,7 @@ func TestCheckConflicts_SingleOverlap(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for overlap at the start (07:00 - 12:00 overlaps with 09:00 - 17:00)
 	req := domain.CheckConflictsRequest{
@@ -202,7 +203,7 @@ func TestCheckConflicts_MultipleOverlaps(t *testing.T) {
 	testutil.CreateScheduleEntry(t, testDB.DB, resource1, eventID, existingStart, existingEnd, nil)
 	testutil.CreateScheduleEntry(t, testDB.DB, resource2, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for overlap on both resources
 	req := domain.CheckConflictsRequest{
@@ -232,7 +233,7 @@ func TestCheckConflicts_ExcludeScheduleID(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	scheduleID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for conflicts but exclude this schedule entry (update scenario)
 	excludeID := scheduleID
@@ -264,7 +265,7 @@ func TestCheckConflicts_ExactBoundary_NoOverlap(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for conflicts starting exactly when existing ends (17:00 - 20:00)
 	// Using [) interval semantics, this should NOT conflict
@@ -295,7 +296,7 @@ func TestCheckConflicts_FullyContained(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Requested range is fully contained within existing (11:00 - 15:00)
 	req := domain.CheckConflictsRequest{
@@ -325,7 +326,7 @@ func TestCheckConflicts_FullyContains(t *testing.T) {
 	existingEnd := baseDay.Add(17 * time.Hour)
 	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, existingStart, existingEnd, nil)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Requested range fully contains existing (07:00 - 19:00)
 	req := domain.CheckConflictsRequest{
@@ -358,7 +359,7 @@ func TestCheckConflicts_WithTaskInfo(t *testing.T) {
 		TaskID: &taskID,
 	})
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for overlap
 	req := domain.CheckConflictsRequest{
@@ -384,7 +385,7 @@ func TestCheckConflicts_NonExistentResource(t *testing.T) {
 	testDB := testutil.SetupTestDB(t)
 	defer testutil.TeardownTestDB(t, testDB)
 
-	service := NewConflictService(testDB.DB)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
 
 	// Check for conflicts with non-existent resource ID
 	req := domain.CheckConflictsRequest{
@@ -399,3 +400,1001 @@ func TestCheckConflicts_NonExistentResource(t *testing.T) {
 	assert.False(t, result.HasConflicts)
 	assert.Empty(t, result.Conflicts)
 }
+
+func TestCheckConflicts_WithinReleaseGrace(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:                "Chafing Dishes",
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	// Existing booking 09:00 - 17:00, effectively busy until 17:30
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	// Request starting at 17:15 lands inside the grace window
+	req := domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(17*time.Hour + 15*time.Minute),
+		EndTime:     baseDay.Add(20 * time.Hour),
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.True(t, result.HasConflicts)
+	require.Len(t, result.Conflicts, 1)
+	assert.Contains(t, result.Conflicts[0].Message, "30 min release grace")
+}
+
+func TestCheckConflicts_AfterReleaseGrace(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:                "Chafing Dishes",
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	// Request starting exactly when the grace window ends
+	req := domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(17*time.Hour + 30*time.Minute),
+		EndTime:     baseDay.Add(20 * time.Hour),
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.False(t, result.HasConflicts)
+	assert.Empty(t, result.Conflicts)
+}
+
+func TestCheckConflicts_ExternalBusyAloneTriggersConflict(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	// Resource has no bookings, but the caller's calendar is busy 10:00 - 12:00
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(11 * time.Hour),
+		EndTime:     baseDay.Add(14 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(10 * time.Hour), End: baseDay.Add(12 * time.Hour)},
+			{Start: baseDay.Add(14 * time.Hour), End: baseDay.Add(15 * time.Hour)}, // touches end, no overlap
+		},
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.True(t, result.HasConflicts)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, domain.ConflictKindExternal, result.Conflicts[0].Kind)
+	assert.Equal(t, baseDay.Add(10*time.Hour), result.Conflicts[0].ExistingStartTime)
+}
+
+func TestCheckConflicts_ExternalBusyWithoutResources(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(10 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(8 * time.Hour), End: baseDay.Add(9*time.Hour + 30*time.Minute)},
+		},
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.True(t, result.HasConflicts)
+	assert.Len(t, result.Conflicts, 1)
+}
+
+func TestCheckConflicts_InvalidExternalBusy(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(10 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(12 * time.Hour), End: baseDay.Add(11 * time.Hour)},
+		},
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code)
+}
+
+func TestCheckConflicts_ApprovalStates(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	approved := testutil.CreateResource(t, testDB.DB, nil)
+	pending := testutil.CreateResource(t, testDB.DB, nil)
+	rejected := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	for id, status := range map[int32]string{approved: "approved", pending: "pending", rejected: "rejected"} {
+		testutil.CreateScheduleEntry(t, testDB.DB, id, eventID,
+			baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour),
+			&testutil.ScheduleEntryOpts{ApprovalStatus: status})
+	}
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	check := func(resourceID int32) *domain.CheckConflictsResponse {
+		result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+			ResourceIDs: []int32{resourceID},
+			StartTime:   baseDay.Add(10 * time.Hour),
+			EndTime:     baseDay.Add(12 * time.Hour),
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("approved is a hard conflict", func(t *testing.T) {
+		result := check(approved)
+		assert.True(t, result.HasConflicts)
+		assert.True(t, result.HasHardConflicts)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, domain.ConflictSeverityHard, result.Conflicts[0].Severity)
+	})
+
+	t.Run("pending is a soft conflict", func(t *testing.T) {
+		result := check(pending)
+		assert.True(t, result.HasConflicts)
+		assert.False(t, result.HasHardConflicts)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, domain.ConflictSeveritySoft, result.Conflicts[0].Severity)
+		assert.Contains(t, result.Conflicts[0].Message, "pending approval")
+	})
+
+	t.Run("rejected does not conflict", func(t *testing.T) {
+		result := check(rejected)
+		assert.False(t, result.HasConflicts)
+		assert.Empty(t, result.Conflicts)
+	})
+}
+
+func TestCheckConflicts_MinOverlapThreshold(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	// Existing booking 10:00 - 12:01 overlaps a 12:00 request by one minute
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(12*time.Hour+time.Minute), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	tests := []struct {
+		name          string
+		minOverlap    int32
+		wantConflicts int
+	}{
+		{name: "ignored at threshold 5", minOverlap: 5, wantConflicts: 0},
+		{name: "flagged at threshold 0", minOverlap: 0, wantConflicts: 1},
+		{name: "flagged at exactly the overlap", minOverlap: 1, wantConflicts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+				ResourceIDs:       []int32{resourceID},
+				StartTime:         baseDay.Add(12 * time.Hour),
+				EndTime:           baseDay.Add(14 * time.Hour),
+				MinOverlapMinutes: tt.minOverlap,
+			})
+
+			require.NoError(t, err)
+			assert.Len(t, result.Conflicts, tt.wantConflicts)
+			assert.Equal(t, tt.wantConflicts > 0, result.HasConflicts)
+		})
+	}
+}
+
+func TestCheckConflicts_MinOverlapAppliesToExternalBusy(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(10 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(8 * time.Hour), End: baseDay.Add(9*time.Hour + time.Minute)},
+			{Start: baseDay.Add(9*time.Hour + 30*time.Minute), End: baseDay.Add(11 * time.Hour)},
+		},
+		MinOverlapMinutes: 5,
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, baseDay.Add(9*time.Hour+30*time.Minute), result.Conflicts[0].ExistingStartTime)
+}
+
+func TestCheckConflicts_NegativeMinOverlap(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	_, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs:       []int32{1},
+		StartTime:         baseDay.Add(9 * time.Hour),
+		EndTime:           baseDay.Add(10 * time.Hour),
+		MinOverlapMinutes: -1,
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
+
+func TestCheckConflicts_BufferBoundaries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	// Existing booking starts 15 minutes after the 15:00-17:00 request ends
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(17*time.Hour+15*time.Minute), baseDay.Add(19*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	tests := []struct {
+		name          string
+		buffer        int32
+		wantConflicts int
+	}{
+		{name: "no buffer keeps the default", buffer: 0, wantConflicts: 0},
+		{name: "buffer ending exactly at the booking", buffer: 15, wantConflicts: 0},
+		{name: "buffer reaching into the booking", buffer: 30, wantConflicts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+				ResourceIDs:   []int32{resourceID},
+				StartTime:     baseDay.Add(15 * time.Hour),
+				EndTime:       baseDay.Add(17 * time.Hour),
+				BufferMinutes: tt.buffer,
+			})
+
+			require.NoError(t, err)
+			require.Len(t, result.Conflicts, tt.wantConflicts)
+			if tt.wantConflicts > 0 {
+				// The conflict reports the range that was requested, not the widened one
+				assert.True(t, baseDay.Add(15*time.Hour).Equal(result.Conflicts[0].RequestedStartTime))
+				assert.True(t, baseDay.Add(17*time.Hour).Equal(result.Conflicts[0].RequestedEndTime))
+				assert.Equal(t, 15.0, result.Conflicts[0].OverlapMinutes)
+				assert.Equal(t, domain.ConflictSeveritySoft, result.Conflicts[0].Severity)
+				assert.False(t, result.HasHardConflicts)
+			}
+		})
+	}
+}
+
+func TestCheckConflicts_BufferBeforeStart(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	// An external commitment ending exactly when the request starts
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime:    baseDay.Add(12 * time.Hour),
+		EndTime:      baseDay.Add(14 * time.Hour),
+		ExternalBusy: []domain.TimeRange{{Start: baseDay.Add(10 * time.Hour), End: baseDay.Add(12 * time.Hour)}},
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, result.HasConflicts)
+
+	req.BufferMinutes = 1
+	result, err = service.CheckConflicts(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, 1.0, result.Conflicts[0].OverlapMinutes)
+	// Only the buffer overlaps, so the conflict is a warning
+	assert.Equal(t, domain.ConflictSeveritySoft, result.Conflicts[0].Severity)
+	assert.False(t, result.HasHardConflicts)
+}
+
+func TestCheckConflicts_BufferSeverity(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	overlapping := domain.TimeRange{Start: baseDay.Add(13 * time.Hour), End: baseDay.Add(15 * time.Hour)}
+	nearby := domain.TimeRange{Start: baseDay.Add(10 * time.Hour), End: baseDay.Add(11*time.Hour + 45*time.Minute)}
+	req := domain.CheckConflictsRequest{
+		StartTime:     baseDay.Add(12 * time.Hour),
+		EndTime:       baseDay.Add(14 * time.Hour),
+		ExternalBusy:  []domain.TimeRange{nearby, overlapping},
+		BufferMinutes: 30,
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 2)
+	bySeverity := map[domain.ConflictSeverity]domain.Conflict{}
+	for _, c := range result.Conflicts {
+		bySeverity[c.Severity] = c
+	}
+	assert.True(t, nearby.Start.Equal(bySeverity[domain.ConflictSeveritySoft].ExistingStartTime))
+	assert.Contains(t, bySeverity[domain.ConflictSeveritySoft].Message, "within buffer")
+	assert.True(t, overlapping.Start.Equal(bySeverity[domain.ConflictSeverityHard].ExistingStartTime))
+	assert.True(t, result.HasConflicts)
+	assert.True(t, result.HasHardConflicts)
+
+	// Without the true overlap only the warning is left
+	req.ExternalBusy = []domain.TimeRange{nearby}
+	result, err = service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.True(t, result.HasConflicts)
+	assert.False(t, result.HasHardConflicts)
+}
+
+func TestCheckConflicts_InvalidBuffer(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	for _, buffer := range []int32{-1, maxBufferMinutes + 1} {
+		_, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+			ResourceIDs:   []int32{1},
+			StartTime:     baseDay.Add(9 * time.Hour),
+			EndTime:       baseDay.Add(10 * time.Hour),
+			BufferMinutes: buffer,
+		})
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr, "buffer %d", buffer)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	}
+}
+
+func TestCheckConflicts_CountOnlyMatchesDetails(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	cancelledAt := baseDay
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(12*time.Hour), baseDay.Add(13*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+	// Ends before the request but its release grace reaches 10 minutes into it
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(7*time.Hour), baseDay.Add(9*time.Hour+40*time.Minute), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	for _, minOverlap := range []int32{0, 15, 90} {
+		req := domain.CheckConflictsRequest{
+			ResourceIDs:       []int32{chef, oven},
+			StartTime:         baseDay.Add(10 * time.Hour),
+			EndTime:           baseDay.Add(14 * time.Hour),
+			ExternalBusy:      []domain.TimeRange{{Start: baseDay.Add(13 * time.Hour), End: baseDay.Add(15 * time.Hour)}},
+			MinOverlapMinutes: minOverlap,
+		}
+
+		detail, err := service.CheckConflicts(context.Background(), req)
+		require.NoError(t, err)
+
+		req.CountOnly = true
+		count, err := service.CheckConflicts(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, len(detail.Conflicts), detail.ConflictCount, "min overlap %d", minOverlap)
+		assert.Equal(t, detail.ConflictCount, count.ConflictCount, "min overlap %d", minOverlap)
+		assert.Equal(t, detail.HasConflicts, count.HasConflicts, "min overlap %d", minOverlap)
+		assert.Equal(t, detail.HasHardConflicts, count.HasHardConflicts, "min overlap %d", minOverlap)
+		assert.Empty(t, count.Conflicts)
+	}
+
+	// Only the pending booking overlaps, so the count is soft
+	result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{chef},
+		StartTime:   baseDay.Add(12 * time.Hour),
+		EndTime:     baseDay.Add(13 * time.Hour),
+		CountOnly:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ConflictCount)
+	assert.True(t, result.HasConflicts)
+	assert.False(t, result.HasHardConflicts)
+}
+
+func TestCheckConflicts_RequiredCertification(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	certified := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Certified Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	uncertified := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Trainee Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	lapsed := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Lapsed Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateResourceCertification(t, testDB.DB, certified, "food_safety", nil)
+	testutil.CreateResourceCertification(t, testDB.DB, uncertified, "bartending", nil)
+	expired := baseDay.Add(10 * time.Hour)
+	testutil.CreateResourceCertification(t, testDB.DB, lapsed, "food_safety", &expired)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	req := domain.CheckConflictsRequest{
+		StartTime:             baseDay.Add(9 * time.Hour),
+		EndTime:               baseDay.Add(12 * time.Hour),
+		RequiredCertification: "food_safety",
+	}
+
+	t.Run("certified resource passes", func(t *testing.T) {
+		req.ResourceIDs = []int32{certified}
+		result, err := service.CheckConflicts(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.False(t, result.HasConflicts)
+	})
+
+	t.Run("uncertified resource is rejected while free", func(t *testing.T) {
+		req.ResourceIDs = []int32{certified, uncertified, lapsed}
+		result, err := service.CheckConflicts(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.True(t, result.HasHardConflicts)
+		require.Len(t, result.Conflicts, 2)
+		for i, id := range []int32{uncertified, lapsed} {
+			c := result.Conflicts[i]
+			assert.Equal(t, domain.ConflictKindCertification, c.Kind)
+			assert.Equal(t, domain.ConflictSeverityHard, c.Severity)
+			assert.Equal(t, id, c.ResourceID)
+			assert.Contains(t, c.Message, "food_safety")
+		}
+	})
+
+	t.Run("count only includes certification conflicts", func(t *testing.T) {
+		req.ResourceIDs = []int32{uncertified}
+		req.CountOnly = true
+		result, err := service.CheckConflicts(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.True(t, result.HasHardConflicts)
+		assert.Equal(t, 1, result.ConflictCount)
+	})
+}
+
+func TestCheckConflicts_PagesSortedResults(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return baseDay.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	// Overlaps with the 10:00-14:00 request: 60, 120, 30, 240 and 120 minutes
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, at(9, 0), at(11, 0), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, at(12, 0), at(15, 0), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, at(13, 30), at(16, 0), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, at(8, 0), at(18, 0), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, at(11, 0), at(13, 0), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	page := func(order domain.ConflictSort, offset, limit int32) *domain.CheckConflictsResponse {
+		t.Helper()
+		result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+			ResourceIDs:  []int32{chef, oven},
+			StartTime:    at(10, 0),
+			EndTime:      at(14, 0),
+			ExternalBusy: []domain.TimeRange{{Start: at(13, 15), End: at(20, 0)}},
+			Sort:         order,
+			Offset:       offset,
+			Limit:        limit,
+		})
+		require.NoError(t, err)
+		return result
+	}
+	starts := func(conflicts []domain.Conflict) []time.Time {
+		out := make([]time.Time, 0, len(conflicts))
+		for _, c := range conflicts {
+			out = append(out, c.ExistingStartTime.UTC())
+		}
+		return out
+	}
+
+	// Walking start_asc pages of two covers every conflict once, in order
+	var walked []domain.Conflict
+	for offset := int32(0); offset < 8; offset += 2 {
+		result := page(domain.ConflictSortStartAsc, offset, 2)
+		assert.Equal(t, 6, result.TotalConflicts)
+		assert.True(t, result.HasHardConflicts)
+		walked = append(walked, result.Conflicts...)
+	}
+	assert.Equal(t, []time.Time{at(8, 0), at(9, 0), at(11, 0), at(12, 0), at(13, 15), at(13, 30)}, starts(walked))
+
+	// overlap_desc puts the longest overlap first; equal overlaps keep start order
+	first := page(domain.ConflictSortOverlapDesc, 0, 3)
+	assert.Equal(t, []time.Time{at(8, 0), at(11, 0), at(12, 0)}, starts(first.Conflicts))
+	assert.Equal(t, []float64{240, 120, 120}, []float64{
+		first.Conflicts[0].OverlapMinutes, first.Conflicts[1].OverlapMinutes, first.Conflicts[2].OverlapMinutes,
+	})
+
+	rest := page(domain.ConflictSortOverlapDesc, 3, 3)
+	assert.Equal(t, []time.Time{at(9, 0), at(13, 15), at(13, 30)}, starts(rest.Conflicts))
+	assert.Equal(t, domain.ConflictKindExternal, rest.Conflicts[1].Kind)
+
+	// The same page twice is identical
+	assert.Equal(t, first.Conflicts, page(domain.ConflictSortOverlapDesc, 0, 3).Conflicts)
+
+	// Past the end is an empty page with the full total
+	past := page(domain.ConflictSortStartAsc, 10, 2)
+	assert.Empty(t, past.Conflicts)
+	assert.Equal(t, 6, past.TotalConflicts)
+}
+
+func TestCheckConflicts_PagesExternalBusyWithoutResources(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return baseDay.Add(time.Duration(hour) * time.Hour) }
+
+	result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		StartTime: at(9),
+		EndTime:   at(17),
+		ExternalBusy: []domain.TimeRange{
+			{Start: at(15), End: at(16)},
+			{Start: at(8), End: at(12)},
+			{Start: at(11), End: at(13)},
+		},
+		Sort:  domain.ConflictSortOverlapDesc,
+		Limit: 2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.TotalConflicts)
+	require.Len(t, result.Conflicts, 2)
+	assert.Equal(t, 180.0, result.Conflicts[0].OverlapMinutes)
+	assert.Equal(t, at(11), result.Conflicts[1].ExistingStartTime)
+}
+
+func TestCheckConflicts_InvalidPaging(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	for _, req := range []domain.CheckConflictsRequest{
+		{Limit: -1},
+		{Limit: maxConflictPageLimit + 1},
+		{Offset: -1},
+		{Sort: "name_asc"},
+	} {
+		req.StartTime = baseDay.Add(9 * time.Hour)
+		req.EndTime = baseDay.Add(10 * time.Hour)
+		req.ExternalBusy = []domain.TimeRange{{Start: baseDay.Add(9 * time.Hour), End: baseDay.Add(10 * time.Hour)}}
+
+		_, err := service.CheckConflicts(context.Background(), req)
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	}
+}
+
+func TestGetConflictClusters_GroupsOverlappingBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	first := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(8*time.Hour), baseDay.Add(11*time.Hour), nil)
+	second := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(13*time.Hour), nil)
+	third := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(12*time.Hour), baseDay.Add(15*time.Hour), nil)
+	// Standalone booking and a rejected overlap are not clustered
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(17*time.Hour), baseDay.Add(18*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(17*time.Hour), baseDay.Add(19*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	result, err := service.GetConflictClusters(context.Background(), domain.ConflictClustersRequest{
+		ResourceID: resourceID,
+		StartDate:  baseDay,
+		EndDate:    baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Clusters, 1)
+	cluster := result.Clusters[0]
+	require.Len(t, cluster.Entries, 3)
+	assert.Equal(t, []int32{first, second, third},
+		[]int32{cluster.Entries[0].ID, cluster.Entries[1].ID, cluster.Entries[2].ID})
+	assert.Equal(t, baseDay.Add(8*time.Hour), cluster.Start)
+	assert.Equal(t, baseDay.Add(15*time.Hour), cluster.End)
+}
+
+func TestGetConflictClusters_InvalidRange(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	now := time.Now()
+	_, err := service.GetConflictClusters(context.Background(), domain.ConflictClustersRequest{
+		ResourceID: 1,
+		StartDate:  now,
+		EndDate:    now,
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
+
+func TestListAllConflicts_AcrossResources(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	chefFirst := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	chefSecond := testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEvent,
+		baseDay.Add(11*time.Hour), baseDay.Add(13*time.Hour), nil)
+	ovenFirst := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil)
+	ovenSecond := testutil.CreateScheduleEntry(t, testDB.DB, oven, otherEvent,
+		baseDay.Add(15*time.Hour), baseDay.Add(17*time.Hour), nil)
+	// Back-to-back and outside the window: not reported
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, otherEvent,
+		baseDay.Add(17*time.Hour), baseDay.Add(18*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(48*time.Hour), baseDay.Add(50*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEvent,
+		baseDay.Add(49*time.Hour), baseDay.Add(51*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	req := domain.AllConflictsRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+		Limit:     50,
+	}
+
+	result, err := service.ListAllConflicts(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), result.Total)
+	require.Len(t, result.Pairs, 2)
+
+	assert.Equal(t, chef, result.Pairs[0].ResourceID)
+	assert.Equal(t, "Chef", result.Pairs[0].ResourceName)
+	assert.Equal(t, chefFirst, result.Pairs[0].Booking.ScheduleID)
+	assert.Equal(t, chefSecond, result.Pairs[0].ConflictsWith.ScheduleID)
+	assert.Equal(t, otherEvent, result.Pairs[0].ConflictsWith.EventID)
+	assert.NotEmpty(t, result.Pairs[0].ConflictsWith.EventName)
+
+	assert.Equal(t, oven, result.Pairs[1].ResourceID)
+	assert.Equal(t, ovenFirst, result.Pairs[1].Booking.ScheduleID)
+	assert.Equal(t, ovenSecond, result.Pairs[1].ConflictsWith.ScheduleID)
+
+	// Paging keeps the total and returns the rest
+	req.Limit = 1
+	req.Offset = 1
+	page, err := service.ListAllConflicts(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+	require.Len(t, page.Pairs, 1)
+	assert.Equal(t, oven, page.Pairs[0].ResourceID)
+}
+
+func TestListAllConflicts_InvalidRange(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	_, err := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil).ListAllConflicts(context.Background(), domain.AllConflictsRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay,
+		Limit:     50,
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
+
+func TestBookingConflict_OverlapWindow(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	req := domain.CheckConflictsRequest{StartTime: at(10, 0), EndTime: at(14, 0)}
+
+	tests := []struct {
+		name        string
+		start, end  time.Time
+		grace       int32
+		wantStart   time.Time
+		wantEnd     time.Time
+		wantMinutes float64
+	}{
+		{"existing overlaps the start", at(8, 0), at(11, 30), 0, at(10, 0), at(11, 30), 90},
+		{"existing overlaps the end", at(13, 15), at(16, 0), 0, at(13, 15), at(14, 0), 45},
+		{"existing inside the request", at(11, 0), at(12, 0), 0, at(11, 0), at(12, 0), 60},
+		{"existing contains the request", at(9, 0), at(15, 0), 0, at(10, 0), at(14, 0), 240},
+		{"identical ranges", at(10, 0), at(14, 0), 0, at(10, 0), at(14, 0), 240},
+		{"release grace reaches into the request", at(8, 0), at(9, 45), 30, at(10, 0), at(10, 15), 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := bookingConflict(repository.CheckConflictsRow{
+				ResourceID:          1,
+				ResourceName:        "Chef",
+				EventName:           "Gala",
+				ExistingStartTime:   tt.start,
+				ExistingEndTime:     tt.end,
+				ReleaseGraceMinutes: tt.grace,
+			}, req, false)
+
+			assert.Equal(t, tt.wantStart, c.OverlapStart)
+			assert.Equal(t, tt.wantEnd, c.OverlapEnd)
+			assert.Equal(t, tt.wantMinutes, c.OverlapMinutes)
+			assert.Equal(t, c.OverlapEnd.Sub(c.OverlapStart).Minutes(), c.OverlapMinutes)
+		})
+	}
+}
+
+func TestExternalConflicts_OverlapWindow(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime: day.Add(10 * time.Hour),
+		EndTime:   day.Add(14 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: day.Add(9 * time.Hour), End: day.Add(11 * time.Hour)},
+			{Start: day.Add(12 * time.Hour), End: day.Add(13 * time.Hour)},
+		},
+	}
+
+	conflicts := externalConflicts(req)
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, day.Add(10*time.Hour), conflicts[0].OverlapStart)
+	assert.Equal(t, day.Add(11*time.Hour), conflicts[0].OverlapEnd)
+	assert.Equal(t, 60.0, conflicts[0].OverlapMinutes)
+	assert.Equal(t, day.Add(12*time.Hour), conflicts[1].OverlapStart)
+	assert.Equal(t, day.Add(13*time.Hour), conflicts[1].OverlapEnd)
+}
+
+func TestCheckConflicts_GroupByResource(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef"})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Oven"})
+	free := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Van"})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	for _, id := range []int32{chef, oven} {
+		testutil.CreateScheduleEntry(t, testDB.DB, id, eventID, day.Add(9*time.Hour), day.Add(11*time.Hour), nil)
+		testutil.CreateScheduleEntry(t, testDB.DB, id, eventID, day.Add(12*time.Hour), day.Add(14*time.Hour), nil)
+	}
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day.Add(15*time.Hour), day.Add(16*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	resp, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs:     []int32{chef, oven, free},
+		StartTime:       day.Add(8 * time.Hour),
+		EndTime:         day.Add(18 * time.Hour),
+		GroupByResource: true,
+	})
+	require.NoError(t, err)
+
+	// The flat list is still there
+	assert.Len(t, resp.Conflicts, 5)
+
+	require.Len(t, resp.ByResource, 3)
+	assert.Len(t, resp.ByResource[chef], 3)
+	assert.Len(t, resp.ByResource[oven], 2)
+	assert.Empty(t, resp.ByResource[free])
+	for id, conflicts := range resp.ByResource {
+		for _, c := range conflicts {
+			assert.Equal(t, id, c.ResourceID)
+		}
+	}
+	assert.Equal(t, map[int32]int{chef: 3, oven: 2, free: 0}, resp.ResourceCounts)
+
+	// Without the option the response isn't grouped
+	resp, err = service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{chef, oven},
+		StartTime:   day.Add(8 * time.Hour),
+		EndTime:     day.Add(18 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, resp.ByResource)
+	assert.Nil(t, resp.ResourceCounts)
+}
+
+func TestGroupConflictsByResource_SkipsExternal(t *testing.T) {
+	resp := &domain.CheckConflictsResponse{Conflicts: []domain.Conflict{
+		{Kind: domain.ConflictKindExternal},
+		{Kind: domain.ConflictKindBooking, ResourceID: 4, ConflictingEventID: 1},
+		{Kind: domain.ConflictKindDowntime, ResourceID: 4},
+		{Kind: domain.ConflictKindBooking, ResourceID: 4, ConflictingEventID: 2},
+	}}
+
+	groupConflictsByResource(resp, []int32{4})
+
+	require.Len(t, resp.ByResource, 1)
+	group := resp.ByResource[4]
+	require.Len(t, group, 3)
+	assert.Equal(t, int32(1), group[0].ConflictingEventID)
+	assert.Equal(t, domain.ConflictKindDowntime, group[1].Kind)
+	assert.Equal(t, int32(2), group[2].ConflictingEventID)
+	assert.Equal(t, map[int32]int{4: 3}, resp.ResourceCounts)
+}
+
+func TestCheckConflicts_GroupByResourceWithCountOnly(t *testing.T) {
+	service := &ConflictService{}
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	_, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs:     []int32{1},
+		StartTime:       start,
+		EndTime:         start.Add(time.Hour),
+		CountOnly:       true,
+		GroupByResource: true,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
+
+func TestCheckConflicts_DuplicateResourceIDs(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day.Add(9*time.Hour), day.Add(11*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, day.Add(9*time.Hour), day.Add(11*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	resp, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{chef, chef, oven, chef},
+		StartTime:   day.Add(10 * time.Hour),
+		EndTime:     day.Add(12 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Conflicts, 2)
+	assert.Equal(t, 2, resp.ConflictCount)
+	assert.ElementsMatch(t, []int32{chef, oven}, []int32{resp.Conflicts[0].ResourceID, resp.Conflicts[1].ResourceID})
+}
+
+func TestCheckConflicts_InvalidResourceIDs(t *testing.T) {
+	service := &ConflictService{}
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	tooMany := make([]int32, maxCheckResources+1)
+	for i := range tooMany {
+		tooMany[i] = int32(i + 1)
+	}
+
+	tests := []struct {
+		name    string
+		ids     []int32
+		message string
+	}{
+		{"zero", []int32{3, 0}, "resource_ids[1] must be a positive ID, got 0"},
+		{"negative", []int32{-7}, "resource_ids[0] must be a positive ID, got -7"},
+		{"too many", tooMany, "resource_ids must not contain more than 200 resources, got 201"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+				ResourceIDs: tt.ids,
+				StartTime:   start,
+				EndTime:     start.Add(time.Hour),
+			})
+			var domainErr *domain.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Equal(t, tt.message, domainErr.Message)
+		})
+	}
+}
+
+func TestUniqueResourceIDs(t *testing.T) {
+	// Repeats collapse, and the limit counts distinct resources
+	repeated := make([]int32, maxCheckResources*2)
+	for i := range repeated {
+		repeated[i] = int32(i%maxCheckResources + 1)
+	}
+	ids, err := uniqueResourceIDs(repeated)
+	require.NoError(t, err)
+	assert.Len(t, ids, maxCheckResources)
+
+	ids, err = uniqueResourceIDs([]int32{5, 2, 5, 9, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{5, 2, 9}, ids)
+}
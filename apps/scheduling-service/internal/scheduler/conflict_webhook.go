@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/webhook"
+)
+
+// ConflictWebhookEnv names the environment variable holding the URL that
+// conflict checks finding conflicts are posted to
+const ConflictWebhookEnv = "CONFLICT_WEBHOOK_URL"
+
+// LoadConflictWebhook starts delivering conflicts to CONFLICT_WEBHOOK_URL if
+// it is set, returning nil if it isn't. Call it once at startup; an invalid
+// URL is returned as an error so the service can refuse to start. The
+// notifier should be closed at shutdown so queued conflicts are delivered.
+func LoadConflictWebhook() (*webhook.Notifier, error) {
+	v, ok := os.LookupEnv(ConflictWebhookEnv)
+	if !ok || v == "" {
+		return nil, nil
+	}
+	u, err := webhook.ParseURL(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ConflictWebhookEnv, err)
+	}
+	return webhook.New(u, webhook.DefaultTimeout, webhook.DefaultQueueSize), nil
+}
+
+// notifyConflicts posts a check's result to the conflict webhook if it found
+// conflicts. Delivery happens in the background and never fails the check.
+func (s *ConflictService) notifyConflicts(ctx context.Context, resp *domain.CheckConflictsResponse) {
+	if s.notifier != nil && resp.HasConflicts {
+		s.notifier.Notify(ctx, resp)
+	}
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+	"github.com/catering-event-manager/scheduling-service/internal/webhook"
+)
+
+// receiveWebhooks starts a server collecting the bodies posted to it
+func receiveWebhooks(t *testing.T) (*webhook.Notifier, chan []byte) {
+	t.Helper()
+	bodies := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	t.Cleanup(srv.Close)
+	return webhook.New(srv.URL, time.Second, 10), bodies
+}
+
+// nextWebhook waits for the next body posted and decodes it
+func nextWebhook(t *testing.T, bodies chan []byte) domain.CheckConflictsResponse {
+	t.Helper()
+	var got domain.CheckConflictsResponse
+	select {
+	case body := <-bodies:
+		require.NoError(t, json.Unmarshal(body, &got))
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+	return got
+}
+
+// assertNoWebhook fails if anything more is posted
+func assertNoWebhook(t *testing.T, bodies chan []byte) {
+	t.Helper()
+	select {
+	case body := <-bodies:
+		t.Fatalf("unexpected delivery: %s", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNotifyConflicts(t *testing.T) {
+	notifier, bodies := receiveWebhooks(t)
+	s := NewConflictService(nil, nil, notifier)
+
+	// A clean check isn't sent
+	s.notifyConflicts(context.Background(), &domain.CheckConflictsResponse{Conflicts: []domain.Conflict{}})
+	s.notifyConflicts(context.Background(), &domain.CheckConflictsResponse{
+		HasConflicts:     true,
+		HasHardConflicts: true,
+		ConflictCount:    1,
+		TotalConflicts:   1,
+		Conflicts:        []domain.Conflict{{ResourceID: 7, ResourceName: "Head Chef", ConflictingEventName: "Garden Gala"}},
+	})
+
+	got := nextWebhook(t, bodies)
+	assert.True(t, got.HasConflicts)
+	require.Len(t, got.Conflicts, 1)
+	assert.Equal(t, "Head Chef", got.Conflicts[0].ResourceName)
+	assertNoWebhook(t, bodies)
+
+	// Without a notifier nothing is sent
+	NewConflictService(nil, nil, nil).notifyConflicts(context.Background(), &domain.CheckConflictsResponse{HasConflicts: true})
+}
+
+func TestNotifyConflicts_BatchChecks(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	busy := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Head Chef", Type: testutil.ResourceTypeStaff})
+	free := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Sous Chef", Type: testutil.ResourceTypeStaff})
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, busy, eventID, baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	notifier, bodies := receiveWebhooks(t)
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), notifier)
+
+	results, err := service.CheckConflictsBatch(context.Background(), []domain.CheckConflictsRequest{
+		{ResourceIDs: []int32{free}, StartTime: baseDay.Add(10 * time.Hour), EndTime: baseDay.Add(11 * time.Hour)},
+		{ResourceIDs: []int32{busy}, StartTime: baseDay.Add(10 * time.Hour), EndTime: baseDay.Add(11 * time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NotNil(t, results[1].Result)
+	assert.True(t, results[1].Result.HasConflicts)
+
+	// Only the check that found a conflict is posted
+	got := nextWebhook(t, bodies)
+	require.Len(t, got.Conflicts, 1)
+	assert.Equal(t, busy, got.Conflicts[0].ResourceID)
+	assertNoWebhook(t, bodies)
+}
+
+func TestNotifyConflicts_RefusedBooking(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	notifier, bodies := receiveWebhooks(t)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), notifier), events.Noop{})
+
+	_, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(11 * time.Hour),
+		EndTime:    baseDay.Add(13 * time.Hour),
+	})
+	require.Error(t, err)
+
+	got := nextWebhook(t, bodies)
+	assert.True(t, got.HasHardConflicts)
+	require.Len(t, got.Conflicts, 1)
+	assert.True(t, baseDay.Add(9*time.Hour).Equal(got.Conflicts[0].ExistingStartTime))
+
+	// A booking that goes through isn't posted
+	_, err = service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(12 * time.Hour),
+		EndTime:    baseDay.Add(14 * time.Hour),
+	})
+	require.NoError(t, err)
+	assertNoWebhook(t, bodies)
+}
+
+func TestLoadConflictWebhook(t *testing.T) {
+	// Unset, nothing is sent
+	t.Setenv(ConflictWebhookEnv, "")
+	require.NoError(t, os.Unsetenv(ConflictWebhookEnv))
+	notifier, err := LoadConflictWebhook()
+	require.NoError(t, err)
+	assert.Nil(t, notifier)
+
+	t.Setenv(ConflictWebhookEnv, "not a url")
+	_, err = LoadConflictWebhook()
+	assert.Error(t, err)
+
+	t.Setenv(ConflictWebhookEnv, "https://hooks.example.com/services/T000/B000")
+	notifier, err = LoadConflictWebhook()
+	require.NoError(t, err)
+	require.NotNil(t, notifier)
+	require.NoError(t, notifier.Close(context.Background()))
+}
@@ -0,0 +1,240 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxConsolidateEntries caps how many bookings a single merge may combine
+const maxConsolidateEntries = 100
+
+// ConsolidationService finds and merges fragmented bookings, where a resource
+// holds several back-to-back entries for the same piece of work
+type ConsolidationService struct {
+	db        *sql.DB
+	queries   *repository.Queries
+	publisher events.Publisher
+}
+
+// NewConsolidationService creates a new consolidation service publishing the
+// bookings it merges through publisher
+func NewConsolidationService(db *sql.DB, publisher events.Publisher) *ConsolidationService {
+	return &ConsolidationService{
+		db:        db,
+		queries:   repository.NewQueries(db),
+		publisher: publisher,
+	}
+}
+
+// SuggestConsolidations lists runs of a resource's bookings that share an
+// event, task, and approval status and touch or overlap, so each run could be
+// a single booking. Cancelled and rejected entries are ignored.
+func (s *ConsolidationService) SuggestConsolidations(ctx context.Context, req domain.ConsolidationSuggestionsRequest) (*domain.ConsolidationSuggestionsResponse, error) {
+	if _, err := s.queries.GetResourceByID(ctx, req.ResourceID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	params := repository.ListConsolidationCandidatesParams{ResourceID: req.ResourceID}
+	if req.EventID != nil {
+		params.EventID = sql.NullInt32{Int32: *req.EventID, Valid: true}
+	}
+	rows, err := s.queries.ListConsolidationCandidates(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to list schedule entries", err)
+	}
+
+	entries := make([]domain.ScheduleEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, toDomainScheduleEntry(repository.GetScheduleEntryByIDRow(row)))
+	}
+
+	return &domain.ConsolidationSuggestionsResponse{
+		ResourceID:  req.ResourceID,
+		Suggestions: suggestConsolidations(entries),
+	}, nil
+}
+
+// Consolidate merges the given bookings of a resource into the earliest one,
+// stretched to cover their union, and deletes the rest. The entries must share
+// an event, task, and approval status and form one unbroken range. Everything
+// happens in a single transaction with the entries locked, so a concurrent
+// change can't slip in between the check and the merge. Once it commits, the
+// deleted entries and the stretched one are published as schedule changes.
+func (s *ConsolidationService) Consolidate(ctx context.Context, req domain.ConsolidateRequest) (*domain.ConsolidateResponse, error) {
+	ids, err := validateConsolidateIDs(req.ScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	locked, err := q.LockScheduleEntries(ctx, ids)
+	if err != nil {
+		return nil, dbError("failed to lock schedule entries", err)
+	}
+	if len(locked) != len(ids) {
+		return nil, domain.NewNotFoundError("schedule entry not found")
+	}
+	merged, err := mergeRange(req.ResourceID, locked)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := locked[0]
+	removed := make([]int32, 0, len(locked)-1)
+	for _, entry := range locked[1:] {
+		removed = append(removed, entry.ID)
+	}
+	deleted, err := loadEntries(ctx, q, removed)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range removed {
+		if err := q.DeleteScheduleEntry(ctx, id); err != nil {
+			return nil, dbError("failed to delete schedule entry", err)
+		}
+	}
+
+	if _, err := q.UpdateScheduleEntryRange(ctx, repository.UpdateScheduleEntryRangeParams{
+		StartTime: merged.Start,
+		EndTime:   merged.End,
+		ID:        keep.ID,
+	}); err != nil {
+		return nil, entryWriteError("failed to update schedule entry", err)
+	}
+
+	row, err := q.GetScheduleEntryByID(ctx, keep.ID)
+	if err != nil {
+		return nil, dbError("failed to get schedule entry", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit consolidation", err)
+	}
+
+	resp := &domain.ConsolidateResponse{
+		Entry:      toDomainScheduleEntry(row),
+		RemovedIDs: removed,
+	}
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeDeleted, deleted)
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeUpdated, resp.Entry)
+	return resp, nil
+}
+
+// validateConsolidateIDs checks the requested IDs name at least two distinct
+// entries and returns them without duplicates
+func validateConsolidateIDs(ids []int32) ([]int32, error) {
+	if len(ids) > maxConsolidateEntries {
+		return nil, domain.NewValidationError("too many schedule_ids")
+	}
+
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, domain.NewValidationError("schedule_ids must be positive")
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) < 2 {
+		return nil, domain.NewValidationError("at least two schedule_ids are required")
+	}
+	return unique, nil
+}
+
+// mergeRange checks that chronologically sorted entries can be merged into one
+// booking of the resource and returns the range that booking would cover
+func mergeRange(resourceID int32, entries []repository.ResourceSchedule) (domain.TimeRange, error) {
+	first := entries[0]
+	merged := domain.TimeRange{Start: first.StartTime, End: first.EndTime}
+
+	for _, entry := range entries {
+		if entry.ResourceID != resourceID {
+			return domain.TimeRange{}, domain.NewNotFoundError("schedule entry not found for this resource")
+		}
+		if entry.CancelledAt.Valid || entry.ApprovalStatus == repository.ApprovalStatusRejected {
+			return domain.TimeRange{}, domain.NewConflictError("cancelled or rejected entries cannot be consolidated")
+		}
+		if entry.EventID != first.EventID || entry.TaskID != first.TaskID || entry.ApprovalStatus != first.ApprovalStatus {
+			return domain.TimeRange{}, domain.NewValidationError("entries must share an event, task, and approval status")
+		}
+		if entry.StartTime.After(merged.End) {
+			return domain.TimeRange{}, domain.NewValidationError("entries must be adjacent or overlapping")
+		}
+		if entry.EndTime.After(merged.End) {
+			merged.End = entry.EndTime
+		}
+	}
+	return merged, nil
+}
+
+// suggestConsolidations sweeps entries already grouped by event, task, and
+// approval status and ordered by start time within each group. An entry extends
+// the current run if it shares the group and starts no later than the run's
+// end so far. Only runs of two or more entries are suggested.
+func suggestConsolidations(entries []domain.ScheduleEntry) []domain.ConsolidationSuggestion {
+	suggestions := []domain.ConsolidationSuggestion{}
+	var run *domain.ConsolidationSuggestion
+
+	flush := func() {
+		if run != nil && len(run.ScheduleIDs) > 1 {
+			suggestions = append(suggestions, *run)
+		}
+		run = nil
+	}
+
+	for _, e := range entries {
+		if run != nil && sameWork(*run, e) && !e.StartTime.After(run.End) {
+			run.ScheduleIDs = append(run.ScheduleIDs, e.ID)
+			if e.EndTime.After(run.End) {
+				run.End = e.EndTime
+			}
+			continue
+		}
+		flush()
+		run = &domain.ConsolidationSuggestion{
+			EventID:        e.EventID,
+			TaskID:         e.TaskID,
+			ApprovalStatus: e.ApprovalStatus,
+			ScheduleIDs:    []int32{e.ID},
+			Start:          e.StartTime,
+			End:            e.EndTime,
+		}
+	}
+	flush()
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if !suggestions[i].Start.Equal(suggestions[j].Start) {
+			return suggestions[i].Start.Before(suggestions[j].Start)
+		}
+		return suggestions[i].ScheduleIDs[0] < suggestions[j].ScheduleIDs[0]
+	})
+	return suggestions
+}
+
+// sameWork reports whether an entry belongs to the same event, task, and
+// approval status as a run
+func sameWork(run domain.ConsolidationSuggestion, e domain.ScheduleEntry) bool {
+	if run.EventID != e.EventID || run.ApprovalStatus != e.ApprovalStatus {
+		return false
+	}
+	if run.TaskID == nil || e.TaskID == nil {
+		return run.TaskID == nil && e.TaskID == nil
+	}
+	return *run.TaskID == *e.TaskID
+}
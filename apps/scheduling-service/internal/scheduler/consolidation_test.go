@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestSuggestConsolidations(t *testing.T) {
+	base := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	task1, task2 := int32(1), int32(2)
+	entry := func(id int32, taskID *int32, startHour, endHour int) domain.ScheduleEntry {
+		return domain.ScheduleEntry{
+			ID:             id,
+			EventID:        10,
+			TaskID:         taskID,
+			StartTime:      base.Add(time.Duration(startHour) * time.Hour),
+			EndTime:        base.Add(time.Duration(endHour) * time.Hour),
+			ApprovalStatus: domain.ApprovalStatusApproved,
+		}
+	}
+
+	// Grouped by task, chronological within each group
+	entries := []domain.ScheduleEntry{
+		entry(1, &task1, 8, 10),
+		entry(2, &task1, 10, 12), // touches 1
+		entry(3, &task1, 11, 13), // overlaps 2
+		entry(4, &task1, 14, 15), // gap after 3
+		entry(5, &task2, 6, 7),
+		entry(6, &task2, 7, 8), // touches 5, different task from 1-4
+	}
+
+	got := suggestConsolidations(entries)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, []int32{5, 6}, got[0].ScheduleIDs)
+	assert.True(t, base.Add(6*time.Hour).Equal(got[0].Start))
+	assert.True(t, base.Add(8*time.Hour).Equal(got[0].End))
+	assert.Equal(t, []int32{1, 2, 3}, got[1].ScheduleIDs)
+	assert.True(t, base.Add(8*time.Hour).Equal(got[1].Start))
+	assert.True(t, base.Add(13*time.Hour).Equal(got[1].End))
+	assert.Equal(t, &task1, got[1].TaskID)
+
+	assert.Empty(t, suggestConsolidations(nil))
+}
+
+func TestValidateConsolidateIDs(t *testing.T) {
+	ids, err := validateConsolidateIDs([]int32{3, 1, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{3, 1}, ids)
+
+	_, err = validateConsolidateIDs([]int32{4, 4})
+	assert.Error(t, err)
+
+	_, err = validateConsolidateIDs([]int32{1, 0})
+	assert.Error(t, err)
+}
+
+func TestConsolidate_MergesAdjacentSameTaskEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	taskID := testutil.CreateTask(t, testDB.DB, eventID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	opts := &testutil.ScheduleEntryOpts{TaskID: &taskID}
+	first := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), opts)
+	second := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(11*time.Hour), baseDay.Add(13*time.Hour), opts)
+	// Adjacent but without a task, so it's not part of the run
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(13*time.Hour), baseDay.Add(14*time.Hour), nil)
+
+	service := NewConsolidationService(testDB.DB, events.Noop{})
+
+	suggestions, err := service.SuggestConsolidations(context.Background(), domain.ConsolidationSuggestionsRequest{
+		ResourceID: chef,
+		EventID:    &eventID,
+	})
+	require.NoError(t, err)
+	require.Len(t, suggestions.Suggestions, 1)
+	suggestion := suggestions.Suggestions[0]
+	assert.Equal(t, []int32{first, second}, suggestion.ScheduleIDs)
+	require.NotNil(t, suggestion.TaskID)
+	assert.Equal(t, taskID, *suggestion.TaskID)
+
+	result, err := service.Consolidate(context.Background(), domain.ConsolidateRequest{
+		ResourceID:  chef,
+		ScheduleIDs: suggestion.ScheduleIDs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first, result.Entry.ID)
+	assert.True(t, baseDay.Add(9*time.Hour).Equal(result.Entry.StartTime))
+	assert.True(t, baseDay.Add(13*time.Hour).Equal(result.Entry.EndTime))
+	assert.Equal(t, []int32{second}, result.RemovedIDs)
+
+	_, err = NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), second)
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+
+	suggestions, err = service.SuggestConsolidations(context.Background(), domain.ConsolidationSuggestionsRequest{ResourceID: chef})
+	require.NoError(t, err)
+	assert.Empty(t, suggestions.Suggestions)
+}
+
+func TestConsolidate_RejectsGap(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	first := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(10*time.Hour), nil)
+	second := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(11*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewConsolidationService(testDB.DB, events.Noop{})
+
+	_, err := service.Consolidate(context.Background(), domain.ConsolidateRequest{
+		ResourceID:  chef,
+		ScheduleIDs: []int32{first, second},
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+
+	// Neither entry was touched
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), second)
+	require.NoError(t, err)
+	assert.True(t, baseDay.Add(11*time.Hour).Equal(entry.StartTime))
+}
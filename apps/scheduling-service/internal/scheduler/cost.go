@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// CostService computes billable resource cost from booked schedule entries
+type CostService struct {
+	queries *repository.Queries
+}
+
+// NewCostService creates a new cost service
+func NewCostService(db *sql.DB) *CostService {
+	return &CostService{
+		queries: repository.NewQueries(db),
+	}
+}
+
+// GetClientCost sums booked hours × hourly_rate across all of a client's events
+// within the window. Resources without a rate are reported as unbillable hours.
+func (s *CostService) GetClientCost(ctx context.Context, req domain.ClientCostRequest) (*domain.ClientCostResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+
+	exists, err := s.queries.ClientExists(ctx, req.ClientID)
+	if err != nil {
+		return nil, dbError("failed to look up client", err)
+	}
+	if !exists {
+		return nil, domain.NewNotFoundError("client not found")
+	}
+
+	rows, err := s.queries.GetClientResourceUsage(ctx, repository.GetClientResourceUsageParams{
+		ClientID:         req.ClientID,
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	})
+	if err != nil {
+		return nil, dbError("failed to get client resource usage", err)
+	}
+
+	usage := make([]resourceUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, resourceUsage(row))
+	}
+	rollup, err := rollupCost(usage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ClientCostResponse{
+		ClientID:        req.ClientID,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		TotalCost:       formatCents(rollup.totalCents),
+		BillableHours:   secondsToHours(rollup.billableSeconds),
+		UnbillableHours: secondsToHours(rollup.unbillableSeconds),
+		Resources:       rollup.resources,
+	}, nil
+}
+
+// GetMarginalCost previews what booking a resource for an event would cost,
+// along with the event's total cost before and after, without booking anything
+func (s *CostService) GetMarginalCost(ctx context.Context, req domain.MarginalCostRequest) (*domain.MarginalCostResponse, error) {
+	if !req.EndTime.After(req.StartTime) {
+		return nil, domain.NewValidationError("end_time must be after start_time")
+	}
+
+	exists, err := s.queries.EventExists(ctx, req.EventID)
+	if err != nil {
+		return nil, dbError("failed to look up event", err)
+	}
+	if !exists {
+		return nil, domain.NewNotFoundError("event not found")
+	}
+
+	resource, err := s.queries.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	rows, err := s.queries.GetEventResourceUsage(ctx, req.EventID)
+	if err != nil {
+		return nil, dbError("failed to get event resource usage", err)
+	}
+	usage := make([]resourceUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, resourceUsage(row))
+	}
+	current, err := rollupCost(usage)
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := int64(req.EndTime.Sub(req.StartTime) / time.Second)
+	resp := &domain.MarginalCostResponse{
+		EventID:          req.EventID,
+		ResourceID:       resource.ID,
+		ResourceName:     resource.Name,
+		Hours:            secondsToHours(seconds),
+		CurrentTotalCost: formatCents(current.totalCents),
+		NewTotalCost:     formatCents(current.totalCents),
+	}
+
+	// A rate-less resource adds hours but no cost
+	if resource.HourlyRate.Valid {
+		rateCents, err := parseCents(resource.HourlyRate.String)
+		if err != nil {
+			return nil, domain.NewInternalError("invalid hourly rate", err)
+		}
+		cents := costCents(rateCents, seconds)
+		rate := resource.HourlyRate.String
+		marginal := formatCents(cents)
+		resp.HourlyRate = &rate
+		resp.MarginalCost = &marginal
+		resp.NewTotalCost = formatCents(current.totalCents + cents)
+	}
+
+	return resp, nil
+}
+
+// resourceUsage is booked time for one resource. Its fields match the usage
+// rows returned by the repository so those rows convert to it directly.
+type resourceUsage struct {
+	ResourceID    int32
+	ResourceName  string
+	HourlyRate    sql.NullString
+	BookedSeconds int64
+}
+
+// costRollup is the priced total of a set of resource usages
+type costRollup struct {
+	totalCents        int64
+	billableSeconds   int64
+	unbillableSeconds int64
+	resources         []domain.ResourceCost
+}
+
+// rollupCost prices each resource's booked time and sums the results.
+// Resources without a rate contribute unbillable hours but no cost.
+func rollupCost(usage []resourceUsage) (*costRollup, error) {
+	rollup := &costRollup{
+		resources: make([]domain.ResourceCost, 0, len(usage)),
+	}
+	for _, u := range usage {
+		rc := domain.ResourceCost{
+			ResourceID:   u.ResourceID,
+			ResourceName: u.ResourceName,
+			BookedHours:  secondsToHours(u.BookedSeconds),
+		}
+
+		if !u.HourlyRate.Valid {
+			rollup.unbillableSeconds += u.BookedSeconds
+			rollup.resources = append(rollup.resources, rc)
+			continue
+		}
+
+		rateCents, err := parseCents(u.HourlyRate.String)
+		if err != nil {
+			return nil, domain.NewInternalError("invalid hourly rate", err)
+		}
+		cents := costCents(rateCents, u.BookedSeconds)
+		rollup.totalCents += cents
+		rollup.billableSeconds += u.BookedSeconds
+
+		rate := u.HourlyRate.String
+		cost := formatCents(cents)
+		rc.HourlyRate = &rate
+		rc.Cost = &cost
+		rollup.resources = append(rollup.resources, rc)
+	}
+	return rollup, nil
+}
+
+// parseCents converts a NUMERIC(10, 2) string such as "45.5" into cents
+func parseCents(s string) (int64, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("amount %q has more than two decimal places", s)
+	}
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(whole, "-")
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	var f int64
+	if frac != "" {
+		f, err = strconv.ParseInt((frac + "0")[:2], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+		}
+	}
+
+	cents := w*100 + f
+	if negative {
+		cents = -cents
+	}
+	return cents, nil
+}
+
+// costCents prices booked seconds at an hourly rate, rounding half up to the cent
+func costCents(rateCents, seconds int64) int64 {
+	return (rateCents*seconds + 1800) / 3600
+}
+
+// formatCents renders cents as a two-decimal string
+func formatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// secondsToHours converts seconds to hours rounded to two decimal places
+func secondsToHours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
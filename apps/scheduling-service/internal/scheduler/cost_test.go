@@ -0,0 +1,299 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestGetClientCost_AggregatesAcrossEvents(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, firstEventID := testutil.SetupBaseData(t, testDB.DB)
+	secondEventID := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+
+	// Another client's booking must not be counted
+	otherClientID := testutil.CreateClient(t, testDB.DB, nil)
+	otherEventID := testutil.CreateEvent(t, testDB.DB, otherClientID, userID, nil)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("45.50"),
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// Chef: 4h on the first event, 2.5h on the second
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, firstEventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(13*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, secondEventID,
+		baseDay.Add(24*time.Hour+14*time.Hour), baseDay.Add(24*time.Hour+16*time.Hour+30*time.Minute), nil)
+	// Oven has no rate: 3h unbillable
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, secondEventID,
+		baseDay.Add(24*time.Hour+10*time.Hour), baseDay.Add(24*time.Hour+13*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEventID,
+		baseDay.Add(18*time.Hour), baseDay.Add(22*time.Hour), nil)
+
+	service := NewCostService(testDB.DB)
+
+	result, err := service.GetClientCost(context.Background(), domain.ClientCostRequest{
+		ClientID:  clientID,
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(48 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, clientID, result.ClientID)
+	// 6.5h × 45.50 = 295.75
+	assert.Equal(t, "295.75", result.TotalCost)
+	assert.Equal(t, 6.5, result.BillableHours)
+	assert.Equal(t, 3.0, result.UnbillableHours)
+
+	require.Len(t, result.Resources, 2)
+	assert.Equal(t, "Chef", result.Resources[0].ResourceName)
+	assert.Equal(t, 6.5, result.Resources[0].BookedHours)
+	require.NotNil(t, result.Resources[0].Cost)
+	assert.Equal(t, "295.75", *result.Resources[0].Cost)
+	assert.Equal(t, "Oven", result.Resources[1].ResourceName)
+	assert.Equal(t, 3.0, result.Resources[1].BookedHours)
+	assert.Nil(t, result.Resources[1].Cost)
+}
+
+func TestGetClientCost_ClipsToWindow(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("20.00"),
+		IsAvailable: true,
+	})
+
+	// 22:00 - 02:00 booking, window ends at midnight
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(22*time.Hour), baseDay.Add(26*time.Hour), nil)
+
+	service := NewCostService(testDB.DB)
+
+	result, err := service.GetClientCost(context.Background(), domain.ClientCostRequest{
+		ClientID:  clientID,
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "40.00", result.TotalCost)
+	assert.Equal(t, 2.0, result.BillableHours)
+}
+
+func TestGetClientCost_CancelledEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("20.00"),
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	cancelledAt := baseDay.Add(-24 * time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(13*time.Hour), baseDay.Add(16*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewCostService(testDB.DB)
+	req := domain.ClientCostRequest{
+		ClientID:  clientID,
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	}
+
+	result, err := service.GetClientCost(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "40.00", result.TotalCost)
+	assert.Equal(t, 2.0, result.BillableHours)
+
+	req.IncludeCancelled = true
+	result, err = service.GetClientCost(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "100.00", result.TotalCost)
+	assert.Equal(t, 5.0, result.BillableHours)
+}
+
+func TestGetClientCost_ClientNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewCostService(testDB.DB)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.GetClientCost(context.Background(), domain.ClientCostRequest{
+		ClientID:  99999,
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
+
+func TestParseCents(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"45.50", 4550},
+		{"45.5", 4550},
+		{"45", 4500},
+		{"0.07", 7},
+		{"-3.25", -325},
+	}
+	for _, tt := range tests {
+		got, err := parseCents(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	_, err := parseCents("1.005")
+	assert.Error(t, err)
+	_, err = parseCents("abc")
+	assert.Error(t, err)
+}
+
+func TestCostCents_RoundsHalfUp(t *testing.T) {
+	// 10.00/h for 1 minute = 16.67 cents
+	assert.Equal(t, int64(17), costCents(1000, 60))
+	// 0.01/h for 30 minutes = 0.5 cents
+	assert.Equal(t, int64(1), costCents(1, 1800))
+	assert.Equal(t, "1234.05", formatCents(123405))
+}
+
+func TestGetMarginalCost_AddsToEventTotal(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		HourlyRate:  strPtr("40.00"),
+		IsAvailable: true,
+	})
+	server := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Server",
+		HourlyRate:  strPtr("22.50"),
+		IsAvailable: true,
+	})
+
+	// Existing bookings: chef 3h (120.00)
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewCostService(testDB.DB)
+
+	// Adding the server for 90 minutes costs 33.75
+	result, err := service.GetMarginalCost(context.Background(), domain.MarginalCostRequest{
+		EventID:    eventID,
+		ResourceID: server,
+		StartTime:  baseDay.Add(10 * time.Hour),
+		EndTime:    baseDay.Add(11*time.Hour + 30*time.Minute),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, result.Hours)
+	require.NotNil(t, result.MarginalCost)
+	assert.Equal(t, "33.75", *result.MarginalCost)
+	assert.Equal(t, "120.00", result.CurrentTotalCost)
+	assert.Equal(t, "153.75", result.NewTotalCost)
+}
+
+func TestGetMarginalCost_RatelessResource(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		HourlyRate:  strPtr("40.00"),
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(10*time.Hour), nil)
+
+	service := NewCostService(testDB.DB)
+
+	result, err := service.GetMarginalCost(context.Background(), domain.MarginalCostRequest{
+		EventID:    eventID,
+		ResourceID: oven,
+		StartTime:  baseDay.Add(10 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, result.Hours)
+	assert.Nil(t, result.MarginalCost)
+	assert.Nil(t, result.HourlyRate)
+	assert.Equal(t, "40.00", result.CurrentTotalCost)
+	assert.Equal(t, "40.00", result.NewTotalCost)
+}
+
+func TestGetMarginalCost_IgnoresCancelledEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		HourlyRate:  strPtr("40.00"),
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(10*time.Hour), nil)
+	// A cancelled booking no longer costs the event anything
+	cancelledAt := baseDay
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(13*time.Hour), baseDay.Add(16*time.Hour),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	result, err := NewCostService(testDB.DB).GetMarginalCost(context.Background(), domain.MarginalCostRequest{
+		EventID:    eventID,
+		ResourceID: chef,
+		StartTime:  baseDay.Add(10 * time.Hour),
+		EndTime:    baseDay.Add(11 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "40.00", result.CurrentTotalCost)
+	assert.Equal(t, "80.00", result.NewTotalCost)
+}
@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxDowntimeReasonLength caps the free-text reason stored with downtime
+const maxDowntimeReasonLength = 500
+
+// ListDowntime returns a resource's downtime overlapping the range, earliest first
+func (s *ResourceService) ListDowntime(ctx context.Context, resourceID int32, r domain.TimeRange) ([]domain.Downtime, error) {
+	if !r.End.After(r.Start) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if err := s.resourceExists(ctx, resourceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListResourceDowntime(ctx, repository.ListResourceDowntimeParams{
+		ResourceID: resourceID,
+		StartTime:  r.Start,
+		EndTime:    r.End,
+	})
+	if err != nil {
+		return nil, dbError("failed to list downtime", err)
+	}
+	downtime := make([]domain.Downtime, 0, len(rows))
+	for _, row := range rows {
+		downtime = append(downtime, toDomainDowntime(row))
+	}
+	return downtime, nil
+}
+
+// CreateDowntime takes a resource out of service for a range. Bookings already
+// in that range are left in place; only new bookings are refused.
+func (s *ResourceService) CreateDowntime(ctx context.Context, resourceID int32, req domain.DowntimeRequest) (*domain.Downtime, error) {
+	if err := validateDowntime(req); err != nil {
+		return nil, err
+	}
+	if err := s.resourceExists(ctx, resourceID); err != nil {
+		return nil, err
+	}
+
+	params := repository.CreateResourceDowntimeParams{
+		ResourceID: resourceID,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	}
+	if req.Reason != nil {
+		params.Reason = sql.NullString{String: *req.Reason, Valid: true}
+	}
+	row, err := s.queries.CreateResourceDowntime(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to create downtime", err)
+	}
+	downtime := toDomainDowntime(row)
+	return &downtime, nil
+}
+
+// DeleteDowntime puts a resource back in service by removing one of its downtime windows
+func (s *ResourceService) DeleteDowntime(ctx context.Context, resourceID, id int32) error {
+	deleted, err := s.queries.DeleteResourceDowntime(ctx, repository.DeleteResourceDowntimeParams{
+		ID:         id,
+		ResourceID: resourceID,
+	})
+	if err != nil {
+		return dbError("failed to delete downtime", err)
+	}
+	if deleted == 0 {
+		return domain.NewNotFoundError("downtime not found")
+	}
+	return nil
+}
+
+// validateDowntime checks a downtime request is a proper range
+func validateDowntime(req domain.DowntimeRequest) error {
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return domain.NewValidationError("start_time and end_time are required")
+	}
+	if !req.EndTime.After(req.StartTime) {
+		return domain.NewValidationError("end_time must be after start_time")
+	}
+	if req.Reason != nil && len(*req.Reason) > maxDowntimeReasonLength {
+		return domain.NewValidationError(fmt.Sprintf("reason must not exceed %d characters", maxDowntimeReasonLength))
+	}
+	return nil
+}
+
+func toDomainDowntime(row repository.ResourceDowntime) domain.Downtime {
+	downtime := domain.Downtime{
+		ID:         row.ID,
+		ResourceID: row.ResourceID,
+		StartTime:  row.StartTime,
+		EndTime:    row.EndTime,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.Reason.Valid {
+		downtime.Reason = &row.Reason.String
+	}
+	return downtime
+}
+
+// downtimeConflicts returns a hard conflict for each downtime window of the
+// requested resources that overlaps the range. Downtime blocks every resource
+// alike, pooled ones included, since all their units are out of service.
+func (s *ConflictService) downtimeConflicts(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest) ([]domain.Conflict, error) {
+	rows, err := q.ListOverlappingDowntime(ctx, repository.ListOverlappingDowntimeParams{
+		ResourceIds: req.ResourceIDs,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	})
+	if err != nil {
+		return nil, dbError("failed to check downtime", err)
+	}
+
+	conflicts := make([]domain.Conflict, 0, len(rows))
+	for _, row := range rows {
+		if !meetsMinOverlap(domain.TimeRange{Start: row.StartTime, End: row.EndTime}, req) {
+			continue
+		}
+		conflicts = append(conflicts, downtimeConflict(row, req))
+	}
+	return conflicts, nil
+}
+
+// downtimeConflict converts an overlapping downtime window into a domain conflict
+func downtimeConflict(row repository.ListOverlappingDowntimeRow, req domain.CheckConflictsRequest) domain.Conflict {
+	message := fmt.Sprintf("Resource '%s' is under maintenance from %s to %s",
+		row.ResourceName, row.StartTime.Format("2006-01-02 15:04"), row.EndTime.Format("2006-01-02 15:04"))
+	if row.Reason.Valid && row.Reason.String != "" {
+		message += ": " + row.Reason.String
+	}
+
+	down := domain.TimeRange{Start: row.StartTime, End: row.EndTime}
+	conflict := domain.Conflict{
+		Kind:               domain.ConflictKindDowntime,
+		Severity:           domain.ConflictSeverityHard,
+		ResourceID:         row.ResourceID,
+		ResourceName:       row.ResourceName,
+		ExistingStartTime:  row.StartTime,
+		ExistingEndTime:    row.EndTime,
+		RequestedStartTime: req.StartTime,
+		RequestedEndTime:   req.EndTime,
+		Message:            message,
+	}
+	setOverlap(&conflict, down, domain.TimeRange{Start: req.StartTime, End: req.EndTime})
+	return conflict
+}
+
+// hasDowntime reports whether any of the conflicts is downtime
+func hasDowntime(conflicts []domain.Conflict) bool {
+	for _, c := range conflicts {
+		if c.Kind == domain.ConflictKindDowntime {
+			return true
+		}
+	}
+	return false
+}
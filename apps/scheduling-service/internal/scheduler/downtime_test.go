@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestValidateDowntime(t *testing.T) {
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	long := strings.Repeat("x", maxDowntimeReasonLength+1)
+
+	tests := []struct {
+		name    string
+		req     domain.DowntimeRequest
+		message string
+	}{
+		{"valid", domain.DowntimeRequest{StartTime: start, EndTime: start.Add(time.Hour)}, ""},
+		{"missing end", domain.DowntimeRequest{StartTime: start}, "required"},
+		{"end before start", domain.DowntimeRequest{StartTime: start, EndTime: start.Add(-time.Hour)}, "end_time must be after"},
+		{"empty range", domain.DowntimeRequest{StartTime: start, EndTime: start}, "end_time must be after"},
+		{"reason too long", domain.DowntimeRequest{StartTime: start, EndTime: start.Add(time.Hour), Reason: &long}, "reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDowntime(tt.req)
+			if tt.message == "" {
+				assert.NoError(t, err)
+				return
+			}
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
+
+func TestDowntimeConflict(t *testing.T) {
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	row := repository.ListOverlappingDowntimeRow{
+		ResourceID:   7,
+		ResourceName: "Oven",
+		StartTime:    start,
+		EndTime:      start.Add(4 * time.Hour),
+		Reason:       sql.NullString{String: "annual service", Valid: true},
+	}
+	req := domain.CheckConflictsRequest{StartTime: start.Add(3 * time.Hour), EndTime: start.Add(6 * time.Hour)}
+
+	c := downtimeConflict(row, req)
+	assert.Equal(t, domain.ConflictKindDowntime, c.Kind)
+	assert.Equal(t, domain.ConflictSeverityHard, c.Severity)
+	assert.Equal(t, 60.0, c.OverlapMinutes)
+	assert.Zero(t, c.ConflictingEventID)
+	assert.Equal(t, "Resource 'Oven' is under maintenance from 2025-06-16 08:00 to 2025-06-16 12:00: annual service", c.Message)
+
+	row.Reason = sql.NullString{}
+	assert.Equal(t, "Resource 'Oven' is under maintenance from 2025-06-16 08:00 to 2025-06-16 12:00", downtimeConflict(row, req).Message)
+}
+
+func TestDowntime_CRUD(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	other := testutil.CreateResource(t, testDB.DB, nil)
+	service := NewResourceService(testDB.DB)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	reason := "deep clean"
+
+	created, err := service.CreateDowntime(ctx, oven, domain.DowntimeRequest{
+		StartTime: start,
+		EndTime:   start.Add(4 * time.Hour),
+		Reason:    &reason,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, oven, created.ResourceID)
+	require.NotNil(t, created.Reason)
+	assert.Equal(t, reason, *created.Reason)
+
+	downtime, err := service.ListDowntime(ctx, oven, domain.TimeRange{Start: start.Add(-time.Hour), End: start.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, downtime, 1)
+	assert.Equal(t, created.ID, downtime[0].ID)
+
+	// A range ending as the downtime starts doesn't include it
+	downtime, err = service.ListDowntime(ctx, oven, domain.TimeRange{Start: start.Add(-time.Hour), End: start})
+	require.NoError(t, err)
+	assert.Empty(t, downtime)
+
+	// Downtime can only be removed through its own resource
+	assert.ErrorIs(t, service.DeleteDowntime(ctx, other, created.ID), &domain.DomainError{Code: domain.ErrCodeNotFound})
+	require.NoError(t, service.DeleteDowntime(ctx, oven, created.ID))
+	assert.ErrorIs(t, service.DeleteDowntime(ctx, oven, created.ID), &domain.DomainError{Code: domain.ErrCodeNotFound})
+
+	_, err = service.CreateDowntime(ctx, 99999, domain.DowntimeRequest{StartTime: start, EndTime: start.Add(time.Hour)})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestCheckConflicts_Downtime(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+	chairs := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chairs",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+		Quantity:    100,
+	})
+	resources := NewResourceService(testDB.DB)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	for _, id := range []int32{oven, chairs} {
+		_, err := resources.CreateDowntime(ctx, id, domain.DowntimeRequest{StartTime: start, EndTime: start.Add(4 * time.Hour)})
+		require.NoError(t, err)
+	}
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	t.Run("overlapping downtime is a hard conflict", func(t *testing.T) {
+		resp, err := service.CheckConflicts(ctx, domain.CheckConflictsRequest{
+			ResourceIDs: []int32{oven, chairs},
+			StartTime:   start.Add(3 * time.Hour),
+			EndTime:     start.Add(5 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.HasHardConflicts)
+		require.Len(t, resp.Conflicts, 2)
+		for _, c := range resp.Conflicts {
+			assert.Equal(t, domain.ConflictKindDowntime, c.Kind)
+			assert.Contains(t, c.Message, "under maintenance")
+		}
+	})
+
+	t.Run("touching the end of downtime is free", func(t *testing.T) {
+		resp, err := service.CheckConflicts(ctx, domain.CheckConflictsRequest{
+			ResourceIDs: []int32{oven},
+			StartTime:   start.Add(4 * time.Hour),
+			EndTime:     start.Add(5 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.HasConflicts)
+	})
+
+	t.Run("count only includes downtime", func(t *testing.T) {
+		resp, err := service.CheckConflicts(ctx, domain.CheckConflictsRequest{
+			ResourceIDs: []int32{oven},
+			StartTime:   start,
+			EndTime:     start.Add(time.Hour),
+			CountOnly:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, resp.ConflictCount)
+		assert.True(t, resp.HasHardConflicts)
+	})
+}
+
+func TestCreateEntryChecked_RefusesDowntime(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	chairs := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+		Quantity:    100,
+	})
+	resources := NewResourceService(testDB.DB)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	for _, id := range []int32{chef, chairs} {
+		_, err := resources.CreateDowntime(ctx, id, domain.DowntimeRequest{StartTime: start, EndTime: start.Add(4 * time.Hour)})
+		require.NoError(t, err)
+	}
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	for _, id := range []int32{chef, chairs} {
+		_, err := service.CreateEntryChecked(ctx, domain.ScheduleEntryRequest{
+			ResourceID: id,
+			EventID:    eventID,
+			StartTime:  start.Add(time.Hour),
+			EndTime:    start.Add(2 * time.Hour),
+		})
+		require.Error(t, err)
+		domainErr, ok := err.(*domain.DomainError)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+		assert.Contains(t, domainErr.Message, "under maintenance")
+	}
+}
+
+func TestGetResourceAvailability_ListsDowntime(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 16, 8, 0, 0, 0, time.UTC)
+	_, err := NewResourceService(testDB.DB).CreateDowntime(ctx, oven, domain.DowntimeRequest{StartTime: start, EndTime: start.Add(4 * time.Hour)})
+	require.NoError(t, err)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	resp, err := service.GetResourceAvailability(ctx, domain.ResourceAvailabilityRequest{
+		ResourceID: oven,
+		StartDate:  start.Add(-time.Hour),
+		EndDate:    start.Add(8 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Entries)
+	require.Len(t, resp.Downtime, 1)
+	assert.True(t, start.Equal(resp.Downtime[0].StartTime))
+
+	// Slot searches step around the downtime like a booking
+	slot, err := service.FindNextAvailableSlot(ctx, oven, start, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, slot)
+	assert.True(t, start.Add(4*time.Hour).Equal(*slot))
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// validateDurationFormat rejects duration formats the service can't render
+func validateDurationFormat(f domain.DurationFormat) error {
+	switch f {
+	case "", domain.DurationFormatMinutes, domain.DurationFormatISO8601:
+		return nil
+	default:
+		return domain.NewValidationError("duration_format must be minutes or iso8601")
+	}
+}
+
+// formatISODuration renders d as an ISO 8601 duration such as PT2H15M. Hours
+// aren't folded into days, since a calendar day isn't always 24 hours, and
+// seconds keep any fraction down to the millisecond. A duration that isn't
+// positive renders as PT0S.
+func formatISODuration(d time.Duration) string {
+	d = d.Round(time.Millisecond)
+	if d <= 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+		b.WriteByte('S')
+	}
+	return b.String()
+}
+
+// minutesDuration converts a fractional minute count back to a duration
+func minutesDuration(minutes float64) time.Duration {
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// renderISODurations adds ISO 8601 renderings of the buffer and each
+// conflict's overlap to resp, alongside the minute fields
+func renderISODurations(resp *domain.CheckConflictsResponse, req domain.CheckConflictsRequest) {
+	if req.BufferMinutes > 0 {
+		resp.Buffer = formatISODuration(time.Duration(req.BufferMinutes) * time.Minute)
+	}
+	for i := range resp.Conflicts {
+		resp.Conflicts[i].Overlap = formatISODuration(minutesDuration(resp.Conflicts[i].OverlapMinutes))
+	}
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+func TestFormatISODuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{name: "zero", d: 0, want: "PT0S"},
+		{name: "negative", d: -time.Minute, want: "PT0S"},
+		{name: "minutes only", d: 45 * time.Minute, want: "PT45M"},
+		{name: "hours only", d: 3 * time.Hour, want: "PT3H"},
+		{name: "hours and minutes", d: 135 * time.Minute, want: "PT2H15M"},
+		{name: "seconds", d: time.Hour + 30*time.Second, want: "PT1H30S"},
+		{name: "fractional seconds", d: 1500 * time.Millisecond, want: "PT1.5S"},
+		{name: "more than a day stays in hours", d: 26 * time.Hour, want: "PT26H"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatISODuration(tt.d))
+		})
+	}
+}
+
+func TestCheckConflicts_ISO8601Durations(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(12 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(8 * time.Hour), End: baseDay.Add(11*time.Hour + 15*time.Minute)},
+		},
+		DurationFormat: domain.DurationFormatISO8601,
+	}
+
+	result, err := service.CheckConflicts(context.Background(), req)
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, 135.0, result.Conflicts[0].OverlapMinutes)
+	assert.Equal(t, "PT2H15M", result.Conflicts[0].Overlap)
+}
+
+func TestCheckConflicts_ISO8601Buffer(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(10 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(8 * time.Hour), End: baseDay.Add(9*time.Hour + 30*time.Minute)},
+		},
+		BufferMinutes:  45,
+		DurationFormat: domain.DurationFormatISO8601,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PT45M", result.Buffer)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, "PT1H15M", result.Conflicts[0].Overlap)
+}
+
+func TestCheckConflicts_MinutesByDefault(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		StartTime: baseDay.Add(9 * time.Hour),
+		EndTime:   baseDay.Add(12 * time.Hour),
+		ExternalBusy: []domain.TimeRange{
+			{Start: baseDay.Add(8 * time.Hour), End: baseDay.Add(11*time.Hour + 15*time.Minute)},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, 135.0, result.Conflicts[0].OverlapMinutes)
+	assert.Empty(t, result.Conflicts[0].Overlap)
+	assert.Empty(t, result.Buffer)
+}
+
+func TestCheckConflicts_InvalidDurationFormat(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	_, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		StartTime:      baseDay.Add(9 * time.Hour),
+		EndTime:        baseDay.Add(10 * time.Hour),
+		DurationFormat: "hours",
+	})
+
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
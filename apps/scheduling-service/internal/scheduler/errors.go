@@ -0,0 +1,16 @@
+package scheduler
+
+import (
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// dbError turns a failed database call into a domain error. Errors the
+// repository recognises, such as constraint violations and cancelled queries,
+// keep their meaning; anything else is an internal error described by message.
+func dbError(message string, err error) error {
+	if mapped, ok := repository.MapError(err).(*domain.DomainError); ok {
+		return mapped
+	}
+	return domain.NewInternalError(message, err)
+}
@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+func TestDBError(t *testing.T) {
+	var domainErr *domain.DomainError
+
+	require.ErrorAs(t, dbError("failed to create entry", &pq.Error{Code: "23503"}), &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+
+	require.ErrorAs(t, dbError("failed to list entries", errors.New("connection reset")), &domainErr)
+	assert.Equal(t, domain.ErrCodeInternal, domainErr.Code)
+	assert.Equal(t, "failed to list entries", domainErr.Message)
+}
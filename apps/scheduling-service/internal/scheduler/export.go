@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxExportRange caps the window of a single schedule export
+const maxExportRange = 366 * 24 * time.Hour
+
+// CheckScheduleExport validates an export request and checks the resource
+// exists, so a handler can report either before it starts streaming
+func (s *ScheduleService) CheckScheduleExport(ctx context.Context, req domain.ScheduleExportRequest) error {
+	if !req.EndDate.After(req.StartDate) {
+		return domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxExportRange {
+		return domain.NewValidationError("range must not exceed 366 days")
+	}
+
+	if _, err := s.queries.GetResourceByID(ctx, req.ResourceID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NewNotFoundError("resource not found")
+		}
+		return dbError("failed to get resource", err)
+	}
+	return nil
+}
+
+// ExportSchedule calls fn with each of the resource's entries within the
+// range as it is read from the database, in chronological order. Entries must
+// lie wholly inside the range; rejected and cancelled entries are left out.
+// It stops at the first error fn returns. Call CheckScheduleExport first.
+func (s *ScheduleService) ExportSchedule(ctx context.Context, req domain.ScheduleExportRequest, fn func(domain.ScheduleExportEntry) error) error {
+	err := s.queries.EachResourceScheduleExportRow(ctx, repository.ResourceScheduleExportParams{
+		ResourceID: req.ResourceID,
+		StartTime:  req.StartDate,
+		EndTime:    req.EndDate,
+	}, func(row repository.ResourceScheduleExportRow) error {
+		entry := domain.ScheduleExportEntry{
+			ID:        row.ID,
+			EventName: row.EventName,
+			StartTime: row.StartTime,
+			EndTime:   row.EndTime,
+		}
+		if row.TaskTitle.Valid {
+			entry.TaskTitle = &row.TaskTitle.String
+		}
+		if row.Notes.Valid {
+			entry.Notes = &row.Notes.String
+		}
+		return fn(entry)
+	})
+	if err != nil {
+		return dbError("failed to export resource schedule", err)
+	}
+	return nil
+}
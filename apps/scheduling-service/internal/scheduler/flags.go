@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// Feature flag keys consulted by the scheduling service
+const (
+	// FlagPendingBookingsBlock makes entries pending approval hard conflicts
+	// instead of soft warnings
+	FlagPendingBookingsBlock = "pending_bookings_block"
+)
+
+// knownFlags lists every flag key the service consults, so reports can show
+// flags that are off as well as those enabled
+var knownFlags = []string{FlagPendingBookingsBlock}
+
+// flagCacheTTL is how long flag values are served from memory before reloading
+const flagCacheTTL = 30 * time.Second
+
+// FlagService reads feature flags from the database, caching them briefly so
+// hot paths don't query on every request. Unknown flags are off.
+type FlagService struct {
+	queries *repository.Queries
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	enabled  map[string]bool
+	loadedAt time.Time
+}
+
+// NewFlagService creates a new feature flag service
+func NewFlagService(db *sql.DB) *FlagService {
+	return &FlagService{
+		queries: repository.NewQueries(db),
+		ttl:     flagCacheTTL,
+		now:     time.Now,
+	}
+}
+
+// Enabled reports whether a flag is on. Flags are reloaded once the cache is
+// older than its TTL, detached from ctx's cancellation so one request giving
+// up doesn't fail the load for the others waiting on it. If they can't be
+// loaded (e.g. the table doesn't exist yet) the values last loaded are kept,
+// or every flag is off if none ever were, until the next attempt a TTL later.
+func (s *FlagService) Enabled(ctx context.Context, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.enabled == nil || now.Sub(s.loadedAt) >= s.ttl {
+		enabled, err := s.load(context.WithoutCancel(ctx))
+		switch {
+		case err == nil:
+			s.enabled = enabled
+		case s.enabled == nil:
+			logger.Get().WithRequestID(ctx).Warn().Err(err).Msg("Failed to load feature flags; treating all as off")
+			s.enabled = map[string]bool{}
+		default:
+			logger.Get().WithRequestID(ctx).Warn().Err(err).Msg("Failed to reload feature flags; keeping the previous values")
+		}
+		s.loadedAt = now
+	}
+	return s.enabled[key]
+}
+
+// States reports every known flag along with any other flag enabled in the
+// database, keyed by flag name. It reads through the same cache as Enabled.
+func (s *FlagService) States(ctx context.Context) map[string]bool {
+	states := make(map[string]bool, len(knownFlags))
+	for _, key := range knownFlags {
+		states[key] = s.Enabled(ctx, key)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, on := range s.enabled {
+		states[key] = on
+	}
+	return states
+}
+
+// load fetches the set of enabled flags
+func (s *FlagService) load(ctx context.Context) (map[string]bool, error) {
+	keys, err := s.queries.ListEnabledFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		enabled[key] = true
+	}
+	return enabled, nil
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestFlagService_MissingRowIsOff(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.SetFeatureFlag(t, testDB.DB, "some_other_flag", true)
+
+	flags := NewFlagService(testDB.DB)
+
+	assert.True(t, flags.Enabled(context.Background(), "some_other_flag"))
+	assert.False(t, flags.Enabled(context.Background(), FlagPendingBookingsBlock))
+}
+
+func TestFlagService_MissingTableIsOff(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, err := testDB.DB.Exec("DROP TABLE feature_flags")
+	require.NoError(t, err)
+
+	flags := NewFlagService(testDB.DB)
+
+	assert.False(t, flags.Enabled(context.Background(), FlagPendingBookingsBlock))
+}
+
+func TestFlagService_CachesUntilTTL(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.SetFeatureFlag(t, testDB.DB, FlagPendingBookingsBlock, true)
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	flags := NewFlagService(testDB.DB)
+	flags.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	require.True(t, flags.Enabled(ctx, FlagPendingBookingsBlock))
+
+	testutil.SetFeatureFlag(t, testDB.DB, FlagPendingBookingsBlock, false)
+
+	now = now.Add(flagCacheTTL - time.Second)
+	assert.True(t, flags.Enabled(ctx, FlagPendingBookingsBlock), "cached value served within TTL")
+
+	now = now.Add(time.Second)
+	assert.False(t, flags.Enabled(ctx, FlagPendingBookingsBlock), "reloaded after TTL")
+}
+
+func TestFlagService_KeepsValuesWhenReloadFails(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.SetFeatureFlag(t, testDB.DB, FlagPendingBookingsBlock, true)
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	flags := NewFlagService(testDB.DB)
+	flags.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	require.True(t, flags.Enabled(ctx, FlagPendingBookingsBlock))
+
+	_, err := testDB.DB.Exec("DROP TABLE feature_flags")
+	require.NoError(t, err)
+
+	now = now.Add(flagCacheTTL)
+	assert.True(t, flags.Enabled(ctx, FlagPendingBookingsBlock), "previous value kept when the reload fails")
+}
+
+func TestFlagService_LoadOutlivesCancelledRequest(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.SetFeatureFlag(t, testDB.DB, FlagPendingBookingsBlock, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	flags := NewFlagService(testDB.DB)
+	assert.True(t, flags.Enabled(ctx, FlagPendingBookingsBlock))
+}
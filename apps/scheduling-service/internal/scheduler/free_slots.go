@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// GetFreeSlots returns the gaps between a resource's bookings within
+// [start, end) that last at least minDuration, including gaps at either edge
+// of the window. Bookings are read as CheckConflicts reads them: rejected and
+// cancelled entries don't count, each booking is extended by the resource's
+// release grace, and back-to-back bookings leave no gap.
+func (s *AvailabilityService) GetFreeSlots(ctx context.Context, resourceID int32, start, end time.Time, minDuration time.Duration) ([]domain.TimeRange, error) {
+	if !end.After(start) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if end.Sub(start) > slotSearchHorizon {
+		return nil, domain.NewValidationError("range must not exceed 30 days")
+	}
+	if minDuration < 0 {
+		return nil, domain.NewValidationError("min_minutes must not be negative")
+	}
+
+	// A missing resource is an error rather than one long free slot
+	if _, err := s.GetResourceByID(ctx, resourceID); err != nil {
+		return nil, err
+	}
+
+	busy, err := s.loadBusy(ctx, []int32{resourceID}, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return freeSlots(busy[resourceID], domain.TimeRange{Start: start, End: end}, minDuration), nil
+}
+
+// freeSlots returns the parts of the window not covered by the merged, sorted
+// busy ranges that last at least minDuration
+func freeSlots(busy []domain.TimeRange, window domain.TimeRange, minDuration time.Duration) []domain.TimeRange {
+	slots := []domain.TimeRange{}
+	for _, gap := range subtractBusy([]domain.TimeRange{window}, busy) {
+		if gap.End.Sub(gap.Start) >= minDuration {
+			slots = append(slots, gap)
+		}
+	}
+	return slots
+}
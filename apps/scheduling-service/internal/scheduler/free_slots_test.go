@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestFreeSlots(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+	window := r(8, 18)
+
+	tests := []struct {
+		name        string
+		busy        []domain.TimeRange
+		minDuration time.Duration
+		want        []domain.TimeRange
+	}{
+		{
+			name: "no bookings returns the whole window",
+			busy: nil,
+			want: []domain.TimeRange{window},
+		},
+		{
+			name: "fully booked window returns nothing",
+			busy: []domain.TimeRange{r(6, 20)},
+			want: []domain.TimeRange{},
+		},
+		{
+			name: "gaps at both edges are included",
+			busy: []domain.TimeRange{r(10, 12), r(14, 16)},
+			want: []domain.TimeRange{r(8, 10), r(12, 14), r(16, 18)},
+		},
+		{
+			name: "back-to-back bookings leave no gap",
+			busy: []domain.TimeRange{r(8, 12), r(12, 18)},
+			want: []domain.TimeRange{},
+		},
+		{
+			name:        "short gaps are dropped",
+			busy:        []domain.TimeRange{r(9, 12), r(13, 15)},
+			minDuration: 2 * time.Hour,
+			want:        []domain.TimeRange{r(15, 18)},
+		},
+		{
+			name:        "a gap exactly min duration long is kept",
+			busy:        []domain.TimeRange{r(10, 18)},
+			minDuration: 2 * time.Hour,
+			want:        []domain.TimeRange{r(8, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, freeSlots(tt.busy, window, tt.minDuration))
+		})
+	}
+}
+
+func TestGetFreeSlots_ClipsStraddlingBookingsAndAddsGrace(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// Starts before the window, so only 08:00-09:30 (with grace) is busy
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(6*time.Hour), day.Add(9*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(12*time.Hour), day.Add(13*time.Hour), nil)
+	// Rejected and cancelled bookings don't take time
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(15*time.Hour), day.Add(16*time.Hour), &testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	cancelledAt := day
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(16*time.Hour), day.Add(17*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	slots, err := service.GetFreeSlots(context.Background(), oven,
+		day.Add(8*time.Hour), day.Add(18*time.Hour), 0)
+
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	assert.True(t, day.Add(9*time.Hour+30*time.Minute).Equal(slots[0].Start))
+	assert.True(t, day.Add(12*time.Hour).Equal(slots[0].End))
+	assert.True(t, day.Add(13*time.Hour+30*time.Minute).Equal(slots[1].Start))
+	assert.True(t, day.Add(18*time.Hour).Equal(slots[1].End))
+}
+
+func TestGetFreeSlots_ResourceNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetFreeSlots(context.Background(), 99999, day, day.Add(24*time.Hour), 0)
+
+	require.Error(t, err)
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
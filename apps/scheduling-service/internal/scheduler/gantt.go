@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// ganttColorHints gives each resource type a fixed color so charts look the
+// same from one request to the next
+var ganttColorHints = map[domain.ResourceType]string{
+	domain.ResourceTypeStaff:     "blue",
+	domain.ResourceTypeEquipment: "orange",
+	domain.ResourceTypeMaterials: "green",
+}
+
+// ganttDefaultColorHint is used for a resource type without its own color
+const ganttDefaultColorHint = "gray"
+
+// EventGantt lays out an event's schedule for a Gantt chart, one row per booked
+// resource with a bar per booking. Rejected and cancelled entries are left out.
+func (s *ScheduleService) EventGantt(ctx context.Context, eventID int32) (*domain.GanttResponse, error) {
+	eventName, err := s.queries.GetEventName(ctx, eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("event not found")
+		}
+		return nil, dbError("failed to get event", err)
+	}
+
+	rows, err := s.queries.ListEventGanttEntries(ctx, eventID)
+	if err != nil {
+		return nil, dbError("failed to get event schedule", err)
+	}
+
+	gantt := buildGantt(rows)
+	gantt.EventID = eventID
+	gantt.EventName = eventName
+	return gantt, nil
+}
+
+// buildGantt groups entry rows, already ordered by resource and then start
+// time, into one row per resource and finds the overall time axis
+func buildGantt(rows []repository.ListEventGanttEntriesRow) *domain.GanttResponse {
+	gantt := &domain.GanttResponse{Rows: []domain.GanttRow{}}
+
+	var current *domain.GanttRow
+	for _, row := range rows {
+		if current == nil || current.ResourceID != row.ResourceID {
+			gantt.Rows = append(gantt.Rows, domain.GanttRow{
+				ResourceID:   row.ResourceID,
+				ResourceName: row.ResourceName,
+				ResourceType: domain.ResourceType(row.ResourceType),
+				Bars:         []domain.GanttBar{},
+			})
+			current = &gantt.Rows[len(gantt.Rows)-1]
+		}
+
+		bar := domain.GanttBar{
+			ScheduleID:     row.ID,
+			Start:          row.StartTime,
+			End:            row.EndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+			ColorHint:      ganttColorHint(current.ResourceType),
+		}
+		if row.TaskTitle.Valid {
+			bar.TaskTitle = &row.TaskTitle.String
+		}
+		current.Bars = append(current.Bars, bar)
+
+		if gantt.AxisStart == nil || bar.Start.Before(*gantt.AxisStart) {
+			gantt.AxisStart = &bar.Start
+		}
+		if gantt.AxisEnd == nil || bar.End.After(*gantt.AxisEnd) {
+			gantt.AxisEnd = &bar.End
+		}
+	}
+	return gantt
+}
+
+// ganttColorHint returns the chart color for a resource type
+func ganttColorHint(t domain.ResourceType) string {
+	if hint, ok := ganttColorHints[t]; ok {
+		return hint
+	}
+	return ganttDefaultColorHint
+}
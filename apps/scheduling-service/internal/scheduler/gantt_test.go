@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestGanttColorHint(t *testing.T) {
+	assert.Equal(t, "blue", ganttColorHint(domain.ResourceTypeStaff))
+	assert.Equal(t, "orange", ganttColorHint(domain.ResourceTypeEquipment))
+	assert.Equal(t, "green", ganttColorHint(domain.ResourceTypeMaterials))
+	assert.Equal(t, ganttDefaultColorHint, ganttColorHint("vehicle"))
+}
+
+func TestEventGantt_TwoResources(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	taskID := testutil.CreateTask(t, testDB.DB, eventID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "A Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "B Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	chefLate := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(14*time.Hour), day.Add(18*time.Hour), nil)
+	chefEarly := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &taskID})
+	ovenEntry := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(7*time.Hour), day.Add(11*time.Hour), nil)
+	// Cancelled entries don't stretch the axis
+	cancelledAt := day
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(20*time.Hour), day.Add(23*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	gantt, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).EventGantt(context.Background(), eventID)
+	require.NoError(t, err)
+
+	assert.Equal(t, eventID, gantt.EventID)
+	require.NotNil(t, gantt.AxisStart)
+	require.NotNil(t, gantt.AxisEnd)
+	assert.True(t, day.Add(7*time.Hour).Equal(*gantt.AxisStart))
+	assert.True(t, day.Add(18*time.Hour).Equal(*gantt.AxisEnd))
+
+	require.Len(t, gantt.Rows, 2)
+	assert.Equal(t, chef, gantt.Rows[0].ResourceID)
+	require.Len(t, gantt.Rows[0].Bars, 2)
+	assert.Equal(t, chefEarly, gantt.Rows[0].Bars[0].ScheduleID)
+	assert.NotNil(t, gantt.Rows[0].Bars[0].TaskTitle)
+	assert.Equal(t, chefLate, gantt.Rows[0].Bars[1].ScheduleID)
+	assert.Equal(t, "blue", gantt.Rows[0].Bars[0].ColorHint)
+
+	assert.Equal(t, oven, gantt.Rows[1].ResourceID)
+	require.Len(t, gantt.Rows[1].Bars, 1)
+	assert.Equal(t, ovenEntry, gantt.Rows[1].Bars[0].ScheduleID)
+	assert.Equal(t, "orange", gantt.Rows[1].Bars[0].ColorHint)
+}
+
+func TestEventGantt_NoBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+
+	gantt, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).EventGantt(context.Background(), eventID)
+	require.NoError(t, err)
+	assert.Empty(t, gantt.Rows)
+	assert.Nil(t, gantt.AxisStart)
+	assert.Nil(t, gantt.AxisEnd)
+}
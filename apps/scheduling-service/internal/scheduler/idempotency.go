@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// IdempotencyKeyTTL is how long a create request's Idempotency-Key is
+	// honoured
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// IdempotencyKeySweepInterval is how often expired idempotency keys
+	// are deleted
+	IdempotencyKeySweepInterval = time.Hour
+
+	// maxIdempotencyKeyLength matches the idempotency_keys.key column
+	maxIdempotencyKeyLength = 255
+)
+
+// errIdempotencyKeyTaken reports that another request holds the key, so this
+// request books nothing and the other's entry is replayed
+var errIdempotencyKeyTaken = errors.New("idempotency key already taken")
+
+// CreateEntryIdempotent is CreateEntryChecked for a request carrying an
+// idempotency key. The first request with a key books as usual and stores the
+// key with the new entry; a repeat within IdempotencyKeyTTL books nothing and
+// returns that entry as it is now, with replayed set. Reusing a key for a
+// different request is a conflict. A key whose entry has since been deleted
+// is forgotten with it.
+//
+// The key is looked up again once the resource is locked, before the booking
+// is checked, so a repeat sent while the first request is still booking waits
+// for it and replays its entry instead of conflicting with it.
+func (s *ScheduleService) CreateEntryIdempotent(ctx context.Context, key string, req domain.ScheduleEntryRequest) (entry *domain.ScheduleEntry, replayed bool, err error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, false, domain.NewValidationError("Idempotency-Key must be 1 to 255 characters")
+	}
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if entry, err := s.replayEntry(ctx, key, hash); err != nil || entry != nil {
+		return entry, entry != nil, err
+	}
+
+	entry, err = s.createEntry(ctx, req, &entryClaim{
+		hold: func(q *repository.Queries) error {
+			_, err := q.GetIdempotencyKey(ctx, repository.GetIdempotencyKeyParams{
+				Key:           key,
+				ExpiredBefore: time.Now().Add(-IdempotencyKeyTTL),
+			})
+			if err == nil {
+				return errIdempotencyKeyTaken
+			}
+			if err != sql.ErrNoRows {
+				return dbError("failed to get idempotency key", err)
+			}
+			return nil
+		},
+		store: func(q *repository.Queries, id int32) error {
+			now := time.Now()
+			stored, err := q.CreateIdempotencyKey(ctx, repository.CreateIdempotencyKeyParams{
+				Key:             key,
+				RequestHash:     hash,
+				ScheduleEntryID: id,
+				CreatedAt:       now,
+				ExpiredBefore:   now.Add(-IdempotencyKeyTTL),
+			})
+			if err != nil {
+				return dbError("failed to store idempotency key", err)
+			}
+			if stored == 0 {
+				// Taken by a request for another resource, which the
+				// resource lock doesn't hold back
+				return errIdempotencyKeyTaken
+			}
+			return nil
+		},
+	})
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		entry, err = s.replayEntry(ctx, key, hash)
+		if err == nil && entry == nil {
+			err = domain.NewConflictError("Idempotency-Key is in use by another request")
+		}
+		return entry, entry != nil, err
+	}
+	return entry, false, err
+}
+
+// SweepIdempotencyKeys deletes expired idempotency keys now and then every
+// interval, until ctx is done. Expired keys are never replayed, so this only
+// keeps the table small; main runs it in its own goroutine.
+func SweepIdempotencyKeys(ctx context.Context, db *sql.DB, interval time.Duration) {
+	q := repository.NewQueries(db)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := q.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(-IdempotencyKeyTTL)); err != nil && ctx.Err() == nil {
+			logger.Get().Warn().Err(err).Msg("Failed to delete expired idempotency keys")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replayEntry returns the entry created by an earlier request with key, or
+// nil if the key isn't stored or has expired
+func (s *ScheduleService) replayEntry(ctx context.Context, key, hash string) (*domain.ScheduleEntry, error) {
+	stored, err := s.queries.GetIdempotencyKey(ctx, repository.GetIdempotencyKeyParams{
+		Key:           key,
+		ExpiredBefore: time.Now().Add(-IdempotencyKeyTTL),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError("failed to get idempotency key", err)
+	}
+	if stored.RequestHash != hash {
+		return nil, domain.NewConflictError("Idempotency-Key was already used for a different request")
+	}
+	return s.GetEntry(ctx, stored.ScheduleEntryID)
+}
+
+// requestHash fingerprints a create request by its JSON form, so the same
+// booking sent with different formatting, field order, or UTC offsets still
+// matches
+func requestHash(req domain.ScheduleEntryRequest) (string, error) {
+	req.StartTime = req.StartTime.UTC()
+	req.EndTime = req.EndTime.UTC()
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
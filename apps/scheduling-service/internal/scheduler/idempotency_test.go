@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestCreateEntryIdempotent_ExpiredKeyBooksAgain(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	req := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	}
+
+	first, replayed, err := service.CreateEntryIdempotent(context.Background(), "retry-me", req)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	// Age the key past its lifetime and free the slot
+	_, err = testDB.DB.Exec("UPDATE idempotency_keys SET created_at = created_at - interval '25 hours'")
+	require.NoError(t, err)
+	_, err = service.CancelEntry(context.Background(), first.ID, domain.CancelEntryRequest{})
+	require.NoError(t, err)
+
+	second, replayed, err := service.CreateEntryIdempotent(context.Background(), "retry-me", req)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	var stored int32
+	require.NoError(t, testDB.DB.QueryRow("SELECT schedule_entry_id FROM idempotency_keys WHERE key = 'retry-me'").Scan(&stored))
+	assert.Equal(t, second.ID, stored)
+}
+
+func TestCreateEntryIdempotent_ConcurrentRepeatsReplay(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	req := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	}
+
+	// Every copy of the first request books or replays; none conflicts with
+	// the booking another copy made
+	const copies = 5
+	ids := make([]int32, copies)
+	replays := make([]bool, copies)
+	errs := make([]error, copies)
+	var wg sync.WaitGroup
+	for i := 0; i < copies; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			entry, replayed, err := service.CreateEntryIdempotent(context.Background(), "same-request", req)
+			errs[i], replays[i] = err, replayed
+			if entry != nil {
+				ids[i] = entry.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	booked := 0
+	for i := 0; i < copies; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, ids[0], ids[i])
+		if !replays[i] {
+			booked++
+		}
+	}
+	assert.Equal(t, 1, booked)
+}
+
+func TestSweepIdempotencyKeys(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	for i, key := range []string{"old", "fresh"} {
+		start := baseDay.Add(time.Duration(i) * 24 * time.Hour)
+		_, _, err := service.CreateEntryIdempotent(context.Background(), key, domain.ScheduleEntryRequest{
+			ResourceID: resourceID,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	_, err := testDB.DB.Exec("UPDATE idempotency_keys SET created_at = created_at - interval '25 hours' WHERE key = 'old'")
+	require.NoError(t, err)
+
+	// The first pass runs straight away
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		SweepIdempotencyKeys(ctx, testDB.DB, time.Hour)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		var count int
+		require.NoError(t, testDB.DB.QueryRow("SELECT count(*) FROM idempotency_keys WHERE key = 'old'").Scan(&count))
+		return count == 0
+	}, 5*time.Second, 20*time.Millisecond)
+	cancel()
+	<-done
+
+	var fresh int
+	require.NoError(t, testDB.DB.QueryRow("SELECT count(*) FROM idempotency_keys WHERE key = 'fresh'").Scan(&fresh))
+	assert.Equal(t, 1, fresh)
+}
+
+func TestCreateEntryIdempotent_KeyLength(t *testing.T) {
+	service := &ScheduleService{}
+	for _, key := range []string{"", strings.Repeat("k", 256)} {
+		_, _, err := service.CreateEntryIdempotent(context.Background(), key, domain.ScheduleEntryRequest{})
+		assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+	}
+}
+
+func TestRequestHash_IgnoresUTCOffset(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	req := domain.ScheduleEntryRequest{ResourceID: 1, EventID: 2, StartTime: start, EndTime: start.Add(time.Hour)}
+	a, err := requestHash(req)
+	require.NoError(t, err)
+
+	chicago := time.FixedZone("CDT", -5*60*60)
+	req.StartTime = req.StartTime.In(chicago)
+	b, err := requestHash(req)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	req.Quantity = 2
+	c, err := requestHash(req)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// IntegrityService detects inconsistent scheduling data
+type IntegrityService struct {
+	queries   *repository.Queries
+	publisher events.Publisher
+}
+
+// NewIntegrityService creates a new data integrity service publishing the
+// bookings it cancels through publisher
+func NewIntegrityService(db *sql.DB, publisher events.Publisher) *IntegrityService {
+	return &IntegrityService{
+		queries:   repository.NewQueries(db),
+		publisher: publisher,
+	}
+}
+
+// FindTaskEventMismatches returns schedule entries whose task's event_id differs
+// from the entry's own event_id
+func (s *IntegrityService) FindTaskEventMismatches(ctx context.Context) (*domain.TaskEventMismatchResponse, error) {
+	rows, err := s.queries.ListTaskEventMismatches(ctx)
+	if err != nil {
+		return nil, dbError("failed to find task event mismatches", err)
+	}
+
+	entries := make([]domain.TaskEventMismatch, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.TaskEventMismatch{
+			ScheduleID:      row.ID,
+			ResourceID:      row.ResourceID,
+			TaskID:          row.TaskID,
+			ScheduleEventID: row.EventID,
+			TaskEventID:     row.TaskEventID,
+			StartTime:       row.StartTime,
+			EndTime:         row.EndTime,
+		})
+	}
+
+	return &domain.TaskEventMismatchResponse{
+		Count:   len(entries),
+		Entries: entries,
+	}, nil
+}
+
+// FindInvertedRanges returns schedule entries whose end_time is not after their
+// start_time
+func (s *IntegrityService) FindInvertedRanges(ctx context.Context) (*domain.InvertedRangesResponse, error) {
+	rows, err := s.queries.ListInvertedScheduleRanges(ctx)
+	if err != nil {
+		return nil, dbError("failed to find inverted ranges", err)
+	}
+
+	entries := make([]domain.InvertedRange, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.InvertedRange{
+			ScheduleID:     row.ID,
+			ResourceID:     row.ResourceID,
+			EventID:        row.EventID,
+			StartTime:      row.StartTime,
+			EndTime:        row.EndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+			Cancelled:      row.CancelledAt.Valid,
+		})
+	}
+
+	return &domain.InvertedRangesResponse{
+		Count:   len(entries),
+		Entries: entries,
+	}, nil
+}
+
+// RepairInvertedRanges swaps the start and end of every entry that ends before
+// it starts. The swapped entries are not checked for conflicts.
+func (s *IntegrityService) RepairInvertedRanges(ctx context.Context) (*domain.InvertedRangeRepairResponse, error) {
+	swapped, err := s.queries.SwapInvertedScheduleRanges(ctx)
+	if err != nil {
+		return nil, dbError("failed to repair inverted ranges", err)
+	}
+
+	remaining, err := s.FindInvertedRanges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.InvertedRangeRepairResponse{
+		SwappedIDs:   append([]int32{}, swapped...),
+		RemainingIDs: make([]int32, 0, remaining.Count),
+	}
+	for _, entry := range remaining.Entries {
+		resp.RemainingIDs = append(resp.RemainingIDs, entry.ScheduleID)
+	}
+	return resp, nil
+}
+
+// FindCreatedWhileUnavailable returns schedule entries that were booked while
+// their resource was marked unavailable. It goes by the snapshot taken when
+// each entry was inserted, so making the resource available again later
+// doesn't hide them.
+func (s *IntegrityService) FindCreatedWhileUnavailable(ctx context.Context) (*domain.UnavailableBookingsResponse, error) {
+	rows, err := s.queries.ListEntriesCreatedWhileUnavailable(ctx)
+	if err != nil {
+		return nil, dbError("failed to find entries created while unavailable", err)
+	}
+
+	entries := make([]domain.UnavailableBooking, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.UnavailableBooking{
+			ScheduleID:     row.ID,
+			ResourceID:     row.ResourceID,
+			ResourceName:   row.ResourceName,
+			EventID:        row.EventID,
+			StartTime:      row.StartTime,
+			EndTime:        row.EndTime,
+			CreatedAt:      row.CreatedAt,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+			Cancelled:      row.CancelledAt.Valid,
+		})
+	}
+
+	return &domain.UnavailableBookingsResponse{
+		Count:   len(entries),
+		Entries: entries,
+	}, nil
+}
+
+// FindStaleByTaskStatus returns live bookings whose task is completed but
+// which haven't ended yet, as candidates for cleanup
+func (s *IntegrityService) FindStaleByTaskStatus(ctx context.Context) (*domain.StaleTaskBookingsResponse, error) {
+	rows, err := s.queries.ListStaleTaskBookings(ctx, time.Now())
+	if err != nil {
+		return nil, dbError("failed to find stale task bookings", err)
+	}
+
+	entries := make([]domain.StaleTaskBooking, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.StaleTaskBooking{
+			ScheduleID:     row.ID,
+			ResourceID:     row.ResourceID,
+			ResourceName:   row.ResourceName,
+			EventID:        row.EventID,
+			TaskID:         row.TaskID.Int32,
+			TaskTitle:      row.TaskTitle,
+			StartTime:      row.StartTime,
+			EndTime:        row.EndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+		})
+	}
+
+	return &domain.StaleTaskBookingsResponse{
+		Count:   len(entries),
+		Entries: entries,
+	}, nil
+}
+
+// CancelStaleByTaskStatus cancels every booking FindStaleByTaskStatus would
+// report, with "Task completed" as the reason. The entries are kept for
+// history like any cancellation, and each is published as a schedule change.
+func (s *IntegrityService) CancelStaleByTaskStatus(ctx context.Context) (*domain.StaleTaskCancelResponse, error) {
+	cancelled, err := s.queries.CancelStaleTaskBookings(ctx, time.Now())
+	if err != nil {
+		return nil, dbError("failed to cancel stale task bookings", err)
+	}
+	entries, err := loadEntries(ctx, s.queries, cancelled)
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeUpdated, entries)
+
+	return &domain.StaleTaskCancelResponse{
+		CancelledIDs: append([]int32{}, cancelled...),
+	}, nil
+}
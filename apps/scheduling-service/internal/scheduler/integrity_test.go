@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestFindTaskEventMismatches_ReportsMismatchedEntry(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventA := testutil.SetupBaseData(t, testDB.DB)
+	eventB := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	taskA := testutil.CreateTask(t, testDB.DB, eventA, nil)
+	taskB := testutil.CreateTask(t, testDB.DB, eventB, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// Consistent: task A on event A
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventA,
+		baseDay.Add(9*time.Hour), baseDay.Add(10*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &taskA})
+	// Inconsistent: task B (event B) booked against event A
+	badID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventA,
+		baseDay.Add(11*time.Hour), baseDay.Add(12*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &taskB})
+	// No task at all is never reported
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventB,
+		baseDay.Add(13*time.Hour), baseDay.Add(14*time.Hour), nil)
+
+	service := NewIntegrityService(testDB.DB, events.Noop{})
+
+	result, err := service.FindTaskEventMismatches(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, badID, result.Entries[0].ScheduleID)
+	assert.Equal(t, taskB, result.Entries[0].TaskID)
+	assert.Equal(t, eventA, result.Entries[0].ScheduleEventID)
+	assert.Equal(t, eventB, result.Entries[0].TaskEventID)
+}
+
+func TestInvertedRanges_ConstraintRejectsInsert(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	_, err := testDB.DB.Exec(`
+		INSERT INTO resource_schedule (resource_id, event_id, start_time, end_time)
+		VALUES ($1, $2, $3, $4)`,
+		resourceID, eventID, baseDay.Add(12*time.Hour), baseDay.Add(9*time.Hour))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource_schedule_time_range_valid")
+}
+
+func TestInvertedRanges_ReportsAndRepairsPreexistingRows(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	// Rows written before the constraint existed, as migration 0020 leaves them
+	_, err := testDB.DB.Exec(`ALTER TABLE resource_schedule DROP CONSTRAINT resource_schedule_time_range_valid`)
+	require.NoError(t, err)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(8*time.Hour), baseDay.Add(9*time.Hour), nil)
+	inverted := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(12*time.Hour), baseDay.Add(10*time.Hour), nil)
+	zeroLength := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(14*time.Hour), nil)
+
+	_, err = testDB.DB.Exec(`
+		ALTER TABLE resource_schedule
+		ADD CONSTRAINT resource_schedule_time_range_valid CHECK (end_time > start_time) NOT VALID`)
+	require.NoError(t, err)
+
+	service := NewIntegrityService(testDB.DB, events.Noop{})
+
+	result, err := service.FindInvertedRanges(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	require.Len(t, result.Entries, 2)
+	assert.Equal(t, inverted, result.Entries[0].ScheduleID)
+	assert.Equal(t, zeroLength, result.Entries[1].ScheduleID)
+
+	repair, err := service.RepairInvertedRanges(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int32{inverted}, repair.SwappedIDs)
+	assert.Equal(t, []int32{zeroLength}, repair.RemainingIDs)
+
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), inverted)
+	require.NoError(t, err)
+	assert.True(t, baseDay.Add(10*time.Hour).Equal(entry.StartTime))
+	assert.True(t, baseDay.Add(12*time.Hour).Equal(entry.EndTime))
+}
+
+func TestFindCreatedWhileUnavailable_ReportsSnapshot(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	unavailable := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: false,
+	})
+	available := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: unavailable,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(11 * time.Hour),
+	})
+	require.NoError(t, err)
+	// Booked while available; marking it unavailable later doesn't change that
+	testutil.CreateScheduleEntry(t, testDB.DB, available, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil)
+	_, err = testDB.DB.Exec(`UPDATE resources SET is_available = NOT is_available WHERE id IN ($1, $2)`,
+		unavailable, available)
+	require.NoError(t, err)
+
+	result, err := NewIntegrityService(testDB.DB, events.Noop{}).FindCreatedWhileUnavailable(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, entry.ID, result.Entries[0].ScheduleID)
+	assert.Equal(t, unavailable, result.Entries[0].ResourceID)
+	assert.Equal(t, "Oven", result.Entries[0].ResourceName)
+	assert.False(t, result.Entries[0].Cancelled)
+}
+
+func TestStaleByTaskStatus_ReportsAndCancelsFutureBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	done := testutil.CreateTask(t, testDB.DB, eventID, &testutil.TaskOpts{Title: "Plating", Status: "completed"})
+	open := testutil.CreateTask(t, testDB.DB, eventID, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	tomorrow := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	staleID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		tomorrow, tomorrow.Add(2*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &done})
+	// Already over, so nothing to clean up
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		tomorrow.Add(-72*time.Hour), tomorrow.Add(-70*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &done})
+	// The task is still open
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		tomorrow.Add(3*time.Hour), tomorrow.Add(4*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &open})
+
+	service := NewIntegrityService(testDB.DB, events.Noop{})
+
+	result, err := service.FindStaleByTaskStatus(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, staleID, result.Entries[0].ScheduleID)
+	assert.Equal(t, done, result.Entries[0].TaskID)
+	assert.Equal(t, "Plating", result.Entries[0].TaskTitle)
+
+	cancelled, err := service.CancelStaleByTaskStatus(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []int32{staleID}, cancelled.CancelledIDs)
+
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), staleID)
+	require.NoError(t, err)
+	require.NotNil(t, entry.CancelledAt)
+	require.NotNil(t, entry.CancellationReason)
+	assert.Equal(t, "Task completed", *entry.CancellationReason)
+
+	result, err = service.FindStaleByTaskStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Count)
+}
@@ -0,0 +1,288 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// mergeBusy sorts busy ranges by start and merges any that overlap or touch,
+// so callers can measure busy time without double-counting
+func mergeBusy(ranges []domain.TimeRange) []domain.TimeRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([]domain.TimeRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []domain.TimeRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start.After(last.End) {
+			merged = append(merged, r)
+			continue
+		}
+		if r.End.After(last.End) {
+			last.End = r.End
+		}
+	}
+	return merged
+}
+
+// overlapDuration returns how long two ranges overlap, or zero if they don't
+func overlapDuration(a, b domain.TimeRange) time.Duration {
+	w, ok := overlapWindow(a, b)
+	if !ok {
+		return 0
+	}
+	return w.End.Sub(w.Start)
+}
+
+// overlapWindow returns the range two ranges share, from the later start to
+// the earlier end, and false if they don't overlap
+func overlapWindow(a, b domain.TimeRange) (domain.TimeRange, bool) {
+	start := a.Start
+	if b.Start.After(start) {
+		start = b.Start
+	}
+	end := a.End
+	if b.End.Before(end) {
+		end = b.End
+	}
+	if !end.After(start) {
+		return domain.TimeRange{}, false
+	}
+	return domain.TimeRange{Start: start, End: end}, true
+}
+
+// setOverlap records on a conflict where its busy range meets the requested
+// range, and for how long
+func setOverlap(c *domain.Conflict, busy, requested domain.TimeRange) {
+	w, _ := overlapWindow(busy, requested)
+	c.OverlapStart, c.OverlapEnd = w.Start, w.End
+	c.OverlapMinutes = w.End.Sub(w.Start).Minutes()
+}
+
+// subtractBusy removes busy time from free windows. Both must be merged and
+// sorted, as mergeBusy returns them; the result is too.
+func subtractBusy(windows, busy []domain.TimeRange) []domain.TimeRange {
+	var free []domain.TimeRange
+	i := 0
+	for _, w := range windows {
+		// Busy ranges ending before this window can't affect it or any later one
+		for i < len(busy) && !busy[i].End.After(w.Start) {
+			i++
+		}
+		start := w.Start
+		for j := i; j < len(busy) && busy[j].Start.Before(w.End); j++ {
+			if busy[j].Start.After(start) {
+				free = append(free, domain.TimeRange{Start: start, End: busy[j].Start})
+			}
+			if busy[j].End.After(start) {
+				start = busy[j].End
+			}
+		}
+		if w.End.After(start) {
+			free = append(free, domain.TimeRange{Start: start, End: w.End})
+		}
+	}
+	return free
+}
+
+// intersectBusy returns the time covered by both a and b. Both must be merged
+// and sorted, as mergeBusy returns them; the result is too. Touching ranges
+// don't intersect.
+func intersectBusy(a, b []domain.TimeRange) []domain.TimeRange {
+	var out []domain.TimeRange
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start := a[i].Start
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		end := a[i].End
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if end.After(start) {
+			out = append(out, domain.TimeRange{Start: start, End: end})
+		}
+		// Whichever range ends first can't overlap anything further along
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// clusterOverlapping groups entries into connected components of overlapping
+// time ranges with a sweep over start times: an entry joins the current
+// cluster if it starts before the cluster's end so far. Only clusters of two or
+// more entries are returned; touching ranges don't overlap.
+func clusterOverlapping(entries []domain.ScheduleEntry) []domain.ConflictCluster {
+	sorted := make([]domain.ScheduleEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	clusters := []domain.ConflictCluster{}
+	var current *domain.ConflictCluster
+	flush := func() {
+		if current != nil && len(current.Entries) > 1 {
+			clusters = append(clusters, *current)
+		}
+	}
+	for _, e := range sorted {
+		if current != nil && e.StartTime.Before(current.End) {
+			current.Entries = append(current.Entries, e)
+			if e.EndTime.After(current.End) {
+				current.End = e.EndTime
+			}
+			continue
+		}
+		flush()
+		current = &domain.ConflictCluster{
+			Start:   e.StartTime,
+			End:     e.EndTime,
+			Entries: []domain.ScheduleEntry{e},
+		}
+	}
+	flush()
+	return clusters
+}
+
+// peakConcurrency sweeps over every resource's merged busy ranges and returns
+// the maximum number of resources busy at once, with the first range during
+// which that many are busy. Merging per resource first means a double-booked
+// resource still counts once. At equal instants ends are processed before
+// starts, so back-to-back ranges don't overlap.
+func peakConcurrency(busy map[int32][]domain.TimeRange) (int, domain.TimeRange) {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	var edges []edge
+	for _, ranges := range busy {
+		for _, r := range mergeBusy(ranges) {
+			edges = append(edges, edge{r.Start, 1}, edge{r.End, -1})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].at.Equal(edges[j].at) {
+			return edges[i].delta < edges[j].delta
+		}
+		return edges[i].at.Before(edges[j].at)
+	})
+
+	var peak, current int
+	var window domain.TimeRange
+	for i, e := range edges {
+		current += e.delta
+		if e.delta > 0 && current > peak {
+			peak = current
+			window = domain.TimeRange{Start: e.at}
+			// The peak lasts until the next edge that changes the count
+			for _, next := range edges[i+1:] {
+				if !next.at.Equal(e.at) {
+					window.End = next.at
+					break
+				}
+			}
+		}
+	}
+	return peak, window
+}
+
+// unitUsage is a range during which a booking takes some units of a resource
+type unitUsage struct {
+	occupied domain.TimeRange
+	units    int32
+}
+
+// peakUnits sweeps over bookings clipped to the window and returns the most
+// units in use at any instant within it. As in peakConcurrency, ends are
+// processed before starts at equal instants, so back-to-back bookings don't
+// stack.
+func peakUnits(usage []unitUsage, window domain.TimeRange) int32 {
+	type edge struct {
+		at    time.Time
+		delta int32
+	}
+	var edges []edge
+	for _, u := range usage {
+		start, end := u.occupied.Start, u.occupied.End
+		if start.Before(window.Start) {
+			start = window.Start
+		}
+		if end.After(window.End) {
+			end = window.End
+		}
+		if !end.After(start) {
+			continue
+		}
+		edges = append(edges, edge{start, u.units}, edge{end, -u.units})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].at.Equal(edges[j].at) {
+			return edges[i].delta < edges[j].delta
+		}
+		return edges[i].at.Before(edges[j].at)
+	})
+
+	var peak, current int32
+	for _, e := range edges {
+		current += e.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return peak
+}
+
+// saturatedRanges sweeps over bookings and returns the ranges during which
+// more than limit units are in use. All edges at one instant are applied
+// before the total is compared, so back-to-back bookings don't leave a
+// zero-length gap or spike.
+func saturatedRanges(usage []unitUsage, limit int32) []domain.TimeRange {
+	type edge struct {
+		at    time.Time
+		delta int32
+	}
+	edges := make([]edge, 0, 2*len(usage))
+	for _, u := range usage {
+		if !u.occupied.End.After(u.occupied.Start) {
+			continue
+		}
+		edges = append(edges, edge{u.occupied.Start, u.units}, edge{u.occupied.End, -u.units})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].at.Before(edges[j].at)
+	})
+
+	var ranges []domain.TimeRange
+	var current int32
+	var openedAt time.Time
+	open := false
+	for i, e := range edges {
+		current += e.delta
+		if i+1 < len(edges) && edges[i+1].at.Equal(e.at) {
+			continue
+		}
+		if current > limit && !open {
+			open = true
+			openedAt = e.at
+		} else if current <= limit && open {
+			open = false
+			ranges = append(ranges, domain.TimeRange{Start: openedAt, End: e.at})
+		}
+	}
+	return ranges
+}
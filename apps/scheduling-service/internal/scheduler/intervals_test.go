@@ -0,0 +1,276 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+func TestMergeBusy(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+
+	tests := []struct {
+		name  string
+		input []domain.TimeRange
+		want  []domain.TimeRange
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  nil,
+		},
+		{
+			name:  "disjoint ranges stay separate and sorted",
+			input: []domain.TimeRange{{Start: at(14), End: at(16)}, {Start: at(9), End: at(11)}},
+			want:  []domain.TimeRange{{Start: at(9), End: at(11)}, {Start: at(14), End: at(16)}},
+		},
+		{
+			name:  "overlapping ranges merge",
+			input: []domain.TimeRange{{Start: at(9), End: at(12)}, {Start: at(11), End: at(14)}},
+			want:  []domain.TimeRange{{Start: at(9), End: at(14)}},
+		},
+		{
+			name:  "touching ranges merge",
+			input: []domain.TimeRange{{Start: at(9), End: at(12)}, {Start: at(12), End: at(14)}},
+			want:  []domain.TimeRange{{Start: at(9), End: at(14)}},
+		},
+		{
+			name:  "contained range is absorbed",
+			input: []domain.TimeRange{{Start: at(9), End: at(17)}, {Start: at(10), End: at(11)}},
+			want:  []domain.TimeRange{{Start: at(9), End: at(17)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeBusy(tt.input))
+		})
+	}
+}
+
+func TestOverlapDuration(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	a := domain.TimeRange{Start: day.Add(9 * time.Hour), End: day.Add(12 * time.Hour)}
+
+	assert.Equal(t, 2*time.Hour, overlapDuration(a, domain.TimeRange{Start: day.Add(10 * time.Hour), End: day.Add(14 * time.Hour)}))
+	assert.Equal(t, time.Duration(0), overlapDuration(a, domain.TimeRange{Start: day.Add(12 * time.Hour), End: day.Add(14 * time.Hour)}))
+}
+
+func TestOverlapWindow(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name string
+		a, b domain.TimeRange
+		want domain.TimeRange
+		ok   bool
+	}{
+		{"partial", r(9, 12), r(10, 14), r(10, 12), true},
+		{"contained", r(9, 17), r(11, 13), r(11, 13), true},
+		{"containing", r(11, 13), r(9, 17), r(11, 13), true},
+		{"touching", r(9, 12), r(12, 14), domain.TimeRange{}, false},
+		{"apart", r(9, 10), r(12, 14), domain.TimeRange{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := overlapWindow(tt.a, tt.b)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+			// The window doesn't depend on the order of the ranges
+			swapped, _ := overlapWindow(tt.b, tt.a)
+			assert.Equal(t, got, swapped)
+		})
+	}
+}
+
+func TestSubtractBusy(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+
+	windows := []domain.TimeRange{{Start: at(8), End: at(12)}, {Start: at(13), End: at(17)}}
+	busy := []domain.TimeRange{
+		{Start: at(6), End: at(9)},   // clips the first window's start
+		{Start: at(10), End: at(11)}, // splits the first window
+		{Start: at(11), End: at(14)}, // spans the gap between windows
+		{Start: at(17), End: at(18)}, // touches the second window's end
+	}
+
+	got := subtractBusy(windows, busy)
+
+	assert.Equal(t, []domain.TimeRange{
+		{Start: at(9), End: at(10)},
+		{Start: at(14), End: at(17)},
+	}, got)
+	assert.Equal(t, windows, subtractBusy(windows, nil))
+	assert.Empty(t, subtractBusy(windows, []domain.TimeRange{{Start: at(0), End: at(24)}}))
+}
+
+func TestIntersectBusy(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+
+	a := []domain.TimeRange{r(8, 12), r(14, 18)}
+	b := []domain.TimeRange{r(10, 15), r(16, 17), r(18, 20)}
+
+	// 18-20 only touches 14-18, so it adds nothing
+	assert.Equal(t, []domain.TimeRange{r(10, 12), r(14, 15), r(16, 17)}, intersectBusy(a, b))
+	assert.Equal(t, intersectBusy(a, b), intersectBusy(b, a))
+	assert.Empty(t, intersectBusy(a, nil))
+	assert.Empty(t, intersectBusy(a, []domain.TimeRange{r(12, 14)}))
+}
+
+func TestClusterOverlapping(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entry := func(id int32, startHour, endHour int) domain.ScheduleEntry {
+		return domain.ScheduleEntry{
+			ID:        id,
+			StartTime: day.Add(time.Duration(startHour) * time.Hour),
+			EndTime:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+	ids := func(c domain.ConflictCluster) []int32 {
+		var out []int32
+		for _, e := range c.Entries {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+
+	t.Run("three-entry chain forms one cluster", func(t *testing.T) {
+		// 1 overlaps 2, 2 overlaps 3, but 1 and 3 don't overlap directly
+		clusters := clusterOverlapping([]domain.ScheduleEntry{
+			entry(3, 12, 15), entry(1, 8, 11), entry(2, 10, 13),
+		})
+
+		assert.Len(t, clusters, 1)
+		assert.Equal(t, []int32{1, 2, 3}, ids(clusters[0]))
+		assert.Equal(t, day.Add(8*time.Hour), clusters[0].Start)
+		assert.Equal(t, day.Add(15*time.Hour), clusters[0].End)
+	})
+
+	t.Run("two disjoint clusters", func(t *testing.T) {
+		clusters := clusterOverlapping([]domain.ScheduleEntry{
+			entry(1, 8, 10), entry(2, 9, 11),
+			entry(3, 11, 12), // touches the first cluster's end; overlaps nothing
+			entry(4, 14, 18), entry(5, 15, 16),
+		})
+
+		assert.Len(t, clusters, 2)
+		assert.Equal(t, []int32{1, 2}, ids(clusters[0]))
+		assert.Equal(t, []int32{4, 5}, ids(clusters[1]))
+		assert.Equal(t, day.Add(18*time.Hour), clusters[1].End)
+	})
+
+	t.Run("no overlaps", func(t *testing.T) {
+		clusters := clusterOverlapping([]domain.ScheduleEntry{entry(1, 8, 9), entry(2, 10, 11)})
+
+		assert.Empty(t, clusters)
+	})
+}
+
+func TestPeakConcurrency(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+
+	t.Run("overlapping resources", func(t *testing.T) {
+		peak, window := peakConcurrency(map[int32][]domain.TimeRange{
+			1: {r(8, 12)},
+			2: {r(10, 14)},
+			3: {r(11, 13), r(16, 18)},
+			4: {r(16, 17)},
+		})
+
+		assert.Equal(t, 3, peak)
+		assert.Equal(t, r(11, 12), window)
+	})
+
+	t.Run("double-booked resource counts once", func(t *testing.T) {
+		peak, _ := peakConcurrency(map[int32][]domain.TimeRange{
+			1: {r(8, 12), r(9, 11)},
+			2: {r(10, 11)},
+		})
+
+		assert.Equal(t, 2, peak)
+	})
+
+	t.Run("back-to-back ranges don't overlap", func(t *testing.T) {
+		peak, window := peakConcurrency(map[int32][]domain.TimeRange{
+			1: {r(8, 10)},
+			2: {r(10, 12)},
+		})
+
+		assert.Equal(t, 1, peak)
+		assert.Equal(t, r(8, 10), window)
+	})
+
+	t.Run("nothing booked", func(t *testing.T) {
+		peak, _ := peakConcurrency(nil)
+
+		assert.Equal(t, 0, peak)
+	})
+}
+
+func TestPeakUnits(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+	usage := []unitUsage{
+		{occupied: r(8, 12), units: 3},
+		{occupied: r(10, 14), units: 4},
+		{occupied: r(12, 16), units: 2},
+		{occupied: r(18, 20), units: 9},
+	}
+
+	assert.Equal(t, int32(7), peakUnits(usage, r(8, 17)))
+	// Ends before starts: 8-12 and 12-16 never stack
+	assert.Equal(t, int32(6), peakUnits(usage, r(12, 17)))
+	// Bookings outside the window don't count
+	assert.Equal(t, int32(2), peakUnits(usage, r(14, 18)))
+	assert.Equal(t, int32(0), peakUnits(nil, r(8, 17)))
+}
+
+func TestSaturatedRanges(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+	usage := []unitUsage{
+		{occupied: r(8, 12), units: 3},
+		{occupied: r(10, 14), units: 4},
+		{occupied: r(12, 16), units: 2},
+		{occupied: r(18, 20), units: 9},
+	}
+
+	assert.Equal(t, []domain.TimeRange{r(10, 14), r(18, 20)}, saturatedRanges(usage, 5))
+	// Any booking saturates a limit of zero; back-to-back bookings join up
+	assert.Equal(t, []domain.TimeRange{r(8, 16), r(18, 20)}, saturatedRanges(usage, 0))
+	assert.Empty(t, saturatedRanges(usage, 9))
+	assert.Empty(t, saturatedRanges(nil, 0))
+}
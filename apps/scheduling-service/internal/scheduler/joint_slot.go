@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// maxJointSlotResources caps how many resources a joint slot search may need
+// free together
+const maxJointSlotResources = 50
+
+// FindBestJointSlot returns the earliest window of the requested duration in
+// which every resource is free at the same time. The resources' busy time is
+// combined and the first common gap long enough is taken. Bookings are read
+// as CheckConflicts reads them, including release grace. It is a not-found
+// error when no such window starts and ends within the search.
+func (s *AvailabilityService) FindBestJointSlot(ctx context.Context, req domain.JointSlotRequest) (*domain.JointSlotResponse, error) {
+	if len(req.ResourceIDs) == 0 {
+		return nil, domain.NewValidationError("resource_ids must not be empty")
+	}
+	if len(req.ResourceIDs) > maxJointSlotResources {
+		return nil, domain.NewValidationError(fmt.Sprintf("resource_ids must not contain more than %d resources", maxJointSlotResources))
+	}
+	if err := validateSlotDuration(req.Duration); err != nil {
+		return nil, err
+	}
+
+	from := req.From
+	if from.IsZero() {
+		from = time.Now().UTC()
+	}
+	until := req.Until
+	if until.IsZero() {
+		until = from.Add(slotSearchHorizon)
+	}
+	if !until.After(from) {
+		return nil, domain.NewValidationError("until must be after from")
+	}
+	if until.Sub(from) > slotSearchHorizon {
+		return nil, domain.NewValidationError("range must not exceed 30 days")
+	}
+
+	// A missing resource is an error rather than never being busy
+	for _, id := range req.ResourceIDs {
+		if _, err := s.GetResourceByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	busy, err := s.loadBusy(ctx, req.ResourceIDs, from, until)
+	if err != nil {
+		return nil, err
+	}
+	gap, ok := firstCommonGap(busy, domain.TimeRange{Start: from, End: until}, req.Duration)
+	if !ok {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("resources are not free together for %s within the search", req.Duration))
+	}
+	return &domain.JointSlotResponse{
+		ResourceIDs: req.ResourceIDs,
+		SlotStart:   gap.Start,
+		SlotEnd:     gap.Start.Add(req.Duration),
+		FreeUntil:   gap.End,
+	}, nil
+}
+
+// firstCommonGap returns the earliest gap within the window, lasting at least
+// d, during which none of the resources is busy. Any one resource's booking
+// closes a gap, so the busy ranges of all of them are merged together.
+func firstCommonGap(busy map[int32][]domain.TimeRange, window domain.TimeRange, d time.Duration) (domain.TimeRange, bool) {
+	var combined []domain.TimeRange
+	for _, ranges := range busy {
+		combined = append(combined, ranges...)
+	}
+	slots := freeSlots(mergeBusy(combined), window, d)
+	if len(slots) == 0 {
+		return domain.TimeRange{}, false
+	}
+	return slots[0], true
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestFirstCommonGap(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	window := domain.TimeRange{Start: at(8), End: at(20)}
+
+	t.Run("aligned availability", func(t *testing.T) {
+		busy := map[int32][]domain.TimeRange{
+			1: {{Start: at(8), End: at(11)}},
+			2: {{Start: at(9), End: at(11)}},
+		}
+		gap, ok := firstCommonGap(busy, window, 3*time.Hour)
+		require.True(t, ok)
+		assert.Equal(t, domain.TimeRange{Start: at(11), End: at(20)}, gap)
+	})
+
+	t.Run("misaligned gaps too short together", func(t *testing.T) {
+		// Each resource alone has a long gap, but they only share 12:00-13:00
+		// before 16:00
+		busy := map[int32][]domain.TimeRange{
+			1: {{Start: at(8), End: at(12)}, {Start: at(16), End: at(17)}},
+			2: {{Start: at(13), End: at(16)}},
+		}
+		gap, ok := firstCommonGap(busy, window, 2*time.Hour)
+		require.True(t, ok)
+		assert.Equal(t, domain.TimeRange{Start: at(17), End: at(20)}, gap)
+	})
+
+	t.Run("no common gap", func(t *testing.T) {
+		busy := map[int32][]domain.TimeRange{
+			1: {{Start: at(8), End: at(14)}},
+			2: {{Start: at(13), End: at(20)}},
+		}
+		_, ok := firstCommonGap(busy, window, time.Hour)
+		assert.False(t, ok)
+	})
+}
+
+func TestFindBestJointSlot(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:                "Oven",
+		Type:                testutil.ResourceTypeEquipment,
+		IsAvailable:         true,
+		ReleaseGraceMinutes: 30,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(14*time.Hour), baseDay.Add(15*time.Hour), nil)
+	// With release grace the oven is busy until 13:30
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, baseDay.Add(10*time.Hour), baseDay.Add(13*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+	req := domain.JointSlotRequest{
+		ResourceIDs: []int32{chef, oven},
+		Duration:    3 * time.Hour,
+		From:        baseDay.Add(9 * time.Hour),
+		Until:       baseDay.Add(24 * time.Hour),
+	}
+
+	result, err := service.FindBestJointSlot(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, baseDay.Add(15*time.Hour), result.SlotStart)
+	assert.Equal(t, baseDay.Add(18*time.Hour), result.SlotEnd)
+	assert.Equal(t, baseDay.Add(24*time.Hour), result.FreeUntil)
+
+	// The 13:30-14:00 gap fits a short task
+	req.Duration = 30 * time.Minute
+	result, err = service.FindBestJointSlot(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, baseDay.Add(13*time.Hour+30*time.Minute), result.SlotStart)
+	assert.Equal(t, baseDay.Add(14*time.Hour), result.FreeUntil)
+
+	// Nothing long enough before the search ends
+	req.Duration = 4 * time.Hour
+	req.Until = baseDay.Add(18 * time.Hour)
+	_, err = service.FindBestJointSlot(context.Background(), req)
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestFindBestJointSlot_InvalidRequest(t *testing.T) {
+	service := NewAvailabilityService(nil, nil)
+	from := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  domain.JointSlotRequest
+	}{
+		{name: "no resources", req: domain.JointSlotRequest{Duration: time.Hour}},
+		{name: "no duration", req: domain.JointSlotRequest{ResourceIDs: []int32{1}}},
+		{name: "until before from", req: domain.JointSlotRequest{ResourceIDs: []int32{1}, Duration: time.Hour, From: from, Until: from.Add(-time.Hour)}},
+		{name: "range too long", req: domain.JointSlotRequest{ResourceIDs: []int32{1}, Duration: time.Hour, From: from, Until: from.Add(31 * 24 * time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.FindBestJointSlot(context.Background(), tt.req)
+			assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+		})
+	}
+}
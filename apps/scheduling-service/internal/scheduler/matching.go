@@ -0,0 +1,107 @@
+package scheduler
+
+import "math"
+
+// noEdge marks a task/staff pair that cannot be matched in a cost matrix
+const noEdge int64 = -1
+
+// minCostMatching assigns each row (task) of the cost matrix to at most one
+// distinct column (staff member), maximizing the number of matched rows and,
+// among those, minimizing the total cost. Entries equal to noEdge can't be
+// matched. It returns, per row, the matched column or -1.
+//
+// Rows are padded with one "unassigned" column each, priced above any
+// combination of real edges, and solved with the Hungarian algorithm.
+func minCostMatching(costs [][]int64) []int {
+	n := len(costs)
+	if n == 0 {
+		return nil
+	}
+	cols := len(costs[0])
+
+	// Leaving a row unassigned must cost more than every real edge combined,
+	// so a larger matching always wins over a cheaper one
+	var total int64
+	for _, row := range costs {
+		for _, c := range row {
+			if c != noEdge {
+				total += c
+			}
+		}
+	}
+	unassigned := total + 1
+	forbidden := 2 * unassigned
+
+	m := cols + n
+	cost := func(i, j int) int64 {
+		if j >= cols {
+			return unassigned
+		}
+		if c := costs[i][j]; c != noEdge {
+			return c
+		}
+		return forbidden
+	}
+
+	// Potentials and matching are 1-indexed; column 0 is the virtual root
+	u := make([]int64, n+1)
+	v := make([]int64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]int64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.MaxInt64
+		}
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := int64(math.MaxInt64)
+			j1 := 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost(i0-1, j-1) - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	for j := 1; j <= cols; j++ {
+		if i := p[j]; i != 0 && costs[i-1][j-1] != noEdge {
+			match[i-1] = j - 1
+		}
+	}
+	return match
+}
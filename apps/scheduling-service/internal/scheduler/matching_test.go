@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinCostMatching_PrefersCheaperColumns(t *testing.T) {
+	costs := [][]int64{
+		{10, 3, 8},
+		{10, 5, 2},
+	}
+
+	assert.Equal(t, []int{1, 2}, minCostMatching(costs))
+}
+
+func TestMinCostMatching_MaximizesMatchedRowsBeforeCost(t *testing.T) {
+	// Row 0 alone prefers column 0, but only row 0 can use column 1, so giving
+	// row 0 the expensive column lets both rows be matched
+	costs := [][]int64{
+		{1, 100},
+		{5, noEdge},
+	}
+
+	assert.Equal(t, []int{1, 0}, minCostMatching(costs))
+}
+
+func TestMinCostMatching_TooFewColumns(t *testing.T) {
+	costs := [][]int64{
+		{4},
+		{2},
+		{noEdge},
+	}
+
+	assert.Equal(t, []int{-1, 0, -1}, minCostMatching(costs))
+}
+
+func TestMinCostMatching_Empty(t *testing.T) {
+	assert.Nil(t, minCostMatching(nil))
+	assert.Equal(t, []int{-1, -1}, minCostMatching([][]int64{{}, {}}))
+}
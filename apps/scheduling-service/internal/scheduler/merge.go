@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// EventMergeService moves bookings between events when two events are merged
+type EventMergeService struct {
+	db        *sql.DB
+	queries   *repository.Queries
+	publisher events.Publisher
+}
+
+// NewEventMergeService creates a new event merge service publishing the
+// bookings it moves through publisher
+func NewEventMergeService(db *sql.DB, publisher events.Publisher) *EventMergeService {
+	return &EventMergeService{
+		db:        db,
+		queries:   repository.NewQueries(db),
+		publisher: publisher,
+	}
+}
+
+// MergeInto moves every booking of the source event onto the target event. If
+// a resource has overlapping bookings in both events the merge would leave it
+// double-booked within one event, so nothing is moved and the overlapping
+// pairs are returned instead. Both events stay locked for the check and the
+// move, so no booking can be added to either in between. Each moved booking is
+// published as a schedule change.
+func (s *EventMergeService) MergeInto(ctx context.Context, sourceID, targetID int32) (*domain.EventMergeResponse, error) {
+	if sourceID == targetID {
+		return nil, domain.NewValidationError("an event cannot be merged into itself")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	locked, err := q.LockEvents(ctx, []int32{sourceID, targetID})
+	if err != nil {
+		return nil, dbError("failed to lock events", err)
+	}
+	if len(locked) != 2 {
+		return nil, domain.NewNotFoundError("event not found")
+	}
+
+	rows, err := q.ListEventMergeConflicts(ctx, repository.ListEventMergeConflictsParams{
+		SourceEventID: sourceID,
+		TargetEventID: targetID,
+	})
+	if err != nil {
+		return nil, dbError("failed to check merge conflicts", err)
+	}
+
+	resp := &domain.EventMergeResponse{
+		SourceEventID: sourceID,
+		TargetEventID: targetID,
+		Conflicts:     make([]domain.EventMergeConflict, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Conflicts = append(resp.Conflicts, domain.EventMergeConflict{
+			ResourceID:       row.ResourceID,
+			ResourceName:     row.ResourceName,
+			SourceScheduleID: row.SourceScheduleID,
+			SourceStartTime:  row.SourceStartTime,
+			SourceEndTime:    row.SourceEndTime,
+			TargetScheduleID: row.TargetScheduleID,
+			TargetStartTime:  row.TargetStartTime,
+			TargetEndTime:    row.TargetEndTime,
+		})
+	}
+	if len(resp.Conflicts) > 0 {
+		return resp, nil
+	}
+
+	ids, err := q.MoveEventScheduleEntries(ctx, repository.MoveEventScheduleEntriesParams{
+		TargetEventID: targetID,
+		SourceEventID: sourceID,
+	})
+	if err != nil {
+		return nil, dbError("failed to move schedule entries", err)
+	}
+	moved, err := loadEntries(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit merge", err)
+	}
+
+	resp.Merged = true
+	resp.MovedCount = int64(len(ids))
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeUpdated, moved)
+	return resp, nil
+}
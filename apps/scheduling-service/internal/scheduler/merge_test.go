@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestMergeInto_MovesBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, targetID := testutil.SetupBaseData(t, testDB.DB)
+	sourceID := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, targetID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	// Back-to-back with the target's booking, so no overlap
+	movedID := testutil.CreateScheduleEntry(t, testDB.DB, chef, sourceID,
+		baseDay.Add(12*time.Hour), baseDay.Add(14*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, sourceID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewEventMergeService(testDB.DB, events.Noop{})
+
+	result, err := service.MergeInto(context.Background(), sourceID, targetID)
+
+	require.NoError(t, err)
+	assert.True(t, result.Merged)
+	assert.Equal(t, int64(2), result.MovedCount)
+	assert.Empty(t, result.Conflicts)
+
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), movedID)
+	require.NoError(t, err)
+	assert.Equal(t, targetID, entry.EventID)
+}
+
+func TestMergeInto_ReportsConflicts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, targetID := testutil.SetupBaseData(t, testDB.DB)
+	sourceID := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	targetEntry := testutil.CreateScheduleEntry(t, testDB.DB, chef, targetID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	sourceEntry := testutil.CreateScheduleEntry(t, testDB.DB, chef, sourceID,
+		baseDay.Add(11*time.Hour), baseDay.Add(14*time.Hour), nil)
+	untouched := testutil.CreateScheduleEntry(t, testDB.DB, oven, sourceID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	// Rejected bookings don't block a merge
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, targetID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	service := NewEventMergeService(testDB.DB, events.Noop{})
+
+	result, err := service.MergeInto(context.Background(), sourceID, targetID)
+
+	require.NoError(t, err)
+	assert.False(t, result.Merged)
+	assert.Equal(t, int64(0), result.MovedCount)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, chef, result.Conflicts[0].ResourceID)
+	assert.Equal(t, sourceEntry, result.Conflicts[0].SourceScheduleID)
+	assert.Equal(t, targetEntry, result.Conflicts[0].TargetScheduleID)
+
+	// Nothing moved, including the source's non-conflicting booking
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), untouched)
+	require.NoError(t, err)
+	assert.Equal(t, sourceID, entry.EventID)
+}
+
+func TestMergeInto_Validation(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	service := NewEventMergeService(testDB.DB, events.Noop{})
+
+	_, err := service.MergeInto(context.Background(), eventID, eventID)
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code)
+
+	_, err = service.MergeInto(context.Background(), 99999, eventID)
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeNotFound, err.(*domain.DomainError).Code)
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ConflictMessageTemplateEnv names the environment variable that overrides the
+// wording of booking conflict messages
+const ConflictMessageTemplateEnv = "CONFLICT_MESSAGE_TEMPLATE"
+
+// defaultConflictMessageTemplate is the wording used unless overridden
+const defaultConflictMessageTemplate = "Resource '{resource}' is already assigned to event '{event}' from {start} to {end}"
+
+// conflictMessageTimeLayout formats the {start} and {end} placeholders
+const conflictMessageTimeLayout = "2006-01-02 15:04"
+
+// conflictMessagePlaceholders are the names a conflict message template may use
+var conflictMessagePlaceholders = map[string]bool{
+	"resource": true,
+	"event":    true,
+	"start":    true,
+	"end":      true,
+}
+
+// conflictMessages renders every booking conflict message. It is replaced at
+// most once, at startup, before any request is served.
+var conflictMessages = mustParseConflictMessageTemplate(defaultConflictMessageTemplate)
+
+// ConflictMessageTemplate is a parsed conflict message. Placeholders are
+// written {resource}, {event}, {start}, and {end}; {{ and }} stand for literal
+// braces.
+type ConflictMessageTemplate struct {
+	// parts alternates literal text (even indexes) and placeholder names (odd)
+	parts []string
+}
+
+// ParseConflictMessageTemplate parses and validates a conflict message template
+func ParseConflictMessageTemplate(s string) (*ConflictMessageTemplate, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("conflict message template is empty")
+	}
+
+	var parts []string
+	var literal strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '{' && i+1 < len(s) && s[i+1] == '{':
+			literal.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(s) && s[i+1] == '}':
+			literal.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(s[i+1:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated placeholder at offset %d", i)
+			}
+			name := s[i+1 : i+1+end]
+			if !conflictMessagePlaceholders[name] {
+				return nil, fmt.Errorf("unknown placeholder {%s}: expected resource, event, start, or end", name)
+			}
+			parts = append(parts, literal.String(), name)
+			literal.Reset()
+			i += end + 1
+		case c == '}':
+			return nil, fmt.Errorf("unexpected } at offset %d", i)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	parts = append(parts, literal.String())
+
+	return &ConflictMessageTemplate{parts: parts}, nil
+}
+
+// mustParseConflictMessageTemplate parses a template known to be valid
+func mustParseConflictMessageTemplate(s string) *ConflictMessageTemplate {
+	t, err := ParseConflictMessageTemplate(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render fills in the template for one conflicting booking
+func (t *ConflictMessageTemplate) Render(resourceName, eventName string, start, end time.Time) string {
+	values := map[string]string{
+		"resource": resourceName,
+		"event":    eventName,
+		"start":    start.Format(conflictMessageTimeLayout),
+		"end":      end.Format(conflictMessageTimeLayout),
+	}
+
+	var b strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 1 {
+			part = values[part]
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// LoadConflictMessageTemplate applies the template from
+// CONFLICT_MESSAGE_TEMPLATE, if set. Call it once at startup; an invalid
+// template is returned as an error so the service can refuse to start.
+func LoadConflictMessageTemplate() error {
+	s, ok := os.LookupEnv(ConflictMessageTemplateEnv)
+	if !ok {
+		return nil
+	}
+	t, err := ParseConflictMessageTemplate(s)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", ConflictMessageTemplateEnv, err)
+	}
+	conflictMessages = t
+	return nil
+}
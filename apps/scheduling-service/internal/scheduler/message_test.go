@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflictMessageTemplate_Default(t *testing.T) {
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 15, 12, 30, 0, 0, time.UTC)
+
+	got := mustParseConflictMessageTemplate(defaultConflictMessageTemplate).Render("Chef Ana", "Smith Wedding", start, end)
+
+	assert.Equal(t, "Resource 'Chef Ana' is already assigned to event 'Smith Wedding' from 2025-06-15 09:00 to 2025-06-15 12:30", got)
+}
+
+func TestConflictMessageTemplate_Custom(t *testing.T) {
+	tmpl, err := ParseConflictMessageTemplate("{resource} busy {{{event}}} {start}-{end}")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 6, 15, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 15, 12, 30, 0, 0, time.UTC)
+	got := tmpl.Render("Oven", "Gala", start, end)
+
+	assert.Equal(t, "Oven busy {Gala} 2025-06-15 09:00-2025-06-15 12:30", got)
+}
+
+func TestParseConflictMessageTemplate_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"{resource} at {venue}",
+		"{resource",
+		"resource}",
+	} {
+		_, err := ParseConflictMessageTemplate(s)
+		assert.Error(t, err, "%q", s)
+	}
+}
+
+func TestLoadConflictMessageTemplate(t *testing.T) {
+	original := conflictMessages
+	t.Cleanup(func() { conflictMessages = original })
+
+	t.Setenv(ConflictMessageTemplateEnv, "{resource} is taken by {event}")
+	require.NoError(t, LoadConflictMessageTemplate())
+	assert.Equal(t, "Oven is taken by Gala", conflictMessages.Render("Oven", "Gala", time.Time{}, time.Time{}))
+
+	t.Setenv(ConflictMessageTemplateEnv, "{resource} is taken by {client}")
+	assert.Error(t, LoadConflictMessageTemplate())
+	// A failed load leaves the previous template in place
+	assert.Equal(t, "Oven is taken by Gala", conflictMessages.Render("Oven", "Gala", time.Time{}, time.Time{}))
+}
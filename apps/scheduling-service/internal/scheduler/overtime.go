@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// OvertimeThresholdEnv names the environment variable holding the booked
+	// hours per day after which overtime applies
+	OvertimeThresholdEnv = "OVERTIME_THRESHOLD_HOURS"
+	// OvertimeMultiplierEnv names the environment variable holding the rate
+	// multiplier for overtime hours
+	OvertimeMultiplierEnv = "OVERTIME_MULTIPLIER"
+
+	// maxOvertimeRange bounds the window an overtime estimate covers
+	maxOvertimeRange = 31 * 24 * time.Hour
+)
+
+// OvertimePolicy decides which booked hours bill at a premium. Amounts are
+// kept in hundredths so they combine with cents without floating point.
+type OvertimePolicy struct {
+	// thresholdHundredths is the daily threshold in hundredths of an hour
+	thresholdHundredths int64
+	// multiplierHundredths is the overtime multiplier in hundredths
+	multiplierHundredths int64
+}
+
+// overtimePolicy prices every overtime estimate. It is replaced at most once,
+// at startup, before any request is served.
+var overtimePolicy = OvertimePolicy{thresholdHundredths: 800, multiplierHundredths: 150}
+
+// ParseOvertimePolicy parses and validates a daily threshold in hours and a
+// rate multiplier, each with at most two decimal places
+func ParseOvertimePolicy(thresholdHours, multiplier string) (OvertimePolicy, error) {
+	threshold, err := parseCents(thresholdHours)
+	if err != nil {
+		return OvertimePolicy{}, fmt.Errorf("threshold: %w", err)
+	}
+	if threshold <= 0 || threshold > 2400 {
+		return OvertimePolicy{}, errors.New("threshold must be more than 0 and at most 24 hours")
+	}
+	mult, err := parseCents(multiplier)
+	if err != nil {
+		return OvertimePolicy{}, fmt.Errorf("multiplier: %w", err)
+	}
+	if mult < 100 {
+		return OvertimePolicy{}, errors.New("multiplier must be at least 1")
+	}
+	return OvertimePolicy{thresholdHundredths: threshold, multiplierHundredths: mult}, nil
+}
+
+// LoadOvertimePolicy applies OVERTIME_THRESHOLD_HOURS and OVERTIME_MULTIPLIER,
+// keeping the default for whichever is unset. Call it once at startup; an
+// invalid value is returned as an error so the service can refuse to start.
+func LoadOvertimePolicy() error {
+	threshold := formatCents(overtimePolicy.thresholdHundredths)
+	if v, ok := os.LookupEnv(OvertimeThresholdEnv); ok {
+		threshold = v
+	}
+	multiplier := formatCents(overtimePolicy.multiplierHundredths)
+	if v, ok := os.LookupEnv(OvertimeMultiplierEnv); ok {
+		multiplier = v
+	}
+	p, err := ParseOvertimePolicy(threshold, multiplier)
+	if err != nil {
+		return fmt.Errorf("invalid %s or %s: %w", OvertimeThresholdEnv, OvertimeMultiplierEnv, err)
+	}
+	overtimePolicy = p
+	return nil
+}
+
+// thresholdSeconds is the daily threshold in seconds
+func (p OvertimePolicy) thresholdSeconds() int64 {
+	return p.thresholdHundredths * 36
+}
+
+// premiumCents prices overtime seconds at the part of the multiplier above
+// one, rounding half up to the cent
+func (p OvertimePolicy) premiumCents(rateCents, seconds int64) int64 {
+	return (rateCents*seconds*(p.multiplierHundredths-100) + 180000) / 360000
+}
+
+// GetOvertimeCost prices a resource's bookings within the window, billing the
+// hours on each local calendar day beyond the overtime threshold at the
+// overtime multiplier. Days are read in the resource's timezone and clipped to
+// the window. Bookings are summed as in client cost reports, so overlapping
+// bookings each count.
+func (s *CostService) GetOvertimeCost(ctx context.Context, req domain.OvertimeCostRequest) (*domain.OvertimeCostResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxOvertimeRange {
+		return nil, domain.NewValidationError("range must not exceed 31 days")
+	}
+
+	row, err := s.queries.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+	resource := toDomainResource(row)
+	loc, err := resourceZone(&resource)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.queries.ListOverlappingResourceSchedule(ctx, repository.ListOverlappingResourceScheduleParams{
+		ResourceID: req.ResourceID,
+		RangeStart: req.StartDate,
+		RangeEnd:   req.EndDate,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+	booked := make([]domain.TimeRange, 0, len(entries))
+	for _, e := range entries {
+		booked = append(booked, domain.TimeRange{Start: e.StartTime, End: e.EndTime})
+	}
+
+	policy := overtimePolicy
+	days := overtimeDays(booked, loc, req.StartDate, req.EndDate, policy.thresholdSeconds())
+
+	resp := &domain.OvertimeCostResponse{
+		ResourceID:     resource.ID,
+		ResourceName:   resource.Name,
+		Timezone:       loc.String(),
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		ThresholdHours: float64(policy.thresholdHundredths) / 100,
+		Multiplier:     formatCents(policy.multiplierHundredths),
+		Days:           make([]domain.OvertimeDay, 0, len(days)),
+	}
+	var bookedSeconds, overtimeSeconds int64
+	for _, d := range days {
+		bookedSeconds += d.bookedSeconds
+		overtimeSeconds += d.overtimeSeconds
+		resp.Days = append(resp.Days, domain.OvertimeDay{
+			Date:          d.date,
+			BookedHours:   secondsToHours(d.bookedSeconds),
+			OvertimeHours: secondsToHours(d.overtimeSeconds),
+		})
+	}
+	resp.BookedHours = secondsToHours(bookedSeconds)
+	resp.OvertimeHours = secondsToHours(overtimeSeconds)
+
+	// A rate-less resource still reports its hours but has no cost
+	if resource.HourlyRate != nil {
+		rateCents, err := parseCents(*resource.HourlyRate)
+		if err != nil {
+			return nil, domain.NewInternalError("invalid hourly rate", err)
+		}
+		baseCents := costCents(rateCents, bookedSeconds)
+		premiumCents := policy.premiumCents(rateCents, overtimeSeconds)
+		base := formatCents(baseCents)
+		premium := formatCents(premiumCents)
+		total := formatCents(baseCents + premiumCents)
+		resp.HourlyRate = resource.HourlyRate
+		resp.BaseCost = &base
+		resp.OvertimePremium = &premium
+		resp.TotalCost = &total
+	}
+
+	return resp, nil
+}
+
+// overtimeDay is the booked and overtime seconds on one local calendar day
+type overtimeDay struct {
+	date            string
+	bookedSeconds   int64
+	overtimeSeconds int64
+}
+
+// overtimeDays buckets booked time by calendar day in loc over [start, end),
+// counting whole seconds so the buckets add up exactly. Days are built from
+// calendar dates so a DST change doesn't shift them; days with no booked time
+// are omitted.
+func overtimeDays(booked []domain.TimeRange, loc *time.Location, start, end time.Time, thresholdSeconds int64) []overtimeDay {
+	y, m, d := start.In(loc).Date()
+
+	var days []overtimeDay
+	for i := 0; ; i++ {
+		dayStart := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		if !dayStart.Before(end) {
+			break
+		}
+		window := domain.TimeRange{Start: dayStart, End: time.Date(y, m, d+i+1, 0, 0, 0, 0, loc)}
+		if window.Start.Before(start) {
+			window.Start = start
+		}
+		if window.End.After(end) {
+			window.End = end
+		}
+
+		var seconds int64
+		for _, b := range booked {
+			seconds += int64(overlapDuration(b, window) / time.Second)
+		}
+		if seconds == 0 {
+			continue
+		}
+		day := overtimeDay{date: dayStart.Format("2006-01-02"), bookedSeconds: seconds}
+		if seconds > thresholdSeconds {
+			day.overtimeSeconds = seconds - thresholdSeconds
+		}
+		days = append(days, day)
+	}
+	return days
+}
@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestOvertimeDays(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	r := func(startHour, endHour int) domain.TimeRange {
+		return domain.TimeRange{
+			Start: day.Add(time.Duration(startHour) * time.Hour),
+			End:   day.Add(time.Duration(endHour) * time.Hour),
+		}
+	}
+	threshold := int64(8 * 3600)
+
+	t.Run("exactly at the threshold has no overtime", func(t *testing.T) {
+		days := overtimeDays([]domain.TimeRange{r(8, 12), r(13, 17)}, time.UTC, day, day.Add(24*time.Hour), threshold)
+
+		require.Len(t, days, 1)
+		assert.Equal(t, "2025-06-15", days[0].date)
+		assert.Equal(t, int64(8*3600), days[0].bookedSeconds)
+		assert.Equal(t, int64(0), days[0].overtimeSeconds)
+	})
+
+	t.Run("hours over the threshold are overtime", func(t *testing.T) {
+		days := overtimeDays([]domain.TimeRange{r(7, 17), r(18, 19)}, time.UTC, day, day.Add(24*time.Hour), threshold)
+
+		require.Len(t, days, 1)
+		assert.Equal(t, int64(11*3600), days[0].bookedSeconds)
+		assert.Equal(t, int64(3*3600), days[0].overtimeSeconds)
+	})
+
+	t.Run("overnight booking splits at local midnight", func(t *testing.T) {
+		// 18:00-06:00 next day: 6h on each day, neither over the threshold
+		days := overtimeDays([]domain.TimeRange{r(18, 30)}, time.UTC, day, day.Add(48*time.Hour), threshold)
+
+		require.Len(t, days, 2)
+		assert.Equal(t, "2025-06-15", days[0].date)
+		assert.Equal(t, int64(6*3600), days[0].bookedSeconds)
+		assert.Equal(t, "2025-06-16", days[1].date)
+		assert.Equal(t, int64(6*3600), days[1].bookedSeconds)
+		assert.Equal(t, int64(0), days[0].overtimeSeconds+days[1].overtimeSeconds)
+	})
+
+	t.Run("days follow the resource timezone", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		// 02:00-12:00 UTC is 22:00-08:00 in New York: 2h on the 14th, 8h on the 15th
+		days := overtimeDays([]domain.TimeRange{r(2, 12)}, ny, day, day.Add(24*time.Hour), threshold)
+
+		require.Len(t, days, 2)
+		assert.Equal(t, "2025-06-14", days[0].date)
+		assert.Equal(t, int64(2*3600), days[0].bookedSeconds)
+		assert.Equal(t, "2025-06-15", days[1].date)
+		assert.Equal(t, int64(8*3600), days[1].bookedSeconds)
+	})
+}
+
+func TestOvertimePolicy_PremiumCents(t *testing.T) {
+	p, err := ParseOvertimePolicy("8", "1.5")
+	require.NoError(t, err)
+
+	// 2h at 45.50 × 0.5 = 45.50
+	assert.Equal(t, int64(4550), p.premiumCents(4550, 2*3600))
+	// 1 minute at 0.25 × 0.5 = 0.00208…, rounds to 0.00
+	assert.Equal(t, int64(0), p.premiumCents(25, 60))
+	assert.Equal(t, int64(0), p.premiumCents(4550, 0))
+}
+
+func TestParseOvertimePolicy_Invalid(t *testing.T) {
+	for _, tc := range [][2]string{
+		{"0", "1.5"},
+		{"25", "1.5"},
+		{"8.125", "1.5"},
+		{"eight", "1.5"},
+		{"8", "0.99"},
+		{"8", ""},
+	} {
+		_, err := ParseOvertimePolicy(tc[0], tc[1])
+		assert.Error(t, err, "threshold %q multiplier %q", tc[0], tc[1])
+	}
+}
+
+func TestLoadOvertimePolicy(t *testing.T) {
+	original := overtimePolicy
+	t.Cleanup(func() { overtimePolicy = original })
+
+	t.Setenv(OvertimeThresholdEnv, "7.5")
+	require.NoError(t, LoadOvertimePolicy())
+	assert.Equal(t, OvertimePolicy{thresholdHundredths: 750, multiplierHundredths: 150}, overtimePolicy)
+
+	t.Setenv(OvertimeMultiplierEnv, "0.5")
+	assert.Error(t, LoadOvertimePolicy())
+	// A failed load leaves the previous policy in place
+	assert.Equal(t, int64(750), overtimePolicy.thresholdHundredths)
+}
+
+func TestGetOvertimeCost_AtAndOverThreshold(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	original := overtimePolicy
+	t.Cleanup(func() { overtimePolicy = original })
+	overtimePolicy = OvertimePolicy{thresholdHundredths: 800, multiplierHundredths: 150}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		HourlyRate:  strPtr("40.00"),
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	// Day one: exactly 8h
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(8*time.Hour), baseDay.Add(16*time.Hour), nil)
+	// Day two: 10.5h across two bookings
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(24*time.Hour+7*time.Hour), baseDay.Add(24*time.Hour+13*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(24*time.Hour+14*time.Hour), baseDay.Add(24*time.Hour+18*time.Hour+30*time.Minute), nil)
+
+	service := NewCostService(testDB.DB)
+
+	result, err := service.GetOvertimeCost(context.Background(), domain.OvertimeCostRequest{
+		ResourceID: chef,
+		StartDate:  baseDay,
+		EndDate:    baseDay.Add(48 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", result.Timezone)
+	assert.Equal(t, 8.0, result.ThresholdHours)
+	assert.Equal(t, "1.50", result.Multiplier)
+	assert.Equal(t, 18.5, result.BookedHours)
+	assert.Equal(t, 2.5, result.OvertimeHours)
+
+	require.Len(t, result.Days, 2)
+	assert.Equal(t, domain.OvertimeDay{Date: "2025-06-15", BookedHours: 8, OvertimeHours: 0}, result.Days[0])
+	assert.Equal(t, domain.OvertimeDay{Date: "2025-06-16", BookedHours: 10.5, OvertimeHours: 2.5}, result.Days[1])
+
+	// 18.5h × 40.00 = 740.00; premium 2.5h × 40.00 × 0.5 = 50.00
+	require.NotNil(t, result.BaseCost)
+	assert.Equal(t, "740.00", *result.BaseCost)
+	require.NotNil(t, result.OvertimePremium)
+	assert.Equal(t, "50.00", *result.OvertimePremium)
+	require.NotNil(t, result.TotalCost)
+	assert.Equal(t, "790.00", *result.TotalCost)
+}
+
+func TestGetOvertimeCost_RatelessResource(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		baseDay.Add(6*time.Hour), baseDay.Add(18*time.Hour), nil)
+
+	service := NewCostService(testDB.DB)
+
+	result, err := service.GetOvertimeCost(context.Background(), domain.OvertimeCostRequest{
+		ResourceID: oven,
+		StartDate:  baseDay,
+		EndDate:    baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, result.BookedHours)
+	assert.Nil(t, result.HourlyRate)
+	assert.Nil(t, result.BaseCost)
+	assert.Nil(t, result.OvertimePremium)
+	assert.Nil(t, result.TotalCost)
+}
+
+func TestGetOvertimeCost_Validation(t *testing.T) {
+	service := &CostService{}
+	start := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		end     time.Time
+		message string
+	}{
+		{"empty range", start, "end_date must be after start_date"},
+		{"range too long", start.Add(32 * 24 * time.Hour), "range must not exceed 31 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetOvertimeCost(context.Background(), domain.OvertimeCostRequest{
+				ResourceID: 1,
+				StartDate:  start,
+				EndDate:    tt.end,
+			})
+
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
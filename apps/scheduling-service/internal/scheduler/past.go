@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// pastStartGrace is how far before now a start may be when past bookings are
+// rejected, so a request for "now" isn't refused over clock skew between the
+// client and the service
+const pastStartGrace = time.Minute
+
+// checkNotPast refuses a start more than pastStartGrace before now, as told
+// by clock; a nil clock reads the system time
+func checkNotPast(clock func() time.Time, start time.Time) error {
+	now := time.Now()
+	if clock != nil {
+		now = clock()
+	}
+	if start.Before(now.Add(-pastStartGrace)) {
+		return domain.NewValidationError(fmt.Sprintf("start_time %s is in the past", start.UTC().Format(time.RFC3339)))
+	}
+	return nil
+}
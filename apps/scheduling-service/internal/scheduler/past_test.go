@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+// frozenClock always tells the same time
+func frozenClock(now time.Time) func() time.Time {
+	return func() time.Time { return now }
+}
+
+func TestCheckNotPast(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		past  bool
+	}{
+		{"an hour ago", now.Add(-time.Hour), true},
+		{"just past the grace", now.Add(-pastStartGrace - time.Second), true},
+		{"within the grace", now.Add(-30 * time.Second), false},
+		{"now", now, false},
+		{"tomorrow", now.AddDate(0, 0, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNotPast(frozenClock(now), tt.start)
+			if tt.past {
+				assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRejectPast_RefusedBeforeAnyLookup(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-2 * time.Hour)
+
+	conflicts := &ConflictService{clock: frozenClock(now)}
+	_, err := conflicts.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{1},
+		StartTime:   start,
+		EndTime:     now,
+		RejectPast:  true,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	schedules := &ScheduleService{clock: frozenClock(now)}
+	_, err = schedules.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: 1,
+		EventID:    1,
+		StartTime:  start,
+		EndTime:    now,
+		RejectPast: true,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
+
+func TestCreateEntryChecked_RejectPast(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	service.clock = frozenClock(now)
+
+	request := func(start time.Time, rejectPast bool) domain.ScheduleEntryRequest {
+		return domain.ScheduleEntryRequest{
+			ResourceID: resourceID,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+			RejectPast: rejectPast,
+		}
+	}
+
+	_, err := service.CreateEntryChecked(context.Background(), request(now.Add(-time.Hour), true))
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	// Starting a few seconds ago is within the clock-skew grace
+	_, err = service.CreateEntryChecked(context.Background(), request(now.Add(-10*time.Second), true))
+	require.NoError(t, err)
+
+	_, err = service.CreateEntryChecked(context.Background(), request(now.Add(2*time.Hour), true))
+	require.NoError(t, err)
+
+	// Without reject_past, a past booking is still accepted
+	_, err = service.CreateEntryChecked(context.Background(), request(now.Add(-4*time.Hour), false))
+	require.NoError(t, err)
+}
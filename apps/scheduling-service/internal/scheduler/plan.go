@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// maxPlanEntries caps how many bookings one schedule plan may propose
+const maxPlanEntries = 500
+
+// DiffEventPlan compares a proposed set of bookings for an event with its
+// live entries and returns what to create, update, and delete to get there.
+// A proposed booking with a schedule_id replaces that entry. The rest are
+// matched, in order, to the earliest unmatched entry with the same resource
+// and task. Unmatched proposals are created and unmatched entries deleted.
+// Rejected and cancelled entries are not part of the current state.
+func (s *ScheduleService) DiffEventPlan(ctx context.Context, req domain.SchedulePlanRequest) (*domain.SchedulePlanDiff, error) {
+	if len(req.Entries) > maxPlanEntries {
+		return nil, domain.NewValidationError(fmt.Sprintf("entries must not contain more than %d bookings", maxPlanEntries))
+	}
+	proposed := make([]domain.PlanEntry, len(req.Entries))
+	for i, e := range req.Entries {
+		if err := validatePlanEntry(i, &e); err != nil {
+			return nil, err
+		}
+		proposed[i] = e
+	}
+
+	exists, err := s.queries.EventExists(ctx, req.EventID)
+	if err != nil {
+		return nil, dbError("failed to get event", err)
+	}
+	if !exists {
+		return nil, domain.NewNotFoundError("event not found")
+	}
+
+	rows, err := s.queries.ListEventLiveEntries(ctx, req.EventID)
+	if err != nil {
+		return nil, dbError("failed to get event schedule", err)
+	}
+	current := make([]domain.PlanEntry, 0, len(rows))
+	index := make(map[int32]int, len(rows))
+	for i, row := range rows {
+		entry := domain.PlanEntry{
+			ScheduleID: &row.ID,
+			ResourceID: row.ResourceID,
+			StartTime:  row.StartTime,
+			EndTime:    row.EndTime,
+			Quantity:   row.Quantity,
+		}
+		if row.TaskID.Valid {
+			entry.TaskID = &row.TaskID.Int32
+		}
+		if row.Notes.Valid {
+			entry.Notes = &row.Notes.String
+		}
+		index[row.ID] = i
+		current = append(current, entry)
+	}
+
+	// matches[i] is the current entry proposal i replaces, or -1
+	matches := make([]int, len(proposed))
+	used := make([]bool, len(current))
+	for i, p := range proposed {
+		matches[i] = -1
+		if p.ScheduleID == nil {
+			continue
+		}
+		j, ok := index[*p.ScheduleID]
+		if !ok {
+			return nil, domain.NewValidationError(fmt.Sprintf("entries[%d].schedule_id %d is not a live entry of the event", i, *p.ScheduleID))
+		}
+		if used[j] {
+			return nil, domain.NewValidationError(fmt.Sprintf("entries[%d].schedule_id %d is proposed more than once", i, *p.ScheduleID))
+		}
+		matches[i], used[j] = j, true
+	}
+	for i, p := range proposed {
+		if p.ScheduleID != nil {
+			continue
+		}
+		for j, c := range current {
+			if !used[j] && c.ResourceID == p.ResourceID && equalInt32Ptr(c.TaskID, p.TaskID) {
+				matches[i], used[j] = j, true
+				break
+			}
+		}
+	}
+
+	diff := &domain.SchedulePlanDiff{
+		EventID:      req.EventID,
+		Create:       []domain.PlanEntry{},
+		Update:       []domain.PlanUpdate{},
+		Delete:       []domain.PlanEntry{},
+		UnchangedIDs: []int32{},
+	}
+	for i, p := range proposed {
+		if matches[i] < 0 {
+			diff.Create = append(diff.Create, p)
+			continue
+		}
+		c := current[matches[i]]
+		p.ScheduleID = c.ScheduleID
+		changed := changedPlanFields(c, p)
+		if len(changed) == 0 {
+			diff.UnchangedIDs = append(diff.UnchangedIDs, *c.ScheduleID)
+			continue
+		}
+		diff.Update = append(diff.Update, domain.PlanUpdate{
+			ScheduleID:    *c.ScheduleID,
+			Current:       c,
+			Proposed:      p,
+			ChangedFields: changed,
+		})
+	}
+	for j, c := range current {
+		if !used[j] {
+			diff.Delete = append(diff.Delete, c)
+		}
+	}
+	return diff, nil
+}
+
+// validatePlanEntry checks one proposed booking and defaults its quantity to one
+func validatePlanEntry(i int, e *domain.PlanEntry) error {
+	if e.ResourceID <= 0 {
+		return domain.NewValidationError(fmt.Sprintf("entries[%d].resource_id is required", i))
+	}
+	if e.StartTime.IsZero() || e.EndTime.IsZero() {
+		return domain.NewValidationError(fmt.Sprintf("entries[%d] must have start_time and end_time", i))
+	}
+	if !e.EndTime.After(e.StartTime) {
+		return domain.NewValidationError(fmt.Sprintf("entries[%d].end_time must be after start_time", i))
+	}
+	if e.Quantity < 0 {
+		return domain.NewValidationError(fmt.Sprintf("entries[%d].quantity must be positive", i))
+	}
+	if e.Quantity == 0 {
+		e.Quantity = 1
+	}
+	return nil
+}
+
+// changedPlanFields names the fields in which a proposed booking differs from
+// the entry it replaces
+func changedPlanFields(current, proposed domain.PlanEntry) []string {
+	var changed []string
+	if current.ResourceID != proposed.ResourceID {
+		changed = append(changed, "resource_id")
+	}
+	if !equalInt32Ptr(current.TaskID, proposed.TaskID) {
+		changed = append(changed, "task_id")
+	}
+	if !current.StartTime.Equal(proposed.StartTime) {
+		changed = append(changed, "start_time")
+	}
+	if !current.EndTime.Equal(proposed.EndTime) {
+		changed = append(changed, "end_time")
+	}
+	if (current.Notes == nil) != (proposed.Notes == nil) || (current.Notes != nil && *current.Notes != *proposed.Notes) {
+		changed = append(changed, "notes")
+	}
+	if current.Quantity != proposed.Quantity {
+		changed = append(changed, "quantity")
+	}
+	return changed
+}
+
+// equalInt32Ptr reports whether two optional IDs are both unset or equal
+func equalInt32Ptr(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestChangedPlanFields(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	task := int32(7)
+	notes := "Bring the long tables"
+	current := domain.PlanEntry{
+		ResourceID: 1,
+		TaskID:     &task,
+		StartTime:  day.Add(9 * time.Hour),
+		EndTime:    day.Add(11 * time.Hour),
+		Notes:      &notes,
+		Quantity:   1,
+	}
+
+	same := current
+	// The same instant in another zone is not a change
+	same.StartTime = current.StartTime.In(time.FixedZone("UTC+2", 2*3600))
+	sameNotes := notes
+	same.Notes = &sameNotes
+	assert.Empty(t, changedPlanFields(current, same))
+
+	moved := current
+	moved.ResourceID = 2
+	moved.TaskID = nil
+	moved.EndTime = day.Add(12 * time.Hour)
+	moved.Notes = nil
+	moved.Quantity = 3
+	assert.Equal(t, []string{"resource_id", "task_id", "end_time", "notes", "quantity"}, changedPlanFields(current, moved))
+}
+
+func TestDiffEventPlan_InvalidEntries(t *testing.T) {
+	service := &ScheduleService{}
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		entry   domain.PlanEntry
+		message string
+	}{
+		{"missing resource", domain.PlanEntry{StartTime: day, EndTime: day.Add(time.Hour)}, "entries[0].resource_id is required"},
+		{"missing times", domain.PlanEntry{ResourceID: 1}, "entries[0] must have start_time and end_time"},
+		{"inverted range", domain.PlanEntry{ResourceID: 1, StartTime: day.Add(time.Hour), EndTime: day}, "entries[0].end_time must be after start_time"},
+		{"negative quantity", domain.PlanEntry{ResourceID: 1, StartTime: day, EndTime: day.Add(time.Hour), Quantity: -1}, "entries[0].quantity must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.DiffEventPlan(context.Background(), domain.SchedulePlanRequest{
+				EventID: 1,
+				Entries: []domain.PlanEntry{tt.entry},
+			})
+
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Equal(t, tt.message, domainErr.Message)
+		})
+	}
+}
+
+func TestDiffEventPlan_MixedProposal(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	taskID := testutil.CreateTask(t, testDB.DB, eventID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, nil)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	server := testutil.CreateResource(t, testDB.DB, nil)
+	van := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	chefEntry := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(9*time.Hour), day.Add(11*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &taskID})
+	ovenEntry := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	serverEntry := testutil.CreateScheduleEntry(t, testDB.DB, server, eventID,
+		day.Add(13*time.Hour), day.Add(14*time.Hour), nil)
+	// Cancelled entries are not part of the current state
+	cancelledAt := day
+	testutil.CreateScheduleEntry(t, testDB.DB, van, eventID,
+		day.Add(8*time.Hour), day.Add(9*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	diff, err := service.DiffEventPlan(context.Background(), domain.SchedulePlanRequest{
+		EventID: eventID,
+		Entries: []domain.PlanEntry{
+			// Matched by resource and task, unchanged
+			{ResourceID: chef, TaskID: &taskID, StartTime: day.Add(9 * time.Hour), EndTime: day.Add(11 * time.Hour)},
+			// Matched by ID, starting later
+			{ScheduleID: &ovenEntry, ResourceID: oven, StartTime: day.Add(10 * time.Hour), EndTime: day.Add(12 * time.Hour)},
+			// New
+			{ResourceID: van, StartTime: day.Add(8 * time.Hour), EndTime: day.Add(9 * time.Hour)},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, eventID, diff.EventID)
+	assert.Equal(t, []int32{chefEntry}, diff.UnchangedIDs)
+
+	require.Len(t, diff.Update, 1)
+	assert.Equal(t, ovenEntry, diff.Update[0].ScheduleID)
+	assert.Equal(t, []string{"start_time"}, diff.Update[0].ChangedFields)
+	assert.True(t, day.Add(9*time.Hour).Equal(diff.Update[0].Current.StartTime))
+	assert.True(t, day.Add(10*time.Hour).Equal(diff.Update[0].Proposed.StartTime))
+
+	require.Len(t, diff.Create, 1)
+	assert.Equal(t, van, diff.Create[0].ResourceID)
+	assert.Equal(t, int32(1), diff.Create[0].Quantity)
+
+	require.Len(t, diff.Delete, 1)
+	require.NotNil(t, diff.Delete[0].ScheduleID)
+	assert.Equal(t, serverEntry, *diff.Delete[0].ScheduleID)
+}
+
+func TestDiffEventPlan_UnknownScheduleIDOrEvent(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	otherEntry := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, otherEvent,
+		day.Add(9*time.Hour), day.Add(10*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	entries := []domain.PlanEntry{
+		{ScheduleID: &otherEntry, ResourceID: resourceID, StartTime: day.Add(9 * time.Hour), EndTime: day.Add(10 * time.Hour)},
+	}
+
+	_, err := service.DiffEventPlan(context.Background(), domain.SchedulePlanRequest{EventID: eventID, Entries: entries})
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+
+	_, err = service.DiffEventPlan(context.Background(), domain.SchedulePlanRequest{EventID: 99999, Entries: entries})
+	domainErr, ok = err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
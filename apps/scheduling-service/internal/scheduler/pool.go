@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxPoolResources caps how many resources a pool-wide busy check considers
+const maxPoolResources = 500
+
+// GetTypeBusyWindows returns the windows within the range during which every
+// available resource of the type is booked, by intersecting each resource's
+// busy time. Bookings are read as CheckConflicts reads them, including release
+// grace. A single free resource anywhere breaks a window.
+func (s *AvailabilityService) GetTypeBusyWindows(ctx context.Context, req domain.TypeBusyWindowsRequest) (*domain.TypeBusyWindowsResponse, error) {
+	if !req.Type.IsValid() {
+		return nil, domain.NewValidationError("type must be one of staff, equipment, materials")
+	}
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > slotSearchHorizon {
+		return nil, domain.NewValidationError("range must not exceed 30 days")
+	}
+
+	// Ask for one more than the cap so a pool over it is refused rather than
+	// silently checked against a subset
+	rows, err := s.queries.ListResources(ctx, repository.ListResourcesParams{
+		Type:        repository.NullResourceType{ResourceType: repository.ResourceType(req.Type), Valid: true},
+		IsAvailable: sql.NullBool{Bool: true, Valid: true},
+		LimitCount:  maxPoolResources + 1,
+	})
+	if err != nil {
+		return nil, dbError("failed to list resources", err)
+	}
+	if len(rows) > maxPoolResources {
+		return nil, domain.NewValidationError("too many resources of this type to check at once")
+	}
+
+	resp := &domain.TypeBusyWindowsResponse{
+		Type:          req.Type,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+		ResourceCount: len(rows),
+		Windows:       []domain.TimeRange{},
+	}
+	if len(rows) == 0 {
+		return resp, nil
+	}
+
+	ids := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	busy, err := s.loadBusy(ctx, ids, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []domain.TimeRange{{Start: req.StartDate, End: req.EndDate}}
+	for _, id := range ids {
+		windows = intersectBusy(windows, busy[id])
+		if len(windows) == 0 {
+			break
+		}
+	}
+	resp.Windows = append(resp.Windows, windows...)
+	return resp, nil
+}
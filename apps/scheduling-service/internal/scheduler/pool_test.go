@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestGetTypeBusyWindows_PoolMomentarilyFull(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	staff := func() int32 {
+		return testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+			Type:        testutil.ResourceTypeStaff,
+			IsAvailable: true,
+		})
+	}
+	alice, bob, carol := staff(), staff(), staff()
+	// Unavailable staff and other types are not part of the pool
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Type: testutil.ResourceTypeStaff})
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Type: testutil.ResourceTypeEquipment, IsAvailable: true})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	book := func(resourceID int32, startHour, endHour int) {
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+			day.Add(time.Duration(startHour)*time.Hour), day.Add(time.Duration(endHour)*time.Hour), nil)
+	}
+	book(alice, 8, 14)
+	book(bob, 10, 12)
+	book(bob, 12, 16) // back-to-back with the previous booking
+	book(carol, 11, 13)
+	book(carol, 15, 18)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetTypeBusyWindows(context.Background(), domain.TypeBusyWindowsRequest{
+		Type:      domain.ResourceTypeStaff,
+		StartDate: day,
+		EndDate:   day.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.ResourceCount)
+	// All three are booked only 11:00-13:00; alice is free by 15:00
+	require.Len(t, result.Windows, 1)
+	assert.True(t, day.Add(11*time.Hour).Equal(result.Windows[0].Start))
+	assert.True(t, day.Add(13*time.Hour).Equal(result.Windows[0].End))
+}
+
+func TestGetTypeBusyWindows_PoolWithSlack(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	busy := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	// Never booked, so the pool always has a free member
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, busy, eventID,
+		day.Add(8*time.Hour), day.Add(20*time.Hour), nil)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	result, err := service.GetTypeBusyWindows(context.Background(), domain.TypeBusyWindowsRequest{
+		Type:      domain.ResourceTypeStaff,
+		StartDate: day,
+		EndDate:   day.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ResourceCount)
+	assert.NotNil(t, result.Windows)
+	assert.Empty(t, result.Windows)
+}
+
+func TestGetTypeBusyWindows_InvalidType(t *testing.T) {
+	service := &AvailabilityService{}
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetTypeBusyWindows(context.Background(), domain.TypeBusyWindowsRequest{
+		Type:      "vehicles",
+		StartDate: day,
+		EndDate:   day.Add(24 * time.Hour),
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+}
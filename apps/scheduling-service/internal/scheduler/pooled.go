@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// pooledConflicts checks the requested resources that pool interchangeable
+// units. Such a resource only conflicts where its bookings leave fewer free
+// units than the check needs, so a fleet of three vans takes three
+// overlapping bookings before a fourth conflicts. It returns one capacity
+// conflict per resource that runs short, and the IDs of every pooled resource
+// so the booking check can skip them. Pending bookings make a shortage soft
+// unless the pending_bookings_block flag is on, as for booking conflicts.
+func (s *ConflictService) pooledConflicts(ctx context.Context, q *repository.Queries, req domain.CheckConflictsRequest) ([]domain.Conflict, map[int32]bool, error) {
+	resources, err := q.ListPooledResources(ctx, req.ResourceIDs)
+	if err != nil {
+		return nil, nil, dbError("failed to list pooled resources", err)
+	}
+	if len(resources) == 0 {
+		return nil, nil, nil
+	}
+
+	pooled := make(map[int32]bool, len(resources))
+	ids := make([]int32, 0, len(resources))
+	for _, r := range resources {
+		pooled[r.ID] = true
+		ids = append(ids, r.ID)
+	}
+
+	rows, err := q.ListPooledUnitUsage(ctx, repository.ListPooledUnitUsageParams{
+		ResourceIds:       ids,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		ExcludeScheduleID: nullInt32(req.ExcludeScheduleID),
+	})
+	if err != nil {
+		return nil, nil, dbError("failed to get resource usage", err)
+	}
+
+	pendingBlocks := len(rows) > 0 && s.flags.Enabled(ctx, FlagPendingBookingsBlock)
+	confirmed := make(map[int32][]unitUsage, len(resources))
+	all := make(map[int32][]unitUsage, len(resources))
+	for _, row := range rows {
+		u := unitUsage{
+			occupied: domain.TimeRange{Start: row.StartTime, End: row.OccupiedUntil},
+			units:    row.Quantity,
+		}
+		all[row.ResourceID] = append(all[row.ResourceID], u)
+		if row.ApprovalStatus != repository.ApprovalStatusPending || pendingBlocks {
+			confirmed[row.ResourceID] = append(confirmed[row.ResourceID], u)
+		}
+	}
+
+	need := req.Quantity
+	if need == 0 {
+		need = 1
+	}
+	var conflicts []domain.Conflict
+	for _, r := range resources {
+		if c, ok := capacityConflict(r, confirmed[r.ID], need, req); ok {
+			conflicts = append(conflicts, c)
+			continue
+		}
+		if c, ok := capacityConflict(r, all[r.ID], need, req); ok {
+			c.Severity = domain.ConflictSeveritySoft
+			c.Message += " (pending approval)"
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts, pooled, nil
+}
+
+// capacityConflict returns a hard conflict when usage leaves fewer than need
+// units of the resource free at some moment of the requested range. The
+// conflict spans the first to the last such moment, and its overlap is the
+// total time the resource runs short.
+func capacityConflict(r repository.ListPooledResourcesRow, usage []unitUsage, need int32, req domain.CheckConflictsRequest) (domain.Conflict, bool) {
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+
+	var short []domain.TimeRange
+	if need > r.Quantity {
+		short = []domain.TimeRange{requested}
+	} else {
+		for _, sat := range saturatedRanges(usage, r.Quantity-need) {
+			start, end := sat.Start, sat.End
+			if start.Before(requested.Start) {
+				start = requested.Start
+			}
+			if end.After(requested.End) {
+				end = requested.End
+			}
+			if end.After(start) {
+				short = append(short, domain.TimeRange{Start: start, End: end})
+			}
+		}
+	}
+	if len(short) == 0 {
+		return domain.Conflict{}, false
+	}
+
+	var total float64
+	for _, w := range short {
+		total += w.End.Sub(w.Start).Minutes()
+	}
+	if total < float64(req.MinOverlapMinutes) {
+		return domain.Conflict{}, false
+	}
+
+	message := fmt.Sprintf("Resource '%s' has %d of %d already booked", r.Name, peakUnits(usage, requested), r.Quantity)
+	if need > r.Quantity {
+		message = fmt.Sprintf("Resource '%s' has only %d units", r.Name, r.Quantity)
+	}
+	return domain.Conflict{
+		Kind:               domain.ConflictKindCapacity,
+		Severity:           domain.ConflictSeverityHard,
+		ResourceID:         r.ID,
+		ResourceName:       r.Name,
+		ExistingStartTime:  short[0].Start,
+		ExistingEndTime:    short[len(short)-1].End,
+		RequestedStartTime: req.StartTime,
+		RequestedEndTime:   req.EndTime,
+		OverlapStart:       short[0].Start,
+		OverlapEnd:         short[len(short)-1].End,
+		OverlapMinutes:     total,
+		Message:            message,
+	}, true
+}
+
+// withoutPooled returns the resource IDs that aren't pooled
+func withoutPooled(ids []int32, pooled map[int32]bool) []int32 {
+	kept := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !pooled[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestCapacityConflict(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	vans := repository.ListPooledResourcesRow{ID: 7, Name: "Vans", Quantity: 3}
+	req := domain.CheckConflictsRequest{StartTime: at(9), EndTime: at(17)}
+	booking := func(from, to int, units int32) unitUsage {
+		return unitUsage{occupied: domain.TimeRange{Start: at(from), End: at(to)}, units: units}
+	}
+
+	t.Run("free units left", func(t *testing.T) {
+		usage := []unitUsage{booking(8, 12, 1), booking(10, 14, 1)}
+		_, ok := capacityConflict(vans, usage, 1, req)
+		assert.False(t, ok)
+	})
+
+	t.Run("pool full for part of the range", func(t *testing.T) {
+		usage := []unitUsage{booking(8, 12, 1), booking(10, 14, 1), booking(11, 18, 1)}
+		c, ok := capacityConflict(vans, usage, 1, req)
+		require.True(t, ok)
+		assert.Equal(t, domain.ConflictKindCapacity, c.Kind)
+		assert.Equal(t, domain.ConflictSeverityHard, c.Severity)
+		assert.Equal(t, at(11), c.ExistingStartTime)
+		assert.Equal(t, at(12), c.ExistingEndTime)
+		assert.Equal(t, 60.0, c.OverlapMinutes)
+		assert.Equal(t, "Resource 'Vans' has 3 of 3 already booked", c.Message)
+	})
+
+	t.Run("needing more units than are free", func(t *testing.T) {
+		usage := []unitUsage{booking(8, 12, 1), booking(10, 14, 1)}
+		c, ok := capacityConflict(vans, usage, 2, req)
+		require.True(t, ok)
+		assert.Equal(t, at(10), c.ExistingStartTime)
+		assert.Equal(t, at(12), c.ExistingEndTime)
+		assert.Equal(t, "Resource 'Vans' has 2 of 3 already booked", c.Message)
+	})
+
+	t.Run("needing more units than the pool owns", func(t *testing.T) {
+		c, ok := capacityConflict(vans, nil, 4, req)
+		require.True(t, ok)
+		assert.Equal(t, req.EndTime.Sub(req.StartTime).Minutes(), c.OverlapMinutes)
+		assert.Equal(t, "Resource 'Vans' has only 3 units", c.Message)
+	})
+
+	t.Run("shortage below the minimum overlap", func(t *testing.T) {
+		usage := []unitUsage{booking(8, 12, 1), booking(10, 14, 1), booking(11, 18, 1)}
+		short := req
+		short.MinOverlapMinutes = 90
+		_, ok := capacityConflict(vans, usage, 1, short)
+		assert.False(t, ok)
+	})
+}
+
+func TestCheckConflicts_PooledResource(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	vans := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Vans",
+		Type:     testutil.ResourceTypeEquipment,
+		Quantity: 3,
+	})
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	start, end := baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour)
+	check := func(quantity int32) *domain.CheckConflictsResponse {
+		t.Helper()
+		result, err := service.CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+			ResourceIDs: []int32{vans},
+			StartTime:   start,
+			EndTime:     end,
+			Quantity:    quantity,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	// Each booking up to the pool size leaves a van free
+	for booked := 1; booked <= 2; booked++ {
+		testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, nil)
+		assert.False(t, check(0).HasConflicts, "%d booked", booked)
+	}
+
+	// A check needing two vans no longer fits
+	result := check(2)
+	require.Len(t, result.Conflicts, 1)
+	assert.True(t, result.HasHardConflicts)
+	assert.Equal(t, domain.ConflictKindCapacity, result.Conflicts[0].Kind)
+	assert.Contains(t, result.Conflicts[0].Message, "2 of 3 already booked")
+
+	// The third booking fills the pool
+	testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, nil)
+	result = check(0)
+	require.Len(t, result.Conflicts, 1)
+	assert.True(t, result.HasHardConflicts)
+	assert.Equal(t, vans, result.Conflicts[0].ResourceID)
+	assert.Contains(t, result.Conflicts[0].Message, "3 of 3 already booked")
+	assert.Equal(t, 180.0, result.Conflicts[0].OverlapMinutes)
+
+	// Beyond the pool size it stays a single conflict
+	testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, nil)
+	result = check(0)
+	require.Len(t, result.Conflicts, 1)
+	assert.Contains(t, result.Conflicts[0].Message, "4 of 3 already booked")
+}
+
+func TestCheckConflicts_PooledPendingIsSoft(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	vans := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Vans",
+		Type:     testutil.ResourceTypeEquipment,
+		Quantity: 2,
+	})
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	start, end := baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, &testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+
+	result, err := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil).CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{vans},
+		StartTime:   start,
+		EndTime:     end,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, domain.ConflictSeveritySoft, result.Conflicts[0].Severity)
+	assert.True(t, result.HasConflicts)
+	assert.False(t, result.HasHardConflicts)
+}
+
+func TestCheckConflicts_StaffIgnoresPooling(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name: "Chef",
+		Type: testutil.ResourceTypeStaff,
+	})
+	vans := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:     "Vans",
+		Type:     testutil.ResourceTypeEquipment,
+		Quantity: 3,
+	})
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	start, end := baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, start, end, nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, vans, eventID, start, end, nil)
+
+	result, err := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil).CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{chef, vans},
+		StartTime:   start,
+		EndTime:     end,
+	})
+
+	// The chef's one booking conflicts; the vans still have two free
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 1)
+	assert.Equal(t, chef, result.Conflicts[0].ResourceID)
+	assert.Equal(t, domain.ConflictKindBooking, result.Conflicts[0].Kind)
+}
@@ -0,0 +1,270 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxRecurrenceOccurrences caps how many entries one recurring booking creates
+const maxRecurrenceOccurrences = 200
+
+// CreateRecurring books an entry and its repeats under one recurrence group,
+// one resource_schedule row per occurrence. Each occurrence is checked as
+// CreateEntryChecked would check it, including against the occurrences booked
+// before it; occurrences refused with a conflict or falling on a blackout day
+// are reported as failed and the rest are still booked. Any other error books
+// nothing. The checks and inserts run in one transaction holding a lock on the
+// resource. Each booked occurrence is published as a schedule change.
+func (s *ScheduleService) CreateRecurring(ctx context.Context, req domain.RecurringEntryRequest) (*domain.RecurringEntryResponse, error) {
+	resource, err := s.validateEntryRequest(ctx, &req.Entry)
+	if err != nil {
+		return nil, err
+	}
+	domainResource := toDomainResource(resource)
+	loc, err := resourceZone(&domainResource)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := expandRecurrence(req.Entry.StartTime, req.Entry.EndTime, req.Rule, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	if _, err := q.LockResource(ctx, req.Entry.ResourceID); err != nil {
+		return nil, dbError("failed to lock resource", err)
+	}
+
+	groupID, err := q.NextRecurrenceGroupID(ctx)
+	if err != nil {
+		return nil, dbError("failed to allocate recurrence group", err)
+	}
+
+	result := &domain.RecurringEntryResponse{
+		RecurrenceGroupID: groupID,
+		Created:           []domain.ScheduleEntry{},
+		Failed:            []domain.RecurrenceFailure{},
+	}
+	for _, occurrence := range occurrences {
+		occReq := req.Entry
+		occReq.StartTime = occurrence.Start
+		occReq.EndTime = occurrence.End
+
+		blackedOut, err := blackoutRanges(ctx, q, occurrence)
+		if err != nil {
+			return nil, err
+		}
+		if len(blackedOut) > 0 {
+			result.Failed = append(result.Failed, domain.RecurrenceFailure{
+				StartTime: occurrence.Start,
+				EndTime:   occurrence.End,
+				Error:     *batchItemError(blackedOutError()),
+			})
+			continue
+		}
+
+		remaining, err := s.checkEntryAvailable(ctx, q, occReq, resource, nil)
+		if err != nil {
+			if !errors.Is(err, &domain.DomainError{Code: domain.ErrCodeConflict}) {
+				return nil, err
+			}
+			result.Failed = append(result.Failed, domain.RecurrenceFailure{
+				StartTime: occurrence.Start,
+				EndTime:   occurrence.End,
+				Error:     *batchItemError(err),
+			})
+			continue
+		}
+
+		id, err := q.CreateRecurringScheduleEntry(ctx, repository.CreateRecurringScheduleEntryParams{
+			ResourceID:        occReq.ResourceID,
+			EventID:           occReq.EventID,
+			TaskID:            nullInt32(occReq.TaskID),
+			StartTime:         occReq.StartTime,
+			EndTime:           occReq.EndTime,
+			Notes:             nullString(occReq.Notes),
+			Quantity:          occReq.Quantity,
+			RecurrenceGroupID: nullInt32(&groupID),
+			CreatedBy:         nullInt32(occReq.CreatedBy),
+		})
+		if err != nil {
+			return nil, entryWriteError("failed to create schedule entry", err)
+		}
+
+		row, err := q.GetScheduleEntryByID(ctx, id)
+		if err != nil {
+			return nil, dbError("failed to get schedule entry", err)
+		}
+		entry := toDomainScheduleEntry(row)
+		entry.RemainingQuantity = remaining
+		result.Created = append(result.Created, entry)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit schedule entries", err)
+	}
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeCreated, result.Created)
+	return result, nil
+}
+
+// CancelRecurrenceGroup cancels every entry of a recurring booking that isn't
+// cancelled yet, with an optional reason, as CancelEntry does for one entry,
+// publishing each cancellation as a schedule change
+func (s *ScheduleService) CancelRecurrenceGroup(ctx context.Context, groupID int32, req domain.CancelEntryRequest) (*domain.RecurrenceCancelResponse, error) {
+	reason, err := cancellationReason(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.queries.CancelRecurrenceGroup(ctx, repository.CancelRecurrenceGroupParams{
+		CancellationReason: reason,
+		RecurrenceGroupID:  nullInt32(&groupID),
+	})
+	if err != nil {
+		return nil, dbError("failed to cancel recurring booking", err)
+	}
+	if len(ids) == 0 {
+		exists, err := s.queries.RecurrenceGroupExists(ctx, nullInt32(&groupID))
+		if err != nil {
+			return nil, dbError("failed to get recurring booking", err)
+		}
+		if !exists {
+			return nil, domain.NewNotFoundError("recurrence group not found")
+		}
+		return nil, domain.NewConflictError("recurring booking is already cancelled")
+	}
+
+	cancelled, err := loadEntries(ctx, s.queries, ids)
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeUpdated, cancelled)
+	return &domain.RecurrenceCancelResponse{RecurrenceGroupID: groupID, CancelledIDs: ids}, nil
+}
+
+// DeleteRecurrenceGroup removes the entries of a recurring booking for good
+// and returns how many were deleted. With from set, only occurrences starting
+// at or after it are deleted, splitting the series so the earlier ones stay.
+// A group with no entries at all is not found; a from past its last
+// occurrence deletes nothing. Each deleted entry is published as a schedule
+// change.
+func (s *ScheduleService) DeleteRecurrenceGroup(ctx context.Context, groupID int32, from *time.Time) (*domain.RecurrenceDeleteResponse, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	params := repository.DeleteRecurrenceGroupParams{RecurrenceGroupID: nullInt32(&groupID)}
+	if from != nil {
+		params.From = sql.NullTime{Time: *from, Valid: true}
+	}
+	ids, err := q.LockRecurrenceGroup(ctx, repository.LockRecurrenceGroupParams(params))
+	if err != nil {
+		return nil, dbError("failed to lock recurring booking", err)
+	}
+	entries, err := loadEntries(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := q.DeleteRecurrenceGroup(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to delete recurring booking", err)
+	}
+	if deleted == 0 {
+		exists, err := q.RecurrenceGroupExists(ctx, nullInt32(&groupID))
+		if err != nil {
+			return nil, dbError("failed to get recurring booking", err)
+		}
+		if !exists {
+			return nil, domain.NewNotFoundError("recurrence group not found")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit recurring booking deletion", err)
+	}
+	publishScheduleChanges(ctx, s.publisher, domain.ScheduleChangeDeleted, entries)
+	return &domain.RecurrenceDeleteResponse{RecurrenceGroupID: groupID, DeletedCount: deleted}, nil
+}
+
+// expandRecurrence lists the occurrences of a booking from start to end
+// repeated by rule. Occurrences fall on calendar days in loc and keep the
+// first booking's wall-clock start and end there, so a booking at 09:00
+// stays at 09:00 across a daylight saving change. Weeks run Sunday to
+// Saturday, counted from the week of the first booking.
+func expandRecurrence(start, end time.Time, rule domain.RecurrenceRule, loc *time.Location) ([]domain.TimeRange, error) {
+	if rule.Frequency != domain.FrequencyWeekly {
+		return nil, domain.NewValidationError(fmt.Sprintf("frequency must be %q", domain.FrequencyWeekly))
+	}
+	interval := rule.Interval
+	if interval < 0 {
+		return nil, domain.NewValidationError("interval must be positive")
+	}
+	if interval == 0 {
+		interval = 1
+	}
+	if rule.Until.IsZero() {
+		return nil, domain.NewValidationError("until is required")
+	}
+	if rule.Until.Before(start) {
+		return nil, domain.NewValidationError("until must not be before start_time")
+	}
+
+	first := start.In(loc)
+	last := end.In(loc)
+
+	var weekdays [7]bool
+	if len(rule.ByWeekday) == 0 {
+		weekdays[first.Weekday()] = true
+	}
+	for _, wd := range rule.ByWeekday {
+		if wd < time.Sunday || wd > time.Saturday {
+			return nil, domain.NewValidationError("by_weekday must hold weekdays from 0 (Sunday) to 6 (Saturday)")
+		}
+		weekdays[wd] = true
+	}
+
+	// Calendar days from the start's date to the end's, for overnight bookings
+	spanDays := int(time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+
+	y, m, d := first.Date()
+	sunday := d - int(first.Weekday())
+
+	var occurrences []domain.TimeRange
+	for week := 0; ; week += interval {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if !weekdays[wd] {
+				continue
+			}
+			day := sunday + week*7 + int(wd)
+			occStart := time.Date(y, m, day, first.Hour(), first.Minute(), first.Second(), first.Nanosecond(), loc)
+			if occStart.Before(start) {
+				continue
+			}
+			if occStart.After(rule.Until) {
+				return occurrences, nil
+			}
+			if len(occurrences) == maxRecurrenceOccurrences {
+				return nil, domain.NewValidationError(fmt.Sprintf("recurrence must not produce more than %d occurrences", maxRecurrenceOccurrences))
+			}
+			occEnd := time.Date(y, m, day+spanDays, last.Hour(), last.Minute(), last.Second(), last.Nanosecond(), loc)
+			occurrences = append(occurrences, domain.TimeRange{Start: occStart, End: occEnd})
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestExpandRecurrence_WeeklyAcrossMonth(t *testing.T) {
+	// Saturday 7 June 2025, 09:00-13:00
+	start := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	rule := domain.RecurrenceRule{
+		Frequency: domain.FrequencyWeekly,
+		Until:     time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	occurrences, err := expandRecurrence(start, start.Add(4*time.Hour), rule, time.UTC)
+
+	require.NoError(t, err)
+	require.Len(t, occurrences, 4)
+	for i, day := range []int{7, 14, 21, 28} {
+		assert.Equal(t, time.Date(2025, 6, day, 9, 0, 0, 0, time.UTC), occurrences[i].Start)
+		assert.Equal(t, time.Date(2025, 6, day, 13, 0, 0, 0, time.UTC), occurrences[i].End)
+	}
+}
+
+func TestExpandRecurrence_IntervalAndWeekdays(t *testing.T) {
+	// Wednesday 4 June 2025; Monday of the first week is already past
+	start := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+	rule := domain.RecurrenceRule{
+		Frequency: domain.FrequencyWeekly,
+		Interval:  2,
+		Until:     time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC),
+		ByWeekday: []time.Weekday{time.Saturday, time.Monday},
+	}
+
+	occurrences, err := expandRecurrence(start, start.Add(time.Hour), rule, time.UTC)
+
+	require.NoError(t, err)
+	var days []int
+	for _, o := range occurrences {
+		days = append(days, o.Start.Day())
+	}
+	// Weeks of 1, 15, and 29 June
+	assert.Equal(t, []int{7, 16, 21, 30}, days)
+}
+
+func TestExpandRecurrence_KeepsWallClockAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("fall back", func(t *testing.T) {
+		// Saturdays 09:00-13:00 in New York; clocks go back on 2 November 2025
+		start := time.Date(2025, 10, 25, 9, 0, 0, 0, ny)
+		rule := domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 11, 15, 9, 0, 0, 0, ny),
+		}
+
+		occurrences, err := expandRecurrence(start, start.Add(4*time.Hour), rule, ny)
+
+		require.NoError(t, err)
+		require.Len(t, occurrences, 4)
+		wantUTCHours := []int{13, 13, 14, 14}
+		for i, o := range occurrences {
+			assert.Equal(t, 9, o.Start.In(ny).Hour())
+			assert.Equal(t, 13, o.End.In(ny).Hour())
+			assert.Equal(t, wantUTCHours[i], o.Start.UTC().Hour())
+			assert.Equal(t, 4*time.Hour, o.End.Sub(o.Start))
+		}
+	})
+
+	t.Run("spring forward", func(t *testing.T) {
+		// Clocks go forward on 9 March 2025
+		start := time.Date(2025, 3, 1, 9, 0, 0, 0, ny)
+		rule := domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 3, 16, 0, 0, 0, 0, ny),
+		}
+
+		occurrences, err := expandRecurrence(start, start.Add(4*time.Hour), rule, ny)
+
+		require.NoError(t, err)
+		require.Len(t, occurrences, 3)
+		assert.Equal(t, 14, occurrences[1].Start.UTC().Hour())
+		assert.Equal(t, 13, occurrences[2].Start.UTC().Hour())
+		assert.Equal(t, 9, occurrences[2].Start.In(ny).Hour())
+	})
+
+	t.Run("overnight booking spanning the change", func(t *testing.T) {
+		// 22:00 Saturday to 02:00 Sunday gains an hour the night clocks go back
+		start := time.Date(2025, 10, 25, 22, 0, 0, 0, ny)
+		end := time.Date(2025, 10, 26, 2, 0, 0, 0, ny)
+		rule := domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 11, 1, 23, 0, 0, 0, ny),
+		}
+
+		occurrences, err := expandRecurrence(start, end, rule, ny)
+
+		require.NoError(t, err)
+		require.Len(t, occurrences, 2)
+		assert.Equal(t, 4*time.Hour, occurrences[0].End.Sub(occurrences[0].Start))
+		assert.Equal(t, 5*time.Hour, occurrences[1].End.Sub(occurrences[1].Start))
+		assert.Equal(t, 2, occurrences[1].End.In(ny).Hour())
+	})
+}
+
+func TestExpandRecurrence_Invalid(t *testing.T) {
+	start := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	until := start.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		rule    domain.RecurrenceRule
+		message string
+	}{
+		{"unknown frequency", domain.RecurrenceRule{Frequency: "daily", Until: until}, "frequency must be"},
+		{"negative interval", domain.RecurrenceRule{Frequency: domain.FrequencyWeekly, Interval: -1, Until: until}, "interval must be positive"},
+		{"missing until", domain.RecurrenceRule{Frequency: domain.FrequencyWeekly}, "until is required"},
+		{"until before start", domain.RecurrenceRule{Frequency: domain.FrequencyWeekly, Until: start.Add(-time.Hour)}, "until must not be before"},
+		{"bad weekday", domain.RecurrenceRule{Frequency: domain.FrequencyWeekly, Until: until, ByWeekday: []time.Weekday{7}}, "by_weekday"},
+		{"too many occurrences", domain.RecurrenceRule{Frequency: domain.FrequencyWeekly, Until: start.AddDate(5, 0, 0)}, "more than 200 occurrences"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expandRecurrence(start, start.Add(time.Hour), tt.rule, time.UTC)
+
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
+
+func TestCreateRecurring_ReportsConflictsAndCancelsTogether(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	start := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	// The third Saturday is already booked
+	taken := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		time.Date(2025, 6, 21, 10, 0, 0, 0, time.UTC), time.Date(2025, 6, 21, 11, 0, 0, 0, time.UTC), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	result, err := service.CreateRecurring(context.Background(), domain.RecurringEntryRequest{
+		Entry: domain.ScheduleEntryRequest{
+			ResourceID: chef,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(4 * time.Hour),
+		},
+		Rule: domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 3)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, time.Date(2025, 6, 21, 9, 0, 0, 0, time.UTC).Equal(result.Failed[0].StartTime))
+	assert.Equal(t, domain.ErrCodeConflict, result.Failed[0].Error.Code)
+	for _, entry := range result.Created {
+		require.NotNil(t, entry.RecurrenceGroupID)
+		assert.Equal(t, result.RecurrenceGroupID, *entry.RecurrenceGroupID)
+	}
+
+	cancelled, err := service.CancelRecurrenceGroup(context.Background(), result.RecurrenceGroupID, domain.CancelEntryRequest{})
+
+	require.NoError(t, err)
+	assert.Len(t, cancelled.CancelledIDs, 3)
+	stored, err := service.GetEntry(context.Background(), result.Created[0].ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.CancelledAt)
+	// The entry outside the group is untouched
+	other, err := service.GetEntry(context.Background(), taken)
+	require.NoError(t, err)
+	assert.Nil(t, other.CancelledAt)
+
+	_, err = service.CancelRecurrenceGroup(context.Background(), result.RecurrenceGroupID, domain.CancelEntryRequest{})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeConflict})
+	_, err = service.CancelRecurrenceGroup(context.Background(), 99999, domain.CancelEntryRequest{})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestDeleteRecurrenceGroup_SplitsThenRemovesSeries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	start := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	series, err := service.CreateRecurring(context.Background(), domain.RecurringEntryRequest{
+		Entry: domain.ScheduleEntryRequest{
+			ResourceID: oven,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Hour),
+		},
+		Rule: domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, series.Created, 4)
+	groupID := series.RecurrenceGroupID
+
+	// Occurrences on 21 and 28 June go; 7 and 14 June stay
+	from := time.Date(2025, 6, 21, 0, 0, 0, 0, time.UTC)
+	result, err := service.DeleteRecurrenceGroup(context.Background(), groupID, &from)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.DeletedCount)
+	_, err = service.GetEntry(context.Background(), series.Created[1].ID)
+	require.NoError(t, err)
+	_, err = service.GetEntry(context.Background(), series.Created[2].ID)
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+
+	// A from past the last remaining occurrence deletes nothing
+	later := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	result, err = service.DeleteRecurrenceGroup(context.Background(), groupID, &later)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.DeletedCount)
+
+	result, err = service.DeleteRecurrenceGroup(context.Background(), groupID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.DeletedCount)
+
+	_, err = service.DeleteRecurrenceGroup(context.Background(), groupID, nil)
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// ReportService produces aggregate reports over the resource schedule
+type ReportService struct {
+	queries *repository.Queries
+}
+
+// NewReportService creates a new report service
+func NewReportService(db *sql.DB) *ReportService {
+	return &ReportService{
+		queries: repository.NewQueries(db),
+	}
+}
+
+// durationBuckets lists the histogram buckets in display order. Labels must
+// match the CASE expression in GetBookingDurationHistogram.
+var durationBuckets = []struct {
+	label      string
+	minMinutes int
+	maxMinutes int // 0 means open-ended
+}{
+	{"<1h", 0, 60},
+	{"1-4h", 60, 240},
+	{"4-8h", 240, 480},
+	{">8h", 480, 0},
+}
+
+// GetDurationHistogram counts bookings starting within the window by duration,
+// optionally limited to one resource type. Empty buckets are reported as zero.
+func (s *ReportService) GetDurationHistogram(ctx context.Context, req domain.DurationHistogramRequest) (*domain.DurationHistogramResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+
+	params := repository.GetBookingDurationHistogramParams{
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	}
+	if req.ResourceType != nil {
+		if !req.ResourceType.IsValid() {
+			return nil, domain.NewValidationError("resource_type must be one of staff, equipment, materials")
+		}
+		params.ResourceType = repository.NullResourceType{ResourceType: repository.ResourceType(*req.ResourceType), Valid: true}
+	}
+
+	rows, err := s.queries.GetBookingDurationHistogram(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to compute duration histogram", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Bucket] = row.BookingCount
+	}
+
+	resp := &domain.DurationHistogramResponse{
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		ResourceType: req.ResourceType,
+		Buckets:      make([]domain.DurationBucket, 0, len(durationBuckets)),
+	}
+	for _, b := range durationBuckets {
+		bucket := domain.DurationBucket{
+			Label:      b.label,
+			MinMinutes: b.minMinutes,
+			Count:      counts[b.label],
+		}
+		if b.maxMinutes > 0 {
+			upper := b.maxMinutes
+			bucket.MaxMinutes = &upper
+		}
+		resp.Total += bucket.Count
+		resp.Buckets = append(resp.Buckets, bucket)
+	}
+
+	return resp, nil
+}
+
+// GetPeakDemand finds the largest number of distinct resources, optionally of
+// one type, booked at the same moment within the window. Bookings are clipped
+// to the window, so a peak is never reported outside it.
+func (s *ReportService) GetPeakDemand(ctx context.Context, req domain.PeakDemandRequest) (*domain.PeakDemandResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+
+	params := repository.ListBookedRangesByTypeParams{
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	}
+	if req.ResourceType != nil {
+		if !req.ResourceType.IsValid() {
+			return nil, domain.NewValidationError("type must be one of staff, equipment, materials")
+		}
+		params.ResourceType = repository.NullResourceType{ResourceType: repository.ResourceType(*req.ResourceType), Valid: true}
+	}
+
+	rows, err := s.queries.ListBookedRangesByType(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to list bookings", err)
+	}
+
+	busy := make(map[int32][]domain.TimeRange)
+	for _, row := range rows {
+		r := domain.TimeRange{Start: row.StartTime, End: row.EndTime}
+		if r.Start.Before(req.StartDate) {
+			r.Start = req.StartDate
+		}
+		if r.End.After(req.EndDate) {
+			r.End = req.EndDate
+		}
+		busy[row.ResourceID] = append(busy[row.ResourceID], r)
+	}
+
+	resp := &domain.PeakDemandResponse{
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		ResourceType: req.ResourceType,
+	}
+	peak, window := peakConcurrency(busy)
+	if peak > 0 {
+		resp.PeakCount = peak
+		resp.PeakStart = &window.Start
+		resp.PeakEnd = &window.End
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,237 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestGetDurationHistogram_BucketsKnownDurations(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	book := func(resourceID int32, day int, startHour int, duration time.Duration) {
+		start := baseDay.AddDate(0, 0, day).Add(time.Duration(startHour) * time.Hour)
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID, start, start.Add(duration), nil)
+	}
+
+	book(chef, 0, 8, 30*time.Minute) // <1h
+	book(chef, 0, 9, time.Hour)      // 1-4h (boundary)
+	book(chef, 0, 12, 3*time.Hour)   // 1-4h
+	book(chef, 1, 8, 4*time.Hour)    // 4-8h (boundary)
+	book(chef, 2, 6, 10*time.Hour)   // >8h
+	book(oven, 0, 8, 2*time.Hour)    // 1-4h, equipment
+	book(oven, 9, 8, 2*time.Hour)    // outside the window
+
+	service := NewReportService(testDB.DB)
+
+	result, err := service.GetDurationHistogram(context.Background(), domain.DurationHistogramRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.AddDate(0, 0, 7),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), result.Total)
+	require.Len(t, result.Buckets, 4)
+
+	counts := map[string]int64{}
+	for _, b := range result.Buckets {
+		counts[b.Label] = b.Count
+	}
+	assert.Equal(t, map[string]int64{"<1h": 1, "1-4h": 3, "4-8h": 1, ">8h": 1}, counts)
+	assert.Nil(t, result.Buckets[3].MaxMinutes)
+
+	// Filter to staff only
+	staff := domain.ResourceTypeStaff
+	result, err = service.GetDurationHistogram(context.Background(), domain.DurationHistogramRequest{
+		StartDate:    baseDay,
+		EndDate:      baseDay.AddDate(0, 0, 7),
+		ResourceType: &staff,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.Total)
+	assert.Equal(t, "1-4h", result.Buckets[1].Label)
+	assert.Equal(t, int64(2), result.Buckets[1].Count)
+}
+
+func TestGetDurationHistogram_EmptyWindow(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewReportService(testDB.DB)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.GetDurationHistogram(context.Background(), domain.DurationHistogramRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.AddDate(0, 0, 1),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.Total)
+	assert.Len(t, result.Buckets, 4)
+}
+
+func TestGetDurationHistogram_CancelledEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	cancelledAt := baseDay
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(8*time.Hour), baseDay.Add(10*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(12*time.Hour), baseDay.Add(14*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewReportService(testDB.DB)
+	req := domain.DurationHistogramRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	}
+
+	result, err := service.GetDurationHistogram(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+
+	req.IncludeCancelled = true
+	result, err = service.GetDurationHistogram(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+}
+
+func TestGetPeakDemand_KnownPeak(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	staff := make([]int32, 4)
+	for i := range staff {
+		staff[i] = testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+			Type:        testutil.ResourceTypeStaff,
+			IsAvailable: true,
+		})
+	}
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	book := func(resourceID int32, startHour, endHour int) {
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+			baseDay.Add(time.Duration(startHour)*time.Hour), baseDay.Add(time.Duration(endHour)*time.Hour), nil)
+	}
+
+	// Three staff are booked together from 11:00 to 12:00
+	book(staff[0], 8, 12)
+	book(staff[1], 10, 14)
+	book(staff[2], 11, 13)
+	book(staff[3], 16, 18)
+	// Equipment doesn't count toward staff demand
+	book(oven, 11, 12)
+
+	service := NewReportService(testDB.DB)
+	staffType := domain.ResourceTypeStaff
+
+	result, err := service.GetPeakDemand(context.Background(), domain.PeakDemandRequest{
+		StartDate:    baseDay,
+		EndDate:      baseDay.Add(24 * time.Hour),
+		ResourceType: &staffType,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.PeakCount)
+	require.NotNil(t, result.PeakStart)
+	require.NotNil(t, result.PeakEnd)
+	assert.True(t, baseDay.Add(11*time.Hour).Equal(*result.PeakStart))
+	assert.True(t, baseDay.Add(12*time.Hour).Equal(*result.PeakEnd))
+
+	// Without a type filter the oven joins the peak
+	result, err = service.GetPeakDemand(context.Background(), domain.PeakDemandRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.PeakCount)
+}
+
+func TestGetPeakDemand_EmptyWindow(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewReportService(testDB.DB)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	result, err := service.GetPeakDemand(context.Background(), domain.PeakDemandRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.PeakCount)
+	assert.Nil(t, result.PeakStart)
+	assert.Nil(t, result.PeakEnd)
+}
+
+func TestGetPeakDemand_CancelledEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	server := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	cancelledAt := baseDay
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, server, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), &testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewReportService(testDB.DB)
+	req := domain.PeakDemandRequest{
+		StartDate: baseDay,
+		EndDate:   baseDay.Add(24 * time.Hour),
+	}
+
+	result, err := service.GetPeakDemand(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.PeakCount)
+
+	req.IncludeCancelled = true
+	result, err = service.GetPeakDemand(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.PeakCount)
+	require.NotNil(t, result.PeakStart)
+	assert.True(t, baseDay.Add(10*time.Hour).Equal(*result.PeakStart))
+}
@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// AutoReschedule moves an entry, keeping its length, to the earliest slot not
+// before its current start where the resource is inside its working hours and
+// has room for it, skipping blackout days and the resource's downtime. An
+// entry that already fits stays where it is. Working hours are read as in
+// GetBookableWindows, and bookings block as in CreateEntryChecked: pending ones
+// only when the pending_bookings_block flag is on, and equipment and materials
+// only once too few units are left. The search and the move run in one
+// transaction holding a lock on the resource.
+// A not-found error is returned if no slot starts within the search horizon.
+// A move is published as a schedule change; an entry left in place isn't.
+func (s *ScheduleService) AutoReschedule(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.CancelledAt != nil {
+		return nil, domain.NewConflictError("schedule entry is cancelled")
+	}
+
+	resource, err := s.queries.GetResourceByID(ctx, entry.ResourceID)
+	if err != nil {
+		return nil, dbError("failed to get resource", err)
+	}
+	domainResource := toDomainResource(resource)
+	loc, err := resourceZone(&domainResource)
+	if err != nil {
+		return nil, err
+	}
+	if !resource.IsAvailable {
+		return nil, domain.NewNotFoundError("resource is marked unavailable, so no slot can be found")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	if _, err := q.LockResource(ctx, entry.ResourceID); err != nil {
+		return nil, dbError("failed to lock resource", err)
+	}
+
+	quantity, err := q.GetScheduleEntryQuantity(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("schedule entry not found")
+		}
+		return nil, dbError("failed to get schedule entry", err)
+	}
+	capacity := quantity
+	if hasUnits(resource.Type) {
+		if capacity, err = q.GetResourceQuantity(ctx, entry.ResourceID); err != nil {
+			return nil, dbError("failed to get resource quantity", err)
+		}
+	}
+
+	d := entry.EndTime.Sub(entry.StartTime)
+	from := entry.StartTime
+	until := from.Add(slotSearchHorizon + d)
+
+	rows, err := q.ListResourceWorkingHours(ctx, entry.ResourceID)
+	if err != nil {
+		return nil, dbError("failed to get working hours", err)
+	}
+	shifts, err := parseShifts(rows)
+	if err != nil {
+		return nil, domain.NewInternalError("resource has invalid working hours", err)
+	}
+	windows := []domain.TimeRange{{Start: from, End: until}}
+	if len(shifts) > 0 {
+		windows = workingWindows(shifts, loc, from, until)
+	}
+	blackouts, err := blackoutRanges(ctx, q, domain.TimeRange{Start: from, End: until})
+	if err != nil {
+		return nil, err
+	}
+	downRows, err := q.ListOverlappingDowntime(ctx, repository.ListOverlappingDowntimeParams{
+		ResourceIds: []int32{entry.ResourceID},
+		StartTime:   from,
+		EndTime:     until,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource downtime", err)
+	}
+	closed := blackouts
+	for _, row := range downRows {
+		closed = append(closed, domain.TimeRange{Start: row.StartTime, End: row.EndTime})
+	}
+	windows = subtractBusy(windows, mergeBusy(closed))
+
+	usageRows, err := q.ListResourceUnitUsage(ctx, repository.ListResourceUnitUsageParams{
+		ResourceID:        entry.ResourceID,
+		StartTime:         from,
+		EndTime:           until,
+		ExcludeScheduleID: nullInt32(&id),
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource usage", err)
+	}
+	pendingBlocks := len(usageRows) > 0 && s.conflicts.flags.Enabled(ctx, FlagPendingBookingsBlock)
+	usage := make([]unitUsage, 0, len(usageRows))
+	for _, row := range usageRows {
+		if row.ApprovalStatus == repository.ApprovalStatusPending && !pendingBlocks {
+			continue
+		}
+		usage = append(usage, unitUsage{
+			occupied: domain.TimeRange{Start: row.StartTime, End: row.OccupiedUntil},
+			units:    row.Quantity,
+		})
+	}
+
+	// The entry fits wherever no more than capacity-quantity units are taken
+	var slot *domain.TimeRange
+	for _, w := range subtractBusy(windows, saturatedRanges(usage, capacity-quantity)) {
+		if w.End.Sub(w.Start) >= d {
+			slot = &domain.TimeRange{Start: w.Start, End: w.Start.Add(d)}
+			break
+		}
+	}
+	if slot == nil {
+		return nil, domain.NewNotFoundError("no free slot for the schedule entry within the search horizon")
+	}
+
+	// Recheck as an update would, so the move can never book over anything
+	req := domain.ScheduleEntryRequest{
+		ResourceID: entry.ResourceID,
+		EventID:    entry.EventID,
+		TaskID:     entry.TaskID,
+		StartTime:  slot.Start,
+		EndTime:    slot.End,
+		Notes:      entry.Notes,
+		Quantity:   quantity,
+	}
+	remaining, err := s.checkEntryAvailable(ctx, q, req, resource, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := q.UpdateScheduleEntryRange(ctx, repository.UpdateScheduleEntryRangeParams{
+		StartTime: slot.Start,
+		EndTime:   slot.End,
+		ID:        id,
+	}); err != nil {
+		return nil, entryWriteError("failed to update schedule entry", err)
+	}
+
+	row, err := q.GetScheduleEntryByID(ctx, id)
+	if err != nil {
+		return nil, dbError("failed to get schedule entry", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit schedule entry", err)
+	}
+
+	moved := toDomainScheduleEntry(row)
+	moved.RemainingQuantity = remaining
+	if !moved.StartTime.Equal(entry.StartTime) {
+		publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeUpdated, moved)
+	}
+	return &moved, nil
+}
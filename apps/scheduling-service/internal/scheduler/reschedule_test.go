@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestAutoReschedule_WorkingHoursPushToNextDay(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+		UserID:      &userID,
+	})
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Monday, "09:00", "17:00")
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Tuesday, "09:00", "17:00")
+
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		monday.Add(13*time.Hour), monday.Add(16*time.Hour), nil)
+	// Double-booked: only 16:00-17:00 is free for the rest of Monday, too short
+	// for two hours, and the evening is outside working hours
+	clash := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		monday.Add(14*time.Hour), monday.Add(16*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.AutoReschedule(context.Background(), clash)
+
+	require.NoError(t, err)
+	assert.True(t, tuesday.Add(9*time.Hour).Equal(entry.StartTime))
+	assert.True(t, tuesday.Add(11*time.Hour).Equal(entry.EndTime))
+
+	stored, err := service.GetEntry(context.Background(), clash)
+	require.NoError(t, err)
+	assert.True(t, tuesday.Add(9*time.Hour).Equal(stored.StartTime))
+}
+
+func TestAutoReschedule_FittingEntryStaysAndPendingDoesNotBlock(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(10*time.Hour), day.Add(12*time.Hour), &testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+	id := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(10*time.Hour), day.Add(11*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.AutoReschedule(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.True(t, day.Add(10*time.Hour).Equal(entry.StartTime))
+	assert.True(t, day.Add(11*time.Hour).Equal(entry.EndTime))
+}
+
+func TestAutoReschedule_SkipsDowntime(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(10*time.Hour), day.Add(12*time.Hour), nil)
+	clash := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(11*time.Hour), day.Add(12*time.Hour), nil)
+	// The first gap, from noon, is taken by maintenance
+	_, err := NewResourceService(testDB.DB).CreateDowntime(context.Background(), oven, domain.DowntimeRequest{
+		StartTime: day.Add(12 * time.Hour),
+		EndTime:   day.Add(14 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.AutoReschedule(context.Background(), clash)
+
+	require.NoError(t, err)
+	assert.True(t, day.Add(14*time.Hour).Equal(entry.StartTime))
+	assert.True(t, day.Add(15*time.Hour).Equal(entry.EndTime))
+}
+
+func TestAutoReschedule_NoSlotWithinHorizon(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+
+	day := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day, day.AddDate(0, 0, 40), nil)
+	id := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(10*time.Hour), day.Add(11*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	_, err := service.AutoReschedule(context.Background(), id)
+
+	require.Error(t, err)
+	domainErr, ok := err.(*domain.DomainError)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+
+	// The entry is left where it was
+	stored, err := service.GetEntry(context.Background(), id)
+	require.NoError(t, err)
+	assert.True(t, day.Add(10*time.Hour).Equal(stored.StartTime))
+}
@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// PreviewEventResolutions finds every conflict of an event's bookings and
+// proposes a resolution for each, without applying anything. A booking is
+// moved at the same time to a free resource listed as a substitute for its
+// resource when one exists, otherwise to its resource's next free slot. Proposals are planned in order,
+// so later ones never collide with earlier ones.
+func (s *AvailabilityService) PreviewEventResolutions(ctx context.Context, eventID int32) (*domain.ResolutionPlanResponse, error) {
+	exists, err := s.queries.EventExists(ctx, eventID)
+	if err != nil {
+		return nil, dbError("failed to get event", err)
+	}
+	if !exists {
+		return nil, domain.NewNotFoundError("event not found")
+	}
+
+	rows, err := s.queries.ListEventBookingConflicts(ctx, eventID)
+	if err != nil {
+		return nil, dbError("failed to list event conflicts", err)
+	}
+	items := groupEventConflicts(rows)
+	if len(items) == 0 {
+		return &domain.ResolutionPlanResponse{
+			EventID:     eventID,
+			Resolutions: []domain.ConflictResolution{},
+		}, nil
+	}
+
+	ids := make([]int32, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.resolution.ResourceID)
+	}
+	substitutes, err := s.queries.ListSubstituteCandidates(ctx, ids)
+	if err != nil {
+		return nil, dbError("failed to list substitutes", err)
+	}
+	candidates := make(map[int32][]repository.ListSubstituteCandidatesRow)
+	for _, sub := range substitutes {
+		candidates[sub.ResourceID] = append(candidates[sub.ResourceID], sub)
+		ids = append(ids, sub.ID)
+	}
+
+	from, until := items[0].resolution.StartTime, items[0].resolution.EndTime
+	for _, item := range items {
+		if item.resolution.StartTime.Before(from) {
+			from = item.resolution.StartTime
+		}
+		if item.resolution.EndTime.After(until) {
+			until = item.resolution.EndTime
+		}
+	}
+	until = until.Add(slotSearchHorizon)
+
+	bookings, err := s.queries.ListOverlappingScheduleEntries(ctx, repository.ListOverlappingScheduleEntriesParams{
+		ResourceIds: ids,
+		StartTime:   from,
+		EndTime:     until,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	resolutions := planResolutions(items, candidates, newResolutionPlanner(bookings), until)
+	unresolved := 0
+	for _, r := range resolutions {
+		if r.Kind == domain.ResolutionUnresolved {
+			unresolved++
+		}
+	}
+	return &domain.ResolutionPlanResponse{
+		EventID:         eventID,
+		Resolutions:     resolutions,
+		UnresolvedCount: unresolved,
+	}, nil
+}
+
+// conflictItem is one of the event's bookings that needs resolving, with the
+// resource details the planner needs
+type conflictItem struct {
+	resolution domain.ConflictResolution
+	grace      time.Duration
+}
+
+// groupEventConflicts collects conflict pairs, ordered by the event's booking,
+// into one item per booking
+func groupEventConflicts(rows []repository.ListEventBookingConflictsRow) []conflictItem {
+	items := []conflictItem{}
+	for _, row := range rows {
+		if len(items) == 0 || items[len(items)-1].resolution.ScheduleID != row.ScheduleID {
+			items = append(items, conflictItem{
+				resolution: domain.ConflictResolution{
+					ScheduleID:    row.ScheduleID,
+					ResourceID:    row.ResourceID,
+					ResourceName:  row.ResourceName,
+					StartTime:     row.StartTime,
+					EndTime:       row.EndTime,
+					ConflictsWith: []domain.ResolutionConflict{},
+				},
+				grace: time.Duration(row.ReleaseGraceMinutes) * time.Minute,
+			})
+		}
+		last := &items[len(items)-1]
+		last.resolution.ConflictsWith = append(last.resolution.ConflictsWith, domain.ResolutionConflict{
+			ScheduleID:     row.ConflictingScheduleID,
+			EventID:        row.ConflictingEventID,
+			StartTime:      row.ConflictingStartTime,
+			EndTime:        row.ConflictingEndTime,
+			ApprovalStatus: domain.ApprovalStatus(row.ConflictingApprovalStatus),
+		})
+	}
+	return items
+}
+
+// resolutionPlanner tracks what each resource's schedule would look like with
+// the proposals made so far applied
+type resolutionPlanner struct {
+	booked map[int32][]plannedBooking
+	moved  map[int32]bool
+}
+
+// plannedBooking is a range a resource is occupied, including release grace.
+// Proposed bookings have no schedule ID.
+type plannedBooking struct {
+	scheduleID int32
+	occupied   domain.TimeRange
+}
+
+// newResolutionPlanner starts a plan from the resources' current bookings
+func newResolutionPlanner(rows []repository.ListOverlappingScheduleEntriesRow) *resolutionPlanner {
+	p := &resolutionPlanner{
+		booked: make(map[int32][]plannedBooking),
+		moved:  make(map[int32]bool),
+	}
+	for _, row := range rows {
+		p.booked[row.ResourceID] = append(p.booked[row.ResourceID], plannedBooking{
+			scheduleID: row.ID,
+			occupied: domain.TimeRange{
+				Start: row.StartTime,
+				End:   row.EndTime.Add(time.Duration(row.ReleaseGraceMinutes) * time.Minute),
+			},
+		})
+	}
+	return p
+}
+
+// busy returns a resource's merged busy ranges under the plan, ignoring the
+// excluded schedule entry
+func (p *resolutionPlanner) busy(resourceID, exclude int32) []domain.TimeRange {
+	var ranges []domain.TimeRange
+	for _, b := range p.booked[resourceID] {
+		if b.scheduleID != 0 && (b.scheduleID == exclude || p.moved[b.scheduleID]) {
+			continue
+		}
+		ranges = append(ranges, b.occupied)
+	}
+	return mergeBusy(ranges)
+}
+
+// move records that a schedule entry leaves its resource and occupies the
+// given range on the target resource instead
+func (p *resolutionPlanner) move(scheduleID, resourceID int32, occupied domain.TimeRange) {
+	p.moved[scheduleID] = true
+	p.booked[resourceID] = append(p.booked[resourceID], plannedBooking{occupied: occupied})
+}
+
+// planResolutions proposes a resolution for each item in turn, updating the
+// plan after each so proposals don't collide. Candidates are the available
+// substitutes for each resource, in preference order.
+func planResolutions(items []conflictItem, candidates map[int32][]repository.ListSubstituteCandidatesRow, p *resolutionPlanner, until time.Time) []domain.ConflictResolution {
+	resolutions := make([]domain.ConflictResolution, 0, len(items))
+	for _, item := range items {
+		r := item.resolution
+		duration := r.EndTime.Sub(r.StartTime)
+
+		// An earlier proposal may already have moved what this booking hit
+		if _, ok := nextFreeSlot(p.busy(r.ResourceID, r.ScheduleID), r.StartTime, r.EndTime.Add(item.grace), duration+item.grace); ok {
+			r.Kind = domain.ResolutionKeep
+			resolutions = append(resolutions, r)
+			continue
+		}
+
+		r.Kind = domain.ResolutionUnresolved
+		for _, c := range candidates[r.ResourceID] {
+			grace := time.Duration(c.ReleaseGraceMinutes) * time.Minute
+			if _, ok := nextFreeSlot(p.busy(c.ID, 0), r.StartTime, r.EndTime.Add(grace), duration+grace); !ok {
+				continue
+			}
+			id, name := c.ID, c.Name
+			r.Kind = domain.ResolutionSubstitute
+			r.SubstituteResourceID = &id
+			r.SubstituteResourceName = &name
+			p.move(r.ScheduleID, c.ID, domain.TimeRange{Start: r.StartTime, End: r.EndTime.Add(grace)})
+			break
+		}
+
+		if r.Kind == domain.ResolutionUnresolved {
+			if slot, ok := nextFreeSlot(p.busy(r.ResourceID, r.ScheduleID), r.StartTime, until, duration+item.grace); ok {
+				end := slot.Add(duration)
+				r.Kind = domain.ResolutionReschedule
+				r.ProposedStart = &slot
+				r.ProposedEnd = &end
+				p.move(r.ScheduleID, r.ResourceID, domain.TimeRange{Start: slot, End: end.Add(item.grace)})
+			}
+		}
+
+		resolutions = append(resolutions, r)
+	}
+	return resolutions
+}
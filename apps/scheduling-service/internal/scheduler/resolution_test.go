@@ -0,0 +1,234 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestPlanResolutions(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	booking := func(id, resourceID int32, start, end int) repository.ListOverlappingScheduleEntriesRow {
+		return repository.ListOverlappingScheduleEntriesRow{ID: id, ResourceID: resourceID, StartTime: at(start), EndTime: at(end)}
+	}
+	item := func(id, resourceID int32, start, end int) conflictItem {
+		return conflictItem{
+			resolution: domain.ConflictResolution{
+				ScheduleID: id,
+				ResourceID: resourceID,
+				StartTime:  at(start),
+				EndTime:    at(end),
+			},
+		}
+	}
+
+	// Chef 1 and oven 3 are double-booked. Chef 2 can cover chef 1 and is
+	// free; nothing can cover the oven.
+	planner := newResolutionPlanner([]repository.ListOverlappingScheduleEntriesRow{
+		booking(10, 1, 9, 12),
+		booking(11, 1, 10, 11),
+		booking(20, 3, 9, 12),
+		booking(21, 3, 11, 13),
+		booking(30, 2, 14, 15),
+	})
+	candidates := map[int32][]repository.ListSubstituteCandidatesRow{
+		1: {{ResourceID: 1, ID: 2, Name: "Chef B"}},
+	}
+	items := []conflictItem{
+		item(11, 1, 10, 11),
+		item(21, 3, 11, 13),
+	}
+
+	got := planResolutions(items, candidates, planner, at(48))
+
+	require.Len(t, got, 2)
+	assert.Equal(t, domain.ResolutionSubstitute, got[0].Kind)
+	require.NotNil(t, got[0].SubstituteResourceID)
+	assert.Equal(t, int32(2), *got[0].SubstituteResourceID)
+
+	assert.Equal(t, domain.ResolutionReschedule, got[1].Kind)
+	require.NotNil(t, got[1].ProposedStart)
+	assert.True(t, at(12).Equal(*got[1].ProposedStart))
+	assert.True(t, at(14).Equal(*got[1].ProposedEnd))
+}
+
+func TestPlanResolutions_LaterProposalsAvoidEarlierOnes(t *testing.T) {
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	// Two bookings both clash with entry 1; the only substitute can take one
+	planner := newResolutionPlanner([]repository.ListOverlappingScheduleEntriesRow{
+		{ID: 1, ResourceID: 1, StartTime: at(9), EndTime: at(12)},
+		{ID: 2, ResourceID: 1, StartTime: at(9), EndTime: at(10)},
+		{ID: 3, ResourceID: 1, StartTime: at(9), EndTime: at(10)},
+	})
+	candidates := map[int32][]repository.ListSubstituteCandidatesRow{
+		1: {{ResourceID: 1, ID: 2, Name: "Chef B"}},
+	}
+	items := []conflictItem{
+		{resolution: domain.ConflictResolution{ScheduleID: 2, ResourceID: 1, StartTime: at(9), EndTime: at(10)}},
+		{resolution: domain.ConflictResolution{ScheduleID: 3, ResourceID: 1, StartTime: at(9), EndTime: at(10)}},
+	}
+
+	got := planResolutions(items, candidates, planner, at(48))
+
+	require.Len(t, got, 2)
+	assert.Equal(t, domain.ResolutionSubstitute, got[0].Kind)
+	assert.Equal(t, domain.ResolutionReschedule, got[1].Kind)
+	assert.True(t, at(12).Equal(*got[1].ProposedStart))
+}
+
+func TestPreviewEventResolutions_TwoConflicts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "A Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	spareChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "B Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+	testutil.CreateResourceSubstitute(t, testDB.DB, chef, spareChef)
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEvent,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	chefEntry := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(10*time.Hour), day.Add(11*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, otherEvent,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	ovenEntry := testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID,
+		day.Add(11*time.Hour), day.Add(13*time.Hour), nil)
+
+	plan, err := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)).PreviewEventResolutions(context.Background(), eventID)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Resolutions, 2)
+	assert.Equal(t, 0, plan.UnresolvedCount)
+
+	byID := make(map[int32]domain.ConflictResolution)
+	for _, r := range plan.Resolutions {
+		require.Len(t, r.ConflictsWith, 1)
+		byID[r.ScheduleID] = r
+	}
+
+	chefPlan := byID[chefEntry]
+	assert.Equal(t, domain.ResolutionSubstitute, chefPlan.Kind)
+	require.NotNil(t, chefPlan.SubstituteResourceID)
+	assert.Equal(t, spareChef, *chefPlan.SubstituteResourceID)
+
+	ovenPlan := byID[ovenEntry]
+	assert.Equal(t, domain.ResolutionReschedule, ovenPlan.Kind)
+	require.NotNil(t, ovenPlan.ProposedStart)
+	assert.True(t, day.Add(12*time.Hour).Equal(*ovenPlan.ProposedStart))
+
+	// Nothing was applied
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), chefEntry)
+	require.NoError(t, err)
+	assert.Equal(t, chef, entry.ResourceID)
+}
+
+func TestPreviewEventResolutions_OnlyCompatibleSubstitutes(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+
+	server := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "A Server",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	// Same type and free, and first by name, but not listed as a substitute
+	pastryChef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "B Pastry Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	otherServer := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "C Server",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	testutil.CreateResourceSubstitute(t, testDB.DB, server, otherServer)
+	// Listings in the other direction don't make the pastry chef a substitute
+	testutil.CreateResourceSubstitute(t, testDB.DB, pastryChef, server)
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, server, otherEvent,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	entry := testutil.CreateScheduleEntry(t, testDB.DB, server, eventID,
+		day.Add(10*time.Hour), day.Add(11*time.Hour), nil)
+
+	plan, err := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)).PreviewEventResolutions(context.Background(), eventID)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Resolutions, 1)
+	assert.Equal(t, entry, plan.Resolutions[0].ScheduleID)
+	assert.Equal(t, domain.ResolutionSubstitute, plan.Resolutions[0].Kind)
+	require.NotNil(t, plan.Resolutions[0].SubstituteResourceID)
+	assert.Equal(t, otherServer, *plan.Resolutions[0].SubstituteResourceID)
+}
+
+func TestPreviewEventResolutions_WithoutSubstitutesReschedules(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	// Free and the same type, but nothing says it can cover the chef
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	day := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEvent,
+		day.Add(9*time.Hour), day.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+		day.Add(10*time.Hour), day.Add(11*time.Hour), nil)
+
+	plan, err := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)).PreviewEventResolutions(context.Background(), eventID)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Resolutions, 1)
+	assert.Equal(t, domain.ResolutionReschedule, plan.Resolutions[0].Kind)
+	assert.Nil(t, plan.Resolutions[0].SubstituteResourceID)
+}
+
+func TestPreviewEventResolutions_EventNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, err := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB)).PreviewEventResolutions(context.Background(), 99999)
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
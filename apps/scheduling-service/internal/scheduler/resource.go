@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxBulkResources caps how many resources a single bulk update may touch
+const maxBulkResources = 500
+
+// ResourceService handles updates to resource records
+type ResourceService struct {
+	queries *repository.Queries
+}
+
+// NewResourceService creates a new resource service
+func NewResourceService(db *sql.DB) *ResourceService {
+	return &ResourceService{
+		queries: repository.NewQueries(db),
+	}
+}
+
+// SetAvailability updates is_available for all listed resources in one statement.
+// When resources are taken out of service, any that still have bookings ending
+// in the future are reported as warnings; the bookings themselves are untouched.
+func (s *ResourceService) SetAvailability(ctx context.Context, req domain.BulkAvailabilityRequest) (*domain.BulkAvailabilityResponse, error) {
+	if len(req.ResourceIDs) == 0 {
+		return nil, domain.NewValidationError("resource_ids must not be empty")
+	}
+	if len(req.ResourceIDs) > maxBulkResources {
+		return nil, domain.NewValidationError("too many resource_ids in a single request")
+	}
+	if req.IsAvailable == nil {
+		return nil, domain.NewValidationError("is_available is required")
+	}
+
+	updated, err := s.queries.SetResourcesAvailability(ctx, repository.SetResourcesAvailabilityParams{
+		IsAvailable: *req.IsAvailable,
+		ResourceIds: req.ResourceIDs,
+	})
+	if err != nil {
+		return nil, dbError("failed to update resource availability", err)
+	}
+
+	warnings := []domain.FutureBookingWarning{}
+	if !*req.IsAvailable {
+		rows, err := s.queries.CountFutureBookingsByResource(ctx, repository.CountFutureBookingsByResourceParams{
+			ResourceIds: req.ResourceIDs,
+			After:       time.Now(),
+		})
+		if err != nil {
+			return nil, dbError("failed to count future bookings", err)
+		}
+		for _, row := range rows {
+			warnings = append(warnings, domain.FutureBookingWarning{
+				ResourceID:         row.ResourceID,
+				FutureBookingCount: row.BookingCount,
+			})
+		}
+	}
+
+	return &domain.BulkAvailabilityResponse{
+		UpdatedCount: updated,
+		Warnings:     warnings,
+	}, nil
+}
+
+// SetTimezone sets the resource's operating timezone, or clears it when the
+// request's timezone is nil or empty. Only IANA zone names are accepted.
+func (s *ResourceService) SetTimezone(ctx context.Context, id int32, req domain.SetTimezoneRequest) (*domain.Resource, error) {
+	params := repository.SetResourceTimezoneParams{ID: id}
+	if req.Timezone != nil && *req.Timezone != "" {
+		if _, err := parseTimezone(*req.Timezone); err != nil {
+			return nil, err
+		}
+		params.Timezone = sql.NullString{String: *req.Timezone, Valid: true}
+	}
+
+	row, err := s.queries.SetResourceTimezone(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to update resource timezone", err)
+	}
+
+	resource := toDomainResource(row)
+	return &resource, nil
+}
+
+// ResolveExternalCode returns the ID of the resource an integration knows by
+// code. An unknown code is reported as not found.
+func (s *ResourceService) ResolveExternalCode(ctx context.Context, code string) (int32, error) {
+	if code == "" {
+		return 0, domain.NewValidationError("external_code must not be empty")
+	}
+
+	id, err := s.queries.GetResourceIDByExternalCode(ctx, sql.NullString{String: code, Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.NewNotFoundError(fmt.Sprintf("no resource has external_code %q", code))
+		}
+		return 0, dbError("failed to look up external code", err)
+	}
+	return id, nil
+}
+
+// GetDeleteImpact reports what deleting a resource would take with it: its
+// schedule entries cascade away, so this lists the bookings that haven't
+// finished yet, by event. Rejected and cancelled bookings are left out, as for
+// the warnings when a resource is marked unavailable. Nothing is changed.
+func (s *ResourceService) GetDeleteImpact(ctx context.Context, id int32) (*domain.ResourceDeleteImpact, error) {
+	resource, err := s.queries.GetResourceByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+
+	rows, err := s.queries.ListResourceDeleteImpact(ctx, repository.ListResourceDeleteImpactParams{
+		ResourceID: id,
+		After:      time.Now(),
+	})
+	if err != nil {
+		return nil, dbError("failed to list future bookings", err)
+	}
+
+	impact := &domain.ResourceDeleteImpact{
+		ResourceID:   resource.ID,
+		ResourceName: resource.Name,
+		Events:       make([]domain.DeleteImpactEvent, 0, len(rows)),
+	}
+	for _, row := range rows {
+		impact.FutureBookingCount += row.BookingCount
+		impact.Events = append(impact.Events, domain.DeleteImpactEvent{
+			EventID:      row.EventID,
+			EventName:    row.EventName,
+			EventDate:    row.EventDate,
+			EventStatus:  string(row.Status),
+			BookingCount: row.BookingCount,
+			FirstStart:   row.FirstStart,
+		})
+	}
+	return impact, nil
+}
+
+// ListResources returns one page of resources matching the request's filters,
+// in the requested order, along with how many match in total. Resources
+// without an hourly rate never match a rate bound.
+func (s *ResourceService) ListResources(ctx context.Context, req domain.ResourceListRequest) (*domain.ResourceListResponse, error) {
+	params, err := resourceListParams(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		return nil, domain.NewValidationError("limit must be positive")
+	}
+	if req.Offset < 0 {
+		return nil, domain.NewValidationError("offset must not be negative")
+	}
+
+	total, err := s.queries.CountResourcesPage(ctx, repository.CountResourcesPageParams{
+		Type:        params.Type,
+		IsAvailable: params.IsAvailable,
+		MinRate:     params.MinRate,
+		MaxRate:     params.MaxRate,
+	})
+	if err != nil {
+		return nil, dbError("failed to count resources", err)
+	}
+
+	params.LimitCount = int32(req.Limit)
+	params.OffsetCount = int32(req.Offset)
+	rows, err := s.queries.ListResourcesPage(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to list resources", err)
+	}
+
+	resources := make([]domain.Resource, 0, len(rows))
+	for _, row := range rows {
+		resources = append(resources, toDomainResource(row))
+	}
+	return &domain.ResourceListResponse{
+		Total:     total,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+		Resources: resources,
+	}, nil
+}
+
+// resourceListParams validates the filters and sort of a resources list
+// request and turns them into query parameters, without the page bounds
+func resourceListParams(req domain.ResourceListRequest) (repository.ListResourcesPageParams, error) {
+	var params repository.ListResourcesPageParams
+	if req.Type != nil {
+		if !req.Type.IsValid() {
+			return params, domain.NewValidationError("type must be one of staff, equipment, materials")
+		}
+		params.Type = repository.NullResourceType{ResourceType: repository.ResourceType(*req.Type), Valid: true}
+	}
+	if req.IsAvailable != nil {
+		params.IsAvailable = sql.NullBool{Bool: *req.IsAvailable, Valid: true}
+	}
+
+	var minCents, maxCents int64
+	var err error
+	if req.MinRate != nil {
+		if minCents, err = parseRateBound("min_rate", *req.MinRate); err != nil {
+			return params, err
+		}
+		params.MinRate = sql.NullString{String: formatCents(minCents), Valid: true}
+	}
+	if req.MaxRate != nil {
+		if maxCents, err = parseRateBound("max_rate", *req.MaxRate); err != nil {
+			return params, err
+		}
+		params.MaxRate = sql.NullString{String: formatCents(maxCents), Valid: true}
+	}
+	if params.MinRate.Valid && params.MaxRate.Valid && minCents > maxCents {
+		return params, domain.NewValidationError("min_rate must not be more than max_rate")
+	}
+
+	switch req.Sort {
+	case "":
+		params.SortField = string(domain.ResourceSortName)
+	case domain.ResourceSortName, domain.ResourceSortType, domain.ResourceSortHourlyRate, domain.ResourceSortCreatedAt:
+		params.SortField = string(req.Sort)
+	default:
+		return params, domain.NewValidationError("sort must be one of name, type, hourly_rate, created_at")
+	}
+	switch req.Order {
+	case "", domain.SortOrderAsc:
+	case domain.SortOrderDesc:
+		params.SortDesc = true
+	default:
+		return params, domain.NewValidationError("order must be asc or desc")
+	}
+	return params, nil
+}
+
+// parseRateBound parses a bound on the hourly rate, a non-negative amount with
+// at most two decimal places
+func parseRateBound(name, rate string) (int64, error) {
+	cents, err := parseCents(rate)
+	if err != nil || cents < 0 {
+		return 0, domain.NewValidationError(name + " must be a non-negative amount with at most two decimal places")
+	}
+	return cents, nil
+}
@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// GetResourceSharing groups the events booked within the window by the
+// resources they share. Two events are in the same group if they booked a
+// common resource, directly or through a chain of other events, so each group
+// is a connected component of the event-resource graph. Events sharing no
+// resource with any other are listed as isolated. Bookings only need to fall
+// in the window, not overlap each other, to link their events.
+func (s *ReportService) GetResourceSharing(ctx context.Context, req domain.ResourceSharingRequest) (*domain.ResourceSharingResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxUtilizationTrendRange {
+		return nil, domain.NewValidationError("range must not exceed 366 days")
+	}
+
+	rows, err := s.queries.ListEventResourcePairs(ctx, repository.ListEventResourcePairsParams{
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	})
+	if err != nil {
+		return nil, dbError("failed to list event resources", err)
+	}
+
+	resp := groupEventsBySharedResources(rows)
+	resp.StartDate = req.StartDate
+	resp.EndDate = req.EndDate
+	return resp, nil
+}
+
+// groupEventsBySharedResources finds the connected components of events linked
+// by shared resources. rows must be distinct event/resource pairs ordered by
+// event. Groups and the events within them are ordered by event ID, and shared
+// resources by resource ID.
+func groupEventsBySharedResources(rows []repository.ListEventResourcePairsRow) *domain.ResourceSharingResponse {
+	var eventIDs []int32
+	events := make(map[int32]*domain.SharingEvent)
+	usedBy := make(map[int32][]int32)
+	resourceNames := make(map[int32]string)
+	parent := make(map[int32]int32)
+
+	var find func(id int32) int32
+	find = func(id int32) int32 {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	for _, row := range rows {
+		event, ok := events[row.EventID]
+		if !ok {
+			event = &domain.SharingEvent{EventID: row.EventID, EventName: row.EventName}
+			events[row.EventID] = event
+			eventIDs = append(eventIDs, row.EventID)
+			parent[row.EventID] = row.EventID
+		}
+		event.ResourceCount++
+
+		if users := usedBy[row.ResourceID]; len(users) > 0 {
+			// Link this event with the first to book the resource
+			if a, b := find(users[0]), find(row.EventID); a != b {
+				parent[b] = a
+			}
+		}
+		usedBy[row.ResourceID] = append(usedBy[row.ResourceID], row.EventID)
+		resourceNames[row.ResourceID] = row.ResourceName
+	}
+
+	// Number the components in order of their lowest event ID
+	component := make(map[int32]int)
+	var members [][]domain.SharingEvent
+	for _, id := range eventIDs {
+		root := find(id)
+		i, ok := component[root]
+		if !ok {
+			i = len(members)
+			component[root] = i
+			members = append(members, nil)
+		}
+		members[i] = append(members[i], *events[id])
+	}
+
+	shared := make([][]domain.SharedResource, len(members))
+	resourceIDs := make([]int32, 0, len(usedBy))
+	for id := range usedBy {
+		resourceIDs = append(resourceIDs, id)
+	}
+	sort.Slice(resourceIDs, func(i, j int) bool { return resourceIDs[i] < resourceIDs[j] })
+	for _, id := range resourceIDs {
+		users := usedBy[id]
+		if len(users) < 2 {
+			continue
+		}
+		i := component[find(users[0])]
+		shared[i] = append(shared[i], domain.SharedResource{
+			ResourceID:   id,
+			ResourceName: resourceNames[id],
+			EventIDs:     users,
+		})
+	}
+
+	resp := &domain.ResourceSharingResponse{
+		EventCount:     len(eventIDs),
+		Groups:         []domain.ResourceSharingGroup{},
+		IsolatedEvents: []domain.SharingEvent{},
+	}
+	for i, events := range members {
+		if len(events) == 1 {
+			resp.IsolatedEvents = append(resp.IsolatedEvents, events[0])
+			continue
+		}
+		resp.Groups = append(resp.Groups, domain.ResourceSharingGroup{
+			Events:          events,
+			SharedResources: shared[i],
+		})
+	}
+	return resp
+}
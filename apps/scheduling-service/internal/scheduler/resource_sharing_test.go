@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestGroupEventsBySharedResources(t *testing.T) {
+	pair := func(eventID, resourceID int32) repository.ListEventResourcePairsRow {
+		return repository.ListEventResourcePairsRow{EventID: eventID, ResourceID: resourceID}
+	}
+
+	// 1 and 3 share resource 10, 3 and 4 share 11, so 1, 3, and 4 are linked
+	// even though 1 and 4 share nothing directly; 2 books only its own resource
+	resp := groupEventsBySharedResources([]repository.ListEventResourcePairsRow{
+		pair(1, 10),
+		pair(2, 12),
+		pair(3, 10),
+		pair(3, 11),
+		pair(4, 11),
+		pair(4, 13),
+		pair(5, 14),
+		pair(6, 14),
+	})
+
+	assert.Equal(t, 6, resp.EventCount)
+	require.Len(t, resp.Groups, 2)
+
+	chain := resp.Groups[0]
+	require.Len(t, chain.Events, 3)
+	assert.Equal(t, []int32{1, 3, 4}, []int32{chain.Events[0].EventID, chain.Events[1].EventID, chain.Events[2].EventID})
+	assert.Equal(t, 2, chain.Events[1].ResourceCount)
+	require.Len(t, chain.SharedResources, 2)
+	assert.Equal(t, int32(10), chain.SharedResources[0].ResourceID)
+	assert.Equal(t, []int32{1, 3}, chain.SharedResources[0].EventIDs)
+	assert.Equal(t, []int32{3, 4}, chain.SharedResources[1].EventIDs)
+
+	require.Len(t, resp.Groups[1].Events, 2)
+	assert.Equal(t, int32(5), resp.Groups[1].Events[0].EventID)
+
+	require.Len(t, resp.IsolatedEvents, 1)
+	assert.Equal(t, int32(2), resp.IsolatedEvents[0].EventID)
+}
+
+func TestGroupEventsBySharedResources_NoBookings(t *testing.T) {
+	resp := groupEventsBySharedResources(nil)
+	assert.Zero(t, resp.EventCount)
+	assert.NotNil(t, resp.Groups)
+	assert.Empty(t, resp.Groups)
+	assert.NotNil(t, resp.IsolatedEvents)
+	assert.Empty(t, resp.IsolatedEvents)
+}
+
+func TestGetResourceSharing(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, wedding := testutil.SetupBaseData(t, testDB.DB)
+	gala := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	picnic := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef", IsAvailable: true})
+	oven := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Oven", IsAvailable: true})
+	van := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Van", IsAvailable: true})
+
+	day := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	// The wedding and the gala both book the chef, at different times
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, wedding, day.Add(8*time.Hour), day.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, wedding, day.Add(8*time.Hour), day.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, gala, day.Add(14*time.Hour), day.Add(20*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, van, picnic, day.Add(9*time.Hour), day.Add(11*time.Hour), nil)
+	// A rejected booking doesn't link the picnic to the oven's users
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, picnic, day.Add(13*time.Hour), day.Add(14*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	service := NewReportService(testDB.DB)
+	resp, err := service.GetResourceSharing(context.Background(), domain.ResourceSharingRequest{
+		StartDate: day,
+		EndDate:   day.AddDate(0, 0, 1),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.EventCount)
+
+	require.Len(t, resp.Groups, 1)
+	group := resp.Groups[0]
+	require.Len(t, group.Events, 2)
+	assert.Equal(t, wedding, group.Events[0].EventID)
+	assert.Equal(t, 2, group.Events[0].ResourceCount)
+	assert.Equal(t, gala, group.Events[1].EventID)
+	require.Len(t, group.SharedResources, 1)
+	assert.Equal(t, "Chef", group.SharedResources[0].ResourceName)
+	assert.Equal(t, []int32{wedding, gala}, group.SharedResources[0].EventIDs)
+
+	require.Len(t, resp.IsolatedEvents, 1)
+	assert.Equal(t, picnic, resp.IsolatedEvents[0].EventID)
+	assert.Equal(t, 1, resp.IsolatedEvents[0].ResourceCount)
+}
+
+func TestGetResourceSharing_Validation(t *testing.T) {
+	service := &ReportService{}
+	start := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetResourceSharing(context.Background(), domain.ResourceSharingRequest{StartDate: start, EndDate: start})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	_, err = service.GetResourceSharing(context.Background(), domain.ResourceSharingRequest{StartDate: start, EndDate: start.AddDate(2, 0, 0)})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
@@ -0,0 +1,340 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSetAvailability_TogglesListedResources(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	testutil.ResetCounters()
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	mixer := testutil.CreateResource(t, testDB.DB, nil)
+	untouched := testutil.CreateResource(t, testDB.DB, nil)
+
+	service := NewResourceService(testDB.DB)
+
+	result, err := service.SetAvailability(context.Background(), domain.BulkAvailabilityRequest{
+		ResourceIDs: []int32{oven, mixer},
+		IsAvailable: boolPtr(false),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.UpdatedCount)
+	assert.Empty(t, result.Warnings)
+
+	for id, want := range map[int32]bool{oven: false, mixer: false, untouched: true} {
+		var isAvailable bool
+		err := testDB.DB.QueryRow(`SELECT is_available FROM resources WHERE id = $1`, id).Scan(&isAvailable)
+		require.NoError(t, err)
+		assert.Equal(t, want, isAvailable, "resource %d", id)
+	}
+
+	// Toggle back on
+	result, err = service.SetAvailability(context.Background(), domain.BulkAvailabilityRequest{
+		ResourceIDs: []int32{oven, mixer, 99999},
+		IsAvailable: boolPtr(true),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.UpdatedCount)
+}
+
+func TestSetAvailability_WarnsAboutFutureBookings(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	booked := testutil.CreateResource(t, testDB.DB, nil)
+	pastOnly := testutil.CreateResource(t, testDB.DB, nil)
+
+	now := time.Now().UTC().Truncate(time.Hour)
+	testutil.CreateScheduleEntry(t, testDB.DB, booked, eventID,
+		now.Add(24*time.Hour), now.Add(28*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, booked, eventID,
+		now.Add(48*time.Hour), now.Add(50*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, pastOnly, eventID,
+		now.Add(-48*time.Hour), now.Add(-44*time.Hour), nil)
+
+	service := NewResourceService(testDB.DB)
+
+	result, err := service.SetAvailability(context.Background(), domain.BulkAvailabilityRequest{
+		ResourceIDs: []int32{booked, pastOnly},
+		IsAvailable: boolPtr(false),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.UpdatedCount)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, booked, result.Warnings[0].ResourceID)
+	assert.Equal(t, int64(2), result.Warnings[0].FutureBookingCount)
+}
+
+func TestSetAvailability_Validation(t *testing.T) {
+	service := NewResourceService(nil)
+
+	_, err := service.SetAvailability(context.Background(), domain.BulkAvailabilityRequest{
+		IsAvailable: boolPtr(false),
+	})
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code)
+
+	_, err = service.SetAvailability(context.Background(), domain.BulkAvailabilityRequest{
+		ResourceIDs: []int32{1},
+	})
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code)
+}
+
+func TestSetTimezone_SetsAndClears(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+	service := NewResourceService(testDB.DB)
+
+	resource, err := service.SetTimezone(context.Background(), resourceID, domain.SetTimezoneRequest{
+		Timezone: strPtr("Europe/Lisbon"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resource.Timezone)
+	assert.Equal(t, "Europe/Lisbon", *resource.Timezone)
+
+	resource, err = service.SetTimezone(context.Background(), resourceID, domain.SetTimezoneRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, resource.Timezone)
+}
+
+func TestSetTimezone_Validation(t *testing.T) {
+	service := NewResourceService(nil)
+
+	for _, tz := range []string{"Mars/Olympus_Mons", "Local", "not a zone"} {
+		_, err := service.SetTimezone(context.Background(), 1, domain.SetTimezoneRequest{Timezone: &tz})
+		require.Error(t, err, tz)
+		assert.Equal(t, domain.ErrCodeValidation, err.(*domain.DomainError).Code, tz)
+	}
+}
+
+func TestResolveExternalCode(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	code := "VAN-02"
+	van := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{ExternalCode: &code, IsAvailable: true})
+	testutil.CreateResource(t, testDB.DB, nil)
+
+	service := NewResourceService(testDB.DB)
+
+	id, err := service.ResolveExternalCode(context.Background(), code)
+	require.NoError(t, err)
+	assert.Equal(t, van, id)
+
+	// Codes are matched exactly
+	_, err = service.ResolveExternalCode(context.Background(), "van-02")
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+
+	_, err = service.ResolveExternalCode(context.Background(), "")
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
+
+func TestGetDeleteImpact_CountsFutureBookingsByEvent(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	gala := testutil.CreateEvent(t, testDB.DB, clientID, userID, &testutil.EventOpts{EventName: "Summer Gala"})
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	other := testutil.CreateResource(t, testDB.DB, nil)
+
+	now := time.Now().Truncate(time.Hour)
+	// Two future bookings for the gala, one for the base event
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, now.Add(48*time.Hour), now.Add(50*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, now.Add(72*time.Hour), now.Add(74*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, now.Add(24*time.Hour), now.Add(26*time.Hour), nil)
+	// Still running, so it counts too
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, eventID, now.Add(-time.Hour), now.Add(2*time.Hour), nil)
+	// None of these count: past, rejected, cancelled, another resource
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, now.Add(-48*time.Hour), now.Add(-46*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, now.Add(96*time.Hour), now.Add(98*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	cancelledAt := now
+	testutil.CreateScheduleEntry(t, testDB.DB, oven, gala, now.Add(120*time.Hour), now.Add(122*time.Hour),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+	testutil.CreateScheduleEntry(t, testDB.DB, other, gala, now.Add(48*time.Hour), now.Add(50*time.Hour), nil)
+
+	service := NewResourceService(testDB.DB)
+
+	impact, err := service.GetDeleteImpact(context.Background(), oven)
+
+	require.NoError(t, err)
+	assert.Equal(t, oven, impact.ResourceID)
+	assert.Equal(t, int64(4), impact.FutureBookingCount)
+	require.Len(t, impact.Events, 2)
+	// Ordered by the first affected booking
+	assert.Equal(t, eventID, impact.Events[0].EventID)
+	assert.Equal(t, int64(2), impact.Events[0].BookingCount)
+	assert.True(t, now.Add(-time.Hour).Equal(impact.Events[0].FirstStart))
+	assert.Equal(t, gala, impact.Events[1].EventID)
+	assert.Equal(t, "Summer Gala", impact.Events[1].EventName)
+	assert.Equal(t, int64(2), impact.Events[1].BookingCount)
+
+	// Nothing was deleted
+	var count int
+	require.NoError(t, testDB.DB.QueryRow(`SELECT COUNT(*) FROM resource_schedule WHERE resource_id = $1`, oven).Scan(&count))
+	assert.Equal(t, 8, count)
+}
+
+func TestGetDeleteImpact_ResourceNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewResourceService(testDB.DB)
+
+	_, err := service.GetDeleteImpact(context.Background(), 99999)
+
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestResourceListParams(t *testing.T) {
+	params, err := resourceListParams(domain.ResourceListRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "name", params.SortField)
+	assert.False(t, params.SortDesc)
+	assert.False(t, params.MinRate.Valid)
+	assert.False(t, params.MaxRate.Valid)
+
+	params, err = resourceListParams(domain.ResourceListRequest{
+		MinRate: strPtr("7.5"),
+		MaxRate: strPtr("20"),
+		Sort:    domain.ResourceSortHourlyRate,
+		Order:   domain.SortOrderDesc,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "7.50", params.MinRate.String)
+	assert.Equal(t, "20.00", params.MaxRate.String)
+	assert.Equal(t, "hourly_rate", params.SortField)
+	assert.True(t, params.SortDesc)
+
+	bogus := domain.ResourceType("vehicles")
+	invalid := []struct {
+		name    string
+		req     domain.ResourceListRequest
+		message string
+	}{
+		{"unknown type", domain.ResourceListRequest{Type: &bogus}, "type"},
+		{"malformed min", domain.ResourceListRequest{MinRate: strPtr("cheap")}, "min_rate"},
+		{"negative max", domain.ResourceListRequest{MaxRate: strPtr("-1")}, "max_rate"},
+		{"too precise", domain.ResourceListRequest{MinRate: strPtr("1.005")}, "min_rate"},
+		{"min above max", domain.ResourceListRequest{MinRate: strPtr("30"), MaxRate: strPtr("20")}, "min_rate must not be more"},
+		{"unknown sort", domain.ResourceListRequest{Sort: "notes"}, "sort"},
+		{"unknown order", domain.ResourceListRequest{Order: "down"}, "order"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resourceListParams(tt.req)
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
+
+func TestListResources(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	now := time.Now()
+	create := func(name, resourceType string, rate *string, age time.Duration) {
+		id := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+			Name:        name,
+			Type:        resourceType,
+			HourlyRate:  rate,
+			IsAvailable: true,
+		})
+		_, err := testDB.DB.Exec(`UPDATE resources SET created_at = $1 WHERE id = $2`, now.Add(-age), id)
+		require.NoError(t, err)
+	}
+	create("Apron", testutil.ResourceTypeMaterials, strPtr("5.50"), 96*time.Hour)
+	create("Chef", testutil.ResourceTypeStaff, strPtr("45.00"), 72*time.Hour)
+	create("Van", testutil.ResourceTypeEquipment, nil, 48*time.Hour)
+	create("Oven", testutil.ResourceTypeEquipment, strPtr("20.00"), 24*time.Hour)
+
+	service := NewResourceService(testDB.DB)
+	list := func(req domain.ResourceListRequest) (int64, []string) {
+		if req.Limit == 0 {
+			req.Limit = 50
+		}
+		resp, err := service.ListResources(context.Background(), req)
+		require.NoError(t, err)
+		names := make([]string, 0, len(resp.Resources))
+		for _, r := range resp.Resources {
+			names = append(names, r.Name)
+		}
+		return resp.Total, names
+	}
+
+	t.Run("sorts", func(t *testing.T) {
+		tests := []struct {
+			sort  domain.ResourceSort
+			order domain.SortOrder
+			want  []string
+		}{
+			{"", "", []string{"Apron", "Chef", "Oven", "Van"}},
+			{domain.ResourceSortName, domain.SortOrderDesc, []string{"Van", "Oven", "Chef", "Apron"}},
+			// Types sort alphabetically, with names breaking ties
+			{domain.ResourceSortType, domain.SortOrderAsc, []string{"Oven", "Van", "Apron", "Chef"}},
+			{domain.ResourceSortType, domain.SortOrderDesc, []string{"Chef", "Apron", "Oven", "Van"}},
+			// Resources without a rate come last in both directions
+			{domain.ResourceSortHourlyRate, domain.SortOrderAsc, []string{"Apron", "Oven", "Chef", "Van"}},
+			{domain.ResourceSortHourlyRate, domain.SortOrderDesc, []string{"Chef", "Oven", "Apron", "Van"}},
+			{domain.ResourceSortCreatedAt, domain.SortOrderAsc, []string{"Apron", "Chef", "Van", "Oven"}},
+			{domain.ResourceSortCreatedAt, domain.SortOrderDesc, []string{"Oven", "Van", "Chef", "Apron"}},
+		}
+		for _, tt := range tests {
+			_, names := list(domain.ResourceListRequest{Sort: tt.sort, Order: tt.order})
+			assert.Equal(t, tt.want, names, "%s %s", tt.sort, tt.order)
+		}
+	})
+
+	t.Run("rate range", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			min, max *string
+			want     []string
+		}{
+			{"minimum only", strPtr("10"), nil, []string{"Chef", "Oven"}},
+			{"maximum is inclusive", nil, strPtr("20"), []string{"Apron", "Oven"}},
+			{"exact rate", strPtr("20"), strPtr("20.00"), []string{"Oven"}},
+			{"zero minimum leaves out unrated", strPtr("0"), nil, []string{"Apron", "Chef", "Oven"}},
+			{"empty range", strPtr("46"), nil, []string{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				total, names := list(domain.ResourceListRequest{MinRate: tt.min, MaxRate: tt.max})
+				assert.Equal(t, tt.want, names)
+				assert.Equal(t, int64(len(tt.want)), total)
+			})
+		}
+	})
+
+	t.Run("pages count every match", func(t *testing.T) {
+		equipment := domain.ResourceType(testutil.ResourceTypeEquipment)
+		total, names := list(domain.ResourceListRequest{Type: &equipment, Limit: 1, Offset: 1})
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"Van"}, names)
+	})
+}
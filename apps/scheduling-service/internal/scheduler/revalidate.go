@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxRevalidatePairs caps how many conflict pairs one revalidation returns
+const maxRevalidatePairs = 1000
+
+// Revalidate re-reads the bookings of an event or a resource and returns the
+// overlapping pairs they are part of now. It is meant for after data has been
+// fixed outside the service, so nothing is cached and nothing is windowed:
+// every live booking in the scope is checked, past and future. An event scope
+// includes pairs with bookings of other events.
+func (s *ConflictService) Revalidate(ctx context.Context, req domain.RevalidateRequest) (*domain.RevalidateResponse, error) {
+	if (req.EventID == nil) == (req.ResourceID == nil) {
+		return nil, domain.NewValidationError("exactly one of event_id or resource_id is required")
+	}
+
+	resp := &domain.RevalidateResponse{Pairs: []domain.ConflictPair{}}
+	if req.EventID != nil {
+		exists, err := s.queries.EventExists(ctx, *req.EventID)
+		if err != nil {
+			return nil, dbError("failed to get event", err)
+		}
+		if !exists {
+			return nil, domain.NewNotFoundError("event not found")
+		}
+		resp.Scope, resp.ScopeID = domain.RevalidateScopeEvent, *req.EventID
+	} else {
+		if _, err := s.queries.GetResourceByID(ctx, *req.ResourceID); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, domain.NewNotFoundError("resource not found")
+			}
+			return nil, dbError("failed to get resource", err)
+		}
+		resp.Scope, resp.ScopeID = domain.RevalidateScopeResource, *req.ResourceID
+	}
+
+	// Ask for one more than the cap to tell a full response from a truncated one
+	resp.CheckedAt = time.Now().UTC()
+	rows, err := s.queries.ListScopedConflictPairs(ctx, repository.ListScopedConflictPairsParams{
+		EventID:    nullInt32(req.EventID),
+		ResourceID: nullInt32(req.ResourceID),
+		LimitCount: maxRevalidatePairs + 1,
+	})
+	if err != nil {
+		return nil, dbError("failed to list conflicts", err)
+	}
+	if len(rows) > maxRevalidatePairs {
+		rows = rows[:maxRevalidatePairs]
+		resp.Truncated = true
+	}
+
+	for _, row := range rows {
+		resp.Pairs = append(resp.Pairs, conflictPairFromRow(repository.ListAllConflictPairsRow(row)))
+	}
+	resp.ConflictCount = len(resp.Pairs)
+	resp.HasConflicts = resp.ConflictCount > 0
+	return resp, nil
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestRevalidate_EventScope(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef", Type: testutil.ResourceTypeStaff})
+	van := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Van", Type: testutil.ResourceTypeEquipment})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	ours := testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	theirs := testutil.CreateScheduleEntry(t, testDB.DB, chef, otherEvent, baseDay.Add(11*time.Hour), baseDay.Add(14*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, van, eventID, baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	// A double booking between other events is out of scope
+	testutil.CreateScheduleEntry(t, testDB.DB, van, otherEvent, baseDay.Add(20*time.Hour), baseDay.Add(22*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, van, otherEvent, baseDay.Add(21*time.Hour), baseDay.Add(23*time.Hour), nil)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	result, err := service.Revalidate(context.Background(), domain.RevalidateRequest{EventID: &eventID})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RevalidateScopeEvent, result.Scope)
+	assert.Equal(t, eventID, result.ScopeID)
+	assert.True(t, result.HasConflicts)
+	assert.False(t, result.Truncated)
+	require.Len(t, result.Pairs, 1)
+	assert.Equal(t, chef, result.Pairs[0].ResourceID)
+	assert.Equal(t, ours, result.Pairs[0].Booking.ScheduleID)
+	assert.Equal(t, theirs, result.Pairs[0].ConflictsWith.ScheduleID)
+	assert.Equal(t, otherEvent, result.Pairs[0].ConflictsWith.EventID)
+
+	// Fix the data behind the service's back and revalidate
+	_, err = testDB.DB.Exec(`UPDATE resource_schedule SET start_time = $1 WHERE id = $2`,
+		baseDay.Add(12*time.Hour), theirs)
+	require.NoError(t, err)
+
+	result, err = service.Revalidate(context.Background(), domain.RevalidateRequest{EventID: &eventID})
+	require.NoError(t, err)
+	assert.False(t, result.HasConflicts)
+	assert.Equal(t, 0, result.ConflictCount)
+	assert.Empty(t, result.Pairs)
+}
+
+func TestRevalidate_ResourceScope(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{Name: "Chef", Type: testutil.ResourceTypeStaff})
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), nil)
+
+	result, err := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil).Revalidate(context.Background(), domain.RevalidateRequest{ResourceID: &chef})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RevalidateScopeResource, result.Scope)
+	assert.Equal(t, 1, result.ConflictCount)
+}
+
+func TestRevalidate_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	missing := int32(99999)
+
+	_, err := service.Revalidate(context.Background(), domain.RevalidateRequest{EventID: &missing})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+
+	_, err = service.Revalidate(context.Background(), domain.RevalidateRequest{ResourceID: &missing})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestRevalidate_InvalidScope(t *testing.T) {
+	service := NewConflictService(nil, nil, nil)
+	id := int32(1)
+
+	_, err := service.Revalidate(context.Background(), domain.RevalidateRequest{})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	_, err = service.Revalidate(context.Background(), domain.RevalidateRequest{EventID: &id, ResourceID: &id})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
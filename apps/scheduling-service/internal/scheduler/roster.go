@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// daysPerWeek is the length of a roster
+const daysPerWeek = 7
+
+// GetWeeklyRoster lays out a resource's bookings for the Monday to Sunday week
+// containing req.WeekStart. Days follow the resource's timezone, so a booking
+// that runs past local midnight is split across the days it touches.
+func (s *AvailabilityService) GetWeeklyRoster(ctx context.Context, req domain.WeeklyRosterRequest) (*domain.WeeklyRosterResponse, error) {
+	loc, err := s.resourceLocation(ctx, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	monday := weekMonday(req.WeekStart, loc)
+	rows, err := s.queries.ListOverlappingResourceSchedule(ctx, repository.ListOverlappingResourceScheduleParams{
+		ResourceID: req.ResourceID,
+		RangeEnd:   monday.AddDate(0, 0, daysPerWeek),
+		RangeStart: monday,
+	})
+	if err != nil {
+		return nil, dbError("failed to get resource schedule", err)
+	}
+
+	entries := make([]domain.ScheduleEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := toDomainScheduleEntry(repository.GetScheduleEntryByIDRow(row))
+		entry.StartTime = entry.StartTime.In(loc)
+		entry.EndTime = entry.EndTime.In(loc)
+		entries = append(entries, entry)
+	}
+
+	days, total := buildRoster(entries, monday)
+	return &domain.WeeklyRosterResponse{
+		ResourceID: req.ResourceID,
+		Timezone:   loc.String(),
+		WeekStart:  monday.Format(time.DateOnly),
+		Days:       days,
+		TotalHours: secondsToHours(int64(total / time.Second)),
+	}, nil
+}
+
+// weekMonday returns local midnight at the start of the Monday on or before the
+// calendar date of t
+func weekMonday(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.Date()
+	sinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(y, m, d-sinceMonday, 0, 0, 0, 0, loc)
+}
+
+// buildRoster splits entries into the seven days starting at monday and sums
+// each day's booked time. Entries are merged first so double-booked time counts
+// once. Days are built from calendar dates rather than fixed 24h steps so a DST
+// change doesn't shift the boundaries.
+func buildRoster(entries []domain.ScheduleEntry, monday time.Time) ([]domain.RosterDay, time.Duration) {
+	y, m, d := monday.Date()
+	loc := monday.Location()
+
+	ranges := make([]domain.TimeRange, 0, len(entries))
+	for _, e := range entries {
+		ranges = append(ranges, domain.TimeRange{Start: e.StartTime, End: e.EndTime})
+	}
+	busy := mergeBusy(ranges)
+
+	days := make([]domain.RosterDay, 0, daysPerWeek)
+	var total time.Duration
+	for i := 0; i < daysPerWeek; i++ {
+		dayRange := domain.TimeRange{
+			Start: time.Date(y, m, d+i, 0, 0, 0, 0, loc),
+			End:   time.Date(y, m, d+i+1, 0, 0, 0, 0, loc),
+		}
+
+		day := domain.RosterDay{
+			Date:    dayRange.Start.Format(time.DateOnly),
+			Weekday: dayRange.Start.Weekday().String(),
+			Entries: []domain.ScheduleEntry{},
+		}
+		for _, e := range entries {
+			if e.StartTime.Before(dayRange.End) && e.EndTime.After(dayRange.Start) {
+				day.Entries = append(day.Entries, e)
+			}
+		}
+
+		var booked time.Duration
+		for _, r := range busy {
+			booked += overlapDuration(r, dayRange)
+		}
+		day.BookedHours = secondsToHours(int64(booked / time.Second))
+		total += booked
+		days = append(days, day)
+	}
+	return days, total
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestWeekMonday(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	want := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	for _, d := range []int{16, 18, 22} {
+		got := weekMonday(time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC), time.UTC)
+		assert.True(t, want.Equal(got), "June %d", d)
+	}
+
+	// The date is kept but midnight is local to the resource
+	got := weekMonday(time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC), chicago)
+	assert.True(t, time.Date(2025, 6, 16, 0, 0, 0, 0, chicago).Equal(got))
+}
+
+func TestBuildRoster_SplitsAcrossMidnight(t *testing.T) {
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	entry := func(id int32, start, end time.Time) domain.ScheduleEntry {
+		return domain.ScheduleEntry{ID: id, StartTime: start, EndTime: end}
+	}
+
+	entries := []domain.ScheduleEntry{
+		entry(1, monday.Add(9*time.Hour), monday.Add(17*time.Hour)),
+		entry(2, monday.Add(12*time.Hour), monday.Add(13*time.Hour)), // inside 1
+		// Friday 22:00 to Saturday 02:00
+		entry(3, monday.AddDate(0, 0, 4).Add(22*time.Hour), monday.AddDate(0, 0, 5).Add(2*time.Hour)),
+	}
+
+	days, total := buildRoster(entries, monday)
+
+	require.Len(t, days, 7)
+	assert.Equal(t, "2025-06-16", days[0].Date)
+	assert.Equal(t, "Monday", days[0].Weekday)
+	assert.Equal(t, "Sunday", days[6].Weekday)
+
+	assert.Len(t, days[0].Entries, 2)
+	assert.Equal(t, 8.0, days[0].BookedHours)
+	assert.Empty(t, days[1].Entries)
+	assert.Equal(t, 0.0, days[1].BookedHours)
+
+	require.Len(t, days[4].Entries, 1)
+	require.Len(t, days[5].Entries, 1)
+	assert.Equal(t, int32(3), days[5].Entries[0].ID)
+	assert.Equal(t, 2.0, days[4].BookedHours)
+	assert.Equal(t, 2.0, days[5].BookedHours)
+
+	assert.Equal(t, 12*time.Hour, total)
+}
+
+func TestGetWeeklyRoster_MultipleDays(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	book := func(day, startHour, endHour int) {
+		dayStart := monday.AddDate(0, 0, day)
+		testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID,
+			dayStart.Add(time.Duration(startHour)*time.Hour), dayStart.Add(time.Duration(endHour)*time.Hour), nil)
+	}
+	book(0, 9, 13)  // Monday
+	book(2, 8, 16)  // Wednesday
+	book(6, 20, 26) // Sunday into the next week
+	book(7, 9, 12)  // next Monday
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	// A mid-week date is normalized to its Monday
+	result, err := service.GetWeeklyRoster(context.Background(), domain.WeeklyRosterRequest{
+		ResourceID: chef,
+		WeekStart:  monday.AddDate(0, 0, 3),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-16", result.WeekStart)
+	assert.Equal(t, "UTC", result.Timezone)
+	require.Len(t, result.Days, 7)
+	assert.Equal(t, 4.0, result.Days[0].BookedHours)
+	assert.Equal(t, 8.0, result.Days[2].BookedHours)
+	assert.Equal(t, 4.0, result.Days[6].BookedHours)
+	assert.Equal(t, 16.0, result.TotalHours)
+}
+
+func TestGetWeeklyRoster_ResourceNotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewAvailabilityService(testDB.DB, NewFlagService(testDB.DB))
+
+	_, err := service.GetWeeklyRoster(context.Background(), domain.WeeklyRosterRequest{
+		ResourceID: 99999,
+		WeekStart:  time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC),
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeNotFound, err.(*domain.DomainError).Code)
+}
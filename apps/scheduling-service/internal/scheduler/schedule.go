@@ -0,0 +1,604 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// maxCancellationReasonLength caps the free-text reason stored with a cancellation
+const maxCancellationReasonLength = 500
+
+// ScheduleService manages the lifecycle of individual schedule entries
+type ScheduleService struct {
+	db        *sql.DB
+	queries   *repository.Queries
+	conflicts *ConflictService
+	// publisher receives a schedule.changed event for every entry changed
+	publisher events.Publisher
+	// clock tells the time past bookings are judged against
+	clock func() time.Time
+}
+
+// NewScheduleService creates a new schedule entry service checking bookings
+// with conflicts and publishing its changes through publisher
+func NewScheduleService(db *sql.DB, conflicts *ConflictService, publisher events.Publisher) *ScheduleService {
+	return &ScheduleService{
+		db:        db,
+		queries:   repository.NewQueries(db),
+		conflicts: conflicts,
+		publisher: publisher,
+		clock:     time.Now,
+	}
+}
+
+// GetEntry retrieves a schedule entry by its ID
+func (s *ScheduleService) GetEntry(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	row, err := s.queries.GetScheduleEntryByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("schedule entry not found")
+		}
+		return nil, dbError("failed to get schedule entry", err)
+	}
+
+	entry := toDomainScheduleEntry(row)
+	return &entry, nil
+}
+
+// CreateEntryChecked books a resource for an event. The booking is refused
+// with the blocking conflicts if the resource already has a hard conflict in
+// that range. Equipment and materials are checked against their free units
+// instead, and the response reports how many remain. The check and the insert
+// run in one transaction holding a lock on the resource, so two concurrent
+// requests can't both pass the check and double-book it.
+func (s *ScheduleService) CreateEntryChecked(ctx context.Context, req domain.ScheduleEntryRequest) (*domain.ScheduleEntry, error) {
+	return s.createEntry(ctx, req, nil)
+}
+
+// entryClaim is something a create holds in the transaction that books the
+// entry, such as an idempotency key. An error from either step undoes the
+// booking.
+type entryClaim struct {
+	// hold runs under the resource lock, before the booking is checked
+	hold func(q *repository.Queries) error
+	// store runs right after the insert, with the new entry's ID
+	store func(q *repository.Queries, id int32) error
+}
+
+// createEntry is CreateEntryChecked, holding claim if it is set
+func (s *ScheduleService) createEntry(ctx context.Context, req domain.ScheduleEntryRequest, claim *entryClaim) (*domain.ScheduleEntry, error) {
+	resource, err := s.validateEntryRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var hold func(q *repository.Queries) error
+	if claim != nil {
+		hold = claim.hold
+	}
+	entry, err := s.writeEntry(ctx, req, resource, nil, hold, func(q *repository.Queries) (int32, error) {
+		created, err := q.CreateScheduleEntry(ctx, repository.CreateScheduleEntryParams{
+			ResourceID: req.ResourceID,
+			EventID:    req.EventID,
+			TaskID:     nullInt32(req.TaskID),
+			StartTime:  req.StartTime,
+			EndTime:    req.EndTime,
+			Notes:      nullString(req.Notes),
+			Quantity:   req.Quantity,
+			CreatedBy:  nullInt32(req.CreatedBy),
+		})
+		if err != nil {
+			return 0, entryWriteError("failed to create schedule entry", err)
+		}
+		if claim != nil {
+			if err := claim.store(q, created.ID); err != nil {
+				return 0, err
+			}
+		}
+		return created.ID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeCreated, *entry)
+	return entry, nil
+}
+
+// UpdateEntry replaces an entry's resource, event, task, time range, notes,
+// and quantity. The entry never conflicts with itself; any other hard conflict
+// refuses the update. Cancelled entries can't be edited. As with creation,
+// the check and the write hold a lock on the target resource.
+func (s *ScheduleService) UpdateEntry(ctx context.Context, id int32, req domain.ScheduleEntryRequest) (*domain.ScheduleEntry, error) {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.CancelledAt != nil {
+		return nil, domain.NewConflictError("schedule entry is cancelled")
+	}
+
+	resource, err := s.validateEntryRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.writeEntry(ctx, req, resource, &id, nil, func(q *repository.Queries) (int32, error) {
+		_, err := q.UpdateScheduleEntry(ctx, repository.UpdateScheduleEntryParams{
+			ResourceID: req.ResourceID,
+			EventID:    req.EventID,
+			TaskID:     nullInt32(req.TaskID),
+			StartTime:  req.StartTime,
+			EndTime:    req.EndTime,
+			Notes:      nullString(req.Notes),
+			Quantity:   req.Quantity,
+			ID:         id,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, domain.NewNotFoundError("schedule entry not found")
+			}
+			return 0, entryWriteError("failed to update schedule entry", err)
+		}
+		return id, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeUpdated, *updated)
+	return updated, nil
+}
+
+// writeEntry locks the booking's resource, checks the booking still fits, and
+// applies write, all in one transaction. A booking touching a blackout day is
+// refused. Time outside the resource's working hours is reported as a warning
+// on the entry rather than refused. write returns the ID of the entry it
+// created or updated. exclude is the entry being replaced, if any. hold, if
+// set, runs once the resource is locked, before anything is checked.
+func (s *ScheduleService) writeEntry(ctx context.Context, req domain.ScheduleEntryRequest, resource repository.Resource, exclude *int32, hold func(q *repository.Queries) error, write func(q *repository.Queries) (int32, error)) (*domain.ScheduleEntry, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback()
+	q := repository.New(repository.WithQueryTimeout(tx))
+
+	if _, err := q.LockResource(ctx, req.ResourceID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewValidationError("resource not found")
+		}
+		return nil, dbError("failed to lock resource", err)
+	}
+	if hold != nil {
+		if err := hold(q); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkBlackout(ctx, q, domain.TimeRange{Start: req.StartTime, End: req.EndTime}); err != nil {
+		return nil, err
+	}
+	remaining, err := s.checkEntryAvailable(ctx, q, req, resource, exclude)
+	if err != nil {
+		return nil, err
+	}
+	warning, err := s.outOfHoursWarning(ctx, q, req, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := write(q)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := q.GetScheduleEntryByID(ctx, id)
+	if err != nil {
+		return nil, dbError("failed to get schedule entry", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, dbError("failed to commit schedule entry", err)
+	}
+
+	entry := toDomainScheduleEntry(row)
+	entry.RemainingQuantity = remaining
+	if warning != nil {
+		entry.Warnings = []domain.Conflict{*warning}
+	}
+	return &entry, nil
+}
+
+// DeleteEntry removes an entry for good. Use CancelEntry to keep it for history.
+func (s *ScheduleService) DeleteEntry(ctx context.Context, id int32) error {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.queries.DeleteScheduleEntry(ctx, id); err != nil {
+		return dbError("failed to delete schedule entry", err)
+	}
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeDeleted, *entry)
+	return nil
+}
+
+// entryWriteError maps a failed schedule entry write onto a domain error. The
+// resource_schedule_no_overlap constraint rejects an approved booking of a
+// whole resource that overlaps another, should one slip past the service's own
+// check or come from another writer.
+func entryWriteError(message string, err error) error {
+	if repository.IsExclusionViolation(err) {
+		return domain.NewConflictError("resource is already booked in the requested time range")
+	}
+	return dbError(message, err)
+}
+
+// validateEntryRequest checks the time range and quantity and that the
+// resource, event, and task exist, with the task belonging to the event. A
+// request rejecting past bookings must start no earlier than now, give or
+// take a minute. It defaults the quantity to one and returns the resource.
+func (s *ScheduleService) validateEntryRequest(ctx context.Context, req *domain.ScheduleEntryRequest) (repository.Resource, error) {
+	var resource repository.Resource
+	if req.ResourceID <= 0 {
+		return resource, domain.NewValidationError("resource_id is required")
+	}
+	if req.EventID <= 0 {
+		return resource, domain.NewValidationError("event_id is required")
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return resource, domain.NewValidationError("start_time and end_time are required")
+	}
+	if !req.EndTime.After(req.StartTime) {
+		return resource, domain.NewValidationError("end_time must be after start_time")
+	}
+	if req.RejectPast {
+		if err := checkNotPast(s.clock, req.StartTime); err != nil {
+			return resource, err
+		}
+	}
+	if req.Quantity < 0 {
+		return resource, domain.NewValidationError("quantity must be positive")
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+
+	resource, err := s.queries.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return resource, domain.NewValidationError("resource not found")
+		}
+		return resource, dbError("failed to get resource", err)
+	}
+	if req.Quantity > 1 && !hasUnits(resource.Type) {
+		return resource, domain.NewValidationError("quantity only applies to equipment and materials")
+	}
+
+	exists, err := s.queries.EventExists(ctx, req.EventID)
+	if err != nil {
+		return resource, dbError("failed to get event", err)
+	}
+	if !exists {
+		return resource, domain.NewValidationError("event not found")
+	}
+
+	if req.TaskID != nil {
+		taskEventID, err := s.queries.GetTaskEventID(ctx, *req.TaskID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return resource, domain.NewValidationError("task not found")
+			}
+			return resource, dbError("failed to get task", err)
+		}
+		if taskEventID != req.EventID {
+			return resource, domain.NewValidationError("task belongs to a different event")
+		}
+	}
+	return resource, nil
+}
+
+// hasUnits reports whether bookings of a resource type take a number of
+// interchangeable units rather than the whole resource
+func hasUnits(t repository.ResourceType) bool {
+	return t == repository.ResourceTypeEquipment || t == repository.ResourceTypeMaterials
+}
+
+// checkEntryAvailable refuses a booking the resource can't take. Equipment and
+// materials are checked for downtime and free units, and the units left over
+// are returned; other resources are checked for conflicts and nil is returned.
+// A booking refused over conflicts or downtime is posted to the conflict
+// webhook.
+func (s *ScheduleService) checkEntryAvailable(ctx context.Context, q *repository.Queries, req domain.ScheduleEntryRequest, resource repository.Resource, exclude *int32) (*int32, error) {
+	if !hasUnits(resource.Type) {
+		return nil, s.checkEntryConflicts(ctx, q, req, exclude)
+	}
+	downtime, err := s.conflicts.downtimeConflicts(ctx, q, domain.CheckConflictsRequest{
+		ResourceIDs: []int32{req.ResourceID},
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(downtime) > 0 {
+		s.conflicts.notifyConflicts(ctx, &domain.CheckConflictsResponse{
+			HasConflicts:     true,
+			HasHardConflicts: true,
+			ConflictCount:    len(downtime),
+			TotalConflicts:   len(downtime),
+			Conflicts:        downtime,
+		})
+		return nil, domain.NewBookingConflictError("resource is under maintenance in the requested time range", downtime)
+	}
+	remaining, err := s.checkEntryCapacity(ctx, q, req, exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &remaining, nil
+}
+
+// checkEntryCapacity finds the most units of the resource in use at any
+// moment of the range and refuses the booking if it needs more than are left.
+// It returns the units still free at that moment once the booking is added.
+// Pending entries only take units when the pending_bookings_block flag is on,
+// matching how conflict checks treat them.
+func (s *ScheduleService) checkEntryCapacity(ctx context.Context, q *repository.Queries, req domain.ScheduleEntryRequest, exclude *int32) (int32, error) {
+	quantity, err := q.GetResourceQuantity(ctx, req.ResourceID)
+	if err != nil {
+		return 0, dbError("failed to get resource quantity", err)
+	}
+
+	rows, err := q.ListResourceUnitUsage(ctx, repository.ListResourceUnitUsageParams{
+		ResourceID:        req.ResourceID,
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		ExcludeScheduleID: nullInt32(exclude),
+	})
+	if err != nil {
+		return 0, dbError("failed to get resource usage", err)
+	}
+
+	pendingBlocks := len(rows) > 0 && s.conflicts.flags.Enabled(ctx, FlagPendingBookingsBlock)
+	usage := make([]unitUsage, 0, len(rows))
+	for _, row := range rows {
+		if row.ApprovalStatus == repository.ApprovalStatusPending && !pendingBlocks {
+			continue
+		}
+		usage = append(usage, unitUsage{
+			occupied: domain.TimeRange{Start: row.StartTime, End: row.OccupiedUntil},
+			units:    row.Quantity,
+		})
+	}
+
+	free := quantity - peakUnits(usage, domain.TimeRange{Start: req.StartTime, End: req.EndTime})
+	if free < req.Quantity {
+		if free < 0 {
+			free = 0
+		}
+		return 0, domain.NewConflictError(fmt.Sprintf("only %d of %d units are free in the requested time range", free, quantity))
+	}
+	return free - req.Quantity, nil
+}
+
+// checkEntryConflicts refuses a booking that has a hard conflict, returning
+// the conflicts with the error and posting them to the conflict webhook. Soft
+// conflicts, against entries awaiting approval, don't block it.
+func (s *ScheduleService) checkEntryConflicts(ctx context.Context, q *repository.Queries, req domain.ScheduleEntryRequest, exclude *int32) error {
+	result, err := s.conflicts.checkConflicts(ctx, q, domain.CheckConflictsRequest{
+		ResourceIDs:       []int32{req.ResourceID},
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		ExcludeScheduleID: exclude,
+	})
+	if err != nil {
+		return err
+	}
+	if result.HasHardConflicts {
+		s.conflicts.notifyConflicts(ctx, result)
+		if hasDowntime(result.Conflicts) {
+			return domain.NewBookingConflictError("resource is under maintenance in the requested time range", result.Conflicts)
+		}
+		return domain.NewBookingConflictError("resource is already booked in the requested time range", result.Conflicts)
+	}
+	return nil
+}
+
+// ApproveEntry approves a pending entry. Approval is refused if the entry would
+// then overlap another booking that already blocks the resource or a blackout
+// day, or, for a pooled resource, if too few units are left for it. As with
+// creation, the check and the approval hold a lock on the resource, so two
+// overlapping pending entries approved at once can't both succeed.
+func (s *ScheduleService) ApproveEntry(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	entry, err := s.pendingEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := s.queries.GetScheduleEntryQuantity(ctx, id)
+	if err != nil {
+		return nil, dbError("failed to get schedule entry quantity", err)
+	}
+	resource, err := s.queries.GetResourceByID(ctx, entry.ResourceID)
+	if err != nil {
+		return nil, dbError("failed to get resource", err)
+	}
+
+	req := domain.ScheduleEntryRequest{
+		ResourceID: entry.ResourceID,
+		EventID:    entry.EventID,
+		TaskID:     entry.TaskID,
+		StartTime:  entry.StartTime,
+		EndTime:    entry.EndTime,
+		Quantity:   quantity,
+	}
+	approved, err := s.writeEntry(ctx, req, resource, &id, nil, func(q *repository.Queries) (int32, error) {
+		_, err := q.UpdateScheduleApprovalStatus(ctx, repository.UpdateScheduleApprovalStatusParams{
+			ApprovalStatus: repository.ApprovalStatusApproved,
+			ID:             id,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// Decided concurrently between loading and locking
+				return 0, domain.NewConflictError("schedule entry is no longer pending")
+			}
+			return 0, entryWriteError("failed to update approval status", err)
+		}
+		return id, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeUpdated, *approved)
+	return approved, nil
+}
+
+// RejectEntry rejects a pending entry. Rejected entries no longer conflict with
+// other bookings.
+func (s *ScheduleService) RejectEntry(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	if _, err := s.pendingEntry(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.decide(ctx, id, repository.ApprovalStatusRejected)
+}
+
+// CancelEntry marks an entry cancelled with an optional reason. Unlike deleting
+// it, the entry is kept for history, but it no longer conflicts with other
+// bookings or shows up in availability.
+func (s *ScheduleService) CancelEntry(ctx context.Context, id int32, req domain.CancelEntryRequest) (*domain.ScheduleEntry, error) {
+	reason, err := cancellationReason(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.queries.CancelScheduleEntry(ctx, repository.CancelScheduleEntryParams{
+		CancellationReason: reason,
+		ID:                 id,
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, dbError("failed to cancel schedule entry", err)
+		}
+		// Either the entry doesn't exist or it was already cancelled
+		if _, err := s.GetEntry(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.NewConflictError("schedule entry is already cancelled")
+	}
+
+	return s.changedEntry(ctx, id)
+}
+
+// cancellationReason trims the optional reason of a cancellation and checks
+// its length. A blank reason is stored as NULL.
+func cancellationReason(req domain.CancelEntryRequest) (sql.NullString, error) {
+	if req.Reason == nil {
+		return sql.NullString{}, nil
+	}
+	trimmed := strings.TrimSpace(*req.Reason)
+	if len(trimmed) > maxCancellationReasonLength {
+		return sql.NullString{}, domain.NewValidationError(fmt.Sprintf("reason must be at most %d characters", maxCancellationReasonLength))
+	}
+	return sql.NullString{String: trimmed, Valid: trimmed != ""}, nil
+}
+
+// pendingEntry loads an entry and checks it is still awaiting a decision
+func (s *ScheduleService) pendingEntry(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.CancelledAt != nil {
+		return nil, domain.NewConflictError("schedule entry is cancelled")
+	}
+	if entry.ApprovalStatus != domain.ApprovalStatusPending {
+		return nil, domain.NewConflictError(fmt.Sprintf("schedule entry is already %s", entry.ApprovalStatus))
+	}
+	return entry, nil
+}
+
+// decide records the approval decision and returns the updated entry
+func (s *ScheduleService) decide(ctx context.Context, id int32, status repository.ApprovalStatus) (*domain.ScheduleEntry, error) {
+	_, err := s.queries.UpdateScheduleApprovalStatus(ctx, repository.UpdateScheduleApprovalStatusParams{
+		ApprovalStatus: status,
+		ID:             id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Decided concurrently between the check and the update
+			return nil, domain.NewConflictError("schedule entry is no longer pending")
+		}
+		return nil, dbError("failed to update approval status", err)
+	}
+
+	return s.changedEntry(ctx, id)
+}
+
+// changedEntry reloads an entry that has just been updated in place and
+// publishes the change
+func (s *ScheduleService) changedEntry(ctx context.Context, id int32) (*domain.ScheduleEntry, error) {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	publishScheduleChange(ctx, s.publisher, domain.ScheduleChangeUpdated, *entry)
+	return entry, nil
+}
+
+// toDomainScheduleEntry converts a schedule entry row to its domain representation
+func toDomainScheduleEntry(row repository.GetScheduleEntryByIDRow) domain.ScheduleEntry {
+	entry := domain.ScheduleEntry{
+		ID:             row.ID,
+		ResourceID:     row.ResourceID,
+		EventID:        row.EventID,
+		EventName:      row.EventName,
+		StartTime:      row.StartTime,
+		EndTime:        row.EndTime,
+		ApprovalStatus: domain.ApprovalStatus(row.ApprovalStatus),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+
+	if row.TaskID.Valid {
+		entry.TaskID = &row.TaskID.Int32
+	}
+	if row.TaskTitle.Valid {
+		entry.TaskTitle = &row.TaskTitle.String
+	}
+	if row.Notes.Valid {
+		entry.Notes = &row.Notes.String
+	}
+	if row.CancelledAt.Valid {
+		entry.CancelledAt = &row.CancelledAt.Time
+	}
+	if row.CancellationReason.Valid {
+		entry.CancellationReason = &row.CancellationReason.String
+	}
+	if row.RecurrenceGroupID.Valid {
+		entry.RecurrenceGroupID = &row.RecurrenceGroupID.Int32
+	}
+	if row.CreatedBy.Valid {
+		entry.CreatedBy = &row.CreatedBy.Int32
+	}
+
+	return entry
+}
+
+// nullInt32 converts an optional value to its nullable column form
+func nullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
+
+// nullString converts an optional value to its nullable column form
+func nullString(v *string) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *v, Valid: true}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// TopicScheduleChanged is the topic schedule entry changes are published on
+const TopicScheduleChanged = "schedule.changed"
+
+// publishScheduleChange announces a change to entry through publisher. A
+// failure to publish is logged, never returned: the change has already been
+// made.
+func publishScheduleChange(ctx context.Context, publisher events.Publisher, change domain.ScheduleChange, entry domain.ScheduleEntry) {
+	err := publisher.Publish(ctx, TopicScheduleChanged, domain.ScheduleChangedEvent{
+		Change:     change,
+		Entry:      entry,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		logger.Get().WithRequestID(ctx).Error().Err(err).Str("change", string(change)).Int32("schedule_id", entry.ID).
+			Msg("Failed to publish schedule change")
+	}
+}
+
+// publishScheduleChanges announces the same change to each of entries
+func publishScheduleChanges(ctx context.Context, publisher events.Publisher, change domain.ScheduleChange, entries []domain.ScheduleEntry) {
+	for _, entry := range entries {
+		publishScheduleChange(ctx, publisher, change, entry)
+	}
+}
+
+// loadEntries loads the entries with the given IDs through q, so changes made
+// in a transaction can be announced once it commits, or a deletion announced
+// with the entries as they were
+func loadEntries(ctx context.Context, q *repository.Queries, ids []int32) ([]domain.ScheduleEntry, error) {
+	entries := make([]domain.ScheduleEntry, 0, len(ids))
+	for _, id := range ids {
+		row, err := q.GetScheduleEntryByID(ctx, id)
+		if err != nil {
+			return nil, dbError("failed to get schedule entry", err)
+		}
+		entries = append(entries, toDomainScheduleEntry(row))
+	}
+	return entries, nil
+}
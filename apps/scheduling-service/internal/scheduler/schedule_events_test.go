@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+// fakePublisher records the schedule changes it is sent
+type fakePublisher struct {
+	mu      sync.Mutex
+	changes []domain.ScheduleChangedEvent
+}
+
+func (p *fakePublisher) Publish(_ context.Context, topic string, payload interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if topic == TopicScheduleChanged {
+		p.changes = append(p.changes, payload.(domain.ScheduleChangedEvent))
+	}
+	return nil
+}
+
+// take returns the changes recorded so far and forgets them
+func (p *fakePublisher) take() []domain.ScheduleChangedEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	changes := p.changes
+	p.changes = nil
+	return changes
+}
+
+func TestScheduleService_PublishesChanges(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), fake)
+	req := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	}
+
+	created, err := service.CreateEntryChecked(context.Background(), req)
+	require.NoError(t, err)
+	req.EndTime = baseDay.Add(13 * time.Hour)
+	_, err = service.UpdateEntry(context.Background(), created.ID, req)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteEntry(context.Background(), created.ID))
+
+	// A refused change publishes nothing
+	err = service.DeleteEntry(context.Background(), created.ID)
+	require.Error(t, err)
+
+	require.Len(t, fake.changes, 3)
+	assert.Equal(t, domain.ScheduleChangeCreated, fake.changes[0].Change)
+	assert.Equal(t, domain.ScheduleChangeUpdated, fake.changes[1].Change)
+	assert.True(t, req.EndTime.Equal(fake.changes[1].Entry.EndTime))
+	assert.Equal(t, domain.ScheduleChangeDeleted, fake.changes[2].Change)
+	for _, change := range fake.changes {
+		assert.Equal(t, created.ID, change.Entry.ID)
+		assert.False(t, change.OccurredAt.IsZero())
+	}
+}
+
+func TestAutoReschedule_PublishesMove(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	clash := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(11*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), fake)
+	_, err := service.AutoReschedule(context.Background(), clash)
+	require.NoError(t, err)
+
+	changes := fake.take()
+	require.Len(t, changes, 1)
+	assert.Equal(t, domain.ScheduleChangeUpdated, changes[0].Change)
+	assert.Equal(t, clash, changes[0].Entry.ID)
+	assert.True(t, baseDay.Add(12*time.Hour).Equal(changes[0].Entry.StartTime))
+
+	// Now that it fits, it stays where it is and nothing is published
+	_, err = service.AutoReschedule(context.Background(), clash)
+	require.NoError(t, err)
+	assert.Empty(t, fake.take())
+}
+
+func TestConsolidate_PublishesChanges(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	first := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil)
+	second := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(11*time.Hour), baseDay.Add(13*time.Hour), nil)
+
+	_, err := NewConsolidationService(testDB.DB, fake).Consolidate(context.Background(), domain.ConsolidateRequest{
+		ResourceID:  resourceID,
+		ScheduleIDs: []int32{first, second},
+	})
+	require.NoError(t, err)
+
+	changes := fake.take()
+	require.Len(t, changes, 2)
+	assert.Equal(t, domain.ScheduleChangeDeleted, changes[0].Change)
+	assert.Equal(t, second, changes[0].Entry.ID)
+	assert.Equal(t, domain.ScheduleChangeUpdated, changes[1].Change)
+	assert.Equal(t, first, changes[1].Entry.ID)
+	assert.True(t, baseDay.Add(13*time.Hour).Equal(changes[1].Entry.EndTime))
+}
+
+func TestMergeInto_PublishesMovedEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	userID, clientID, targetID := testutil.SetupBaseData(t, testDB.DB)
+	sourceID := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	movedIDs := []int32{
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, sourceID,
+			baseDay.Add(9*time.Hour), baseDay.Add(11*time.Hour), nil),
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, sourceID,
+			baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil),
+	}
+
+	result, err := NewEventMergeService(testDB.DB, fake).MergeInto(context.Background(), sourceID, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.MovedCount)
+
+	changes := fake.take()
+	require.Len(t, changes, 2)
+	published := []int32{}
+	for _, change := range changes {
+		assert.Equal(t, domain.ScheduleChangeUpdated, change.Change)
+		assert.Equal(t, targetID, change.Entry.EventID)
+		published = append(published, change.Entry.ID)
+	}
+	assert.ElementsMatch(t, movedIDs, published)
+}
+
+func TestRecurrence_PublishesChanges(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), fake)
+	start := time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)
+	series, err := service.CreateRecurring(context.Background(), domain.RecurringEntryRequest{
+		Entry: domain.ScheduleEntryRequest{
+			ResourceID: resourceID,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Hour),
+		},
+		Rule: domain.RecurrenceRule{
+			Frequency: domain.FrequencyWeekly,
+			Until:     time.Date(2025, 6, 22, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, series.Created, 3)
+
+	assertChanges := func(change domain.ScheduleChange) []domain.ScheduleChangedEvent {
+		changes := fake.take()
+		require.Len(t, changes, 3)
+		for i, c := range changes {
+			assert.Equal(t, change, c.Change)
+			assert.Equal(t, series.Created[i].ID, c.Entry.ID)
+		}
+		return changes
+	}
+	assertChanges(domain.ScheduleChangeCreated)
+
+	_, err = service.CancelRecurrenceGroup(context.Background(), series.RecurrenceGroupID, domain.CancelEntryRequest{})
+	require.NoError(t, err)
+	for _, c := range assertChanges(domain.ScheduleChangeUpdated) {
+		assert.NotNil(t, c.Entry.CancelledAt)
+	}
+
+	_, err = service.DeleteRecurrenceGroup(context.Background(), series.RecurrenceGroupID, nil)
+	require.NoError(t, err)
+	assertChanges(domain.ScheduleChangeDeleted)
+}
+
+func TestCancelStaleByTaskStatus_PublishesCancellations(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+	fake := &fakePublisher{}
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	done := testutil.CreateTask(t, testDB.DB, eventID, &testutil.TaskOpts{Status: "completed"})
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	tomorrow := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	staleID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		tomorrow, tomorrow.Add(2*time.Hour), &testutil.ScheduleEntryOpts{TaskID: &done})
+
+	_, err := NewIntegrityService(testDB.DB, fake).CancelStaleByTaskStatus(context.Background())
+	require.NoError(t, err)
+
+	changes := fake.take()
+	require.Len(t, changes, 1)
+	assert.Equal(t, domain.ScheduleChangeUpdated, changes[0].Change)
+	assert.Equal(t, staleID, changes[0].Entry.ID)
+	assert.NotNil(t, changes[0].Entry.CancelledAt)
+}
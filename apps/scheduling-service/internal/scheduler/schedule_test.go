@@ -0,0 +1,530 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestApproveEntry_PendingWithoutConflicts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entryID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.ApproveEntry(context.Background(), entryID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entryID, entry.ID)
+	assert.Equal(t, domain.ApprovalStatusApproved, entry.ApprovalStatus)
+
+	// A decided entry can't be decided again
+	_, err = service.RejectEntry(context.Background(), entryID)
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeConflict, err.(*domain.DomainError).Code)
+}
+
+func TestApproveEntry_RefusedWhenOverlappingApproved(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(17*time.Hour), nil)
+	pendingID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(12*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	_, err := service.ApproveEntry(context.Background(), pendingID)
+
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeConflict, err.(*domain.DomainError).Code)
+
+	entry, err := service.GetEntry(context.Background(), pendingID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusPending, entry.ApprovalStatus)
+}
+
+func TestRejectEntry_StopsConflicting(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	pendingID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "pending"})
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.RejectEntry(context.Background(), pendingID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusRejected, entry.ApprovalStatus)
+
+	result, err := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil).CheckConflicts(context.Background(), domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(10 * time.Hour),
+		EndTime:     baseDay.Add(11 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.False(t, result.HasConflicts)
+}
+
+func TestApproveEntry_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	_, err := service.ApproveEntry(context.Background(), 99999)
+
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeNotFound, err.(*domain.DomainError).Code)
+}
+
+func TestCancelEntry_StopsConflictingAndKeepsReason(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entryID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	conflicts := NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil)
+	check := domain.CheckConflictsRequest{
+		ResourceIDs: []int32{resourceID},
+		StartTime:   baseDay.Add(10 * time.Hour),
+		EndTime:     baseDay.Add(11 * time.Hour),
+	}
+	result, err := conflicts.CheckConflicts(context.Background(), check)
+	require.NoError(t, err)
+	require.True(t, result.HasConflicts)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	entry, err := service.CancelEntry(context.Background(), entryID, domain.CancelEntryRequest{
+		Reason: strPtr("  Client postponed  "),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, entry.CancelledAt)
+	require.NotNil(t, entry.CancellationReason)
+	assert.Equal(t, "Client postponed", *entry.CancellationReason)
+
+	// The entry is kept for history
+	entry, err = service.GetEntry(context.Background(), entryID)
+	require.NoError(t, err)
+	assert.NotNil(t, entry.CancelledAt)
+
+	result, err = conflicts.CheckConflicts(context.Background(), check)
+	require.NoError(t, err)
+	assert.False(t, result.HasConflicts)
+
+	// Cancelling twice is refused
+	_, err = service.CancelEntry(context.Background(), entryID, domain.CancelEntryRequest{})
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeConflict, err.(*domain.DomainError).Code)
+}
+
+func TestCancelEntry_NotFound(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	_, err := service.CancelEntry(context.Background(), 99999, domain.CancelEntryRequest{})
+
+	require.Error(t, err)
+	assert.Equal(t, domain.ErrCodeNotFound, err.(*domain.DomainError).Code)
+}
+
+func TestCreateEntryChecked_RefusedOnHardConflict(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	existingID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+
+	_, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(11 * time.Hour),
+		EndTime:    baseDay.Add(13 * time.Hour),
+	})
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+	require.Len(t, domainErr.Conflicts, 1)
+	assert.True(t, baseDay.Add(9*time.Hour).Equal(domainErr.Conflicts[0].ExistingStartTime))
+
+	// A free slot is booked
+	notes := "Prep shift"
+	entry, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(12 * time.Hour),
+		EndTime:    baseDay.Add(14 * time.Hour),
+		Notes:      &notes,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, existingID, entry.ID)
+	assert.Equal(t, domain.ApprovalStatusApproved, entry.ApprovalStatus)
+	require.NotNil(t, entry.Notes)
+	assert.Equal(t, notes, *entry.Notes)
+}
+
+func TestCreateEntryChecked_Validation(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, clientID, eventID := testutil.SetupBaseData(t, testDB.DB)
+	otherEvent := testutil.CreateEvent(t, testDB.DB, clientID, userID, nil)
+	otherTask := testutil.CreateTask(t, testDB.DB, otherEvent, nil)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	valid := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*domain.ScheduleEntryRequest)
+	}{
+		{"inverted range", func(r *domain.ScheduleEntryRequest) { r.EndTime = r.StartTime }},
+		{"unknown resource", func(r *domain.ScheduleEntryRequest) { r.ResourceID = 99999 }},
+		{"unknown event", func(r *domain.ScheduleEntryRequest) { r.EventID = 99999 }},
+		{"task of another event", func(r *domain.ScheduleEntryRequest) { r.TaskID = &otherTask }},
+	}
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+
+			_, err := service.CreateEntryChecked(context.Background(), req)
+
+			var domainErr *domain.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+		})
+	}
+}
+
+func TestUpdateEntry_ExcludesItself(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entryID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(14*time.Hour), baseDay.Add(16*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	req := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(10 * time.Hour),
+		EndTime:    baseDay.Add(13 * time.Hour),
+	}
+
+	// Overlaps only its own current range
+	entry, err := service.UpdateEntry(context.Background(), entryID, req)
+	require.NoError(t, err)
+	assert.True(t, req.StartTime.Equal(entry.StartTime))
+	assert.True(t, req.EndTime.Equal(entry.EndTime))
+
+	// Moving onto the other booking is refused
+	req.EndTime = baseDay.Add(15 * time.Hour)
+	_, err = service.UpdateEntry(context.Background(), entryID, req)
+
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+	assert.Len(t, domainErr.Conflicts, 1)
+}
+
+func TestDeleteEntry(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entryID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), nil)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	require.NoError(t, service.DeleteEntry(context.Background(), entryID))
+
+	_, err := service.GetEntry(context.Background(), entryID)
+	var domainErr *domain.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+
+	err = service.DeleteEntry(context.Background(), entryID)
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domain.ErrCodeNotFound, domainErr.Code)
+}
+
+func TestCreateEntryChecked_RecordsCreatedBy(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	book := func(start time.Time, createdBy *int32) *domain.ScheduleEntry {
+		entry, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+			ResourceID: resourceID,
+			EventID:    eventID,
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Hour),
+			CreatedBy:  createdBy,
+		})
+		require.NoError(t, err)
+		return entry
+	}
+
+	byUser := book(baseDay.Add(9*time.Hour), &userID)
+	require.NotNil(t, byUser.CreatedBy)
+	assert.Equal(t, userID, *byUser.CreatedBy)
+
+	// Internal jobs book without a user
+	byJob := book(baseDay.Add(12*time.Hour), nil)
+	assert.Nil(t, byJob.CreatedBy)
+
+	// Updating an entry keeps its creator
+	updated, err := service.UpdateEntry(context.Background(), byUser.ID, domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(15 * time.Hour),
+		EndTime:    baseDay.Add(17 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, updated.CreatedBy)
+	assert.Equal(t, userID, *updated.CreatedBy)
+}
+
+func TestGetEntry_CreatedByRoundTrips(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	entryID := testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour),
+		&testutil.ScheduleEntryOpts{CreatedBy: &userID})
+
+	entry, err := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{}).GetEntry(context.Background(), entryID)
+
+	require.NoError(t, err)
+	require.NotNil(t, entry.CreatedBy)
+	assert.Equal(t, userID, *entry.CreatedBy)
+}
+
+func TestCreateEntryChecked_UnitCapacity(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	dishes := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chafing Dish",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+		Quantity:    10,
+	})
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), &testutil.ScheduleEntryOpts{Quantity: 4})
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(11*time.Hour), baseDay.Add(14*time.Hour), &testutil.ScheduleEntryOpts{Quantity: 3})
+	// Pending entries don't take units unless pending_bookings_block is on
+	testutil.CreateScheduleEntry(t, testDB.DB, dishes, eventID,
+		baseDay.Add(10*time.Hour), baseDay.Add(13*time.Hour),
+		&testutil.ScheduleEntryOpts{Quantity: 5, ApprovalStatus: "pending"})
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	req := domain.ScheduleEntryRequest{
+		ResourceID: dishes,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(10 * time.Hour),
+		EndTime:    baseDay.Add(13 * time.Hour),
+		Quantity:   2,
+	}
+
+	t.Run("leaves capacity", func(t *testing.T) {
+		// 7 of 10 are in use at 11:00-12:00
+		entry, err := service.CreateEntryChecked(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, entry.RemainingQuantity)
+		assert.Equal(t, int32(1), *entry.RemainingQuantity)
+	})
+
+	t.Run("would exceed capacity", func(t *testing.T) {
+		// Only 1 unit is left after the booking above
+		_, err := service.CreateEntryChecked(context.Background(), req)
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+		assert.Contains(t, domainErr.Message, "only 1 of 10")
+	})
+
+	t.Run("quantity is for equipment and materials", func(t *testing.T) {
+		chef := testutil.CreateResource(t, testDB.DB, nil)
+		staffReq := req
+		staffReq.ResourceID = chef
+
+		_, err := service.CreateEntryChecked(context.Background(), staffReq)
+
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+	})
+}
+
+func TestCreateEntryChecked_ConcurrentBookingsOfSameSlot(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	req := domain.ScheduleEntryRequest{
+		ResourceID: resourceID,
+		EventID:    eventID,
+		StartTime:  baseDay.Add(9 * time.Hour),
+		EndTime:    baseDay.Add(12 * time.Hour),
+	}
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	start := make(chan struct{})
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = service.CreateEntryChecked(context.Background(), req)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+		assert.Len(t, domainErr.Conflicts, 1)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	var count int
+	require.NoError(t, testDB.DB.QueryRow(
+		`SELECT COUNT(*) FROM resource_schedule WHERE resource_id = $1`, resourceID).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestApproveEntry_ConcurrentApprovalsOfOverlappingEntries(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	resourceID := testutil.CreateResource(t, testDB.DB, nil)
+
+	baseDay := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	pending := &testutil.ScheduleEntryOpts{ApprovalStatus: "pending"}
+	ids := []int32{
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+			baseDay.Add(9*time.Hour), baseDay.Add(12*time.Hour), pending),
+		testutil.CreateScheduleEntry(t, testDB.DB, resourceID, eventID,
+			baseDay.Add(10*time.Hour), baseDay.Add(13*time.Hour), pending),
+	}
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	start := make(chan struct{})
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = service.ApproveEntry(context.Background(), ids[i])
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		var domainErr *domain.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, domain.ErrCodeConflict, domainErr.Code)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	var approved int
+	require.NoError(t, testDB.DB.QueryRow(
+		`SELECT COUNT(*) FROM resource_schedule WHERE resource_id = $1 AND approval_status = 'approved'`, resourceID).Scan(&approved))
+	assert.Equal(t, 1, approved)
+}
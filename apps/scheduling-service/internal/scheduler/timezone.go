@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+// maxTimezoneLength matches the width of the resources.timezone column
+const maxTimezoneLength = 64
+
+// parseTimezone resolves a timezone field from a request. An empty name means
+// UTC; anything else must be an IANA zone name. "Local" is rejected because it
+// depends on the server's configuration. Every timezone field goes through
+// here so they all fail with the same validation error.
+func parseTimezone(name string) (*time.Location, *domain.DomainError) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if len(name) > maxTimezoneLength || name == "Local" {
+		return nil, invalidTimezoneError()
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, invalidTimezoneError()
+	}
+	return loc, nil
+}
+
+func invalidTimezoneError() *domain.DomainError {
+	return domain.NewValidationError("timezone must be an IANA zone name such as America/Chicago")
+}
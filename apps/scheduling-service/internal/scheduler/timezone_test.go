@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+)
+
+func TestParseTimezone(t *testing.T) {
+	loc, err := parseTimezone("America/Chicago")
+	require.Nil(t, err)
+	assert.Equal(t, "America/Chicago", loc.String())
+
+	loc, err = parseTimezone("")
+	require.Nil(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	for _, name := range []string{"Not/AZone", "CST6CDT/../../etc", "Local", strings.Repeat("a", maxTimezoneLength+1)} {
+		loc, err := parseTimezone(name)
+		assert.Nil(t, loc, name)
+		require.NotNil(t, err, name)
+		assert.Equal(t, domain.ErrCodeValidation, err.Code, name)
+	}
+}
@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// WeeklyCapacityEnv names the environment variable holding the hours a
+	// resource can be booked per week, the baseline for utilization trends
+	WeeklyCapacityEnv = "WEEKLY_CAPACITY_HOURS"
+
+	// maxUtilizationTrendRange bounds the window a utilization trend covers
+	maxUtilizationTrendRange = 366 * 24 * time.Hour
+)
+
+// weeklyCapacityHundredths is the weekly capacity in hundredths of an hour. It
+// is replaced at most once, at startup, before any request is served.
+var weeklyCapacityHundredths int64 = 4000
+
+// ParseWeeklyCapacity parses and validates a weekly capacity in hours with at
+// most two decimal places, returning it in hundredths of an hour
+func ParseWeeklyCapacity(hours string) (int64, error) {
+	capacity, err := parseCents(hours)
+	if err != nil {
+		return 0, err
+	}
+	if capacity <= 0 || capacity > 16800 {
+		return 0, errors.New("capacity must be more than 0 and at most 168 hours")
+	}
+	return capacity, nil
+}
+
+// LoadWeeklyCapacity applies WEEKLY_CAPACITY_HOURS if it is set. Call it once
+// at startup; an invalid value is returned as an error so the service can
+// refuse to start.
+func LoadWeeklyCapacity() error {
+	v, ok := os.LookupEnv(WeeklyCapacityEnv)
+	if !ok {
+		return nil
+	}
+	capacity, err := ParseWeeklyCapacity(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", WeeklyCapacityEnv, err)
+	}
+	weeklyCapacityHundredths = capacity
+	return nil
+}
+
+// GetUtilizationTrend reports a resource's booked hours in each week of the
+// window as a share of the weekly capacity. Weeks run Monday to Monday in the
+// resource's timezone. Bookings crossing a week boundary count toward each
+// week only for the time inside it, and the first and last weeks are clipped
+// to the window, with their capacity scaled down to match. Bookings are
+// counted as in reports, so overlapping bookings each count and utilization
+// can pass 100%.
+func (s *ReportService) GetUtilizationTrend(ctx context.Context, req domain.UtilizationTrendRequest) (*domain.UtilizationTrendResponse, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxUtilizationTrendRange {
+		return nil, domain.NewValidationError("range must not exceed 366 days")
+	}
+
+	row, err := s.queries.GetResourceByID(ctx, req.ResourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("resource not found")
+		}
+		return nil, dbError("failed to get resource", err)
+	}
+	resource := toDomainResource(row)
+	loc, err := resourceZone(&resource)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.GetResourceWeeklyBookedSeconds(ctx, repository.GetResourceWeeklyBookedSecondsParams{
+		Timezone:         loc.String(),
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		ResourceID:       req.ResourceID,
+		IncludeCancelled: req.IncludeCancelled,
+	})
+	if err != nil {
+		return nil, dbError("failed to compute weekly utilization", err)
+	}
+	booked := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		booked[r.WeekStart.Unix()] = r.BookedSeconds
+	}
+
+	capacity := weeklyCapacityHundredths
+	weeks := utilizationWeeks(loc, req.StartDate, req.EndDate)
+	resp := &domain.UtilizationTrendResponse{
+		ResourceID:          resource.ID,
+		ResourceName:        resource.Name,
+		Timezone:            loc.String(),
+		StartDate:           req.StartDate,
+		EndDate:             req.EndDate,
+		WeeklyCapacityHours: float64(capacity) / 100,
+		Weeks:               make([]domain.UtilizationWeek, 0, len(weeks)),
+	}
+	for _, w := range weeks {
+		// Capacity in seconds for the share of the week inside the window
+		capacitySeconds := float64(capacity) * 36 * w.inWindow.Seconds() / w.length.Seconds()
+		bookedSeconds := booked[w.start.Unix()]
+		resp.Weeks = append(resp.Weeks, domain.UtilizationWeek{
+			WeekStart:          w.start,
+			BookedHours:        secondsToHours(bookedSeconds),
+			CapacityHours:      secondsToHours(int64(math.Round(capacitySeconds))),
+			UtilizationPercent: math.Round(float64(bookedSeconds)/capacitySeconds*10000) / 100,
+		})
+	}
+
+	return resp, nil
+}
+
+// utilizationWeek is one Monday-to-Monday week touching a window
+type utilizationWeek struct {
+	start time.Time
+	// length is the whole week's duration, which a daylight saving change
+	// makes an hour longer or shorter than seven days
+	length time.Duration
+	// inWindow is how much of the week falls inside the window
+	inWindow time.Duration
+}
+
+// utilizationWeeks lists the weeks, starting Monday at midnight in loc, that
+// overlap [start, end), in order
+func utilizationWeeks(loc *time.Location, start, end time.Time) []utilizationWeek {
+	local := start.In(loc)
+	y, m, d := local.Date()
+	// Days back to Monday, with Sunday as the last day of the week
+	d -= (int(local.Weekday()) + 6) % 7
+
+	var weeks []utilizationWeek
+	for weekStart := time.Date(y, m, d, 0, 0, 0, 0, loc); weekStart.Before(end); {
+		d += 7
+		weekEnd := time.Date(y, m, d, 0, 0, 0, 0, loc)
+
+		from, until := weekStart, weekEnd
+		if from.Before(start) {
+			from = start
+		}
+		if until.After(end) {
+			until = end
+		}
+		weeks = append(weeks, utilizationWeek{
+			start:    weekStart,
+			length:   weekEnd.Sub(weekStart),
+			inWindow: until.Sub(from),
+		})
+		weekStart = weekEnd
+	}
+	return weeks
+}
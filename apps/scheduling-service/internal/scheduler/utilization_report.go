@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+const (
+	// utilizationReportTitle heads every utilization report
+	utilizationReportTitle = "Resource Utilization Report"
+
+	// workingDaysPerWeek is the number of days the weekly capacity is assumed
+	// to be spread over, for stating the hours per working day
+	workingDaysPerWeek = 5
+)
+
+// GetUtilizationReport reports every resource's booked hours over the period
+// against its share of the weekly capacity, wrapped with the metadata needed to
+// render it as a document. Capacity is prorated by the period's length in
+// weeks, so a 14-day period gives each resource twice the weekly capacity.
+// Bookings are counted as in the utilization trend: clipped to the period,
+// with overlapping bookings each counting.
+func (s *ReportService) GetUtilizationReport(ctx context.Context, req domain.UtilizationReportRequest) (*domain.UtilizationReport, error) {
+	if !req.EndDate.After(req.StartDate) {
+		return nil, domain.NewValidationError("end_date must be after start_date")
+	}
+	if req.EndDate.Sub(req.StartDate) > maxUtilizationTrendRange {
+		return nil, domain.NewValidationError("range must not exceed 366 days")
+	}
+
+	params := repository.ListResourceBookedSecondsParams{
+		StartDate:        req.StartDate,
+		EndDate:          req.EndDate,
+		IncludeCancelled: req.IncludeCancelled,
+	}
+	if req.ResourceType != nil {
+		if !req.ResourceType.IsValid() {
+			return nil, domain.NewValidationError("resource_type must be one of staff, equipment, materials")
+		}
+		params.ResourceType = repository.NullResourceType{ResourceType: repository.ResourceType(*req.ResourceType), Valid: true}
+	}
+
+	rows, err := s.queries.ListResourceBookedSeconds(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to compute resource utilization", err)
+	}
+
+	report := buildUtilizationReport(rows, req, weeklyCapacityHundredths)
+	report.GeneratedAt = time.Now().UTC()
+	return report, nil
+}
+
+// buildUtilizationReport lays out the report for the booked time of each
+// resource, given the weekly capacity in hundredths of an hour
+func buildUtilizationReport(rows []repository.ListResourceBookedSecondsRow, req domain.UtilizationReportRequest, capacity int64) *domain.UtilizationReport {
+	period := req.EndDate.Sub(req.StartDate)
+	capacitySeconds := float64(capacity) * 36 * period.Seconds() / (7 * 24 * time.Hour).Seconds()
+	capacityHours := secondsToHours(int64(math.Round(capacitySeconds)))
+	weeklyHours := float64(capacity) / 100
+	dailyHours := math.Round(weeklyHours/workingDaysPerWeek*100) / 100
+
+	report := &domain.UtilizationReport{
+		Title:        utilizationReportTitle,
+		Period:       domain.TimeRange{Start: req.StartDate, End: req.EndDate},
+		ResourceType: req.ResourceType,
+		Assumptions: domain.UtilizationAssumptions{
+			WeeklyCapacityHours:      weeklyHours,
+			WorkingDaysPerWeek:       workingDaysPerWeek,
+			HoursPerWorkingDay:       dailyHours,
+			CapacityHoursPerResource: capacityHours,
+			Description: fmt.Sprintf("Each resource can be booked %g hours a week (%d working days of %g hours), prorated over the %g-day period. Overlapping bookings each count, so utilization can pass 100%%.",
+				weeklyHours, workingDaysPerWeek, dailyHours, math.Round(period.Hours()/24*100)/100),
+		},
+		Rows: make([]domain.UtilizationReportRow, 0, len(rows)),
+	}
+
+	var bookedSeconds int64
+	percents := make([]float64, 0, len(rows))
+	summary := &report.Summary
+	for _, row := range rows {
+		percent := utilizationPercent(float64(row.BookedSeconds), capacitySeconds)
+		report.Rows = append(report.Rows, domain.UtilizationReportRow{
+			ResourceID:         row.ID,
+			ResourceName:       row.Name,
+			ResourceType:       domain.ResourceType(row.Type),
+			BookingCount:       row.BookingCount,
+			BookedHours:        secondsToHours(row.BookedSeconds),
+			CapacityHours:      capacityHours,
+			UtilizationPercent: percent,
+		})
+		bookedSeconds += row.BookedSeconds
+		report.Totals.BookingCount += row.BookingCount
+		percents = append(percents, percent)
+
+		current := &domain.ResourceUtilization{ResourceID: row.ID, ResourceName: row.Name, UtilizationPercent: percent}
+		if summary.MostUtilized == nil || percent > summary.MostUtilized.UtilizationPercent {
+			summary.MostUtilized = current
+		}
+		if summary.LeastUtilized == nil || percent < summary.LeastUtilized.UtilizationPercent {
+			summary.LeastUtilized = current
+		}
+		if row.BookedSeconds == 0 {
+			summary.IdleResourceCount++
+		}
+		if percent > 100 {
+			summary.OverCapacityCount++
+		}
+	}
+
+	n := len(rows)
+	report.Totals.ResourceCount = n
+	report.Totals.BookedHours = secondsToHours(bookedSeconds)
+	report.Totals.CapacityHours = secondsToHours(int64(math.Round(capacitySeconds * float64(n))))
+	if n > 0 {
+		report.Totals.UtilizationPercent = utilizationPercent(float64(bookedSeconds), capacitySeconds*float64(n))
+		summary.AverageUtilizationPercent = mean(percents)
+		summary.MedianUtilizationPercent = median(percents)
+	}
+	return report
+}
+
+// utilizationPercent is booked as a percentage of capacity, to two decimal places
+func utilizationPercent(booked, capacity float64) float64 {
+	return math.Round(booked/capacity*10000) / 100
+}
+
+// mean averages the values to two decimal places
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return math.Round(sum/float64(len(values))*100) / 100
+}
+
+// median returns the middle value, or the mean of the middle two, to two
+// decimal places. values is sorted in place.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[mid]
+	}
+	return math.Round((values[mid-1]+values[mid])/2*100) / 100
+}
@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestBuildUtilizationReport(t *testing.T) {
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	req := domain.UtilizationReportRequest{StartDate: start, EndDate: start.AddDate(0, 0, 14)}
+	rows := []repository.ListResourceBookedSecondsRow{
+		{ID: 1, Name: "Chef", Type: repository.ResourceTypeStaff, BookingCount: 4, BookedSeconds: 40 * 3600},
+		{ID: 2, Name: "Oven", Type: repository.ResourceTypeEquipment, BookingCount: 9, BookedSeconds: 100 * 3600},
+		{ID: 3, Name: "Van", Type: repository.ResourceTypeEquipment},
+	}
+
+	report := buildUtilizationReport(rows, req, 4000)
+
+	assert.Equal(t, "Resource Utilization Report", report.Title)
+	assert.Equal(t, domain.TimeRange{Start: req.StartDate, End: req.EndDate}, report.Period)
+	assert.Equal(t, 40.0, report.Assumptions.WeeklyCapacityHours)
+	assert.Equal(t, 5, report.Assumptions.WorkingDaysPerWeek)
+	assert.Equal(t, 8.0, report.Assumptions.HoursPerWorkingDay)
+	assert.Equal(t, 80.0, report.Assumptions.CapacityHoursPerResource)
+	assert.Contains(t, report.Assumptions.Description, "14-day period")
+
+	require.Len(t, report.Rows, 3)
+	assert.Equal(t, 50.0, report.Rows[0].UtilizationPercent)
+	assert.Equal(t, 125.0, report.Rows[1].UtilizationPercent)
+	assert.Equal(t, 0.0, report.Rows[2].UtilizationPercent)
+
+	assert.Equal(t, domain.UtilizationReportTotals{
+		ResourceCount:      3,
+		BookingCount:       13,
+		BookedHours:        140,
+		CapacityHours:      240,
+		UtilizationPercent: 58.33,
+	}, report.Totals)
+
+	assert.Equal(t, 58.33, report.Summary.AverageUtilizationPercent)
+	assert.Equal(t, 50.0, report.Summary.MedianUtilizationPercent)
+	require.NotNil(t, report.Summary.MostUtilized)
+	assert.Equal(t, "Oven", report.Summary.MostUtilized.ResourceName)
+	require.NotNil(t, report.Summary.LeastUtilized)
+	assert.Equal(t, "Van", report.Summary.LeastUtilized.ResourceName)
+	assert.Equal(t, 1, report.Summary.IdleResourceCount)
+	assert.Equal(t, 1, report.Summary.OverCapacityCount)
+}
+
+func TestBuildUtilizationReport_NoResources(t *testing.T) {
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	report := buildUtilizationReport(nil, domain.UtilizationReportRequest{StartDate: start, EndDate: start.AddDate(0, 0, 7)}, 4000)
+
+	assert.NotNil(t, report.Rows)
+	assert.Empty(t, report.Rows)
+	assert.Zero(t, report.Totals.UtilizationPercent)
+	assert.Nil(t, report.Summary.MostUtilized)
+	assert.Nil(t, report.Summary.LeastUtilized)
+}
+
+func TestGetUtilizationReport(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	original := weeklyCapacityHundredths
+	t.Cleanup(func() { weeklyCapacityHundredths = original })
+	weeklyCapacityHundredths = 4000
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Oven",
+		Type:        testutil.ResourceTypeEquipment,
+		IsAvailable: true,
+	})
+
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	// 8h inside the week, plus 2h of a booking that starts before it
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, monday.Add(8*time.Hour), monday.Add(16*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, monday.Add(-2*time.Hour), monday.Add(2*time.Hour), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, monday.Add(30*time.Hour), monday.Add(34*time.Hour),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+
+	service := NewReportService(testDB.DB)
+	before := time.Now().UTC()
+
+	report, err := service.GetUtilizationReport(context.Background(), domain.UtilizationReportRequest{
+		StartDate: monday,
+		EndDate:   monday.AddDate(0, 0, 7),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Resource Utilization Report", report.Title)
+	assert.True(t, monday.Equal(report.Period.Start))
+	assert.True(t, monday.AddDate(0, 0, 7).Equal(report.Period.End))
+	assert.False(t, report.GeneratedAt.Before(before))
+	assert.Equal(t, 40.0, report.Assumptions.CapacityHoursPerResource)
+	assert.Nil(t, report.ResourceType)
+
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, "Chef", report.Rows[0].ResourceName)
+	assert.Equal(t, domain.ResourceTypeStaff, report.Rows[0].ResourceType)
+	assert.Equal(t, int64(2), report.Rows[0].BookingCount)
+	assert.Equal(t, 10.0, report.Rows[0].BookedHours)
+	assert.Equal(t, 25.0, report.Rows[0].UtilizationPercent)
+	assert.Equal(t, "Oven", report.Rows[1].ResourceName)
+	assert.Equal(t, 0.0, report.Rows[1].BookedHours)
+
+	assert.Equal(t, 2, report.Totals.ResourceCount)
+	assert.Equal(t, 12.5, report.Totals.UtilizationPercent)
+	assert.Equal(t, 1, report.Summary.IdleResourceCount)
+
+	equipment := domain.ResourceTypeEquipment
+	report, err = service.GetUtilizationReport(context.Background(), domain.UtilizationReportRequest{
+		StartDate:    monday,
+		EndDate:      monday.AddDate(0, 0, 7),
+		ResourceType: &equipment,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, "Oven", report.Rows[0].ResourceName)
+}
+
+func TestGetUtilizationReport_Validation(t *testing.T) {
+	service := &ReportService{}
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetUtilizationReport(context.Background(), domain.UtilizationReportRequest{
+		StartDate: start,
+		EndDate:   start,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	_, err = service.GetUtilizationReport(context.Background(), domain.UtilizationReportRequest{
+		StartDate: start,
+		EndDate:   start.AddDate(1, 1, 0),
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	bogus := domain.ResourceType("vehicles")
+	_, err = service.GetUtilizationReport(context.Background(), domain.UtilizationReportRequest{
+		StartDate:    start,
+		EndDate:      start.AddDate(0, 0, 7),
+		ResourceType: &bogus,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
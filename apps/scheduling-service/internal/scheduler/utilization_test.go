@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestUtilizationWeeks(t *testing.T) {
+	t.Run("window starting mid-week clips the first and last weeks", func(t *testing.T) {
+		// Wednesday 4 June to Wednesday 18 June 2025
+		start := time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+
+		weeks := utilizationWeeks(time.UTC, start, end)
+
+		require.Len(t, weeks, 3)
+		assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), weeks[0].start)
+		assert.Equal(t, 5*24*time.Hour, weeks[0].inWindow)
+		assert.Equal(t, 7*24*time.Hour, weeks[1].inWindow)
+		assert.Equal(t, time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC), weeks[2].start)
+		assert.Equal(t, 2*24*time.Hour, weeks[2].inWindow)
+	})
+
+	t.Run("a Sunday start belongs to the week begun the Monday before", func(t *testing.T) {
+		start := time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)
+
+		weeks := utilizationWeeks(time.UTC, start, start.Add(time.Hour))
+
+		require.Len(t, weeks, 1)
+		assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC), weeks[0].start)
+	})
+
+	t.Run("weeks follow local midnight across daylight saving", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		// Clocks go back on Sunday 2 November 2025
+		start := time.Date(2025, 10, 27, 0, 0, 0, 0, ny)
+
+		weeks := utilizationWeeks(ny, start, time.Date(2025, 11, 10, 0, 0, 0, 0, ny))
+
+		require.Len(t, weeks, 2)
+		assert.Equal(t, 7*24*time.Hour+time.Hour, weeks[0].length)
+		assert.Equal(t, weeks[0].length, weeks[0].inWindow)
+		assert.Equal(t, time.Date(2025, 11, 3, 0, 0, 0, 0, ny), weeks[1].start)
+	})
+}
+
+func TestParseWeeklyCapacity_Invalid(t *testing.T) {
+	for _, v := range []string{"0", "-4", "168.01", "forty", "37.125"} {
+		_, err := ParseWeeklyCapacity(v)
+		assert.Error(t, err, "capacity %q", v)
+	}
+}
+
+func TestLoadWeeklyCapacity(t *testing.T) {
+	original := weeklyCapacityHundredths
+	t.Cleanup(func() { weeklyCapacityHundredths = original })
+
+	t.Setenv(WeeklyCapacityEnv, "37.5")
+	require.NoError(t, LoadWeeklyCapacity())
+	assert.Equal(t, int64(3750), weeklyCapacityHundredths)
+
+	t.Setenv(WeeklyCapacityEnv, "0")
+	assert.Error(t, LoadWeeklyCapacity())
+	// A failed load leaves the previous capacity in place
+	assert.Equal(t, int64(3750), weeklyCapacityHundredths)
+}
+
+func TestGetUtilizationTrend_ThreeWeeks(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	original := weeklyCapacityHundredths
+	t.Cleanup(func() { weeklyCapacityHundredths = original })
+	weeklyCapacityHundredths = 4000
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Name:        "Chef",
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	day := func(n, hour int) time.Time {
+		return monday.AddDate(0, 0, n).Add(time.Duration(hour) * time.Hour)
+	}
+	// Week one: 10h, plus 2h of a booking that runs from Sunday night into Monday
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(0, 8), day(0, 14), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(2, 9), day(2, 13), nil)
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(6, 22), day(7, 2), nil)
+	// Week two: the other 2h of that booking and 18h more
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(8, 6), day(9, 0), nil)
+	// Week three: only bookings that don't count
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(14, 8), day(14, 16),
+		&testutil.ScheduleEntryOpts{ApprovalStatus: "rejected"})
+	cancelledAt := monday
+	testutil.CreateScheduleEntry(t, testDB.DB, chef, eventID, day(15, 8), day(15, 16),
+		&testutil.ScheduleEntryOpts{CancelledAt: &cancelledAt})
+
+	service := NewReportService(testDB.DB)
+
+	result, err := service.GetUtilizationTrend(context.Background(), domain.UtilizationTrendRequest{
+		ResourceID: chef,
+		StartDate:  monday,
+		EndDate:    monday.AddDate(0, 0, 21),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", result.Timezone)
+	assert.Equal(t, 40.0, result.WeeklyCapacityHours)
+	require.Len(t, result.Weeks, 3)
+
+	assert.True(t, monday.Equal(result.Weeks[0].WeekStart))
+	assert.Equal(t, 12.0, result.Weeks[0].BookedHours)
+	assert.Equal(t, 40.0, result.Weeks[0].CapacityHours)
+	assert.Equal(t, 30.0, result.Weeks[0].UtilizationPercent)
+
+	assert.True(t, monday.AddDate(0, 0, 7).Equal(result.Weeks[1].WeekStart))
+	assert.Equal(t, 20.0, result.Weeks[1].BookedHours)
+	assert.Equal(t, 50.0, result.Weeks[1].UtilizationPercent)
+
+	assert.True(t, monday.AddDate(0, 0, 14).Equal(result.Weeks[2].WeekStart))
+	assert.Equal(t, 0.0, result.Weeks[2].BookedHours)
+	assert.Equal(t, 0.0, result.Weeks[2].UtilizationPercent)
+}
+
+func TestGetUtilizationTrend_Validation(t *testing.T) {
+	service := &ReportService{}
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetUtilizationTrend(context.Background(), domain.UtilizationTrendRequest{
+		ResourceID: 1,
+		StartDate:  start,
+		EndDate:    start,
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+
+	_, err = service.GetUtilizationTrend(context.Background(), domain.UtilizationTrendRequest{
+		ResourceID: 1,
+		StartDate:  start,
+		EndDate:    start.AddDate(1, 1, 0),
+	})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeValidation})
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/repository"
+)
+
+// ListWorkingHours returns the working hours set on a resource itself. A
+// resource without any falls back to its linked user's shifts, which are not
+// listed here, or else counts as always working.
+func (s *ResourceService) ListWorkingHours(ctx context.Context, resourceID int32) ([]domain.WorkingHours, error) {
+	if err := s.resourceExists(ctx, resourceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListResourceOwnWorkingHours(ctx, resourceID)
+	if err != nil {
+		return nil, dbError("failed to list working hours", err)
+	}
+	hours := make([]domain.WorkingHours, 0, len(rows))
+	for _, row := range rows {
+		hours = append(hours, toDomainWorkingHours(row))
+	}
+	return hours, nil
+}
+
+// CreateWorkingHours adds a weekly shift to a resource
+func (s *ResourceService) CreateWorkingHours(ctx context.Context, resourceID int32, req domain.WorkingHoursRequest) (*domain.WorkingHours, error) {
+	if err := validateWorkingHours(req); err != nil {
+		return nil, err
+	}
+	if err := s.resourceExists(ctx, resourceID); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.CreateResourceWorkingHours(ctx, repository.CreateResourceWorkingHoursParams{
+		ResourceID: resourceID,
+		DayOfWeek:  req.DayOfWeek,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+	})
+	if err != nil {
+		return nil, dbError("failed to create working hours", err)
+	}
+	hours := toDomainWorkingHours(row)
+	return &hours, nil
+}
+
+// UpdateWorkingHours replaces one of a resource's shifts
+func (s *ResourceService) UpdateWorkingHours(ctx context.Context, resourceID, id int32, req domain.WorkingHoursRequest) (*domain.WorkingHours, error) {
+	if err := validateWorkingHours(req); err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdateResourceWorkingHours(ctx, repository.UpdateResourceWorkingHoursParams{
+		DayOfWeek:  req.DayOfWeek,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		ID:         id,
+		ResourceID: resourceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.NewNotFoundError("working hours not found")
+		}
+		return nil, dbError("failed to update working hours", err)
+	}
+	hours := toDomainWorkingHours(row)
+	return &hours, nil
+}
+
+// DeleteWorkingHours removes one of a resource's shifts
+func (s *ResourceService) DeleteWorkingHours(ctx context.Context, resourceID, id int32) error {
+	deleted, err := s.queries.DeleteResourceWorkingHours(ctx, repository.DeleteResourceWorkingHoursParams{
+		ID:         id,
+		ResourceID: resourceID,
+	})
+	if err != nil {
+		return dbError("failed to delete working hours", err)
+	}
+	if deleted == 0 {
+		return domain.NewNotFoundError("working hours not found")
+	}
+	return nil
+}
+
+// resourceExists returns a not-found error for a resource that doesn't exist
+func (s *ResourceService) resourceExists(ctx context.Context, id int32) error {
+	if _, err := s.queries.GetResourceByID(ctx, id); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NewNotFoundError("resource not found")
+		}
+		return dbError("failed to get resource", err)
+	}
+	return nil
+}
+
+// validateWorkingHours checks a shift can be read back by parseShifts. 24:00
+// only makes sense as an end, and a shift must not start and end at the same
+// time.
+func validateWorkingHours(req domain.WorkingHoursRequest) error {
+	if req.DayOfWeek < 0 || req.DayOfWeek > 6 {
+		return domain.NewValidationError("day_of_week must be from 0 (Sunday) to 6 (Saturday)")
+	}
+	sh, _, err := parseClock(req.StartTime)
+	if err != nil || sh == 24 {
+		return domain.NewValidationError("start_time must be HH:MM from 00:00 to 23:59")
+	}
+	if _, _, err := parseClock(req.EndTime); err != nil {
+		return domain.NewValidationError("end_time must be HH:MM from 00:00 to 24:00")
+	}
+	if req.StartTime == req.EndTime {
+		return domain.NewValidationError("end_time must differ from start_time")
+	}
+	return nil
+}
+
+func toDomainWorkingHours(row repository.ResourceWorkingHour) domain.WorkingHours {
+	return domain.WorkingHours{
+		ID:         row.ID,
+		ResourceID: row.ResourceID,
+		DayOfWeek:  row.DayOfWeek,
+		StartTime:  row.StartTime,
+		EndTime:    row.EndTime,
+	}
+}
+
+// outOfHoursWarning returns a soft conflict when part of a booking falls
+// outside the resource's working hours, or nil when all of it is inside them
+// or the resource has no working hours, which means it always works. The
+// conflict spans from the first to the last minute outside the hours.
+func (s *ScheduleService) outOfHoursWarning(ctx context.Context, q *repository.Queries, req domain.ScheduleEntryRequest, resource repository.Resource) (*domain.Conflict, error) {
+	rows, err := q.ListResourceWorkingHours(ctx, resource.ID)
+	if err != nil {
+		return nil, dbError("failed to get working hours", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	shifts, err := parseShifts(rows)
+	if err != nil {
+		return nil, domain.NewInternalError("resource has invalid working hours", err)
+	}
+	domainResource := toDomainResource(resource)
+	loc, err := resourceZone(&domainResource)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := domain.TimeRange{Start: req.StartTime, End: req.EndTime}
+	outside := subtractBusy([]domain.TimeRange{requested}, workingWindows(shifts, loc, req.StartTime, req.EndTime))
+	if len(outside) == 0 {
+		return nil, nil
+	}
+	var d time.Duration
+	for _, r := range outside {
+		d += r.End.Sub(r.Start)
+	}
+
+	return &domain.Conflict{
+		Kind:               domain.ConflictKindWorkingHours,
+		Severity:           domain.ConflictSeveritySoft,
+		ResourceID:         resource.ID,
+		ResourceName:       resource.Name,
+		ExistingStartTime:  outside[0].Start,
+		ExistingEndTime:    outside[len(outside)-1].End,
+		RequestedStartTime: req.StartTime,
+		RequestedEndTime:   req.EndTime,
+		OverlapStart:       outside[0].Start,
+		OverlapEnd:         outside[len(outside)-1].End,
+		OverlapMinutes:     d.Minutes(),
+		Message:            fmt.Sprintf("Resource '%s' is booked outside its working hours for %.0f minutes", resource.Name, d.Minutes()),
+	}, nil
+}
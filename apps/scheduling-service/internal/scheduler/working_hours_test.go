@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/domain"
+	"github.com/catering-event-manager/scheduling-service/internal/events"
+	"github.com/catering-event-manager/scheduling-service/internal/testutil"
+)
+
+func TestValidateWorkingHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.WorkingHoursRequest
+		message string
+	}{
+		{"day shift", domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00"}, ""},
+		{"runs to midnight", domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "18:00", EndTime: "24:00"}, ""},
+		{"overnight", domain.WorkingHoursRequest{DayOfWeek: 5, StartTime: "22:00", EndTime: "02:00"}, ""},
+		{"day out of range", domain.WorkingHoursRequest{DayOfWeek: 7, StartTime: "08:00", EndTime: "18:00"}, "day_of_week"},
+		{"start at 24:00", domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "24:00", EndTime: "08:00"}, "start_time"},
+		{"malformed end", domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "6pm"}, "end_time"},
+		{"empty shift", domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "08:00"}, "differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWorkingHours(tt.req)
+			if tt.message == "" {
+				assert.NoError(t, err)
+				return
+			}
+			domainErr, ok := err.(*domain.DomainError)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrCodeValidation, domainErr.Code)
+			assert.Contains(t, domainErr.Message, tt.message)
+		})
+	}
+}
+
+func TestWorkingHours_CRUD(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	oven := testutil.CreateResource(t, testDB.DB, nil)
+	other := testutil.CreateResource(t, testDB.DB, nil)
+	service := NewResourceService(testDB.DB)
+	ctx := context.Background()
+
+	created, err := service.CreateWorkingHours(ctx, oven, domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00"})
+	require.NoError(t, err)
+	assert.Equal(t, oven, created.ResourceID)
+
+	updated, err := service.UpdateWorkingHours(ctx, oven, created.ID, domain.WorkingHoursRequest{DayOfWeek: 2, StartTime: "07:00", EndTime: "15:00"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), updated.DayOfWeek)
+
+	hours, err := service.ListWorkingHours(ctx, oven)
+	require.NoError(t, err)
+	require.Len(t, hours, 1)
+	assert.Equal(t, "07:00", hours[0].StartTime)
+
+	// A shift can only be changed through its own resource
+	_, err = service.UpdateWorkingHours(ctx, other, created.ID, domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00"})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+	assert.ErrorIs(t, service.DeleteWorkingHours(ctx, other, created.ID), &domain.DomainError{Code: domain.ErrCodeNotFound})
+
+	require.NoError(t, service.DeleteWorkingHours(ctx, oven, created.ID))
+	hours, err = service.ListWorkingHours(ctx, oven)
+	require.NoError(t, err)
+	assert.Empty(t, hours)
+
+	_, err = service.CreateWorkingHours(ctx, 99999, domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00"})
+	assert.ErrorIs(t, err, &domain.DomainError{Code: domain.ErrCodeNotFound})
+}
+
+func TestCreateEntryChecked_WarnsOutsideWorkingHours(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	_, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	roundTheClock := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+	})
+	resources := NewResourceService(testDB.DB)
+	_, err := resources.CreateWorkingHours(context.Background(), chef, domain.WorkingHoursRequest{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00"})
+	require.NoError(t, err)
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	book := func(resourceID int32, start, end time.Duration) *domain.ScheduleEntry {
+		entry, err := service.CreateEntryChecked(context.Background(), domain.ScheduleEntryRequest{
+			ResourceID: resourceID,
+			EventID:    eventID,
+			StartTime:  monday.Add(start),
+			EndTime:    monday.Add(end),
+		})
+		require.NoError(t, err)
+		return entry
+	}
+
+	t.Run("inside working hours has no warning", func(t *testing.T) {
+		assert.Empty(t, book(chef, 9*time.Hour, 12*time.Hour).Warnings)
+	})
+
+	t.Run("straddling the end of the working day warns but still books", func(t *testing.T) {
+		entry := book(chef, 17*time.Hour, 19*time.Hour)
+
+		require.Len(t, entry.Warnings, 1)
+		warning := entry.Warnings[0]
+		assert.Equal(t, domain.ConflictKindWorkingHours, warning.Kind)
+		assert.Equal(t, domain.ConflictSeveritySoft, warning.Severity)
+		assert.Equal(t, 60.0, warning.OverlapMinutes)
+		assert.True(t, monday.Add(18*time.Hour).Equal(warning.ExistingStartTime))
+		assert.True(t, monday.Add(19*time.Hour).Equal(warning.ExistingEndTime))
+
+		stored, err := service.GetEntry(context.Background(), entry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entry.ID, stored.ID)
+	})
+
+	t.Run("past midnight into a day without hours warns for the whole night", func(t *testing.T) {
+		entry := book(chef, 20*time.Hour, 26*time.Hour)
+
+		require.Len(t, entry.Warnings, 1)
+		assert.Equal(t, 360.0, entry.Warnings[0].OverlapMinutes)
+	})
+
+	t.Run("no configured hours means always working", func(t *testing.T) {
+		assert.Empty(t, book(roundTheClock, 22*time.Hour, 26*time.Hour).Warnings)
+	})
+}
+
+func TestCreateEntryChecked_ResourceHoursReplaceUserShifts(t *testing.T) {
+	testDB := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, testDB)
+
+	userID, _, eventID := testutil.SetupBaseData(t, testDB.DB)
+	chef := testutil.CreateResource(t, testDB.DB, &testutil.ResourceOpts{
+		Type:        testutil.ResourceTypeStaff,
+		IsAvailable: true,
+		UserID:      &userID,
+	})
+	testutil.CreateStaffAvailability(t, testDB.DB, userID, time.Monday, "09:00", "17:00")
+
+	service := NewScheduleService(testDB.DB, NewConflictService(testDB.DB, NewFlagService(testDB.DB), nil), events.Noop{})
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	req := domain.ScheduleEntryRequest{
+		ResourceID: chef,
+		EventID:    eventID,
+		StartTime:  monday.Add(18 * time.Hour),
+		EndTime:    monday.Add(20 * time.Hour),
+	}
+
+	// The user's shift ends at 17:00
+	entry, err := service.CreateEntryChecked(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, entry.Warnings, 1)
+
+	_, err = NewResourceService(testDB.DB).CreateWorkingHours(context.Background(), chef,
+		domain.WorkingHoursRequest{DayOfWeek: int32(time.Monday), StartTime: "12:00", EndTime: "22:00"})
+	require.NoError(t, err)
+
+	req.StartTime = req.StartTime.Add(24 * 7 * time.Hour)
+	req.EndTime = req.EndTime.Add(24 * 7 * time.Hour)
+	entry, err = service.CreateEntryChecked(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, entry.Warnings)
+}
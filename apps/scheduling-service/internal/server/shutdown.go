@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// ShutdownTimeoutEnv names the environment variable that overrides how long
+// shutdown waits for in-flight requests, as a Go duration such as "30s"
+const ShutdownTimeoutEnv = "SHUTDOWN_TIMEOUT"
+
+// DefaultShutdownTimeout is how long shutdown waits for in-flight requests
+// when SHUTDOWN_TIMEOUT is unset. It stays under the 30 second grace period
+// Kubernetes allows a pod after SIGTERM.
+const DefaultShutdownTimeout = 15 * time.Second
+
+// LoadShutdownTimeout returns SHUTDOWN_TIMEOUT if it is set, or the default.
+// Call it once at startup; an invalid value is returned as an error so the
+// service can refuse to start.
+func LoadShutdownTimeout() (time.Duration, error) {
+	v, ok := os.LookupEnv(ShutdownTimeoutEnv)
+	if !ok {
+		return DefaultShutdownTimeout, nil
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", ShutdownTimeoutEnv, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be positive", ShutdownTimeoutEnv)
+	}
+	return timeout, nil
+}
+
+// DrainDelayEnv names the environment variable that overrides how long
+// shutdown keeps serving after readiness starts failing, as a Go duration
+const DrainDelayEnv = "SHUTDOWN_DRAIN_DELAY"
+
+// DefaultDrainDelay is how long shutdown keeps serving after readiness starts
+// failing when SHUTDOWN_DRAIN_DELAY is unset. It covers a readiness probe run
+// every 5 seconds noticing the failure before the listener closes.
+const DefaultDrainDelay = 5 * time.Second
+
+// LoadDrainDelay returns SHUTDOWN_DRAIN_DELAY if it is set, or the default.
+// Zero skips the delay. Call it once at startup; an invalid value is returned
+// as an error so the service can refuse to start.
+func LoadDrainDelay() (time.Duration, error) {
+	v, ok := os.LookupEnv(DrainDelayEnv)
+	if !ok {
+		return DefaultDrainDelay, nil
+	}
+	delay, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", DrainDelayEnv, err)
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", DrainDelayEnv)
+	}
+	return delay, nil
+}
+
+// BeginDrain calls setDraining, which should fail readiness, then keeps
+// serving for delay. Load balancers only stop routing here once a readiness
+// probe has seen the failure; closing the listener sooner refuses the requests
+// still sent in the meantime.
+func BeginDrain(setDraining func(), delay time.Duration) {
+	setDraining()
+	if delay <= 0 {
+		return
+	}
+	logger.Get().Info().Dur("delay_ms", delay).Msg("Readiness failing, waiting before closing the listener")
+	time.Sleep(delay)
+}
+
+// Shutdowner is the part of *fiber.App that Shutdown needs
+type Shutdowner interface {
+	ShutdownWithTimeout(timeout time.Duration) error
+}
+
+// Flusher holds work that must finish after the server stops and before the
+// database is closed, such as events still waiting to be published
+type Flusher interface {
+	Close(ctx context.Context) error
+}
+
+// Shutdown stops srv accepting connections and waits up to timeout for
+// in-flight requests to finish, then closes each of flushers in turn and
+// finally db. Connections still open when the timeout runs out are closed
+// forcibly. The flushers share what is left of the timeout. db is closed
+// either way, since the process is about to exit; every error is returned.
+func Shutdown(srv Shutdowner, db io.Closer, timeout time.Duration, flushers ...Flusher) error {
+	l := logger.Get()
+	l.Info().Dur("timeout_ms", timeout).Msg("Shutting down, draining in-flight requests")
+
+	start := time.Now()
+	srvErr := srv.ShutdownWithTimeout(timeout)
+	if srvErr != nil {
+		l.Error().Err(srvErr).Msg("Server did not shut down cleanly")
+	} else {
+		l.Info().Dur("duration_ms", time.Since(start)).Msg("Server stopped")
+	}
+	errs := []error{srvErr}
+
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(timeout))
+	defer cancel()
+	for _, f := range flushers {
+		if err := f.Close(ctx); err != nil {
+			l.Error().Err(err).Msg("Failed to flush before closing the database")
+			errs = append(errs, err)
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		l.Error().Err(err).Msg("Failed to close database pool")
+		errs = append(errs, err)
+	} else {
+		l.Info().Msg("Database pool closed")
+	}
+	return errors.Join(errs...)
+}
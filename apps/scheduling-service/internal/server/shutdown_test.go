@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer stands in for the Fiber app. Its shutdown takes drain to
+// finish, as if requests were still in flight, and records the order of
+// calls in steps.
+type fakeServer struct {
+	drain time.Duration
+	err   error
+	steps *[]string
+	mu    *sync.Mutex
+
+	timeout time.Duration
+}
+
+func (s *fakeServer) ShutdownWithTimeout(timeout time.Duration) error {
+	s.timeout = timeout
+	time.Sleep(s.drain)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.steps = append(*s.steps, "server")
+	return s.err
+}
+
+// fakeDB records when it is closed
+type fakeDB struct {
+	err   error
+	steps *[]string
+	mu    *sync.Mutex
+}
+
+func (d *fakeDB) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	*d.steps = append(*d.steps, "db")
+	return d.err
+}
+
+// fakeFlusher records when it is closed and whether it was given a deadline
+type fakeFlusher struct {
+	err   error
+	steps *[]string
+	mu    *sync.Mutex
+
+	hadDeadline bool
+}
+
+func (f *fakeFlusher) Close(ctx context.Context) error {
+	_, f.hadDeadline = ctx.Deadline()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	*f.steps = append(*f.steps, "flush")
+	return f.err
+}
+
+func newFakes(drain time.Duration, srvErr, dbErr error) (*fakeServer, *fakeDB, *[]string) {
+	var steps []string
+	var mu sync.Mutex
+	return &fakeServer{drain: drain, err: srvErr, steps: &steps, mu: &mu},
+		&fakeDB{err: dbErr, steps: &steps, mu: &mu},
+		&steps
+}
+
+func TestShutdown_DrainsThenClosesDB(t *testing.T) {
+	srv, db, steps := newFakes(20*time.Millisecond, nil, nil)
+
+	start := time.Now()
+	require.NoError(t, Shutdown(srv, db, 5*time.Second))
+
+	assert.Equal(t, 5*time.Second, srv.timeout)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "waits for the drain")
+	assert.Equal(t, []string{"server", "db"}, *steps)
+}
+
+func TestShutdown_ClosesDBWhenServerFails(t *testing.T) {
+	drainErr := errors.New("context deadline exceeded")
+	srv, db, steps := newFakes(0, drainErr, nil)
+
+	err := Shutdown(srv, db, time.Second)
+
+	assert.ErrorIs(t, err, drainErr)
+	assert.Equal(t, []string{"server", "db"}, *steps)
+}
+
+func TestShutdown_ReportsBothErrors(t *testing.T) {
+	drainErr := errors.New("drain failed")
+	closeErr := errors.New("close failed")
+	srv, db, _ := newFakes(0, drainErr, closeErr)
+
+	err := Shutdown(srv, db, time.Second)
+
+	assert.ErrorIs(t, err, drainErr)
+	assert.ErrorIs(t, err, closeErr)
+}
+
+func TestShutdown_FlushesBetweenServerAndDB(t *testing.T) {
+	flushErr := errors.New("events abandoned")
+	srv, db, steps := newFakes(0, nil, nil)
+	flusher := &fakeFlusher{err: flushErr, steps: steps, mu: db.mu}
+
+	err := Shutdown(srv, db, time.Second, flusher)
+
+	assert.ErrorIs(t, err, flushErr)
+	assert.True(t, flusher.hadDeadline)
+	assert.Equal(t, []string{"server", "flush", "db"}, *steps)
+}
+
+func TestLoadShutdownTimeout(t *testing.T) {
+	// Unset for the default, restored when the test ends
+	t.Setenv(ShutdownTimeoutEnv, "")
+	require.NoError(t, os.Unsetenv(ShutdownTimeoutEnv))
+
+	timeout, err := LoadShutdownTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultShutdownTimeout, timeout)
+
+	t.Setenv(ShutdownTimeoutEnv, "45s")
+	timeout, err = LoadShutdownTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Second, timeout)
+
+	for _, v := range []string{"15", "soon", "0s", "-5s"} {
+		t.Setenv(ShutdownTimeoutEnv, v)
+		_, err := LoadShutdownTimeout()
+		assert.Error(t, err, v)
+	}
+}
+
+func TestBeginDrain_KeepsServingAfterReadinessFails(t *testing.T) {
+	var drainingAt time.Time
+	BeginDrain(func() { drainingAt = time.Now() }, 30*time.Millisecond)
+
+	require.False(t, drainingAt.IsZero(), "readiness is failed")
+	assert.GreaterOrEqual(t, time.Since(drainingAt), 30*time.Millisecond, "waits after failing readiness")
+
+	// Zero skips the wait
+	start := time.Now()
+	called := false
+	BeginDrain(func() { called = true }, 0)
+	assert.True(t, called)
+	assert.Less(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestLoadDrainDelay(t *testing.T) {
+	// Unset for the default, restored when the test ends
+	t.Setenv(DrainDelayEnv, "")
+	require.NoError(t, os.Unsetenv(DrainDelayEnv))
+
+	delay, err := LoadDrainDelay()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDrainDelay, delay)
+
+	for v, want := range map[string]time.Duration{"10s": 10 * time.Second, "0s": 0} {
+		t.Setenv(DrainDelayEnv, v)
+		delay, err = LoadDrainDelay()
+		require.NoError(t, err, v)
+		assert.Equal(t, want, delay, v)
+	}
+
+	for _, v := range []string{"5", "soon", "-1s"} {
+		t.Setenv(DrainDelayEnv, v)
+		_, err := LoadDrainDelay()
+		assert.Error(t, err, v)
+	}
+}
@@ -97,11 +97,19 @@ func CleanupTables(t *testing.T, db *sql.DB) {
 
 	// Truncate in reverse dependency order
 	tables := []string{
+		"feature_flags",
+		"idempotency_keys",
 		"resource_schedule",
 		"task_resources",
+		"resource_certifications",
+		"resource_substitutes",
+		"resource_working_hours",
+		"resource_downtime",
+		"blackout_dates",
 		"tasks",
 		"events",
 		"resources",
+		"staff_availability",
 		"clients",
 		"users",
 	}
@@ -117,12 +125,16 @@ func CleanupTables(t *testing.T, db *sql.DB) {
 // initSchema creates all tables and enums needed for testing.
 func initSchema(db *sql.DB) error {
 	schema := `
+	-- Extensions
+	CREATE EXTENSION IF NOT EXISTS btree_gist;
+
 	-- Enums
 	CREATE TYPE user_role AS ENUM ('administrator', 'manager');
 	CREATE TYPE event_status AS ENUM ('inquiry', 'planning', 'preparation', 'in_progress', 'completed', 'follow_up');
 	CREATE TYPE task_status AS ENUM ('pending', 'in_progress', 'completed');
 	CREATE TYPE task_category AS ENUM ('pre_event', 'during_event', 'post_event');
 	CREATE TYPE resource_type AS ENUM ('staff', 'equipment', 'materials');
+	CREATE TYPE approval_status AS ENUM ('pending', 'approved', 'rejected');
 
 	-- Users table
 	CREATE TABLE users (
@@ -158,11 +170,31 @@ func initSchema(db *sql.DB) error {
 		is_available BOOLEAN NOT NULL DEFAULT true,
 		notes TEXT,
 		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		release_grace_minutes INTEGER NOT NULL DEFAULT 0 CHECK (release_grace_minutes >= 0),
+		timezone VARCHAR(64),
+		user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+		external_code VARCHAR(64)
 	);
 	CREATE INDEX idx_resources_type ON resources(type);
 	CREATE INDEX idx_resources_available ON resources(is_available);
 	CREATE INDEX idx_resources_name ON resources(name);
+	CREATE UNIQUE INDEX idx_resources_user_id ON resources(user_id) WHERE user_id IS NOT NULL;
+	CREATE UNIQUE INDEX idx_resources_external_code ON resources(external_code) WHERE external_code IS NOT NULL;
+
+	-- Staff working hours, one row per weekly shift
+	CREATE TABLE staff_availability (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		day_of_week INTEGER NOT NULL,
+		start_time VARCHAR(5) NOT NULL,
+		end_time VARCHAR(5) NOT NULL,
+		is_recurring BOOLEAN NOT NULL DEFAULT true,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX idx_staff_availability_user_id ON staff_availability(user_id);
 
 	-- Events table
 	CREATE TABLE events (
@@ -214,13 +246,66 @@ func initSchema(db *sql.DB) error {
 		end_time TIMESTAMPTZ NOT NULL,
 		notes TEXT,
 		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		approval_status approval_status NOT NULL DEFAULT 'approved',
+		cancelled_at TIMESTAMPTZ,
+		cancellation_reason TEXT,
+		quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+		resource_was_available BOOLEAN NOT NULL DEFAULT true,
+		recurrence_group_id INTEGER,
+		created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		whole_resource BOOLEAN,
+		CONSTRAINT resource_schedule_time_range_valid CHECK (end_time > start_time),
+		CONSTRAINT resource_schedule_no_overlap EXCLUDE USING gist (
+			resource_id WITH =,
+			tstzrange(start_time, end_time, '[)') WITH &&
+		) WHERE (whole_resource AND approval_status = 'approved' AND cancelled_at IS NULL)
 	);
 	CREATE INDEX idx_resource_schedule_resource_id ON resource_schedule(resource_id);
 	CREATE INDEX idx_resource_schedule_event_id ON resource_schedule(event_id);
 	CREATE INDEX idx_resource_schedule_task_id ON resource_schedule(task_id);
 	CREATE INDEX idx_resource_schedule_start_time ON resource_schedule(start_time);
 	CREATE INDEX idx_resource_schedule_end_time ON resource_schedule(end_time);
+	CREATE SEQUENCE resource_schedule_recurrence_group_seq;
+	CREATE INDEX idx_resource_schedule_recurrence_group ON resource_schedule(recurrence_group_id) WHERE recurrence_group_id IS NOT NULL;
+
+	-- Snapshot resources.is_available onto each new schedule entry
+	CREATE FUNCTION snapshot_resource_availability()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		SELECT is_available INTO NEW.resource_was_available
+		FROM resources
+		WHERE id = NEW.resource_id;
+		NEW.resource_was_available := COALESCE(NEW.resource_was_available, true);
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;
+	CREATE TRIGGER resource_schedule_availability_snapshot
+	BEFORE INSERT ON resource_schedule
+	FOR EACH ROW
+	EXECUTE FUNCTION snapshot_resource_availability();
+
+	-- Record whether each entry books its resource whole, unless the writer says
+	CREATE FUNCTION set_schedule_whole_resource()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		IF TG_OP = 'UPDATE' OR NEW.whole_resource IS NULL THEN
+			SELECT type = 'staff' INTO NEW.whole_resource
+			FROM resources
+			WHERE id = NEW.resource_id;
+		END IF;
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;
+	CREATE TRIGGER resource_schedule_whole_resource
+	BEFORE INSERT ON resource_schedule
+	FOR EACH ROW
+	EXECUTE FUNCTION set_schedule_whole_resource();
+	CREATE TRIGGER resource_schedule_whole_resource_moved
+	BEFORE UPDATE OF resource_id ON resource_schedule
+	FOR EACH ROW
+	WHEN (OLD.resource_id IS DISTINCT FROM NEW.resource_id)
+	EXECUTE FUNCTION set_schedule_whole_resource();
 
 	-- Task resources junction table (for completeness)
 	CREATE TABLE task_resources (
@@ -230,6 +315,78 @@ func initSchema(db *sql.DB) error {
 		assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
 		UNIQUE(task_id, resource_id)
 	);
+
+	-- Resource certifications
+	CREATE TABLE resource_certifications (
+		resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+		certification VARCHAR(100) NOT NULL,
+		expires_at TIMESTAMPTZ,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (resource_id, certification)
+	);
+	CREATE INDEX idx_resource_certifications_certification ON resource_certifications(certification);
+
+	-- Which resources can stand in for which
+	CREATE TABLE resource_substitutes (
+		resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+		substitute_resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (resource_id, substitute_resource_id),
+		CONSTRAINT resource_substitutes_not_self CHECK (resource_id <> substitute_resource_id)
+	);
+	CREATE INDEX idx_resource_substitutes_substitute ON resource_substitutes(substitute_resource_id);
+
+	-- Working hours set on the resource, replacing its user's shifts
+	CREATE TABLE resource_working_hours (
+		id SERIAL PRIMARY KEY,
+		resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+		day_of_week INTEGER NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+		start_time VARCHAR(5) NOT NULL,
+		end_time VARCHAR(5) NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX idx_resource_working_hours_resource_id ON resource_working_hours(resource_id);
+
+	-- Maintenance and other downtime, not tied to an event
+	CREATE TABLE resource_downtime (
+		id SERIAL PRIMARY KEY,
+		resource_id INTEGER NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+		start_time TIMESTAMPTZ NOT NULL,
+		end_time TIMESTAMPTZ NOT NULL,
+		reason TEXT,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		CONSTRAINT resource_downtime_time_range_valid CHECK (end_time > start_time)
+	);
+	CREATE INDEX idx_resource_downtime_resource_time ON resource_downtime(resource_id, start_time, end_time);
+
+	-- Company-wide days nothing can be booked on
+	CREATE TABLE blackout_dates (
+		id SERIAL PRIMARY KEY,
+		start_date DATE NOT NULL,
+		end_date DATE NOT NULL,
+		reason TEXT,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		CONSTRAINT blackout_dates_range_valid CHECK (end_date >= start_date)
+	);
+	CREATE INDEX idx_blackout_dates_range ON blackout_dates(start_date, end_date);
+
+	-- Idempotency keys of schedule entry creation
+	CREATE TABLE idempotency_keys (
+		key VARCHAR(255) PRIMARY KEY,
+		request_hash CHAR(64) NOT NULL,
+		schedule_entry_id INTEGER NOT NULL REFERENCES resource_schedule(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+	-- Feature flags
+	CREATE TABLE feature_flags (
+		key VARCHAR(100) PRIMARY KEY,
+		enabled BOOLEAN NOT NULL DEFAULT false,
+		description TEXT,
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
 	`
 
 	_, err := db.Exec(schema)
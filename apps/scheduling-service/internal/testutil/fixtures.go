@@ -120,11 +120,19 @@ func CreateClient(t *testing.T, db *sql.DB, opts *ClientOpts) int32 {
 
 // ResourceOpts contains optional fields for creating a resource
 type ResourceOpts struct {
-	Name        string
-	Type        string
-	HourlyRate  *string
-	IsAvailable bool
-	Notes       *string
+	Name                string
+	Type                string
+	HourlyRate          *string
+	IsAvailable         bool
+	Notes               *string
+	ReleaseGraceMinutes int32
+	Timezone            *string
+	// UserID links a staff resource to the user whose working hours apply
+	UserID *int32
+	// Quantity is the number of units owned; zero means one
+	Quantity int32
+	// ExternalCode is the code integrations use for the resource
+	ExternalCode *string
 }
 
 // CreateResource creates a test resource and returns its ID
@@ -135,6 +143,7 @@ func CreateResource(t *testing.T, db *sql.DB, opts *ResourceOpts) int32 {
 	name := fmt.Sprintf("Resource %d", resourceCounter)
 	resourceType := ResourceTypeStaff
 	isAvailable := true
+	var releaseGraceMinutes int32
 
 	if opts != nil {
 		if opts.Name != "" {
@@ -144,6 +153,20 @@ func CreateResource(t *testing.T, db *sql.DB, opts *ResourceOpts) int32 {
 			resourceType = opts.Type
 		}
 		isAvailable = opts.IsAvailable
+		releaseGraceMinutes = opts.ReleaseGraceMinutes
+	}
+
+	var timezone *string
+	var userID *int32
+	var externalCode *string
+	quantity := int32(1)
+	if opts != nil {
+		timezone = opts.Timezone
+		userID = opts.UserID
+		externalCode = opts.ExternalCode
+		if opts.Quantity > 0 {
+			quantity = opts.Quantity
+		}
 	}
 
 	var id int32
@@ -151,16 +174,16 @@ func CreateResource(t *testing.T, db *sql.DB, opts *ResourceOpts) int32 {
 
 	if opts != nil && opts.HourlyRate != nil {
 		err = db.QueryRow(`
-			INSERT INTO resources (name, type, hourly_rate, is_available, notes)
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO resources (name, type, hourly_rate, is_available, notes, release_grace_minutes, timezone, user_id, quantity, external_code)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			RETURNING id
-		`, name, resourceType, *opts.HourlyRate, isAvailable, opts.Notes).Scan(&id)
+		`, name, resourceType, *opts.HourlyRate, isAvailable, opts.Notes, releaseGraceMinutes, timezone, userID, quantity, externalCode).Scan(&id)
 	} else {
 		err = db.QueryRow(`
-			INSERT INTO resources (name, type, is_available)
-			VALUES ($1, $2, $3)
+			INSERT INTO resources (name, type, is_available, release_grace_minutes, timezone, user_id, quantity, external_code)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 			RETURNING id
-		`, name, resourceType, isAvailable).Scan(&id)
+		`, name, resourceType, isAvailable, releaseGraceMinutes, timezone, userID, quantity, externalCode).Scan(&id)
 	}
 
 	if err != nil {
@@ -260,6 +283,19 @@ func CreateTask(t *testing.T, db *sql.DB, eventID int32, opts *TaskOpts) int32 {
 type ScheduleEntryOpts struct {
 	TaskID *int32
 	Notes  *string
+	// ApprovalStatus defaults to approved when empty
+	ApprovalStatus string
+	// CancelledAt marks the entry as cancelled when set
+	CancelledAt *time.Time
+	// Quantity is the number of units booked; zero means one
+	Quantity int32
+	// CreatedBy is the user recorded as having booked the entry
+	CreatedBy *int32
+	// WholeResource leaves whole_resource for the database to take from the
+	// resource, as it does for any other writer, so the entry falls under the
+	// overlap constraint. Otherwise it is stored false, so tests can seed
+	// overlapping bookings.
+	WholeResource bool
 }
 
 // CreateScheduleEntry creates a resource schedule entry and returns its ID.
@@ -269,18 +305,34 @@ func CreateScheduleEntry(t *testing.T, db *sql.DB, resourceID, eventID int32, st
 
 	var taskID *int32
 	var notes *string
+	var cancelledAt *time.Time
+	var createdBy *int32
+	approvalStatus := "approved"
+	quantity := int32(1)
+	wholeResource := sql.NullBool{Valid: true}
 
 	if opts != nil {
+		if opts.WholeResource {
+			wholeResource = sql.NullBool{}
+		}
 		taskID = opts.TaskID
 		notes = opts.Notes
+		cancelledAt = opts.CancelledAt
+		createdBy = opts.CreatedBy
+		if opts.ApprovalStatus != "" {
+			approvalStatus = opts.ApprovalStatus
+		}
+		if opts.Quantity > 0 {
+			quantity = opts.Quantity
+		}
 	}
 
 	var id int32
 	err := db.QueryRow(`
-		INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO resource_schedule (resource_id, event_id, task_id, start_time, end_time, notes, approval_status, cancelled_at, quantity, created_by, whole_resource)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
-	`, resourceID, eventID, taskID, startTime, endTime, notes).Scan(&id)
+	`, resourceID, eventID, taskID, startTime, endTime, notes, approvalStatus, cancelledAt, quantity, createdBy, wholeResource).Scan(&id)
 
 	if err != nil {
 		t.Fatalf("failed to create schedule entry: %v", err)
@@ -289,6 +341,54 @@ func CreateScheduleEntry(t *testing.T, db *sql.DB, resourceID, eventID int32, st
 	return id
 }
 
+// CreateStaffAvailability adds a weekly working-hours shift for a user and
+// returns its ID. dayOfWeek is 0 for Sunday; times are HH:MM.
+func CreateStaffAvailability(t *testing.T, db *sql.DB, userID int32, dayOfWeek time.Weekday, startTime, endTime string) int32 {
+	t.Helper()
+
+	var id int32
+	err := db.QueryRow(`
+		INSERT INTO staff_availability (user_id, day_of_week, start_time, end_time)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, userID, int(dayOfWeek), startTime, endTime).Scan(&id)
+
+	if err != nil {
+		t.Fatalf("failed to create staff availability: %v", err)
+	}
+
+	return id
+}
+
+// CreateResourceCertification records that a resource holds a certification.
+// A nil expiresAt means it never expires.
+func CreateResourceCertification(t *testing.T, db *sql.DB, resourceID int32, certification string, expiresAt *time.Time) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		INSERT INTO resource_certifications (resource_id, certification, expires_at)
+		VALUES ($1, $2, $3)
+	`, resourceID, certification, expiresAt)
+
+	if err != nil {
+		t.Fatalf("failed to create resource certification: %v", err)
+	}
+}
+
+// CreateResourceSubstitute records that substituteID can cover resourceID
+func CreateResourceSubstitute(t *testing.T, db *sql.DB, resourceID, substituteID int32) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		INSERT INTO resource_substitutes (resource_id, substitute_resource_id)
+		VALUES ($1, $2)
+	`, resourceID, substituteID)
+
+	if err != nil {
+		t.Fatalf("failed to create resource substitute: %v", err)
+	}
+}
+
 // TimeRange represents a start and end time for test scenarios
 type TimeRange struct {
 	Start time.Time
@@ -353,3 +453,17 @@ func SetupBaseData(t *testing.T, db *sql.DB) (userID, clientID, eventID int32) {
 
 	return userID, clientID, eventID
 }
+
+// SetFeatureFlag creates or updates a feature flag row
+func SetFeatureFlag(t *testing.T, db *sql.DB, key string, enabled bool) {
+	t.Helper()
+
+	_, err := db.Exec(`
+		INSERT INTO feature_flags (key, enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()
+	`, key, enabled)
+	if err != nil {
+		t.Fatalf("failed to set feature flag %s: %v", key, err)
+	}
+}
@@ -0,0 +1,14 @@
+// Package version identifies the running build
+package version
+
+// Build information, set at build time with -ldflags, e.g.
+// -ldflags "-X github.com/catering-event-manager/scheduling-service/internal/version.Commit=abc123".
+// The Dockerfile sets all three from its build arguments.
+var (
+	// Version is the release version, "dev" for a local build
+	Version = "dev"
+	// Commit is the git commit the binary was built from
+	Commit = "unknown"
+	// BuildTime is when the binary was built
+	BuildTime = "unknown"
+)
@@ -0,0 +1,170 @@
+// Package webhook delivers JSON notifications to an external URL without
+// holding up the request that raised them
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+const (
+	// DefaultTimeout bounds a single delivery, so a slow receiver can't back
+	// up the queue for long
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultQueueSize is how many notifications may wait for delivery before
+	// new ones are dropped
+	DefaultQueueSize = 100
+)
+
+// Notifier posts notifications to a URL from a bounded queue, one at a time,
+// on its own goroutine. Deliveries are attempted once; failures are logged.
+type Notifier struct {
+	url string
+	// host is logged in place of the URL, which may embed a secret token, as
+	// Slack's do
+	host   string
+	client *http.Client
+	queue  chan notification
+	// done is closed once the queue is closed and emptied
+	done chan struct{}
+
+	// mu guards closed, so nothing is queued after Close
+	mu     sync.Mutex
+	closed bool
+}
+
+// notification is a delivery waiting in the queue. ctx is the notifying
+// request's context without its cancellation, so delivery logs keep the
+// request's ID.
+type notification struct {
+	ctx  context.Context
+	body []byte
+}
+
+// ParseURL validates a webhook URL, which must be absolute http or https
+func ParseURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("must be an absolute http or https URL")
+	}
+	return u.String(), nil
+}
+
+// New starts a notifier posting to rawURL, each delivery bounded by timeout and
+// at most queueSize notifications waiting
+func New(rawURL string, timeout time.Duration, queueSize int) *Notifier {
+	var host string
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Host
+	}
+	n := &Notifier{
+		url:    rawURL,
+		host:   host,
+		client: &http.Client{Timeout: timeout},
+		queue:  make(chan notification, queueSize),
+		done:   make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Notify queues payload for delivery as JSON and returns at once. If the queue
+// is full, or the notifier is closed, the notification is dropped and logged
+// rather than waiting. What is logged about it carries ctx's request ID, even
+// once ctx has ended.
+func (n *Notifier) Notify(ctx context.Context, payload interface{}) {
+	l := logger.Get().WithRequestID(ctx)
+	// Encode now, so the caller is free to change payload afterwards
+	body, err := json.Marshal(payload)
+	if err != nil {
+		l.Error().Err(err).Msg("Failed to encode webhook payload")
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		l.Warn().Str("host", n.host).Msg("Webhook closed, notification dropped")
+		return
+	}
+	select {
+	case n.queue <- notification{ctx: context.WithoutCancel(ctx), body: body}:
+	default:
+		l.Warn().Str("host", n.host).Msg("Webhook queue full, notification dropped")
+	}
+}
+
+// Close stops taking notifications and waits until those already queued have
+// been delivered, or until ctx is done, in which case the rest are abandoned
+// and ctx's error is returned
+func (n *Notifier) Close(ctx context.Context) error {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		logger.Get().Warn().Str("host", n.host).Int("pending", len(n.queue)).Msg("Webhook notifications abandoned at shutdown")
+		return fmt.Errorf("webhook queue not drained: %w", ctx.Err())
+	}
+}
+
+// run delivers queued notifications in order
+func (n *Notifier) run() {
+	defer close(n.done)
+	for note := range n.queue {
+		n.deliver(note)
+	}
+}
+
+// deliver posts one notification, logging any failure
+func (n *Notifier) deliver(note notification) {
+	l := logger.Get().WithRequestID(note.ctx)
+	req, err := http.NewRequestWithContext(note.ctx, http.MethodPost, n.url, bytes.NewReader(note.body))
+	if err != nil {
+		l.Error().Err(redactURL(err)).Str("host", n.host).Msg("Failed to build webhook request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		l.Error().Err(redactURL(err)).Str("host", n.host).Msg("Webhook delivery failed")
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		l.Error().
+			Str("host", n.host).
+			Int("status", resp.StatusCode).
+			Msg("Webhook delivery rejected")
+	}
+}
+
+// redactURL strips the request URL from an error about it, keeping only the
+// cause, since the URL may embed a secret token
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request failed: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
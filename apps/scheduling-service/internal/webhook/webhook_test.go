@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/catering-event-manager/scheduling-service/internal/logger"
+)
+
+// receive starts a server that passes each request body it receives to the
+// returned channel, answering with status
+func receive(t *testing.T, status int) (*httptest.Server, <-chan []byte) {
+	t.Helper()
+	bodies := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, bodies
+}
+
+// next waits for the next delivery
+func next(t *testing.T, bodies <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case body := <-bodies:
+		return body
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+		return nil
+	}
+}
+
+func TestNotify_DeliversPayload(t *testing.T) {
+	srv, bodies := receive(t, http.StatusOK)
+	n := New(srv.URL, time.Second, 10)
+
+	type payload struct {
+		HasConflicts bool `json:"has_conflicts"`
+		Count        int  `json:"conflict_count"`
+	}
+	sent := &payload{HasConflicts: true, Count: 2}
+	n.Notify(context.Background(), sent)
+	// Changing the payload after Notify doesn't change what is delivered
+	sent.Count = 99
+
+	var got payload
+	require.NoError(t, json.Unmarshal(next(t, bodies), &got))
+	assert.Equal(t, payload{HasConflicts: true, Count: 2}, got)
+}
+
+func TestNotify_KeepsDeliveringAfterFailure(t *testing.T) {
+	srv, bodies := receive(t, http.StatusInternalServerError)
+	n := New(srv.URL, time.Second, 10)
+
+	n.Notify(context.Background(), map[string]int{"n": 1})
+	n.Notify(context.Background(), map[string]int{"n": 2})
+
+	assert.JSONEq(t, `{"n":1}`, string(next(t, bodies)))
+	assert.JSONEq(t, `{"n":2}`, string(next(t, bodies)))
+}
+
+func TestNotify_DoesNotBlockWhenQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	n := New(srv.URL, time.Second, 1)
+
+	done := make(chan struct{})
+	go func() {
+		// One in flight, one queued, the rest dropped
+		for i := 0; i < 5; i++ {
+			n.Notify(context.Background(), i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Notify blocked on a full queue")
+	}
+}
+
+// logBuffer collects log lines written from the delivery goroutine
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestClose_DeliversQueuedNotifications(t *testing.T) {
+	srv, bodies := receive(t, http.StatusOK)
+	n := New(srv.URL, time.Second, 10)
+	for i := 0; i < 3; i++ {
+		n.Notify(context.Background(), i)
+	}
+
+	require.NoError(t, n.Close(context.Background()))
+	assert.Len(t, bodies, 3, "every queued notification is delivered before Close returns")
+
+	// Later notifications are dropped rather than sent on a closed queue
+	n.Notify(context.Background(), 4)
+	assert.Len(t, bodies, 3)
+	require.NoError(t, n.Close(context.Background()))
+}
+
+func TestClose_GivesUpAtDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	n := New(srv.URL, 5*time.Second, 10)
+	n.Notify(context.Background(), 1)
+	n.Notify(context.Background(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, n.Close(ctx), context.DeadlineExceeded)
+}
+
+func TestNotify_FailedDeliveryDoesNotLogURL(t *testing.T) {
+	logs := &logBuffer{}
+	original := logger.Default
+	logger.Default = logger.NewWithOutput(logs, logger.InfoLevel)
+	t.Cleanup(func() { logger.Default = original })
+
+	// Nothing listens any more, so the delivery fails before any response
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	n := New(srv.URL+"/services/T000/B000/s3cr3tT0ken", time.Second, 10)
+
+	n.Notify(context.Background(), map[string]int{"n": 1})
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), "Webhook delivery failed")
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, logs.String(), "s3cr3tT0ken")
+	assert.NotContains(t, logs.String(), "/services/")
+	assert.Contains(t, logs.String(), strings.TrimPrefix(srv.URL, "http://"))
+}
+
+func TestParseURL(t *testing.T) {
+	u, err := ParseURL("https://hooks.example.com/services/T000/B000")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/services/T000/B000", u)
+
+	for _, v := range []string{"hooks.example.com/x", "ftp://example.com", "/relative", "https://", "::"} {
+		_, err := ParseURL(v)
+		assert.Error(t, err, v)
+	}
+}
@@ -0,0 +1,11 @@
+-- Migration 0014: Add release grace period to resources
+-- Cleanup often runs past a booking's nominal end_time. The scheduling service
+-- extends each existing booking's effective end by this many minutes when
+-- checking for conflicts, so a new booking can't start immediately.
+
+ALTER TABLE resources
+  ADD COLUMN IF NOT EXISTS release_grace_minutes integer DEFAULT 0 NOT NULL;
+
+ALTER TABLE resources
+  ADD CONSTRAINT resources_release_grace_minutes_non_negative
+  CHECK (release_grace_minutes >= 0);
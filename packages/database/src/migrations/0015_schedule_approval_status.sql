@@ -0,0 +1,13 @@
+-- Migration 0015: Add approval workflow state to resource schedule entries
+-- Bookings can require a manager's approval before becoming active. Existing
+-- rows default to approved so current behavior is unchanged. The scheduling
+-- service treats pending entries as soft conflicts and ignores rejected ones.
+
+CREATE TYPE "public"."approval_status" AS ENUM('pending', 'approved', 'rejected');
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS approval_status approval_status DEFAULT 'approved' NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_resource_schedule_pending
+  ON resource_schedule (resource_id)
+  WHERE approval_status = 'pending';
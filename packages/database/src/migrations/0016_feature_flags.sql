@@ -0,0 +1,18 @@
+-- Migration 0016: Add feature flags
+-- Toggles optional scheduling behaviour without a redeploy. The scheduling
+-- service caches flags briefly and treats a missing row (or table) as off.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+  key varchar(100) PRIMARY KEY,
+  enabled boolean DEFAULT false NOT NULL,
+  description text,
+  updated_at timestamp DEFAULT now() NOT NULL
+);
+
+-- Blocked from the Supabase REST API like every other table (see 0005); the
+-- service connects as the superuser, which bypasses RLS
+ALTER TABLE feature_flags ENABLE ROW LEVEL SECURITY;
+
+INSERT INTO feature_flags (key, enabled, description)
+VALUES ('pending_bookings_block', false, 'Treat bookings pending approval as hard conflicts instead of warnings')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Migration 0017: Add an operating timezone to resources
+-- Venues and similar resources run on a fixed local clock that can differ from
+-- the event's. The scheduling service validates the IANA zone name on write and
+-- can render a resource's availability in it. NULL means no local zone (UTC).
+
+ALTER TABLE resources
+  ADD COLUMN IF NOT EXISTS timezone varchar(64);
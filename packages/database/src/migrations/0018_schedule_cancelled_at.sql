@@ -0,0 +1,6 @@
+-- Migration 0018: Record when a schedule entry was cancelled
+-- Cancelled entries are kept for history instead of being deleted. Reports
+-- exclude them unless asked to include them with include_cancelled=true.
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS cancelled_at timestamp with time zone;
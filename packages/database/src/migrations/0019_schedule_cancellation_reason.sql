@@ -0,0 +1,6 @@
+-- Migration 0019: Record why a schedule entry was cancelled
+-- Cancelling keeps the entry for history with cancelled_at set; the optional
+-- reason explains the intent, unlike a hard delete which leaves no trace.
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS cancellation_reason text;
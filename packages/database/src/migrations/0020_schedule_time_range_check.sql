@@ -0,0 +1,15 @@
+-- Migration 0020: Require schedule entries to end after they start
+-- Conflict detection assumes every booking has a positive length. Databases
+-- built from the drizzle journal never got this constraint, so rows imported
+-- out of band may break that assumption. The constraint is added NOT VALID so
+-- existing rows don't block the migration; the scheduling service reports them
+-- at /api/v1/scheduling/integrity/inverted-ranges. Once they are repaired, run:
+--   ALTER TABLE resource_schedule VALIDATE CONSTRAINT resource_schedule_time_range_valid;
+
+DO $$ BEGIN
+  ALTER TABLE resource_schedule
+    ADD CONSTRAINT resource_schedule_time_range_valid
+    CHECK (end_time > start_time) NOT VALID;
+EXCEPTION
+  WHEN duplicate_object THEN null;
+END $$;
@@ -0,0 +1,19 @@
+-- Migration 0021: Track certifications held by resources
+-- Some tasks can only be staffed or equipped by a resource holding a given
+-- certification (e.g. food-safety). The scheduling service reports a hard
+-- conflict when a conflict check names a required certification the resource
+-- lacks. A NULL expires_at means the certification does not expire.
+
+CREATE TABLE IF NOT EXISTS resource_certifications (
+  resource_id integer NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+  certification varchar(100) NOT NULL,
+  expires_at timestamptz,
+  created_at timestamp NOT NULL DEFAULT now(),
+  PRIMARY KEY (resource_id, certification)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_certifications_certification
+  ON resource_certifications (certification);
+
+-- Enable RLS, as for every other table
+ALTER TABLE resource_certifications ENABLE ROW LEVEL SECURITY;
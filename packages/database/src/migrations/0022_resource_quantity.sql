@@ -0,0 +1,27 @@
+-- Migration 0022: Track how many units of a resource exist and are booked
+-- Equipment and materials often come in interchangeable units (ten chafing
+-- dishes, not one). resources.quantity is the number of units owned and
+-- resource_schedule.quantity the number a booking takes. The scheduling service
+-- refuses equipment and materials bookings that would take more units than are
+-- free at the busiest moment of the range. Both default to 1, which keeps the
+-- existing one-booking-at-a-time behaviour.
+
+ALTER TABLE resources
+  ADD COLUMN IF NOT EXISTS quantity integer NOT NULL DEFAULT 1;
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS quantity integer NOT NULL DEFAULT 1;
+
+DO $$ BEGIN
+  ALTER TABLE resources
+    ADD CONSTRAINT resources_quantity_positive CHECK (quantity > 0);
+EXCEPTION
+  WHEN duplicate_object THEN null;
+END $$;
+
+DO $$ BEGIN
+  ALTER TABLE resource_schedule
+    ADD CONSTRAINT resource_schedule_quantity_positive CHECK (quantity > 0);
+EXCEPTION
+  WHEN duplicate_object THEN null;
+END $$;
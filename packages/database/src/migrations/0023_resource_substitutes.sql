@@ -0,0 +1,20 @@
+-- Migration 0023: Record which resources can substitute for which
+-- Conflict resolution used to propose any free resource of the same type as a
+-- substitute. Same type isn't enough (a pastry chef can't cover a sommelier),
+-- so the scheduling service now only proposes resources listed here. Each row
+-- says substitute_resource_id can cover resource_id; list both directions for
+-- resources that can cover each other.
+
+CREATE TABLE IF NOT EXISTS resource_substitutes (
+  resource_id integer NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+  substitute_resource_id integer NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+  created_at timestamp NOT NULL DEFAULT now(),
+  PRIMARY KEY (resource_id, substitute_resource_id),
+  CONSTRAINT resource_substitutes_not_self CHECK (resource_id <> substitute_resource_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_substitutes_substitute
+  ON resource_substitutes (substitute_resource_id);
+
+-- Enable RLS, as for every other table
+ALTER TABLE resource_substitutes ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,26 @@
+-- Migration 0024: Snapshot whether the resource was available when an entry was created
+-- Bookings can be written against a resource marked unavailable, both by the
+-- web app and by the scheduling service. A BEFORE INSERT trigger copies
+-- resources.is_available onto resource_schedule.resource_was_available so every
+-- writer records it. Later changes to the resource don't touch the snapshot.
+-- Entries created before this migration are assumed to have been available.
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS resource_was_available boolean NOT NULL DEFAULT true;
+
+CREATE OR REPLACE FUNCTION snapshot_resource_availability()
+RETURNS TRIGGER AS $$
+BEGIN
+  SELECT is_available INTO NEW.resource_was_available
+  FROM resources
+  WHERE id = NEW.resource_id;
+  NEW.resource_was_available := COALESCE(NEW.resource_was_available, true);
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resource_schedule_availability_snapshot ON resource_schedule;
+CREATE TRIGGER resource_schedule_availability_snapshot
+BEFORE INSERT ON resource_schedule
+FOR EACH ROW
+EXECUTE FUNCTION snapshot_resource_availability();
@@ -0,0 +1,13 @@
+-- Migration 0025: Group the entries of a recurring booking
+-- A recurring booking is stored as one resource_schedule row per occurrence.
+-- recurrence_group_id ties the rows together so the set can be cancelled at
+-- once; ids come from their own sequence. One-off entries leave it NULL.
+
+CREATE SEQUENCE IF NOT EXISTS resource_schedule_recurrence_group_seq;
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS recurrence_group_id integer;
+
+CREATE INDEX IF NOT EXISTS idx_resource_schedule_recurrence_group
+  ON resource_schedule(recurrence_group_id)
+  WHERE recurrence_group_id IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Migration 0026: Working hours configured on the resource itself
+-- Until now a resource's working hours came only from the staff_availability
+-- shifts of its linked user, so equipment and unlinked staff had none. Rows
+-- here use the same weekly HH:MM shifts and, when a resource has any, replace
+-- its user's shifts. A resource with neither is treated as always available.
+
+CREATE TABLE IF NOT EXISTS resource_working_hours (
+  id serial PRIMARY KEY,
+  resource_id integer NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+  day_of_week integer NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+  start_time varchar(5) NOT NULL,
+  end_time varchar(5) NOT NULL,
+  created_at timestamp DEFAULT now() NOT NULL,
+  updated_at timestamp DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_working_hours_resource_id
+  ON resource_working_hours(resource_id);
+
+-- Enable RLS, as for every other table
+ALTER TABLE resource_working_hours ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,21 @@
+-- Migration 0027: Downtime windows on resources
+-- Maintenance, cleaning, or repairs take a resource out of service without
+-- belonging to any event, so they can't be stored in resource_schedule, whose
+-- event_id is required. A resource can't be booked during its downtime, and
+-- conflict checks report any overlap as a hard conflict.
+
+CREATE TABLE IF NOT EXISTS resource_downtime (
+  id serial PRIMARY KEY,
+  resource_id integer NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+  start_time timestamptz NOT NULL,
+  end_time timestamptz NOT NULL,
+  reason text,
+  created_at timestamp DEFAULT now() NOT NULL,
+  CONSTRAINT resource_downtime_time_range_valid CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_downtime_resource_time
+  ON resource_downtime(resource_id, start_time, end_time);
+
+-- Enable RLS, as for every other table
+ALTER TABLE resource_downtime ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,20 @@
+-- Migration 0028: Blackout dates
+-- Company-wide days, such as public holidays or staff training days, on which
+-- no resource can be booked. Dates are whole days in the scheduling service's
+-- business timezone (BUSINESS_TIMEZONE); end_date is inclusive, so a
+-- single-day blackout has start_date = end_date.
+
+CREATE TABLE IF NOT EXISTS blackout_dates (
+  id serial PRIMARY KEY,
+  start_date date NOT NULL,
+  end_date date NOT NULL,
+  reason text,
+  created_at timestamp DEFAULT now() NOT NULL,
+  CONSTRAINT blackout_dates_range_valid CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blackout_dates_range
+  ON blackout_dates(start_date, end_date);
+
+-- Enable RLS, as for every other table
+ALTER TABLE blackout_dates ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,7 @@
+-- Migration 0029: Record who created each schedule entry
+-- created_by is the authenticated user whose request booked the entry, kept
+-- for auditing. Entries written by internal jobs, and those that predate this
+-- column, leave it NULL. Deleting the user keeps the entry.
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS created_by integer REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+-- Migration 0030: External codes for resources
+-- Integrations refer to resources by their own code rather than our serial
+-- id. external_code is optional, and unique among the resources that have one.
+
+ALTER TABLE resources
+  ADD COLUMN IF NOT EXISTS external_code varchar(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_resources_external_code
+  ON resources(external_code)
+  WHERE external_code IS NOT NULL;
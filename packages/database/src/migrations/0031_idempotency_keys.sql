@@ -0,0 +1,19 @@
+-- Migration 0031: Idempotency keys for schedule entry creation
+-- A client retrying a booking sends the same Idempotency-Key, and the
+-- scheduling service answers with the entry the first attempt created instead
+-- of booking again. request_hash fingerprints the request body so a key can't
+-- be reused for a different booking. Keys are honoured for 24 hours after
+-- created_at; the service deletes older rows hourly.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  key varchar(255) PRIMARY KEY,
+  request_hash char(64) NOT NULL,
+  schedule_entry_id integer NOT NULL REFERENCES resource_schedule(id) ON DELETE CASCADE,
+  created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at
+  ON idempotency_keys(created_at);
+
+-- Enable RLS, as for every other table
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,57 @@
+-- Migration 0032: Refuse overlapping bookings of a whole resource
+-- The scheduling service checks for conflicts under a lock on the resource,
+-- but other writers to resource_schedule don't. This backs the check with an
+-- exclusion constraint over the bookings that block a resource outright:
+-- approved, not cancelled, and of a resource booked whole. Pending entries
+-- only soft-conflict and equipment and materials bookings share units, so
+-- those may still overlap.
+--
+-- whole_resource records whether the entry books its resource whole, which is
+-- true of staff. It is copied from the resource when the entry is written or
+-- moved to another resource, unless the writer sets it. Entries written before
+-- this migration keep NULL and are not covered, so overlaps already in the
+-- table don't stop the constraint from being added; the scheduling service
+-- reports them at /api/v1/scheduling/all-conflicts.
+--
+-- 0003_resources.sql installed a constraint of the same name over every row,
+-- which the data model doesn't allow; databases built from the drizzle journal
+-- never got it. It is replaced here.
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE resource_schedule
+  ADD COLUMN IF NOT EXISTS whole_resource boolean;
+
+CREATE OR REPLACE FUNCTION set_schedule_whole_resource()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' OR NEW.whole_resource IS NULL THEN
+    SELECT type = 'staff' INTO NEW.whole_resource
+    FROM resources
+    WHERE id = NEW.resource_id;
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS resource_schedule_whole_resource ON resource_schedule;
+CREATE TRIGGER resource_schedule_whole_resource
+BEFORE INSERT ON resource_schedule
+FOR EACH ROW
+EXECUTE FUNCTION set_schedule_whole_resource();
+
+DROP TRIGGER IF EXISTS resource_schedule_whole_resource_moved ON resource_schedule;
+CREATE TRIGGER resource_schedule_whole_resource_moved
+BEFORE UPDATE OF resource_id ON resource_schedule
+FOR EACH ROW
+WHEN (OLD.resource_id IS DISTINCT FROM NEW.resource_id)
+EXECUTE FUNCTION set_schedule_whole_resource();
+
+ALTER TABLE resource_schedule DROP CONSTRAINT IF EXISTS resource_schedule_no_overlap;
+ALTER TABLE resource_schedule
+  ADD CONSTRAINT resource_schedule_no_overlap
+  EXCLUDE USING gist (
+    resource_id WITH =,
+    tstzrange(start_time, end_time, '[)') WITH &&
+  )
+  WHERE (whole_resource AND approval_status = 'approved' AND cancelled_at IS NULL);
@@ -0,0 +1,17 @@
+import { date, index, pgTable, serial, text, timestamp } from 'drizzle-orm/pg-core';
+
+// Company-wide days when nothing can be booked; both dates are inclusive and
+// read in the business timezone
+export const blackoutDates = pgTable(
+  'blackout_dates',
+  {
+    id: serial('id').primaryKey(),
+    startDate: date('start_date').notNull(),
+    endDate: date('end_date').notNull(),
+    reason: text('reason'),
+    createdAt: timestamp('created_at').defaultNow().notNull(),
+  },
+  (table) => ({
+    rangeIdx: index('idx_blackout_dates_range').on(table.startDate, table.endDate),
+  })
+);
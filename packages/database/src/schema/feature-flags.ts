@@ -0,0 +1,9 @@
+import { boolean, pgTable, text, timestamp, varchar } from 'drizzle-orm/pg-core';
+
+// Scheduling service feature flags; a missing row reads as off
+export const featureFlags = pgTable('feature_flags', {
+  key: varchar('key', { length: 100 }).primaryKey(),
+  enabled: boolean('enabled').default(false).notNull(),
+  description: text('description'),
+  updatedAt: timestamp('updated_at').defaultNow().notNull(),
+});
@@ -0,0 +1,18 @@
+import { char, index, integer, pgTable, timestamp, varchar } from 'drizzle-orm/pg-core';
+import { resourceSchedule } from './resource-schedule';
+
+// Idempotency-Key headers of schedule entry creation, honoured for 24 hours
+export const idempotencyKeys = pgTable(
+  'idempotency_keys',
+  {
+    key: varchar('key', { length: 255 }).primaryKey(),
+    requestHash: char('request_hash', { length: 64 }).notNull(), // SHA-256 of the request, hex
+    scheduleEntryId: integer('schedule_entry_id')
+      .references(() => resourceSchedule.id, { onDelete: 'cascade' })
+      .notNull(),
+    createdAt: timestamp('created_at', { withTimezone: true }).defaultNow().notNull(),
+  },
+  (table) => ({
+    createdAtIdx: index('idx_idempotency_keys_created_at').on(table.createdAt),
+  })
+);
@@ -1,3 +1,4 @@
+export * from './blackout-dates';
 export * from './clients'; // Must be before users due to FK reference
 export * from './communications';
 export * from './documents';
@@ -6,6 +7,8 @@ export * from './event-menus';
 export * from './event-status-log';
 export * from './events';
 export * from './expenses';
+export * from './feature-flags';
+export * from './idempotency-keys';
 export * from './invoice-line-items';
 export * from './invoices';
 export * from './menu-items';
@@ -13,7 +16,11 @@ export * from './notification-preferences';
 export * from './notifications';
 export * from './payments';
 export * from './portal-access-log';
+export * from './resource-certifications';
+export * from './resource-downtime';
 export * from './resource-schedule';
+export * from './resource-substitutes';
+export * from './resource-working-hours';
 export * from './resources';
 export * from './staff-availability';
 export * from './staff-skills';
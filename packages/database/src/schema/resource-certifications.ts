@@ -0,0 +1,19 @@
+import { index, integer, pgTable, primaryKey, timestamp, varchar } from 'drizzle-orm/pg-core';
+import { resources } from './resources';
+
+// Certifications held by resources; a null expiresAt never expires
+export const resourceCertifications = pgTable(
+  'resource_certifications',
+  {
+    resourceId: integer('resource_id')
+      .references(() => resources.id, { onDelete: 'cascade' })
+      .notNull(),
+    certification: varchar('certification', { length: 100 }).notNull(),
+    expiresAt: timestamp('expires_at', { withTimezone: true }),
+    createdAt: timestamp('created_at').defaultNow().notNull(),
+  },
+  (table) => ({
+    pk: primaryKey({ columns: [table.resourceId, table.certification] }),
+    certificationIdx: index('idx_resource_certifications_certification').on(table.certification),
+  })
+);
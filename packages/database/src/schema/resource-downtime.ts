@@ -0,0 +1,24 @@
+import { index, integer, pgTable, serial, text, timestamp } from 'drizzle-orm/pg-core';
+import { resources } from './resources';
+
+// Maintenance windows during which a resource can't be booked
+export const resourceDowntime = pgTable(
+  'resource_downtime',
+  {
+    id: serial('id').primaryKey(),
+    resourceId: integer('resource_id')
+      .references(() => resources.id, { onDelete: 'cascade' })
+      .notNull(),
+    startTime: timestamp('start_time', { withTimezone: true }).notNull(),
+    endTime: timestamp('end_time', { withTimezone: true }).notNull(),
+    reason: text('reason'),
+    createdAt: timestamp('created_at').defaultNow().notNull(),
+  },
+  (table) => ({
+    resourceTimeIdx: index('idx_resource_downtime_resource_time').on(
+      table.resourceId,
+      table.startTime,
+      table.endTime
+    ),
+  })
+);
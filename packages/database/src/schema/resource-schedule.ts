@@ -1,12 +1,25 @@
-import { index, integer, pgTable, serial, text, timestamp } from 'drizzle-orm/pg-core';
+import { sql } from 'drizzle-orm';
+import {
+  boolean,
+  index,
+  integer,
+  pgEnum,
+  pgTable,
+  serial,
+  text,
+  timestamp,
+} from 'drizzle-orm/pg-core';
 import { events } from './events';
 import { resources } from './resources';
 import { tasks } from './tasks';
+import { users } from './users';
 
 // Note: PostgreSQL tstzrange type is not directly supported by Drizzle ORM
 // We use start_time and end_time columns with application-level checks
 // The migration will create a GiST index and EXCLUDE constraint using raw SQL
 
+export const approvalStatusEnum = pgEnum('approval_status', ['pending', 'approved', 'rejected']);
+
 export const resourceSchedule = pgTable(
   'resource_schedule',
   {
@@ -21,6 +34,19 @@ export const resourceSchedule = pgTable(
     startTime: timestamp('start_time', { withTimezone: true }).notNull(),
     endTime: timestamp('end_time', { withTimezone: true }).notNull(),
     notes: text('notes'),
+    quantity: integer('quantity').default(1).notNull(),
+    approvalStatus: approvalStatusEnum('approval_status').default('approved').notNull(),
+    cancelledAt: timestamp('cancelled_at', { withTimezone: true }),
+    cancellationReason: text('cancellation_reason'),
+    // Set by a trigger from resources.is_available when the entry is created
+    resourceWasAvailable: boolean('resource_was_available').default(true).notNull(),
+    // Shared by the entries of one recurring booking, numbered from
+    // resource_schedule_recurrence_group_seq
+    recurrenceGroupId: integer('recurrence_group_id'),
+    createdBy: integer('created_by').references(() => users.id, { onDelete: 'set null' }),
+    // Set by a trigger: true for staff, whose approved bookings may not overlap
+    // (resource_schedule_no_overlap)
+    wholeResource: boolean('whole_resource'),
     createdAt: timestamp('created_at').defaultNow().notNull(),
     updatedAt: timestamp('updated_at').defaultNow().notNull(),
   },
@@ -36,5 +62,11 @@ export const resourceSchedule = pgTable(
       table.startTime,
       table.endTime
     ),
+    pendingIdx: index('idx_resource_schedule_pending')
+      .on(table.resourceId)
+      .where(sql`approval_status = 'pending'`),
+    recurrenceGroupIdx: index('idx_resource_schedule_recurrence_group')
+      .on(table.recurrenceGroupId)
+      .where(sql`recurrence_group_id IS NOT NULL`),
   })
 );
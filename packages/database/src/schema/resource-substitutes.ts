@@ -0,0 +1,21 @@
+import { index, integer, pgTable, primaryKey, timestamp } from 'drizzle-orm/pg-core';
+import { resources } from './resources';
+
+// Each row says substituteResourceId can cover resourceId; the migration adds
+// a CHECK that a resource isn't its own substitute
+export const resourceSubstitutes = pgTable(
+  'resource_substitutes',
+  {
+    resourceId: integer('resource_id')
+      .references(() => resources.id, { onDelete: 'cascade' })
+      .notNull(),
+    substituteResourceId: integer('substitute_resource_id')
+      .references(() => resources.id, { onDelete: 'cascade' })
+      .notNull(),
+    createdAt: timestamp('created_at').defaultNow().notNull(),
+  },
+  (table) => ({
+    pk: primaryKey({ columns: [table.resourceId, table.substituteResourceId] }),
+    substituteIdx: index('idx_resource_substitutes_substitute').on(table.substituteResourceId),
+  })
+);
@@ -0,0 +1,22 @@
+import { index, integer, pgTable, serial, timestamp, varchar } from 'drizzle-orm/pg-core';
+import { resources } from './resources';
+
+// Weekly shifts of a resource; when a resource has any they replace its
+// user's staff_availability
+export const resourceWorkingHours = pgTable(
+  'resource_working_hours',
+  {
+    id: serial('id').primaryKey(),
+    resourceId: integer('resource_id')
+      .references(() => resources.id, { onDelete: 'cascade' })
+      .notNull(),
+    dayOfWeek: integer('day_of_week').notNull(), // 0=Sunday, 6=Saturday
+    startTime: varchar('start_time', { length: 5 }).notNull(), // HH:MM format
+    endTime: varchar('end_time', { length: 5 }).notNull(), // HH:MM format
+    createdAt: timestamp('created_at').defaultNow().notNull(),
+    updatedAt: timestamp('updated_at').defaultNow().notNull(),
+  },
+  (table) => ({
+    resourceIdIdx: index('idx_resource_working_hours_resource_id').on(table.resourceId),
+  })
+);
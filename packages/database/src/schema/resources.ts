@@ -26,6 +26,11 @@ export const resources = pgTable(
     isAvailable: boolean('is_available').default(true).notNull(),
     notes: text('notes'),
     userId: integer('user_id').references(() => users.id, { onDelete: 'set null' }),
+    // Minutes after a booking ends before the resource is free again
+    releaseGraceMinutes: integer('release_grace_minutes').default(0).notNull(),
+    timezone: varchar('timezone', { length: 64 }), // IANA name, e.g. America/Chicago
+    quantity: integer('quantity').default(1).notNull(), // Units owned, for equipment and materials
+    externalCode: varchar('external_code', { length: 64 }), // ID in an external system
     createdAt: timestamp('created_at').defaultNow().notNull(),
     updatedAt: timestamp('updated_at').defaultNow().notNull(),
   },
@@ -36,5 +41,8 @@ export const resources = pgTable(
     userIdUniqueIdx: uniqueIndex('idx_resources_user_id')
       .on(table.userId)
       .where(sql`user_id IS NOT NULL`),
+    externalCodeUniqueIdx: uniqueIndex('idx_resources_external_code')
+      .on(table.externalCode)
+      .where(sql`external_code IS NOT NULL`),
   })
 );